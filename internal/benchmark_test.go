@@ -140,6 +140,23 @@ func BenchmarkUI_ModelUpdate_Navigation(b *testing.B) {
 	}
 }
 
+func BenchmarkUI_ModelUpdate_PageDown_Huge(b *testing.B) {
+	model := ui.Model{
+		Model: testutil.NewTestModel().
+			WithWindowSize(140, 40).
+			WithMCPs(generateBenchmarkMCPDataset(10000)).
+			Build(),
+	}
+
+	keyMsg := tea.KeyMsg{Type: tea.KeyPgDown}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		updatedModel, _ := model.Update(keyMsg)
+		model = updatedModel.(ui.Model)
+	}
+}
+
 func BenchmarkUI_ModelUpdate_Search(b *testing.B) {
 	model := ui.Model{
 		Model: testutil.NewTestModel().
@@ -219,6 +236,22 @@ func BenchmarkUI_View_Rendering_Large(b *testing.B) {
 	}
 }
 
+func BenchmarkUI_View_Rendering_Huge(b *testing.B) {
+	// Only the rows that fit are rendered, so the cost is the sort and layout of 10k MCPs
+	model := ui.Model{
+		Model: testutil.NewTestModel().
+			WithWindowSize(140, 40).
+			WithMCPs(generateBenchmarkMCPDataset(10000)).
+			WithSelectedItem(5000).
+			Build(),
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = model.View()
+	}
+}
+
 func BenchmarkUI_View_Rendering_Search(b *testing.B) {
 	model := ui.Model{
 		Model: testutil.NewTestModel().
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"mcp-hub/internal/ui/services"
+)
+
+// DefaultManifestFile is the manifest apply reads when no file is given
+const DefaultManifestFile = "mcp-hub.yaml"
+
+// planMarks prefixes each plan step in the text output
+var planMarks = map[string]string{
+	services.PlanCreate:  "+",
+	services.PlanUpdate:  "~",
+	services.PlanDelete:  "-",
+	services.PlanDisable: "-",
+	services.PlanReload:  "~",
+	services.PlanEnable:  "+",
+}
+
+// applyResult is the JSON output of the apply command
+type applyResult struct {
+	services.ManifestPlan
+	File    string `json:"file"`
+	DryRun  bool   `json:"dry_run"`
+	Applied bool   `json:"applied"`
+	Summary string `json:"summary"`
+}
+
+func (a *App) runApply(ctx context.Context, args []string) error {
+	flags := a.newFlags("apply")
+	file := flags.String("file", DefaultManifestFile, "manifest to apply, or - for stdin")
+	flags.StringVar(file, "f", DefaultManifestFile, "shorthand for --file")
+	dryRun := flags.Bool("dry-run", false, "print the plan without changing anything")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 0, 0, commands["apply"].usage); err != nil {
+		return err
+	}
+
+	data, err := a.readInput(*file)
+	if err != nil {
+		return fail(ExitFailure, "failed to read %s: %v", *file, err)
+	}
+	manifest, err := services.ParseManifest(data)
+	if err != nil {
+		return fail(ExitUsage, "%s: %v", *file, err)
+	}
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+	plan, err := services.ComputeManifestPlan(manifest, items, a.claude.RefreshClaudeStatus(ctx))
+	if err != nil {
+		return fail(ExitUsage, "%s: %v", *file, err)
+	}
+
+	result := applyResult{ManifestPlan: plan, File: *file, DryRun: *dryRun, Summary: plan.Summary()}
+	if !a.jsonOutput {
+		a.printPlan(plan)
+	}
+	if !*dryRun && !plan.Empty() {
+		if err := services.ApplyManifestPlan(ctx, a.claude, a.platformService, items, plan); err != nil {
+			return fail(ExitFailure, "apply stopped: %v; rerun apply to finish", err)
+		}
+		if err := a.syncToolPermissions(toolFilterChanges(items, plan.Items)); err != nil {
+			return err
+		}
+		result.Applied = true
+	}
+
+	if a.jsonOutput {
+		a.writeJSON(result)
+	} else if result.Applied {
+		a.printf("Applied: %s\n", plan.Summary())
+	}
+	return nil
+}
+
+// printPlan lists the plan's steps in the text output
+func (a *App) printPlan(plan services.ManifestPlan) {
+	if !plan.ClaudeAvailable {
+		a.printf("Claude CLI unavailable: planning from the inventory's enabled flags\n")
+	}
+	for _, step := range plan.Steps {
+		detail := ""
+		switch {
+		case len(step.Changes) > 0:
+			detail = " (" + strings.Join(step.Changes, ", ") + ")"
+		case step.Scope != "":
+			detail = " (" + step.Scope + ")"
+		}
+		a.printf("  %s %-7s %s%s\n", planMarks[step.Action], step.Action, step.Name, detail)
+	}
+	a.printf("Plan: %s\n", plan.Summary())
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+const testManifest = `version: 1
+servers:
+  - name: github
+    command: github-mcp
+    args: [--stdio]
+  - name: fs
+    command: fs-mcp
+active:
+  user: [github, fs]
+`
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultManifestFile)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestApply_DryRunThenApply(t *testing.T) {
+	env := newTestEnv(t)
+	env.claude.status.Available = true
+	env.claude.status.ActiveMCPs = []string{"github"}
+	path := writeManifest(t, testManifest)
+
+	code, stdout, stderr := env.run("apply", "-f", path, "--dry-run")
+	if code != ExitOK || !strings.Contains(stdout, "  + create  fs\n") || !strings.Contains(stdout, "  ~ reload  github (user)\n") {
+		t.Fatalf("Unexpected plan %d %q %q", code, stdout, stderr)
+	}
+	if len(env.claude.toggles) != 0 || len(env.inventory(t)) != 3 {
+		t.Errorf("--dry-run should change nothing, got toggles %v", env.claude.toggles)
+	}
+
+	code, stdout, stderr = env.run("apply", "--file", path, "--json")
+	var result applyResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("apply --json should print only the result, got %q: %v", stdout, err)
+	}
+	if code != ExitOK || !result.Applied || result.Summary != "1 to create, 1 to update, 1 to reload, 1 to enable" {
+		t.Errorf("Unexpected apply result %d %+v %q", code, result, stderr)
+	}
+	if strings.Join(env.claude.toggles, ",") != "github=false,fs=true,github=true" {
+		t.Errorf("Toggles = %v", env.claude.toggles)
+	}
+	items := env.inventory(t)
+	if len(items) != 4 || items[3].Name != "fs" || !items[3].Active || items[3].Scope != "user" {
+		t.Errorf("fs should be created and enabled in the user scope, got %+v", items)
+	}
+}
+
+func TestApply_MirrorsToolFilterInClaude(t *testing.T) {
+	env := newTestEnv(t)
+	items := env.inventory(t)
+	cache := map[string]types.IntrospectionResult{"github": {
+		MCPName: "github", ConfigHash: services.ComputeConfigHash(items[0]),
+		Tools: []types.ToolInfo{{Name: "create_issue"}, {Name: "delete_repo"}},
+	}}
+	if err := services.SaveIntrospectionCache(env.platform, cache); err != nil {
+		t.Fatal(err)
+	}
+	path := writeManifest(t, `version: 1
+servers:
+  - name: github
+    command: github-mcp
+    args: [--stdio]
+    tools:
+      deny: [delete_*]
+`)
+
+	if code, _, stderr := env.run("apply", "-f", path); code != ExitOK {
+		t.Fatalf("apply failed: %d %q", code, stderr)
+	}
+	data, err := os.ReadFile(services.GetClaudeSettingsPath(env.platform))
+	if err != nil || !strings.Contains(string(data), "mcp__github__delete_repo") || strings.Contains(string(data), "create_issue") {
+		t.Errorf("The manifest's tool filter should be denied in Claude, got %s, %v", data, err)
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	env := newTestEnv(t)
+
+	if code, _, stderr := env.run("apply", "-f", filepath.Join(t.TempDir(), "missing.yaml")); code != ExitFailure || !strings.Contains(stderr, "failed to read") {
+		t.Errorf("A missing manifest should fail, got %d %q", code, stderr)
+	}
+	if code, _, stderr := env.run("apply", "-f", writeManifest(t, "version: 1\nactive: {user: [nope]}")); code != ExitUsage || !strings.Contains(stderr, "nope") {
+		t.Errorf("An unknown active name should be a usage error, got %d %q", code, stderr)
+	}
+
+	env.stdin.WriteString(testManifest)
+	env.claude.fail["fs"] = true
+	code, stdout, stderr := env.run("apply", "-f", "-")
+	if code != ExitFailure || !strings.Contains(stderr, "rerun apply") || !strings.Contains(stdout, "Claude CLI unavailable") {
+		t.Errorf("A failed toggle should stop the apply, got %d %q %q", code, stdout, stderr)
+	}
+}
@@ -0,0 +1,257 @@
+// Package cli implements mcp-hub's non-interactive subcommands, so scripts and dotfiles
+// can manage the inventory without a terminal.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// Exit codes returned by Run
+const (
+	// ExitOK means the command succeeded
+	ExitOK = 0
+	// ExitFailure means the command could not complete, for example because Claude failed
+	ExitFailure = 1
+	// ExitUsage means the command line was invalid
+	ExitUsage = 2
+	// ExitNotFound means a named MCP is not in the inventory
+	ExitNotFound = 3
+	// ExitConflict means the name of a new or renamed MCP is already taken
+	ExitConflict = 4
+	// ExitOutOfSync means status found the inventory and Claude disagreeing
+	ExitOutOfSync = 5
+)
+
+// Claude is the part of services.ClaudeService the subcommands use
+type Claude interface {
+	DetectClaudeCLI(ctx context.Context) types.ClaudeStatus
+	RefreshClaudeStatus(ctx context.Context) types.ClaudeStatus
+	ToggleMCPStatus(ctx context.Context, mcpName string, activate bool, mcpConfig *types.MCPItem) (*services.ToggleResult, error)
+}
+
+// command is the help text of one subcommand
+type command struct {
+	usage   string
+	summary string
+}
+
+// commands describes each subcommand for usage output
+var commands = map[string]command{
+	"list":    {"list [--active|--inactive] [--json]", "List the MCPs in the inventory"},
+	"show":    {"show <name> [--json]", "Show one MCP's configuration and cached capabilities"},
+	"add":     {"add <name> [flags]", "Add an MCP to the inventory"},
+	"edit":    {"edit <name> [flags]", "Change an MCP; only the given flags are changed"},
+	"remove":  {"remove <name> [--json]", "Remove an MCP, disabling it in Claude first"},
+	"enable":  {"enable <name>... [--hub] [--json]", "Add MCPs to Claude, or with --hub to mcp-hub serve"},
+	"disable": {"disable <name>... [--hub] [--json]", "Remove MCPs from Claude, or with --hub from mcp-hub serve"},
+	"status":  {"status [--json]", "Compare the inventory with the MCPs Claude has loaded"},
+	"import":  {"import <file|-> [--replace] [--json]", "Import an inventory or mcpServers file"},
+	"export":  {"export [--format inventory|claude] [--output file]", "Export the inventory"},
+	"doctor":  {"doctor [--json]", "Check the Claude CLI, paths, inventory and clipboard"},
+	"apply":   {"apply [-f mcp-hub.yaml] [--dry-run] [--json]", "Bring the inventory and Claude in line with a manifest"},
+}
+
+// runners holds the implementation of each command
+var runners = map[string]func(a *App, ctx context.Context, args []string) error{
+	"list":    (*App).runList,
+	"show":    (*App).runShow,
+	"add":     (*App).runAdd,
+	"edit":    (*App).runEdit,
+	"remove":  (*App).runRemove,
+	"enable":  (*App).runEnable,
+	"disable": (*App).runDisable,
+	"status":  (*App).runStatus,
+	"import":  (*App).runImport,
+	"export":  (*App).runExport,
+	"doctor":  (*App).runDoctor,
+	"apply":   (*App).runApply,
+}
+
+// IsCommand reports whether name is one of the subcommands handled by Run
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// PrintUsage lists the subcommands
+func PrintUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  mcp-hub %-52s %s\n", commands[name].usage, commands[name].summary)
+	}
+}
+
+// App runs subcommands against the inventory of a platform
+type App struct {
+	platformService platform.PlatformService
+	claude          Claude
+	stdin           io.Reader
+	stdout          io.Writer
+	stderr          io.Writer
+
+	// jsonOutput is set by a command's --json flag
+	jsonOutput bool
+
+	// newDoctor builds the doctor used by the doctor command
+	newDoctor func(platform.PlatformService) *services.Doctor
+}
+
+// NewApp creates an App reading input from stdin and writing results to stdout
+// and diagnostics to stderr
+func NewApp(platformService platform.PlatformService, claude Claude, stdin io.Reader, stdout, stderr io.Writer) *App {
+	return &App{
+		platformService: platformService,
+		claude:          claude,
+		stdin:           stdin,
+		stdout:          stdout,
+		stderr:          stderr,
+		newDoctor:       services.NewDoctor,
+	}
+}
+
+// exitError carries the exit code a failed command should end with
+type exitError struct {
+	code int
+	err  error
+
+	// reported is set when the command already printed its JSON result
+	reported bool
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// fail returns an error that makes Run exit with code
+func fail(code int, format string, args ...interface{}) error {
+	return &exitError{code: code, err: fmt.Errorf(format, args...)}
+}
+
+// Run executes the subcommand named by args[0] and returns the process exit code
+func (a *App) Run(ctx context.Context, args []string) int {
+	if len(args) == 0 || !IsCommand(args[0]) {
+		_, _ = fmt.Fprintln(a.stderr, "usage:")
+		PrintUsage(a.stderr)
+		return ExitUsage
+	}
+
+	name := args[0]
+	err := runners[name](a, ctx, args[1:])
+	if err == nil {
+		return ExitOK
+	}
+
+	code := ExitFailure
+	var exit *exitError
+	if errors.As(err, &exit) {
+		code = exit.code
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	_, _ = fmt.Fprintf(a.stderr, "mcp-hub %s: %v\n", name, err)
+	if a.jsonOutput && (exit == nil || !exit.reported) {
+		a.writeJSON(map[string]interface{}{"error": err.Error(), "exit_code": code})
+	}
+	return code
+}
+
+// newFlags creates a command's flag set with the shared --json flag
+func (a *App) newFlags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet("mcp-hub "+name, flag.ContinueOnError)
+	flags.SetOutput(a.stderr)
+	flags.BoolVar(&a.jsonOutput, "json", false, "print the result as JSON")
+	return flags
+}
+
+// parseFlags parses flags that may come before, between or after positional arguments
+// and returns the positional ones
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &exitError{code: ExitUsage, err: err}
+		}
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// requireArgs checks the number of positional arguments
+func requireArgs(args []string, minimum, maximum int, usage string) error {
+	if len(args) < minimum || (maximum >= 0 && len(args) > maximum) {
+		return fail(ExitUsage, "usage: mcp-hub %s", usage)
+	}
+	return nil
+}
+
+// writeJSON prints value as indented JSON
+func (a *App) writeJSON(value interface{}) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "failed to encode result: %v\n", err)
+		return
+	}
+	_, _ = fmt.Fprintln(a.stdout, string(data))
+}
+
+// printf writes a text result; it is silent in JSON mode
+func (a *App) printf(format string, args ...interface{}) {
+	if !a.jsonOutput {
+		_, _ = fmt.Fprintf(a.stdout, format, args...)
+	}
+}
+
+// loadInventory loads the inventory, failing the command when it cannot be read
+func (a *App) loadInventory() ([]types.MCPItem, error) {
+	items, err := services.LoadInventory(a.platformService)
+	if err != nil {
+		return nil, fail(ExitFailure, "failed to load inventory: %v", err)
+	}
+	return items, nil
+}
+
+// findIndex returns the position of the named MCP, failing with ExitNotFound when missing
+func findIndex(items []types.MCPItem, name string) (int, error) {
+	index := services.FindMCPIndex(items, name)
+	if index < 0 {
+		return -1, fail(ExitNotFound, "no MCP named %q in the inventory", name)
+	}
+	return index, nil
+}
+
+// target describes where an MCP runs: its command line or URL
+func target(item types.MCPItem) string {
+	if item.Command != "" {
+		return strings.TrimSpace(item.Command + " " + strings.Join(item.Args, " "))
+	}
+	return item.URL
+}
+
+// statusText names an MCP's state in the inventory
+func statusText(item types.MCPItem) string {
+	if item.Active {
+		return "enabled"
+	}
+	return "disabled"
+}
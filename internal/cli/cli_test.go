@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// fakeClaude records toggles instead of running the Claude CLI
+type fakeClaude struct {
+	status  types.ClaudeStatus
+	toggles []string
+	fail    map[string]bool
+}
+
+func (f *fakeClaude) DetectClaudeCLI(context.Context) types.ClaudeStatus {
+	return f.status
+}
+
+func (f *fakeClaude) RefreshClaudeStatus(context.Context) types.ClaudeStatus {
+	return f.status
+}
+
+func (f *fakeClaude) ToggleMCPStatus(_ context.Context, mcpName string, activate bool, _ *types.MCPItem) (*services.ToggleResult, error) {
+	if f.fail[mcpName] {
+		return &services.ToggleResult{MCPName: mcpName, ErrorMsg: "permission denied"}, nil
+	}
+	f.toggles = append(f.toggles, fmt.Sprintf("%s=%v", mcpName, activate))
+	return &services.ToggleResult{Success: true, MCPName: mcpName}, nil
+}
+
+// testEnv is a temporary inventory with github enabled and postgres and docs disabled
+type testEnv struct {
+	platform platform.PlatformService
+	claude   *fakeClaude
+	stdin    *bytes.Buffer
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+	// Tool filters are mirrored into ~/.claude/settings.json
+	mockPlatform.SetHomeDirectory(tempDir + "/home")
+
+	items := []types.MCPItem{
+		{Name: "github", Type: "CMD", Command: "github-mcp", Args: []string{"--stdio"}, Active: true},
+		{Name: "postgres", Type: "CMD", Command: "pg-mcp", Environment: map[string]string{"PGHOST": "localhost"}},
+		{Name: "docs", Type: "HTTP", URL: "https://docs.example.com/mcp"},
+	}
+	if err := services.SaveInventory(items, mockPlatform); err != nil {
+		t.Fatal(err)
+	}
+	return &testEnv{platform: mockPlatform, claude: &fakeClaude{fail: map[string]bool{}}, stdin: &bytes.Buffer{}}
+}
+
+// run executes a command line and returns its exit code, stdout and stderr
+func (e *testEnv) run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := NewApp(e.platform, e.claude, e.stdin, &stdout, &stderr).Run(context.Background(), args)
+	return code, stdout.String(), stderr.String()
+}
+
+// inventory loads the saved inventory
+func (e *testEnv) inventory(t *testing.T) []types.MCPItem {
+	t.Helper()
+	items, err := services.LoadInventory(e.platform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return items
+}
+
+func TestRun_Usage(t *testing.T) {
+	env := newTestEnv(t)
+
+	code, _, stderr := env.run()
+	if code != ExitUsage || !strings.Contains(stderr, "mcp-hub list") {
+		t.Errorf("No command should print usage, got %d %q", code, stderr)
+	}
+	if code, _, _ := env.run("frobnicate"); code != ExitUsage {
+		t.Errorf("Unknown command should be a usage error, got %d", code)
+	}
+	if code, _, stderr := env.run("list", "--bogus"); code != ExitUsage || !strings.Contains(stderr, "bogus") {
+		t.Errorf("Unknown flag should be a usage error, got %d %q", code, stderr)
+	}
+	if code, _, stderr := env.run("show"); code != ExitUsage || !strings.Contains(stderr, "usage: mcp-hub show") {
+		t.Errorf("Missing argument should print the command usage, got %d %q", code, stderr)
+	}
+	if code, _, _ := env.run("list", "-h"); code != ExitOK {
+		t.Errorf("-h should succeed, got %d", code)
+	}
+}
+
+func TestRun_JSONErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	code, stdout, _ := env.run("show", "missing", "--json")
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("JSON error output should be valid JSON, got %q: %v", stdout, err)
+	}
+	if code != ExitNotFound || result["exit_code"] != float64(ExitNotFound) || !strings.Contains(result["error"].(string), "missing") {
+		t.Errorf("Unexpected JSON error %d %v", code, result)
+	}
+}
+
+func TestParseFlags_Interleaved(t *testing.T) {
+	var stderr bytes.Buffer
+	app := NewApp(nil, nil, nil, &bytes.Buffer{}, &stderr)
+	flags := app.newFlags("enable")
+	positional, err := parseFlags(flags, []string{"github", "--json", "postgres"})
+	if err != nil || strings.Join(positional, ",") != "github,postgres" || !app.jsonOutput {
+		t.Errorf("Flags between arguments should be parsed, got %v, %v, json=%v", positional, err, app.jsonOutput)
+	}
+}
+
+func TestIsCommand(t *testing.T) {
+	for name := range runners {
+		if !IsCommand(name) {
+			t.Errorf("%s has a runner but no usage", name)
+		}
+	}
+	if len(runners) != len(commands) || IsCommand("serve") {
+		t.Error("Every command needs a runner, and server modes are not CLI commands")
+	}
+}
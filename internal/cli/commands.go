@@ -0,0 +1,652 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// stringList is a flag that may be repeated
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, " ") }
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// itemFlags are the flags describing an MCP's configuration in add and edit
+type itemFlags struct {
+	itemType string
+	command  string
+	url      string
+	config   string
+	allow    string
+	deny     string
+	args     stringList
+	env      stringList
+	headers  stringList
+}
+
+// register adds the configuration flags to a command's flag set
+func (f *itemFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.itemType, "type", "", "transport: CMD, SSE, HTTP or JSON (inferred from --command, --url or --config)")
+	flags.StringVar(&f.command, "command", "", "command that starts the server")
+	flags.Var(&f.args, "arg", "command argument; repeat for each argument")
+	flags.StringVar(&f.url, "url", "", "server URL for SSE and HTTP servers")
+	flags.StringVar(&f.config, "config", "", "JSON configuration for JSON servers")
+	flags.Var(&f.env, "env", "environment variable as KEY=value; repeat for each variable")
+	flags.Var(&f.headers, "header", `HTTP header as "Name: value"; repeat for each header`)
+	flags.StringVar(&f.allow, "allow", "", "comma-separated tool patterns to expose")
+	flags.StringVar(&f.deny, "deny", "", "comma-separated tool patterns to hide")
+}
+
+// apply copies the flags that were set onto item. Repeated flags replace the whole list.
+func (f *itemFlags) apply(flags *flag.FlagSet, item *types.MCPItem) error {
+	var err error
+	changed := map[string]bool{}
+	flags.Visit(func(set *flag.Flag) {
+		changed[set.Name] = true
+		if err != nil {
+			return
+		}
+		switch set.Name {
+		case "type":
+			item.Type = strings.ToUpper(f.itemType)
+		case "command":
+			item.Command = f.command
+		case "arg":
+			item.Args = append([]string{}, f.args...)
+		case "url":
+			item.URL = f.url
+		case "config":
+			item.JSONConfig = f.config
+		case "env":
+			item.Environment, err = parsePairs(f.env, "=", "environment variable")
+		case "header":
+			item.Headers, err = parsePairs(f.headers, ":", "header")
+		case "allow":
+			item.ToolAllow = services.ParseToolPatterns(f.allow)
+		case "deny":
+			item.ToolDeny = services.ParseToolPatterns(f.deny)
+		}
+	})
+	if err != nil {
+		return &exitError{code: ExitUsage, err: err}
+	}
+
+	// A new command, URL or configuration switches the item to its transport, unless
+	// --type says otherwise. A URL keeps SSE and HTTP servers as they are.
+	if !changed["type"] {
+		switch {
+		case changed["command"]:
+			item.Type = "CMD"
+		case changed["url"] && item.Type != "SSE" && item.Type != "HTTP":
+			item.Type = "SSE"
+		case changed["config"]:
+			item.Type = "JSON"
+		}
+	}
+	if item.Type == "" {
+		item.Type = services.InferMCPType(*item)
+	}
+	clearUnusedFields(item)
+	return nil
+}
+
+// clearUnusedFields drops configuration that does not apply to the item's type,
+// so switching types with edit leaves no stale command or URL behind
+func clearUnusedFields(item *types.MCPItem) {
+	switch item.Type {
+	case "CMD":
+		item.URL, item.Headers, item.JSONConfig = "", nil, ""
+	case "SSE", "HTTP":
+		item.Command, item.Args, item.JSONConfig = "", nil, ""
+	case "JSON":
+		item.Command, item.Args, item.URL, item.Headers = "", nil, "", nil
+	}
+}
+
+// parsePairs parses repeated key/value flags split at the first separator
+func parsePairs(values []string, separator, what string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	pairs := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, separator)
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid %s %q - use KEY%svalue", what, value, separator)
+		}
+		pairs[key] = strings.TrimSpace(val)
+	}
+	return pairs, nil
+}
+
+// validate checks an item before saving, mapping a taken name to ExitConflict
+func validate(item types.MCPItem, items []types.MCPItem, originalName string) error {
+	err := services.ValidateMCPItem(item, items, originalName)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, services.ErrMCPNameExists):
+		return &exitError{code: ExitConflict, err: err}
+	default:
+		return &exitError{code: ExitUsage, err: err}
+	}
+}
+
+// saveInventory saves the inventory, failing the command when it cannot be written
+func (a *App) saveInventory(items []types.MCPItem) error {
+	if err := services.SaveInventory(items, a.platformService); err != nil {
+		return fail(ExitFailure, "%v", err)
+	}
+	return nil
+}
+
+// syncToolPermissions mirrors the tool filters of items into Claude's settings, so they
+// hold without mcp-hub serve. MCPs whose tools are not known yet are noted on stderr.
+func (a *App) syncToolPermissions(items []types.MCPItem) error {
+	cache := services.LoadIntrospectionCache(a.platformService, items)
+	for _, item := range items {
+		_, known, err := services.SyncClaudeToolPermissions(a.platformService, item, cache)
+		if err != nil {
+			return fail(ExitFailure, "saved %s but failed to update Claude permissions: %v", item.Name, err)
+		}
+		if !known {
+			_, _ = fmt.Fprintf(a.stderr, "%s: the tool filter applies in Claude once its tools are introspected (press i in the TUI)\n", item.Name)
+		}
+	}
+	return nil
+}
+
+// toolFilterChanges returns the MCPs of after whose tool filter differs from before, and
+// an MCP without a filter in place of each filtered one that is gone
+func toolFilterChanges(before, after []types.MCPItem) []types.MCPItem {
+	var changed []types.MCPItem
+	for _, item := range after {
+		index := services.FindMCPIndex(before, item.Name)
+		switch {
+		case index < 0:
+			if services.HasToolFilter(item) {
+				changed = append(changed, item)
+			}
+		case !slices.Equal(before[index].ToolAllow, item.ToolAllow) || !slices.Equal(before[index].ToolDeny, item.ToolDeny):
+			changed = append(changed, item)
+		}
+	}
+	for _, item := range before {
+		if services.HasToolFilter(item) && services.FindMCPIndex(after, item.Name) < 0 {
+			changed = append(changed, types.MCPItem{Name: item.Name})
+		}
+	}
+	return changed
+}
+
+func (a *App) runList(_ context.Context, args []string) error {
+	flags := a.newFlags("list")
+	activeOnly := flags.Bool("active", false, "only list enabled MCPs")
+	inactiveOnly := flags.Bool("inactive", false, "only list disabled MCPs")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 0, 0, commands["list"].usage); err != nil {
+		return err
+	}
+
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+	listed := make([]types.MCPItem, 0, len(items))
+	for _, item := range items {
+		if (*activeOnly && !item.Active) || (*inactiveOnly && item.Active) {
+			continue
+		}
+		listed = append(listed, item)
+	}
+
+	if a.jsonOutput {
+		a.writeJSON(listed)
+		return nil
+	}
+	if len(listed) == 0 {
+		a.printf("No MCPs in the inventory\n")
+		return nil
+	}
+	table := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "NAME\tSTATUS\tTYPE\tTARGET")
+	for _, item := range listed {
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", item.Name, statusText(item), item.Type, target(item))
+	}
+	return table.Flush()
+}
+
+func (a *App) runShow(_ context.Context, args []string) error {
+	positional, err := parseFlags(a.newFlags("show"), args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 1, 1, commands["show"].usage); err != nil {
+		return err
+	}
+
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+	index, err := findIndex(items, positional[0])
+	if err != nil {
+		return err
+	}
+	item := items[index]
+	model := types.Model{MCPItems: items, Introspection: services.LoadIntrospectionCache(a.platformService, items)}
+	introspection, introspected := services.GetIntrospection(model, item)
+
+	if a.jsonOutput {
+		result := map[string]interface{}{"mcp": item}
+		if introspected {
+			result["introspection"] = introspection
+		}
+		a.writeJSON(result)
+		return nil
+	}
+	a.printf("%s (%s)\n", item.Name, statusText(item))
+	lines := services.FormatMCPConfigForDisplay(item)
+	if introspected {
+		lines = append(lines, services.FormatCapabilitiesForDisplay(model, item)...)
+	}
+	a.printf("%s\n", strings.Join(lines, "\n"))
+	return nil
+}
+
+func (a *App) runAdd(ctx context.Context, args []string) error {
+	flags := a.newFlags("add")
+	var config itemFlags
+	config.register(flags)
+	enable := flags.Bool("enable", false, "also enable the MCP in Claude")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 1, 1, commands["add"].usage); err != nil {
+		return err
+	}
+
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+	item := types.MCPItem{Name: positional[0]}
+	if err := config.apply(flags, &item); err != nil {
+		return err
+	}
+	if item.Type == "" {
+		return fail(ExitUsage, "one of --command, --url or --config is required")
+	}
+	if err := validate(item, items, ""); err != nil {
+		return err
+	}
+
+	items = append(items, item)
+	if err := a.saveInventory(items); err != nil {
+		return err
+	}
+	if err := a.syncToolPermissions(toolFilterChanges(nil, []types.MCPItem{item})); err != nil {
+		return err
+	}
+	if *enable {
+		if err := services.SetMCPActive(ctx, a.claude, a.platformService, items, len(items)-1, true); err != nil {
+			return fail(ExitFailure, "added %s but failed to enable it: %v", item.Name, err)
+		}
+	}
+
+	if a.jsonOutput {
+		a.writeJSON(items[len(items)-1])
+		return nil
+	}
+	a.printf("Added %s\n", item.Name)
+	if *enable {
+		a.printf("Enabled %s in Claude\n", item.Name)
+	}
+	return nil
+}
+
+func (a *App) runEdit(_ context.Context, args []string) error {
+	flags := a.newFlags("edit")
+	var config itemFlags
+	config.register(flags)
+	rename := flags.String("name", "", "new name for the MCP")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 1, 1, commands["edit"].usage); err != nil {
+		return err
+	}
+
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+	index, err := findIndex(items, positional[0])
+	if err != nil {
+		return err
+	}
+	item := items[index]
+	if *rename != "" {
+		item.Name = *rename
+	}
+	if err := config.apply(flags, &item); err != nil {
+		return err
+	}
+	if err := validate(item, items, positional[0]); err != nil {
+		return err
+	}
+
+	// As in the TUI, the active state is kept and Claude picks the change up on the next toggle
+	before := slices.Clone(items)
+	items[index] = item
+	if err := a.saveInventory(items); err != nil {
+		return err
+	}
+	if err := a.syncToolPermissions(toolFilterChanges(before, items)); err != nil {
+		return err
+	}
+	if a.jsonOutput {
+		a.writeJSON(item)
+		return nil
+	}
+	a.printf("Updated %s\n", item.Name)
+	return nil
+}
+
+func (a *App) runRemove(ctx context.Context, args []string) error {
+	positional, err := parseFlags(a.newFlags("remove"), args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 1, 1, commands["remove"].usage); err != nil {
+		return err
+	}
+
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+	index, err := findIndex(items, positional[0])
+	if err != nil {
+		return err
+	}
+	if items[index].Active {
+		// Leaving it in Claude would orphan a server mcp-hub no longer knows about
+		if err := services.SetMCPActive(ctx, a.claude, a.platformService, items, index, false); err != nil {
+			return fail(ExitFailure, "failed to disable %s in Claude: %v", positional[0], err)
+		}
+	}
+
+	before := slices.Clone(items)
+	items = append(items[:index], items[index+1:]...)
+	if err := a.saveInventory(items); err != nil {
+		return err
+	}
+	if err := a.syncToolPermissions(toolFilterChanges(before, items)); err != nil {
+		return err
+	}
+	if a.jsonOutput {
+		a.writeJSON(map[string]string{"removed": positional[0]})
+		return nil
+	}
+	a.printf("Removed %s\n", positional[0])
+	return nil
+}
+
+// toggleResult reports what enable or disable did to one MCP
+type toggleResult struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Changed bool   `json:"changed"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (a *App) runEnable(ctx context.Context, args []string) error {
+	return a.setEnabled(ctx, "enable", args, true)
+}
+
+func (a *App) runDisable(ctx context.Context, args []string) error {
+	return a.setEnabled(ctx, "disable", args, false)
+}
+
+// setEnabled toggles each named MCP, carrying on past failures so one bad name
+// does not leave the rest unchanged. With --hub it changes the set mcp-hub serve
+// proxies instead of the MCPs registered with Claude.
+func (a *App) setEnabled(ctx context.Context, name string, args []string, enable bool) error {
+	flags := a.newFlags(name)
+	hub := flags.Bool("hub", false, "serve the MCP through mcp-hub serve instead of registering it with Claude")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 1, -1, commands[name].usage); err != nil {
+		return err
+	}
+
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+
+	var results []toggleResult
+	code := ExitOK
+	for _, mcpName := range positional {
+		result := toggleResult{Name: mcpName}
+		index, err := findIndex(items, mcpName)
+		switch {
+		case err != nil:
+			code = ExitNotFound
+		case *hub && items[index].Served == enable, !*hub && items[index].Active == enable:
+			result.Enabled = enable
+		case *hub:
+			if err = services.SetMCPServed(a.platformService, items, index, enable); err != nil {
+				if code == ExitOK {
+					code = ExitFailure
+				}
+			} else {
+				result.Changed = true
+			}
+			result.Enabled = items[index].Served
+		default:
+			if err = services.SetMCPActive(ctx, a.claude, a.platformService, items, index, enable); err != nil {
+				if code == ExitOK {
+					code = ExitFailure
+				}
+			} else {
+				result.Changed = true
+			}
+			result.Enabled = items[index].Active
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	if a.jsonOutput {
+		a.writeJSON(map[string]interface{}{"results": results})
+	} else {
+		for _, result := range results {
+			switch {
+			case result.Error != "":
+				_, _ = fmt.Fprintf(a.stderr, "%s: %s\n", result.Name, result.Error)
+			case result.Changed:
+				a.printf("%sd %s\n", strings.ToUpper(name[:1])+name[1:], result.Name)
+			default:
+				a.printf("%s is already %sd\n", result.Name, name)
+			}
+		}
+	}
+	if code != ExitOK {
+		// Each failure was already reported above
+		return &exitError{code: code, err: fmt.Errorf("failed to %s some MCPs", name), reported: true}
+	}
+	return nil
+}
+
+func (a *App) runStatus(ctx context.Context, args []string) error {
+	positional, err := parseFlags(a.newFlags("status"), args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 0, 0, commands["status"].usage); err != nil {
+		return err
+	}
+
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+	report := services.BuildSyncReport(items, a.claude.RefreshClaudeStatus(ctx))
+
+	if a.jsonOutput {
+		a.writeJSON(report)
+	} else {
+		a.printf("Status: %s\n", report.Status)
+		if report.ClaudeAvailable {
+			a.printf("Claude: available %s\n", report.ClaudeVersion)
+		} else {
+			a.printf("Claude: unavailable %s\n", report.Error)
+		}
+		a.printf("Enabled in mcp-hub: %s\n", joinNames(report.EnabledInHub))
+		a.printf("Active in Claude: %s\n", joinNames(report.ActiveInClaude))
+		if len(report.OnlyInHub) > 0 {
+			a.printf("Only in mcp-hub: %s\n", joinNames(report.OnlyInHub))
+		}
+		if len(report.OnlyInClaude) > 0 {
+			a.printf("Only in Claude: %s\n", joinNames(report.OnlyInClaude))
+		}
+	}
+
+	switch {
+	case !report.ClaudeAvailable:
+		return &exitError{code: ExitFailure, err: fmt.Errorf("claude CLI is not available"), reported: true}
+	case !report.InSync:
+		return &exitError{code: ExitOutOfSync, err: fmt.Errorf("inventory and Claude are out of sync"), reported: true}
+	}
+	return nil
+}
+
+// joinNames lists names for text output
+func joinNames(names []string) string {
+	if len(names) == 0 {
+		return "(none)"
+	}
+	return strings.Join(names, ", ")
+}
+
+func (a *App) runImport(_ context.Context, args []string) error {
+	flags := a.newFlags("import")
+	replace := flags.Bool("replace", false, "replace MCPs whose name is already in the inventory")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 1, 1, commands["import"].usage); err != nil {
+		return err
+	}
+
+	data, err := a.readInput(positional[0])
+	if err != nil {
+		return fail(ExitFailure, "failed to read %s: %v", positional[0], err)
+	}
+	imported, err := services.ParseInventoryImport(data)
+	if err != nil {
+		return fail(ExitUsage, "%v", err)
+	}
+	for i, item := range imported {
+		// Checking against the earlier entries catches names repeated in the file
+		if err := services.ValidateMCPItem(item, imported[:i], ""); err != nil {
+			return fail(ExitUsage, "invalid MCP %q: %v", item.Name, err)
+		}
+	}
+
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+	merged, result := services.MergeInventory(items, imported, *replace)
+	if len(result.Added)+len(result.Replaced) > 0 {
+		if err := a.saveInventory(merged); err != nil {
+			return err
+		}
+		if err := a.syncToolPermissions(toolFilterChanges(items, merged)); err != nil {
+			return err
+		}
+	}
+
+	if a.jsonOutput {
+		a.writeJSON(result)
+		return nil
+	}
+	a.printf("Imported %d MCPs: %d added, %d replaced, %d skipped\n",
+		len(imported), len(result.Added), len(result.Replaced), len(result.Skipped))
+	if len(result.Skipped) > 0 {
+		a.printf("Skipped existing: %s (use --replace to overwrite)\n", joinNames(result.Skipped))
+	}
+	return nil
+}
+
+// readInput reads a file, or stdin when path is -
+func (a *App) readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(a.stdin)
+	}
+	// #nosec G304 -- importing a file the user names is the purpose of this command
+	return os.ReadFile(path)
+}
+
+func (a *App) runExport(_ context.Context, args []string) error {
+	flags := a.newFlags("export")
+	format := flags.String("format", services.FormatInventory, "output format: inventory or claude")
+	output := flags.String("output", "", "write to this file instead of stdout")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 0, 0, commands["export"].usage); err != nil {
+		return err
+	}
+
+	items, err := a.loadInventory()
+	if err != nil {
+		return err
+	}
+	data, err := services.ExportInventory(items, *format)
+	if err != nil {
+		return fail(ExitUsage, "%v", err)
+	}
+
+	if *output == "" {
+		_, _ = fmt.Fprintln(a.stdout, string(data))
+		return nil
+	}
+	// The export may hold environment secrets, so it gets the inventory's permissions
+	if err := os.WriteFile(*output, append(data, '\n'), a.platformService.GetDefaultFilePermissions()); err != nil {
+		return fail(ExitFailure, "failed to write %s: %v", *output, err)
+	}
+	if !a.jsonOutput {
+		_, _ = fmt.Fprintf(a.stderr, "Exported %d MCPs to %s\n", len(items), *output)
+	}
+	return nil
+}
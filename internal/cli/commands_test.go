@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestList(t *testing.T) {
+	env := newTestEnv(t)
+
+	code, stdout, _ := env.run("list")
+	if code != ExitOK || !strings.Contains(stdout, "NAME") || !strings.Contains(stdout, "github-mcp --stdio") {
+		t.Errorf("Unexpected list output %d %q", code, stdout)
+	}
+
+	_, stdout, _ = env.run("list", "--json", "--inactive")
+	var items []types.MCPItem
+	if err := json.Unmarshal([]byte(stdout), &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Name != "postgres" || items[1].Name != "docs" {
+		t.Errorf("--inactive should list the disabled MCPs, got %+v", items)
+	}
+
+	_, stdout, _ = env.run("list", "--active", "--json")
+	if err := json.Unmarshal([]byte(stdout), &items); err != nil || len(items) != 1 || items[0].Name != "github" {
+		t.Errorf("--active should list the enabled MCPs, got %+v", items)
+	}
+}
+
+func TestShow(t *testing.T) {
+	env := newTestEnv(t)
+
+	code, stdout, _ := env.run("show", "postgres")
+	if code != ExitOK || !strings.Contains(stdout, "postgres (disabled)") || !strings.Contains(stdout, "Env: PGHOST") {
+		t.Errorf("Unexpected show output %d %q", code, stdout)
+	}
+	if strings.Contains(stdout, "localhost") {
+		t.Error("Text output should not print environment values")
+	}
+
+	items := env.inventory(t)
+	cache := map[string]types.IntrospectionResult{"github": {
+		MCPName: "github", ConfigHash: services.ComputeConfigHash(items[0]), FetchedAt: time.Now(),
+		Tools: []types.ToolInfo{{Name: "create_issue"}},
+	}}
+	if err := services.SaveIntrospectionCache(env.platform, cache); err != nil {
+		t.Fatal(err)
+	}
+	_, stdout, _ = env.run("show", "--json", "github")
+	var result struct {
+		MCP           types.MCPItem              `json:"mcp"`
+		Introspection *types.IntrospectionResult `json:"introspection"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.MCP.Name != "github" || result.Introspection == nil || result.Introspection.Tools[0].Name != "create_issue" {
+		t.Errorf("show --json should include cached capabilities, got %+v", result)
+	}
+	if _, stdout, _ = env.run("show", "github"); !strings.Contains(stdout, "create_issue") {
+		t.Errorf("Text output should list cached tools, got %q", stdout)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	env := newTestEnv(t)
+
+	code, stdout, stderr := env.run("add", "search", "--url", "https://search.example.com/sse", "--header", "Authorization: Bearer x", "--json")
+	var item types.MCPItem
+	if err := json.Unmarshal([]byte(stdout), &item); err != nil || code != ExitOK {
+		t.Fatalf("add failed: %d %q %q", code, stdout, stderr)
+	}
+	if item.Type != "SSE" || item.Headers["Authorization"] != "Bearer x" || item.Active {
+		t.Errorf("A URL should add a disabled SSE server, got %+v", item)
+	}
+
+	code, _, _ = env.run("add", "fs", "--command", "fs-mcp", "--arg", "--root", "--arg", "/tmp dir", "--env", "DEBUG=1", "--enable")
+	items := env.inventory(t)
+	fs := items[len(items)-1]
+	if code != ExitOK || fs.Type != "CMD" || !reflect.DeepEqual(fs.Args, []string{"--root", "/tmp dir"}) || fs.Environment["DEBUG"] != "1" || !fs.Active {
+		t.Errorf("Unexpected command server %d %+v", code, fs)
+	}
+	if !reflect.DeepEqual(env.claude.toggles, []string{"fs=true"}) {
+		t.Errorf("--enable should enable the server in Claude, got %v", env.claude.toggles)
+	}
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"taken name", []string{"add", "github", "--command", "x"}, ExitConflict},
+		{"no transport", []string{"add", "empty"}, ExitUsage},
+		{"bad URL", []string{"add", "bad", "--url", "not-a-url"}, ExitUsage},
+		{"bad env", []string{"add", "bad", "--command", "x", "--env", "NOEQUALS"}, ExitUsage},
+		{"bad JSON", []string{"add", "bad", "--config", "{"}, ExitUsage},
+		{"bad type", []string{"add", "bad", "--type", "ftp", "--command", "x"}, ExitUsage},
+	} {
+		if code, _, _ := env.run(tc.args...); code != tc.code {
+			t.Errorf("%s: exit code %d, expected %d", tc.name, code, tc.code)
+		}
+	}
+	if len(env.inventory(t)) != 5 {
+		t.Error("Rejected adds should not change the inventory")
+	}
+}
+
+func TestEdit(t *testing.T) {
+	env := newTestEnv(t)
+
+	code, _, stderr := env.run("edit", "github", "--arg", "--verbose", "--deny", "delete_*")
+	items := env.inventory(t)
+	if code != ExitOK || !reflect.DeepEqual(items[0].Args, []string{"--verbose"}) || items[0].Command != "github-mcp" ||
+		!reflect.DeepEqual(items[0].ToolDeny, []string{"delete_*"}) || !items[0].Active {
+		t.Errorf("edit should change only the given fields, got %d %q %+v", code, stderr, items[0])
+	}
+
+	code, _, _ = env.run("edit", "postgres", "--name", "pg", "--type", "http", "--url", "https://pg.example.com/mcp")
+	items = env.inventory(t)
+	if code != ExitOK || items[1].Name != "pg" || items[1].Type != "HTTP" || items[1].Command != "" {
+		t.Errorf("Switching type should drop the command, got %d %+v", code, items[1])
+	}
+
+	code, _, _ = env.run("edit", "github", "--url", "https://github.example.com/sse")
+	items = env.inventory(t)
+	if code != ExitOK || items[0].Type != "SSE" || items[0].URL != "https://github.example.com/sse" || items[0].Command != "" {
+		t.Errorf("A URL on a command MCP should switch it to SSE, got %d %+v", code, items[0])
+	}
+	code, _, _ = env.run("edit", "pg", "--url", "https://pg.example.com/v2")
+	items = env.inventory(t)
+	if code != ExitOK || items[1].Type != "HTTP" || items[1].URL != "https://pg.example.com/v2" {
+		t.Errorf("A new URL should keep an HTTP MCP as HTTP, got %d %+v", code, items[1])
+	}
+	code, _, _ = env.run("edit", "pg", "--command", "pg-mcp")
+	items = env.inventory(t)
+	if code != ExitOK || items[1].Type != "CMD" || items[1].Command != "pg-mcp" || items[1].URL != "" {
+		t.Errorf("A command on an HTTP MCP should switch it to CMD, got %d %+v", code, items[1])
+	}
+
+	if code, _, _ := env.run("edit", "pg", "--name", "docs"); code != ExitConflict {
+		t.Errorf("Renaming onto a taken name should conflict, got %d", code)
+	}
+	if code, _, _ := env.run("edit", "missing", "--command", "x"); code != ExitNotFound {
+		t.Errorf("Editing a missing MCP should fail with not found, got %d", code)
+	}
+}
+
+func TestEdit_MirrorsToolFilterInClaude(t *testing.T) {
+	env := newTestEnv(t)
+	settingsPath := services.GetClaudeSettingsPath(env.platform)
+
+	code, _, stderr := env.run("edit", "github", "--deny", "delete_*")
+	if code != ExitOK || !strings.Contains(stderr, "once its tools are introspected") {
+		t.Errorf("A filter on an MCP that was never introspected should be noted, got %d %q", code, stderr)
+	}
+
+	items := env.inventory(t)
+	cache := map[string]types.IntrospectionResult{"github": {
+		MCPName: "github", ConfigHash: services.ComputeConfigHash(items[0]), FetchedAt: time.Now(),
+		Tools: []types.ToolInfo{{Name: "create_issue"}, {Name: "delete_repo"}},
+	}}
+	if err := services.SaveIntrospectionCache(env.platform, cache); err != nil {
+		t.Fatal(err)
+	}
+	if code, _, stderr := env.run("edit", "github", "--deny", "delete_*,create_*"); code != ExitOK || stderr != "" {
+		t.Fatalf("edit failed: %d %q", code, stderr)
+	}
+	data, err := os.ReadFile(settingsPath)
+	if err != nil || !strings.Contains(string(data), "mcp__github__delete_repo") || !strings.Contains(string(data), "mcp__github__create_issue") {
+		t.Errorf("The hidden tools should be denied in Claude, got %s, %v", data, err)
+	}
+
+	if code, _, _ := env.run("remove", "github"); code != ExitOK {
+		t.Fatalf("remove failed: %d", code)
+	}
+	if data, _ := os.ReadFile(settingsPath); strings.Contains(string(data), "mcp__github__") {
+		t.Errorf("Removing the MCP should drop its rules, got %s", data)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	env := newTestEnv(t)
+
+	if code, _, _ := env.run("remove", "docs"); code != ExitOK || len(env.claude.toggles) != 0 {
+		t.Errorf("Removing a disabled MCP should not touch Claude, got %d %v", code, env.claude.toggles)
+	}
+
+	env.claude.fail["github"] = true
+	if code, _, _ := env.run("remove", "github"); code != ExitFailure || len(env.inventory(t)) != 2 {
+		t.Errorf("A failed disable should keep the MCP, got %d", code)
+	}
+	env.claude.fail["github"] = false
+	code, stdout, _ := env.run("remove", "github", "--json")
+	if code != ExitOK || !strings.Contains(stdout, `"removed": "github"`) || !reflect.DeepEqual(env.claude.toggles, []string{"github=false"}) {
+		t.Errorf("Removing an enabled MCP should disable it first, got %d %q %v", code, stdout, env.claude.toggles)
+	}
+	if items := env.inventory(t); len(items) != 1 || items[0].Name != "postgres" {
+		t.Errorf("Unexpected inventory %+v", items)
+	}
+}
+
+func TestEnableDisable(t *testing.T) {
+	env := newTestEnv(t)
+
+	code, stdout, _ := env.run("enable", "postgres", "github")
+	if code != ExitOK || !strings.Contains(stdout, "Enabled postgres") || !strings.Contains(stdout, "github is already enabled") {
+		t.Errorf("Unexpected enable output %d %q", code, stdout)
+	}
+	if !env.inventory(t)[1].Active || !reflect.DeepEqual(env.claude.toggles, []string{"postgres=true"}) {
+		t.Error("enable should toggle only the disabled MCP and save it")
+	}
+
+	env.claude.fail["github"] = true
+	code, stdout, _ = env.run("disable", "github", "missing", "postgres", "--json")
+	var result struct {
+		Results []toggleResult `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("Partial failure should still print one JSON result, got %q: %v", stdout, err)
+	}
+	expected := []toggleResult{
+		{Name: "github", Enabled: true, Error: "permission denied"},
+		{Name: "missing", Error: `no MCP named "missing" in the inventory`},
+		{Name: "postgres", Enabled: false, Changed: true},
+	}
+	if code != ExitNotFound || !reflect.DeepEqual(result.Results, expected) {
+		t.Errorf("disable = %d %+v, expected not found and %+v", code, result.Results, expected)
+	}
+
+	if code, _, _ := env.run("disable", "github"); code != ExitFailure {
+		t.Errorf("A failed toggle should exit with failure, got %d", code)
+	}
+}
+
+func TestEnableDisable_Hub(t *testing.T) {
+	env := newTestEnv(t)
+
+	code, stdout, _ := env.run("enable", "--hub", "postgres")
+	if code != ExitOK || !strings.Contains(stdout, "Enabled postgres") {
+		t.Errorf("Unexpected enable --hub output %d %q", code, stdout)
+	}
+	items := env.inventory(t)
+	if !items[1].Served || items[1].Active || len(env.claude.toggles) != 0 {
+		t.Errorf("enable --hub should only mark the MCP served, got %+v and toggles %v", items[1], env.claude.toggles)
+	}
+
+	if code, stdout, _ := env.run("disable", "postgres", "--hub"); code != ExitOK || env.inventory(t)[1].Served {
+		t.Errorf("disable --hub should stop serving the MCP, got %d %q", code, stdout)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	env := newTestEnv(t)
+	env.claude.status = types.ClaudeStatus{Available: true, Version: "1.0.0", ActiveMCPs: []string{"github"}, LastCheck: time.Now()}
+
+	code, stdout, _ := env.run("status")
+	if code != ExitOK || !strings.Contains(stdout, "Status: In Sync") {
+		t.Errorf("Matching sets should be in sync, got %d %q", code, stdout)
+	}
+
+	env.claude.status.ActiveMCPs = []string{"figma"}
+	code, stdout, _ = env.run("status", "--json")
+	var report services.SyncReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("status --json should print only the report, got %q: %v", stdout, err)
+	}
+	if code != ExitOutOfSync || report.InSync || !reflect.DeepEqual(report.OnlyInClaude, []string{"figma"}) {
+		t.Errorf("Unexpected out-of-sync status %d %+v", code, report)
+	}
+
+	env.claude.status = types.ClaudeStatus{Available: false, Error: "claude not found", LastCheck: time.Now()}
+	if code, stdout, _ := env.run("status"); code != ExitFailure || !strings.Contains(stdout, "unavailable") {
+		t.Errorf("Missing Claude should fail, got %d %q", code, stdout)
+	}
+}
+
+func TestImportExport(t *testing.T) {
+	env := newTestEnv(t)
+	path := filepath.Join(t.TempDir(), "export.json")
+
+	if code, _, _ := env.run("export", "--format", "claude", "--output", path); code != ExitOK {
+		t.Fatalf("export failed with %d", code)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != env.platform.GetDefaultFilePermissions() {
+		t.Errorf("Export should use the inventory's permissions, got %v (%v)", info, err)
+	}
+
+	other := newTestEnv(t)
+	if err := services.SaveInventory([]types.MCPItem{{Name: "github", Type: "CMD", Command: "old"}}, other.platform); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, _ := other.run("import", path)
+	if code != ExitOK || !strings.Contains(stdout, "2 added, 0 replaced, 1 skipped") {
+		t.Errorf("Unexpected import output %d %q", code, stdout)
+	}
+	items := other.inventory(t)
+	if len(items) != 3 || items[0].Command != "old" || items[1].Type != "HTTP" || items[1].URL != "https://docs.example.com/mcp" {
+		t.Errorf("Import should add new MCPs and keep existing ones, got %+v", items)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.stdin.Write(data)
+	code, stdout, _ = other.run("import", "-", "--replace", "--json")
+	var result services.ImportResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || code != ExitOK {
+		t.Fatalf("import from stdin failed: %d %q %v", code, stdout, err)
+	}
+	if len(result.Replaced) != 3 || other.inventory(t)[0].Command != "github-mcp" {
+		t.Errorf("--replace should overwrite existing MCPs, got %+v", result)
+	}
+
+	if code, stdout, _ := env.run("export"); code != ExitOK || !strings.Contains(stdout, `"inventory"`) {
+		t.Errorf("Default export should use the inventory format, got %d %q", code, stdout)
+	}
+	if code, _, _ := env.run("export", "--format", "yaml"); code != ExitUsage {
+		t.Errorf("Unknown format should be a usage error, got %d", code)
+	}
+	other.stdin.WriteString(`{"mcpServers": {"a": {"command": "x"}, "b": {"url": "nope"}}}`)
+	if code, _, _ := other.run("import", "-"); code != ExitUsage {
+		t.Errorf("Invalid imported MCPs should be rejected, got %d", code)
+	}
+	if code, _, _ := other.run("import", filepath.Join(t.TempDir(), "missing.json")); code != ExitFailure {
+		t.Errorf("Missing import file should fail, got %d", code)
+	}
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcp-hub/internal/ui/services"
+)
+
+// checkMarks prefixes each check in the text report
+var checkMarks = map[services.CheckStatus]string{
+	services.CheckPass: "✓",
+	services.CheckWarn: "!",
+	services.CheckFail: "✗",
+}
+
+func (a *App) runDoctor(ctx context.Context, args []string) error {
+	positional, err := parseFlags(a.newFlags("doctor"), args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(positional, 0, 0, commands["doctor"].usage); err != nil {
+		return err
+	}
+
+	doctor := a.newDoctor(a.platformService)
+	doctor.Claude = a.claude
+	report := doctor.Run(ctx)
+
+	if a.jsonOutput {
+		a.writeJSON(report)
+	} else {
+		category := ""
+		for _, check := range report.Checks {
+			if check.Category != category {
+				category = check.Category
+				a.printf("%s\n", category)
+			}
+			a.printf("  %s %s: %s\n", checkMarks[check.Status], check.Name, check.Message)
+			if check.Hint != "" {
+				// Multi-line hints such as the install guide stay aligned under the check
+				a.printf("      hint: %s\n", strings.ReplaceAll(check.Hint, "\n", "\n            "))
+			}
+		}
+		a.printf("\n%d passed, %d warnings, %d failed\n", report.Passed, report.Warnings, report.Failures)
+	}
+
+	if report.Failures > 0 {
+		return &exitError{code: ExitFailure, err: fmt.Errorf("%d checks failed", report.Failures), reported: true}
+	}
+	return nil
+}
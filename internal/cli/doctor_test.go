@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/services"
+)
+
+// testDoctor builds doctors whose system lookups succeed, except for Claude when claudeFound is false
+func testDoctor(claudeFound bool) func(platform.PlatformService) *services.Doctor {
+	return func(platformService platform.PlatformService) *services.Doctor {
+		return &services.Doctor{
+			PlatformService: platformService,
+			LookPath: func(file string) (string, error) {
+				if file == services.ClaudeCommand && !claudeFound {
+					return "", errors.New("not found")
+				}
+				return "/usr/bin/" + file, nil
+			},
+			ClipboardAvailable: func() bool { return true },
+		}
+	}
+}
+
+func TestDoctor(t *testing.T) {
+	env := newTestEnv(t)
+	env.claude.status.Available, env.claude.status.Version = true, "1.0.0"
+
+	var stdout, stderr strings.Builder
+	app := NewApp(env.platform, env.claude, env.stdin, &stdout, &stderr)
+	app.newDoctor = testDoctor(true)
+	code := app.Run(t.Context(), []string{"doctor"})
+	if code != ExitOK || !strings.Contains(stdout.String(), "  ✓ version: 1.0.0\nPaths") || !strings.Contains(stdout.String(), "0 failed") {
+		t.Errorf("Unexpected doctor output %d %q %q", code, stdout.String(), stderr.String())
+	}
+
+	stdout.Reset()
+	app = NewApp(env.platform, env.claude, env.stdin, &stdout, &stderr)
+	app.newDoctor = testDoctor(false)
+	code = app.Run(t.Context(), []string{"doctor", "--json"})
+	var report services.DoctorReport
+	if err := json.Unmarshal([]byte(stdout.String()), &report); err != nil {
+		t.Fatalf("doctor --json should print only the report, got %q: %v", stdout.String(), err)
+	}
+	if code != ExitFailure || report.Failures != 1 || report.Checks[0].Status != services.CheckFail || report.Checks[0].Hint == "" {
+		t.Errorf("Missing Claude should fail the doctor, got %d %+v", code, report)
+	}
+}
@@ -0,0 +1,155 @@
+// Package logging provides mcp-hub's leveled, structured log: JSON lines written to a
+// rotating mcp-hub.log, tagged with the component that wrote them.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// FileName is the application log inside the platform log directory
+	FileName = "mcp-hub.log"
+	// LevelEnv selects the log level when --log-level is not given
+	LevelEnv = "MCP_HUB_LOG_LEVEL"
+	// ComponentKey is the attribute naming the part of mcp-hub that wrote an entry
+	ComponentKey = "component"
+
+	// DefaultMaxFileSize is the size at which the log is rotated to mcp-hub.log.1
+	DefaultMaxFileSize = 5 << 20
+	// DefaultMaxBackups is the number of rotated logs kept
+	DefaultMaxBackups = 3
+)
+
+// levelNames lists the accepted level names from least to most severe
+var levelNames = []struct {
+	name  string
+	level slog.Level
+}{
+	{"debug", slog.LevelDebug},
+	{"info", slog.LevelInfo},
+	{"warn", slog.LevelWarn},
+	{"error", slog.LevelError},
+}
+
+// ParseLevel converts debug, info, warn (or warning) and error into a slog level
+func ParseLevel(name string) (slog.Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warning" {
+		name = "warn"
+	}
+	for _, entry := range levelNames {
+		if entry.name == name {
+			return entry.level, nil
+		}
+	}
+	return slog.LevelInfo, fmt.Errorf("invalid log level %q - use debug, info, warn or error", name)
+}
+
+// LevelFromEnv returns the level named by MCP_HUB_LOG_LEVEL, info when it is unset
+func LevelFromEnv() (slog.Level, error) {
+	name := os.Getenv(LevelEnv)
+	if name == "" {
+		return slog.LevelInfo, nil
+	}
+	return ParseLevel(name)
+}
+
+// LevelName returns the lowercase name of a level, e.g. "warn"
+func LevelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
+
+// Options configures the log file written by Setup
+type Options struct {
+	Dir        string
+	Level      slog.Level
+	DirMode    os.FileMode
+	FileMode   os.FileMode
+	MaxSize    int64 // DefaultMaxFileSize when zero
+	MaxBackups int   // DefaultMaxBackups when zero
+}
+
+// Setup makes the rotating log file in opts.Dir the destination of slog's default logger,
+// and through it of the standard log package. The returned function restores the
+// previous destinations and closes the file.
+func Setup(opts Options) (func(), error) {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = DefaultMaxFileSize
+	}
+	if opts.MaxBackups <= 0 {
+		opts.MaxBackups = DefaultMaxBackups
+	}
+	if err := os.MkdirAll(opts.Dir, opts.DirMode); err != nil {
+		return func() {}, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	file, err := OpenRotatingFile(filepath.Join(opts.Dir, FileName), opts.MaxSize, opts.MaxBackups, opts.FileMode)
+	if err != nil {
+		return func() {}, err
+	}
+
+	previous, previousWriter, previousFlags := slog.Default(), log.Writer(), log.Flags()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(file, &slog.HandlerOptions{Level: opts.Level})))
+	return func() {
+		slog.SetDefault(previous)
+		log.SetOutput(previousWriter)
+		log.SetFlags(previousFlags)
+		_ = file.Close()
+	}, nil
+}
+
+// For returns a logger tagging its entries with component. It writes through whatever
+// logger is the default when it logs, so package-level loggers created before Setup
+// still reach the log file.
+func For(component string) *slog.Logger {
+	return slog.New(currentHandler{}).With(ComponentKey, component)
+}
+
+// currentHandler forwards records to the handler of slog's default logger at the time
+// they are logged, replaying the attributes and groups added with With
+type currentHandler struct {
+	wrap func(slog.Handler) slog.Handler
+}
+
+func (h currentHandler) handler() slog.Handler {
+	handler := slog.Default().Handler()
+	if h.wrap != nil {
+		handler = h.wrap(handler)
+	}
+	return handler
+}
+
+// Enabled reports whether the default logger records the level
+func (h currentHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return slog.Default().Handler().Enabled(ctx, level)
+}
+
+// Handle writes the record with the default logger's handler
+func (h currentHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.handler().Handle(ctx, record)
+}
+
+// WithAttrs returns a handler adding attrs to every record
+func (h currentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.chain(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+// WithGroup returns a handler nesting later attributes under name
+func (h currentHandler) WithGroup(name string) slog.Handler {
+	return h.chain(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h currentHandler) chain(next func(slog.Handler) slog.Handler) currentHandler {
+	previous := h.wrap
+	return currentHandler{wrap: func(handler slog.Handler) slog.Handler {
+		if previous != nil {
+			handler = previous(handler)
+		}
+		return next(handler)
+	}}
+}
@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	for name, expected := range map[string]slog.Level{
+		"debug": slog.LevelDebug, "INFO": slog.LevelInfo, "warn": slog.LevelWarn, " warning ": slog.LevelWarn, "error": slog.LevelError,
+	} {
+		if level, err := ParseLevel(name); err != nil || level != expected {
+			t.Errorf("ParseLevel(%q) = %v, %v", name, level, err)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("Unknown levels should be rejected")
+	}
+	if LevelName(slog.LevelWarn) != "warn" {
+		t.Errorf("Unexpected level name %q", LevelName(slog.LevelWarn))
+	}
+}
+
+func TestLevelFromEnv(t *testing.T) {
+	t.Setenv(LevelEnv, "")
+	if level, err := LevelFromEnv(); err != nil || level != slog.LevelInfo {
+		t.Errorf("Unset level should be info, got %v, %v", level, err)
+	}
+	t.Setenv(LevelEnv, "debug")
+	if level, err := LevelFromEnv(); err != nil || level != slog.LevelDebug {
+		t.Errorf("Expected debug, got %v, %v", level, err)
+	}
+	t.Setenv(LevelEnv, "loud")
+	if _, err := LevelFromEnv(); err == nil {
+		t.Error("Invalid level should be reported")
+	}
+}
+
+func TestSetup_WritesLeveledJSON(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	// Created before Setup, like package-level loggers
+	logger := For("storage")
+
+	restore, err := Setup(Options{Dir: dir, Level: slog.LevelInfo, DirMode: 0o700, FileMode: 0o600})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	logger.Debug("hidden")
+	logger.With("path", "/tmp/inventory.json").Info("inventory saved", "items", 3)
+	log.Printf("plain message")
+	restore()
+	logger.Info("after restore")
+
+	entries, err := ReadTail(filepath.Join(dir, FileName), 0)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %+v, %v", entries, err)
+	}
+	if entry := entries[0]; entry.Component != "storage" || entry.Message != "inventory saved" ||
+		strings.Join(entry.Fields, " ") != "items=3 path=/tmp/inventory.json" || entry.Time.IsZero() {
+		t.Errorf("Unexpected structured entry %+v", entry)
+	}
+	if entries[1].Message != "plain message" || entries[1].Level != slog.LevelInfo {
+		t.Errorf("The standard logger should write through slog, got %+v", entries[1])
+	}
+	if info, err := os.Stat(filepath.Join(dir, FileName)); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Log file should use the given mode, got %v, %v", info, err)
+	}
+}
@@ -0,0 +1,168 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// tailBytes is how much of the end of the log file ReadTail parses
+const tailBytes = 512 << 10
+
+// Entry is one parsed line of the log file
+type Entry struct {
+	Time      time.Time
+	Level     slog.Level
+	Component string
+	Message   string
+	Fields    []string // Remaining attributes as sorted key=value pairs
+}
+
+// ParseLine parses one JSON log line. Lines written before structured logging are kept
+// as info entries with the whole line as the message.
+func ParseLine(line []byte) Entry {
+	var values map[string]any
+	if err := json.Unmarshal(line, &values); err != nil {
+		return Entry{Level: slog.LevelInfo, Message: string(line)}
+	}
+
+	var entry Entry
+	if text, ok := values[slog.TimeKey].(string); ok {
+		entry.Time, _ = time.Parse(time.RFC3339Nano, text)
+	}
+	if text, ok := values[slog.LevelKey].(string); ok {
+		_ = entry.Level.UnmarshalText([]byte(text))
+	}
+	entry.Message, _ = values[slog.MessageKey].(string)
+	entry.Component, _ = values[ComponentKey].(string)
+
+	for _, key := range []string{slog.TimeKey, slog.LevelKey, slog.MessageKey, ComponentKey} {
+		delete(values, key)
+	}
+	for key, value := range values {
+		entry.Fields = append(entry.Fields, key+"="+formatFieldValue(value))
+	}
+	sort.Strings(entry.Fields)
+	return entry
+}
+
+// formatFieldValue renders an attribute value, quoting strings with spaces
+func formatFieldValue(value any) string {
+	if text, ok := value.(string); ok {
+		if strings.ContainsAny(text, " \t\"=") {
+			return fmt.Sprintf("%q", text)
+		}
+		return text
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// ReadTail parses the newest entries of the log file, at most limit of them.
+// A missing file has no entries.
+func ReadTail(path string, limit int) ([]Entry, error) {
+	//nolint:gosec // G304: The path is the log file inside the platform log directory
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat log file: %w", err)
+	}
+	offset := info.Size() - tailBytes
+	if offset < 0 {
+		offset = 0
+	}
+	data, err := io.ReadAll(io.NewSectionReader(file, offset, info.Size()-offset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log file: %w", err)
+	}
+	if offset > 0 {
+		// Drop the line cut in half by the offset
+		if newline := bytes.IndexByte(data, '\n'); newline >= 0 {
+			data = data[newline+1:]
+		}
+	}
+
+	var entries []Entry
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			entries = append(entries, ParseLine(line))
+		}
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+// Filter returns the entries at or above minLevel, from component when it is not empty
+func Filter(entries []Entry, minLevel slog.Level, component string) []Entry {
+	var filtered []Entry
+	for _, entry := range entries {
+		if entry.Level >= minLevel && (component == "" || entry.Component == component) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// Components returns the sorted names of the components that wrote entries
+func Components(entries []Entry) []string {
+	seen := make(map[string]bool)
+	var components []string
+	for _, entry := range entries {
+		if entry.Component != "" && !seen[entry.Component] {
+			seen[entry.Component] = true
+			components = append(components, entry.Component)
+		}
+	}
+	sort.Strings(components)
+	return components
+}
+
+// NextLevel cycles a level filter through debug, info, warn and error
+func NextLevel(level slog.Level) slog.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return slog.LevelInfo
+	case level < slog.LevelWarn:
+		return slog.LevelWarn
+	case level < slog.LevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelDebug
+	}
+}
+
+// FormatEntry formats an entry as one line, e.g. "14:02:11 WARN  [storage] msg key=value"
+func FormatEntry(entry Entry) string {
+	timestamp := "--:--:--"
+	if !entry.Time.IsZero() {
+		timestamp = entry.Time.Local().Format("15:04:05")
+	}
+	component := entry.Component
+	if component == "" {
+		component = "-"
+	}
+	line := fmt.Sprintf("%s %-5s [%s] %s", timestamp, entry.Level, component, entry.Message)
+	if len(entry.Fields) > 0 {
+		line += " " + strings.Join(entry.Fields, " ")
+	}
+	return line
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLine(t *testing.T) {
+	entry := ParseLine([]byte(`{"time":"2026-01-02T03:04:05Z","level":"WARN","msg":"toggle retried","component":"claude","mcp":"github","took":"8s","attempt":2,"ok":false}`))
+	expected := Entry{
+		Time:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:     slog.LevelWarn,
+		Component: "claude",
+		Message:   "toggle retried",
+		Fields:    []string{"attempt=2", "mcp=github", "ok=false", "took=8s"},
+	}
+	if !reflect.DeepEqual(entry, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, entry)
+	}
+
+	plain := ParseLine([]byte("2025/01/01 10:00:00 serve: failed"))
+	if plain.Level != slog.LevelInfo || plain.Message != "2025/01/01 10:00:00 serve: failed" {
+		t.Errorf("Plain lines should be kept as info messages, got %+v", plain)
+	}
+}
+
+func TestReadTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if entries, err := ReadTail(path, 10); err != nil || entries != nil {
+		t.Errorf("Missing file should have no entries, got %v, %v", entries, err)
+	}
+
+	var lines []string
+	for i := range 5 {
+		lines = append(lines, fmt.Sprintf(`{"level":"INFO","msg":"message %d"}`, i))
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ReadTail(path, 2)
+	if err != nil || len(entries) != 2 || entries[0].Message != "message 3" || entries[1].Message != "message 4" {
+		t.Errorf("Expected the two newest entries, got %+v, %v", entries, err)
+	}
+}
+
+func TestFilterAndComponents(t *testing.T) {
+	entries := []Entry{
+		{Level: slog.LevelDebug, Component: "storage", Message: "loaded"},
+		{Level: slog.LevelWarn, Component: "claude", Message: "slow"},
+		{Level: slog.LevelError, Component: "storage", Message: "corrupted"},
+		{Level: slog.LevelInfo, Message: "plain"},
+	}
+	if filtered := Filter(entries, slog.LevelWarn, ""); len(filtered) != 2 {
+		t.Errorf("Expected warn and error entries, got %+v", filtered)
+	}
+	if filtered := Filter(entries, slog.LevelDebug, "storage"); len(filtered) != 2 || filtered[1].Message != "corrupted" {
+		t.Errorf("Expected storage entries, got %+v", filtered)
+	}
+	if components := Components(entries); !reflect.DeepEqual(components, []string{"claude", "storage"}) {
+		t.Errorf("Unexpected components %v", components)
+	}
+}
+
+func TestNextLevel(t *testing.T) {
+	level := slog.LevelDebug
+	var cycle []slog.Level
+	for range 4 {
+		level = NextLevel(level)
+		cycle = append(cycle, level)
+	}
+	if !reflect.DeepEqual(cycle, []slog.Level{slog.LevelInfo, slog.LevelWarn, slog.LevelError, slog.LevelDebug}) {
+		t.Errorf("Unexpected cycle %v", cycle)
+	}
+}
+
+func TestFormatEntry(t *testing.T) {
+	entry := Entry{
+		Time:      time.Date(2026, 1, 2, 14, 2, 11, 0, time.Local),
+		Level:     slog.LevelWarn,
+		Component: "storage",
+		Message:   "inventory corrupted",
+		Fields:    []string{"backup=inventory.json.corrupted"},
+	}
+	if line := FormatEntry(entry); line != "14:02:11 WARN  [storage] inventory corrupted backup=inventory.json.corrupted" {
+		t.Errorf("Unexpected line %q", line)
+	}
+	if line := FormatEntry(Entry{Level: slog.LevelInfo, Message: "plain"}); line != "--:--:-- INFO  [-] plain" {
+		t.Errorf("Unexpected line %q", line)
+	}
+}
@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// RotatingFile is an append-only log file that is renamed to path.1 when a write would
+// take it past its size limit, shifting older backups up to path.N
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	mode       os.FileMode
+	file       *os.File
+	size       int64
+}
+
+// OpenRotatingFile opens path for appending, keeping at most maxBackups rotated files
+func OpenRotatingFile(path string, maxSize int64, maxBackups int, mode os.FileMode) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups, mode: mode}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// BackupPath returns the name of the nth rotated log, e.g. mcp-hub.log.1
+func BackupPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+// Write appends p, rotating first when the file would grow past its limit
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *RotatingFile) open() error {
+	//nolint:gosec // G304: The path is the log file inside the platform log directory
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, r.mode)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+// rotate shifts path.N-1 to path.N down to path to path.1 and starts a new file
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	r.file = nil
+
+	_ = os.Remove(BackupPath(r.path, r.maxBackups))
+	for n := r.maxBackups - 1; n >= 1; n-- {
+		_ = os.Rename(BackupPath(r.path, n), BackupPath(r.path, n+1))
+	}
+	var err error
+	if r.maxBackups > 0 {
+		err = os.Rename(r.path, BackupPath(r.path, 1))
+	} else {
+		err = os.Remove(r.path)
+	}
+	// Keep logging to the old file when it cannot be moved aside
+	if openErr := r.open(); openErr != nil {
+		return openErr
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile_RotatesAndKeepsBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	file, err := OpenRotatingFile(path, 10, 2, 0o600)
+	if err != nil {
+		t.Fatalf("OpenRotatingFile failed: %v", err)
+	}
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	for path, expected := range map[string]string{
+		path:                "fourth\n",
+		BackupPath(path, 1): "third\n",
+		BackupPath(path, 2): "second\n",
+	} {
+		if data, err := os.ReadFile(path); err != nil || string(data) != expected {
+			t.Errorf("%s: expected %q, got %q, %v", path, expected, data, err)
+		}
+	}
+	if _, err := os.Stat(BackupPath(path, 3)); !os.IsNotExist(err) {
+		t.Error("Only two backups should be kept")
+	}
+	if _, err := file.Write([]byte("late")); err == nil {
+		t.Error("Writing to a closed file should fail")
+	}
+}
+
+func TestRotatingFile_AppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("old line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := OpenRotatingFile(path, 12, 1, 0o600)
+	if err != nil {
+		t.Fatalf("OpenRotatingFile failed: %v", err)
+	}
+	_, _ = file.Write([]byte("new\n"))
+	_ = file.Close()
+
+	current, _ := os.ReadFile(path)
+	backup, _ := os.ReadFile(BackupPath(path, 1))
+	if string(current) != "new\n" || !strings.Contains(string(backup), "old line") {
+		t.Errorf("The existing size should count toward rotation, got %q and %q", current, backup)
+	}
+}
@@ -0,0 +1,135 @@
+// Package manage serves mcp-hub's own inventory operations as MCP tools, so Claude
+// can list, enable and disable servers and apply profiles on the user's behalf.
+package manage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// ServerInfo identifies the management server to MCP clients
+var ServerInfo = mcp.Implementation{Name: "mcp-hub-manage", Version: "1.0"}
+
+// ConfirmPolicy decides whether mutating tools need an explicit confirm argument
+type ConfirmPolicy string
+
+const (
+	// ConfirmAlways makes mutating tools report what they would do until called with confirm: true
+	ConfirmAlways ConfirmPolicy = "always"
+	// ConfirmNever applies changes as soon as a mutating tool is called
+	ConfirmNever ConfirmPolicy = "never"
+)
+
+// ParseConfirmPolicy parses a policy name; an empty name selects ConfirmAlways
+func ParseConfirmPolicy(name string) (ConfirmPolicy, error) {
+	switch ConfirmPolicy(name) {
+	case "", ConfirmAlways:
+		return ConfirmAlways, nil
+	case ConfirmNever:
+		return ConfirmNever, nil
+	}
+	return "", fmt.Errorf("unknown confirmation policy %q (expected %s or %s)", name, ConfirmAlways, ConfirmNever)
+}
+
+// Claude is the part of services.ClaudeService the management server uses
+type Claude interface {
+	RefreshClaudeStatus(ctx context.Context) types.ClaudeStatus
+	ToggleMCPStatus(ctx context.Context, mcpName string, activate bool, mcpConfig *types.MCPItem) (*services.ToggleResult, error)
+}
+
+// Server answers MCP requests with inventory management tools
+type Server struct {
+	platformService platform.PlatformService
+	claude          Claude
+	policy          ConfirmPolicy
+
+	// mu serializes inventory changes so concurrent calls do not overwrite each other
+	mu sync.Mutex
+}
+
+// NewServer creates a management server backed by the inventory of platformService
+func NewServer(platformService platform.PlatformService, claude Claude, policy ConfirmPolicy) *Server {
+	return &Server{platformService: platformService, claude: claude, policy: policy}
+}
+
+// Respond answers one request from the MCP client
+func (s *Server) Respond(ctx context.Context, request mcp.Message) *mcp.Message {
+	if !request.IsRequest() {
+		return nil
+	}
+
+	var result interface{}
+	var rpcErr *mcp.RPCError
+	switch request.Method {
+	case mcp.MethodInitialize:
+		result = s.initializeResult(request.Params)
+	case mcp.MethodPing:
+		result = struct{}{}
+	case mcp.MethodToolsList:
+		result = mcp.ListToolsResult{Tools: Tools()}
+	case mcp.MethodToolsCall:
+		var params mcp.CallToolParams
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			rpcErr = &mcp.RPCError{Code: mcp.ErrorCodeInvalidParams, Message: err.Error()}
+			break
+		}
+		result, rpcErr = s.CallTool(ctx, params.Name, params.Arguments)
+	default:
+		rpcErr = &mcp.RPCError{Code: mcp.ErrorCodeMethodNotFound, Message: "method not found: " + request.Method}
+	}
+
+	response, err := mcp.NewResponse(request.ID, result, rpcErr)
+	if err != nil {
+		response, _ = mcp.NewResponse(request.ID, nil, &mcp.RPCError{Code: mcp.ErrorCodeInternalError, Message: err.Error()})
+	}
+	return response
+}
+
+// initializeResult echoes the client's protocol version and advertises tools only
+func (s *Server) initializeResult(rawParams json.RawMessage) mcp.InitializeResult {
+	var params mcp.InitializeParams
+	_ = json.Unmarshal(rawParams, &params)
+	version := params.ProtocolVersion
+	if version == "" {
+		version = mcp.LatestProtocolVersion
+	}
+
+	instructions := "Manage the MCP servers in the user's mcp-hub inventory."
+	if s.policy == ConfirmAlways {
+		instructions += " Mutating tools only describe the change until called with confirm: true; ask the user first."
+	}
+	return mcp.InitializeResult{
+		ProtocolVersion: version,
+		ServerInfo:      ServerInfo,
+		Capabilities:    mcp.ServerCapabilities{Tools: &mcp.ListChangedCapability{}},
+		Instructions:    instructions,
+	}
+}
+
+// Serve answers newline-delimited JSON-RPC from r on w until r ends. Inventory
+// changes are serialized anyway, so requests are handled one at a time.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		var message mcp.Message
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil || !message.IsRequest() {
+			continue
+		}
+		if err := encoder.Encode(s.Respond(ctx, message)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
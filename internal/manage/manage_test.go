@@ -0,0 +1,114 @@
+package manage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// fakeClaude records toggles instead of running the Claude CLI
+type fakeClaude struct {
+	status  types.ClaudeStatus
+	toggles []string
+	fail    map[string]bool
+}
+
+func (f *fakeClaude) RefreshClaudeStatus(context.Context) types.ClaudeStatus {
+	return f.status
+}
+
+func (f *fakeClaude) ToggleMCPStatus(_ context.Context, mcpName string, activate bool, _ *types.MCPItem) (*services.ToggleResult, error) {
+	if f.fail[mcpName] {
+		return &services.ToggleResult{MCPName: mcpName, ErrorMsg: "permission denied"}, nil
+	}
+	f.toggles = append(f.toggles, fmt.Sprintf("%s=%v", mcpName, activate))
+	return &services.ToggleResult{Success: true, MCPName: mcpName}, nil
+}
+
+// newTestServer returns a server over a temporary inventory with github enabled and postgres disabled
+func newTestServer(t *testing.T, policy ConfirmPolicy) (*Server, *fakeClaude, platform.PlatformService) {
+	t.Helper()
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+
+	items := []types.MCPItem{
+		{Name: "github", Type: "CMD", Command: "github-mcp", Args: []string{"--stdio"}, Active: true},
+		{Name: "postgres", Type: "CMD", Command: "pg-mcp"},
+		{Name: "docs", Type: "HTTP", URL: "https://docs.example.com/mcp"},
+	}
+	if err := services.SaveInventory(items, mockPlatform); err != nil {
+		t.Fatal(err)
+	}
+
+	claude := &fakeClaude{fail: map[string]bool{}}
+	return NewServer(mockPlatform, claude, policy), claude, mockPlatform
+}
+
+func TestParseConfirmPolicy(t *testing.T) {
+	for name, expected := range map[string]ConfirmPolicy{"": ConfirmAlways, "always": ConfirmAlways, "never": ConfirmNever} {
+		if policy, err := ParseConfirmPolicy(name); err != nil || policy != expected {
+			t.Errorf("ParseConfirmPolicy(%q) = %v, %v", name, policy, err)
+		}
+	}
+	if _, err := ParseConfirmPolicy("sometimes"); err == nil {
+		t.Error("Unknown policy should be rejected")
+	}
+}
+
+func TestServer_Serve(t *testing.T) {
+	server, _, _ := newTestServer(t, ConfirmAlways)
+
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	go func() {
+		_ = server.Serve(context.Background(), serverReader, serverWriter)
+		_ = serverWriter.Close()
+	}()
+	client := mcp.NewClient(mcp.NewStreamTransport(clientReader, clientWriter))
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	result, err := client.Initialize(ctx, mcp.DefaultClientInfo)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if result.ServerInfo.Name != "mcp-hub-manage" || result.Capabilities.Tools == nil {
+		t.Errorf("Unexpected initialize result %+v", result)
+	}
+
+	tools, err := client.ListTools(ctx)
+	if err != nil || len(tools) != len(Tools()) {
+		t.Fatalf("tools/list = %d tools, %v", len(tools), err)
+	}
+
+	call, err := client.CallTool(ctx, ToolListServers, nil)
+	if err != nil || call.IsError {
+		t.Fatalf("list_servers failed: %+v, %v", call, err)
+	}
+	var listed struct {
+		Servers []serverSummary `json:"servers"`
+	}
+	if err := json.Unmarshal(call.StructuredContent, &listed); err != nil || len(listed.Servers) != 3 {
+		t.Fatalf("Unexpected servers %s, %v", call.StructuredContent, err)
+	}
+	if first := listed.Servers[0]; first.Name != "github" || !first.Enabled || first.Target != "github-mcp --stdio" {
+		t.Errorf("Unexpected first server %+v", first)
+	}
+	if listed.Servers[2].Target != "https://docs.example.com/mcp" {
+		t.Errorf("URL servers should report their URL, got %+v", listed.Servers[2])
+	}
+
+	if _, err := client.CallTool(ctx, "format_disk", nil); err == nil {
+		t.Error("Unknown tool should be a protocol error")
+	}
+}
@@ -0,0 +1,342 @@
+package manage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// Tool names offered by the management server
+const (
+	ToolListServers   = "list_servers"
+	ToolListProfiles  = "list_profiles"
+	ToolGetSyncStatus = "get_sync_status"
+	ToolEnableServer  = "enable_server"
+	ToolDisableServer = "disable_server"
+	ToolApplyProfile  = "apply_profile"
+	ToolSaveProfile   = "save_profile"
+)
+
+var (
+	readOnlyAnnotations = json.RawMessage(`{"readOnlyHint":true}`)
+	mutatingAnnotations = json.RawMessage(`{"readOnlyHint":false,"idempotentHint":true}`)
+
+	noArgumentsSchema = json.RawMessage(`{"type":"object","properties":{}}`)
+	nameSchema        = `{"type":"object","properties":{` +
+		`"name":{"type":"string","description":%q},` +
+		`"confirm":{"type":"boolean","description":"Set to true once the user has agreed to the change"}` +
+		`},"required":["name"]}`
+)
+
+// Tools returns the tools offered by the management server
+func Tools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        ToolListServers,
+			Description: "List the MCP servers in the mcp-hub inventory and whether each is enabled.",
+			InputSchema: noArgumentsSchema,
+			Annotations: readOnlyAnnotations,
+		},
+		{
+			Name:        ToolListProfiles,
+			Description: "List the saved profiles, each a named set of servers to enable together.",
+			InputSchema: noArgumentsSchema,
+			Annotations: readOnlyAnnotations,
+		},
+		{
+			Name:        ToolGetSyncStatus,
+			Description: "Compare the servers enabled in mcp-hub with the servers configured in Claude.",
+			InputSchema: noArgumentsSchema,
+			Annotations: readOnlyAnnotations,
+		},
+		{
+			Name:        ToolEnableServer,
+			Description: "Enable an inventory server by adding it to Claude.",
+			InputSchema: json.RawMessage(fmt.Sprintf(nameSchema, "Server name as shown by list_servers")),
+			Annotations: mutatingAnnotations,
+		},
+		{
+			Name:        ToolDisableServer,
+			Description: "Disable an inventory server by removing it from Claude. It stays in the inventory.",
+			InputSchema: json.RawMessage(fmt.Sprintf(nameSchema, "Server name as shown by list_servers")),
+			Annotations: mutatingAnnotations,
+		},
+		{
+			Name:        ToolApplyProfile,
+			Description: "Enable exactly the servers of a saved profile and disable all others.",
+			InputSchema: json.RawMessage(fmt.Sprintf(nameSchema, "Profile name as shown by list_profiles")),
+			Annotations: mutatingAnnotations,
+		},
+		{
+			Name:        ToolSaveProfile,
+			Description: "Save the currently enabled servers as a profile, replacing any profile with the same name.",
+			InputSchema: json.RawMessage(fmt.Sprintf(nameSchema, "Profile name")),
+			Annotations: mutatingAnnotations,
+		},
+	}
+}
+
+// serverSummary is one inventory entry as reported by list_servers
+type serverSummary struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+	Target  string `json:"target,omitempty"` // Command line or URL
+}
+
+// CallTool runs one management tool. Unknown tools are a protocol error;
+// failures of a known tool are reported in the result with IsError set.
+func (s *Server) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*mcp.CallToolResult, *mcp.RPCError) {
+	target, _ := arguments["name"].(string)
+	confirmed, _ := arguments["confirm"].(bool)
+
+	switch name {
+	case ToolListServers:
+		return s.listServers()
+	case ToolListProfiles:
+		return s.listProfiles()
+	case ToolGetSyncStatus:
+		return s.getSyncStatus(ctx)
+	case ToolEnableServer, ToolDisableServer:
+		if target == "" {
+			return nil, missingName()
+		}
+		return s.setServerEnabled(ctx, target, name == ToolEnableServer, confirmed), nil
+	case ToolApplyProfile:
+		if target == "" {
+			return nil, missingName()
+		}
+		return s.applyProfile(ctx, target, confirmed), nil
+	case ToolSaveProfile:
+		if target == "" {
+			return nil, missingName()
+		}
+		return s.saveProfile(target, confirmed), nil
+	}
+	return nil, &mcp.RPCError{Code: mcp.ErrorCodeInvalidParams, Message: "unknown tool: " + name}
+}
+
+// missingName rejects a mutating call without its name argument
+func missingName() *mcp.RPCError {
+	return &mcp.RPCError{Code: mcp.ErrorCodeInvalidParams, Message: `missing required argument "name"`}
+}
+
+// listServers reports every inventory entry
+func (s *Server) listServers() (*mcp.CallToolResult, *mcp.RPCError) {
+	items, err := services.LoadInventory(s.platformService)
+	if err != nil {
+		return errorResult("Failed to load inventory: %v", err), nil
+	}
+
+	servers := make([]serverSummary, 0, len(items))
+	for _, item := range items {
+		target := item.URL
+		if item.Command != "" {
+			target = strings.TrimSpace(item.Command + " " + strings.Join(item.Args, " "))
+		}
+		servers = append(servers, serverSummary{Name: item.Name, Type: item.Type, Enabled: item.Active, Target: target})
+	}
+	return jsonResult(map[string]interface{}{"servers": servers})
+}
+
+// listProfiles reports the saved profiles
+func (s *Server) listProfiles() (*mcp.CallToolResult, *mcp.RPCError) {
+	profiles, err := services.LoadProfiles(s.platformService)
+	if err != nil {
+		return errorResult("%v", err), nil
+	}
+	return jsonResult(map[string]interface{}{"profiles": profiles})
+}
+
+// getSyncStatus compares the inventory's enabled set with what Claude reports
+func (s *Server) getSyncStatus(ctx context.Context) (*mcp.CallToolResult, *mcp.RPCError) {
+	items, err := services.LoadInventory(s.platformService)
+	if err != nil {
+		return errorResult("Failed to load inventory: %v", err), nil
+	}
+
+	return jsonResult(services.BuildSyncReport(items, s.claude.RefreshClaudeStatus(ctx)))
+}
+
+// setServerEnabled enables or disables one server in Claude and records it in the inventory
+func (s *Server) setServerEnabled(ctx context.Context, name string, enable bool, confirmed bool) *mcp.CallToolResult {
+	verb, tool := "disable", ToolDisableServer
+	if enable {
+		verb, tool = "enable", ToolEnableServer
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := services.LoadInventory(s.platformService)
+	if err != nil {
+		return errorResult("Failed to load inventory: %v", err)
+	}
+	item := findItem(items, name)
+	if item == nil {
+		return errorResult("No server named %q in the inventory. Call %s to see the available names.", name, ToolListServers)
+	}
+	if item.Active == enable {
+		return textResult(fmt.Sprintf("%s is already %sd.", name, verb))
+	}
+	if s.needsConfirmation(confirmed) {
+		return confirmationResult(fmt.Sprintf("This would %s %s.", verb, name), tool)
+	}
+
+	if err := s.toggle(ctx, items, item, enable); err != nil {
+		return errorResult("Failed to %s %s: %v", verb, name, err)
+	}
+	return textResult(fmt.Sprintf("%sd %s. Claude loads the change when its session restarts.", capitalize(verb), name))
+}
+
+// applyProfile makes the profile's servers exactly the enabled set
+func (s *Server) applyProfile(ctx context.Context, name string, confirmed bool) *mcp.CallToolResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles, err := services.LoadProfiles(s.platformService)
+	if err != nil {
+		return errorResult("%v", err)
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return errorResult("No profile named %q. Call %s to see the saved profiles.", name, ToolListProfiles)
+	}
+	items, err := services.LoadInventory(s.platformService)
+	if err != nil {
+		return errorResult("Failed to load inventory: %v", err)
+	}
+
+	changes := services.ComputeProfileChanges(items, profile)
+	summary := describeProfileChanges(changes)
+	if len(changes.Enable) == 0 && len(changes.Disable) == 0 {
+		return textResult(fmt.Sprintf("Profile %s is already applied.%s", name, summary))
+	}
+	if s.needsConfirmation(confirmed) {
+		return confirmationResult(fmt.Sprintf("Applying profile %s would change:%s", name, summary), ToolApplyProfile)
+	}
+
+	var failures []string
+	for _, change := range []struct {
+		names  []string
+		enable bool
+	}{{changes.Disable, false}, {changes.Enable, true}} {
+		for _, serverName := range change.names {
+			if err := s.toggle(ctx, items, findItem(items, serverName), change.enable); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", serverName, err))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return errorResult("Profile %s was applied partially:\n%s", name, strings.Join(failures, "\n"))
+	}
+	return textResult(fmt.Sprintf("Applied profile %s.%s", name, summary))
+}
+
+// saveProfile stores the enabled set under a profile name
+func (s *Server) saveProfile(name string, confirmed bool) *mcp.CallToolResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := services.LoadInventory(s.platformService)
+	if err != nil {
+		return errorResult("Failed to load inventory: %v", err)
+	}
+	profiles, err := services.LoadProfiles(s.platformService)
+	if err != nil {
+		return errorResult("%v", err)
+	}
+
+	enabled := services.ActiveMCPNames(items)
+	description := fmt.Sprintf("profile %s with %d servers: %s", name, len(enabled), strings.Join(enabled, ", "))
+	if s.needsConfirmation(confirmed) {
+		action := "This would save "
+		if _, exists := profiles[name]; exists {
+			action = "This would replace "
+		}
+		return confirmationResult(action+description+".", ToolSaveProfile)
+	}
+
+	if enabled == nil {
+		enabled = []string{}
+	}
+	profiles[name] = enabled
+	if err := services.SaveProfiles(s.platformService, profiles); err != nil {
+		return errorResult("%v", err)
+	}
+	return textResult("Saved " + description + ".")
+}
+
+// toggle changes one server in Claude, then saves its new state in the inventory
+func (s *Server) toggle(ctx context.Context, items []types.MCPItem, item *types.MCPItem, enable bool) error {
+	return services.SetMCPActive(ctx, s.claude, s.platformService, items, services.FindMCPIndex(items, item.Name), enable)
+}
+
+// needsConfirmation reports whether a mutating call must stop at describing the change
+func (s *Server) needsConfirmation(confirmed bool) bool {
+	return s.policy != ConfirmNever && !confirmed
+}
+
+// describeProfileChanges renders the changes of a profile as indented lines
+func describeProfileChanges(changes services.ProfileChanges) string {
+	var text strings.Builder
+	if len(changes.Enable) > 0 {
+		text.WriteString("\n  enable: " + strings.Join(changes.Enable, ", "))
+	}
+	if len(changes.Disable) > 0 {
+		text.WriteString("\n  disable: " + strings.Join(changes.Disable, ", "))
+	}
+	if len(changes.Missing) > 0 {
+		text.WriteString("\n  not in inventory, skipped: " + strings.Join(changes.Missing, ", "))
+	}
+	return text.String()
+}
+
+// findItem returns the inventory entry with the given name, or nil
+func findItem(items []types.MCPItem, name string) *types.MCPItem {
+	for i := range items {
+		if items[i].Name == name {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+// capitalize upper-cases the first letter of an ASCII verb
+func capitalize(verb string) string {
+	return strings.ToUpper(verb[:1]) + verb[1:]
+}
+
+// textResult wraps a message as tool output
+func textResult(text string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: text}}}
+}
+
+// errorResult reports a tool failure the model can read and act on
+func errorResult(format string, args ...interface{}) *mcp.CallToolResult {
+	result := textResult(fmt.Sprintf(format, args...))
+	result.IsError = true
+	return result
+}
+
+// confirmationResult stops a mutating call until the user agrees. It is flagged as an
+// error so the model does not report the change as done.
+func confirmationResult(description, tool string) *mcp.CallToolResult {
+	return errorResult("Confirmation required. %s Nothing was changed. Ask the user, then call %s again with confirm set to true.", description, tool)
+}
+
+// jsonResult returns value both as structured content and as its JSON text
+func jsonResult(value interface{}) (*mcp.CallToolResult, *mcp.RPCError) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, &mcp.RPCError{Code: mcp.ErrorCodeInternalError, Message: err.Error()}
+	}
+	result := textResult(string(data))
+	result.StructuredContent = data
+	return result, nil
+}
@@ -0,0 +1,160 @@
+package manage
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// resultText returns the text of a single-block tool result
+func resultText(t *testing.T, result *mcp.CallToolResult, rpcErr *mcp.RPCError) string {
+	t.Helper()
+	if rpcErr != nil {
+		t.Fatalf("Unexpected protocol error: %v", rpcErr)
+	}
+	if len(result.Content) != 1 {
+		t.Fatalf("Expected one content block, got %+v", result.Content)
+	}
+	return result.Content[0].Text
+}
+
+func TestEnableServer_RequiresConfirmation(t *testing.T) {
+	server, claude, platformService := newTestServer(t, ConfirmAlways)
+
+	result, rpcErr := server.CallTool(t.Context(), ToolEnableServer, map[string]interface{}{"name": "postgres"})
+	text := resultText(t, result, rpcErr)
+	if !result.IsError || !strings.Contains(text, "Confirmation required") || !strings.Contains(text, "enable postgres") {
+		t.Errorf("Unconfirmed call should only describe the change, got %q", text)
+	}
+	if len(claude.toggles) != 0 {
+		t.Fatalf("Unconfirmed call should not toggle, got %v", claude.toggles)
+	}
+
+	result, rpcErr = server.CallTool(t.Context(), ToolEnableServer, map[string]interface{}{"name": "postgres", "confirm": true})
+	if text := resultText(t, result, rpcErr); result.IsError || !strings.Contains(text, "Enabled postgres") {
+		t.Errorf("Confirmed call should enable, got %q", text)
+	}
+	if !reflect.DeepEqual(claude.toggles, []string{"postgres=true"}) {
+		t.Errorf("Expected postgres to be added to Claude, got %v", claude.toggles)
+	}
+	items, _ := services.LoadInventory(platformService)
+	if !items[1].Active {
+		t.Error("Enabled state should be saved in the inventory")
+	}
+}
+
+func TestDisableServer_NeverPolicyAndErrors(t *testing.T) {
+	server, claude, _ := newTestServer(t, ConfirmNever)
+
+	result, rpcErr := server.CallTool(t.Context(), ToolDisableServer, map[string]interface{}{"name": "github"})
+	if text := resultText(t, result, rpcErr); result.IsError || !strings.Contains(text, "Disabled github") {
+		t.Errorf("Never policy should apply immediately, got %q", text)
+	}
+
+	result, rpcErr = server.CallTool(t.Context(), ToolDisableServer, map[string]interface{}{"name": "github"})
+	if text := resultText(t, result, rpcErr); !strings.Contains(text, "already disabled") || len(claude.toggles) != 1 {
+		t.Errorf("Disabling twice should be a no-op, got %q, %v", text, claude.toggles)
+	}
+
+	result, rpcErr = server.CallTool(t.Context(), ToolEnableServer, map[string]interface{}{"name": "nope"})
+	if text := resultText(t, result, rpcErr); !result.IsError || !strings.Contains(text, ToolListServers) {
+		t.Errorf("Unknown server should point at list_servers, got %q", text)
+	}
+
+	claude.fail["postgres"] = true
+	result, rpcErr = server.CallTool(t.Context(), ToolEnableServer, map[string]interface{}{"name": "postgres"})
+	if text := resultText(t, result, rpcErr); !result.IsError || !strings.Contains(text, "permission denied") {
+		t.Errorf("Claude failure should be reported, got %q", text)
+	}
+
+	if _, rpcErr := server.CallTool(t.Context(), ToolEnableServer, nil); rpcErr == nil || rpcErr.Code != mcp.ErrorCodeInvalidParams {
+		t.Errorf("Missing name should be invalid params, got %v", rpcErr)
+	}
+}
+
+func TestProfiles_SaveAndApply(t *testing.T) {
+	server, claude, platformService := newTestServer(t, ConfirmNever)
+
+	result, rpcErr := server.CallTool(t.Context(), ToolSaveProfile, map[string]interface{}{"name": "review"})
+	if text := resultText(t, result, rpcErr); !strings.Contains(text, "1 servers: github") {
+		t.Errorf("Unexpected save result %q", text)
+	}
+
+	profiles := map[string][]string{"review": {"github"}, "data": {"postgres", "docs", "gone"}}
+	if err := services.SaveProfiles(platformService, profiles); err != nil {
+		t.Fatal(err)
+	}
+
+	result, rpcErr = server.CallTool(t.Context(), ToolApplyProfile, map[string]interface{}{"name": "data"})
+	text := resultText(t, result, rpcErr)
+	if result.IsError || !strings.Contains(text, "enable: postgres, docs") || !strings.Contains(text, "disable: github") || !strings.Contains(text, "skipped: gone") {
+		t.Errorf("Unexpected apply result %q", text)
+	}
+	if !reflect.DeepEqual(claude.toggles, []string{"github=false", "postgres=true", "docs=true"}) {
+		t.Errorf("Profile should disable before enabling, got %v", claude.toggles)
+	}
+	items, _ := services.LoadInventory(platformService)
+	if names := services.ActiveMCPNames(items); !reflect.DeepEqual(names, []string{"docs", "postgres"}) {
+		t.Errorf("Inventory should match the profile, got %v", names)
+	}
+
+	result, rpcErr = server.CallTool(t.Context(), ToolApplyProfile, map[string]interface{}{"name": "data"})
+	if text := resultText(t, result, rpcErr); !strings.Contains(text, "already applied") {
+		t.Errorf("Reapplying should be a no-op, got %q", text)
+	}
+
+	result, rpcErr = server.CallTool(t.Context(), ToolApplyProfile, map[string]interface{}{"name": "missing"})
+	if text := resultText(t, result, rpcErr); !result.IsError || !strings.Contains(text, ToolListProfiles) {
+		t.Errorf("Unknown profile should point at list_profiles, got %q", text)
+	}
+}
+
+func TestApplyProfile_RequiresConfirmation(t *testing.T) {
+	server, claude, platformService := newTestServer(t, ConfirmAlways)
+	if err := services.SaveProfiles(platformService, map[string][]string{"data": {"postgres"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, rpcErr := server.CallTool(t.Context(), ToolApplyProfile, map[string]interface{}{"name": "data"})
+	text := resultText(t, result, rpcErr)
+	if !result.IsError || !strings.Contains(text, "enable: postgres") || !strings.Contains(text, "disable: github") || len(claude.toggles) != 0 {
+		t.Errorf("Unconfirmed apply should only preview, got %q, %v", text, claude.toggles)
+	}
+}
+
+func TestGetSyncStatus(t *testing.T) {
+	server, claude, _ := newTestServer(t, ConfirmAlways)
+	claude.status = types.ClaudeStatus{Available: true, Version: "1.0.0", ActiveMCPs: []string{"figma"}, LastCheck: time.Now()}
+
+	result, rpcErr := server.CallTool(t.Context(), ToolGetSyncStatus, nil)
+	resultText(t, result, rpcErr)
+
+	var report services.SyncReport
+	if err := json.Unmarshal(result.StructuredContent, &report); err != nil {
+		t.Fatal(err)
+	}
+	expected := services.SyncReport{
+		Status:          "Out of Sync",
+		ClaudeAvailable: true,
+		ClaudeVersion:   "1.0.0",
+		EnabledInHub:    []string{"github"},
+		ActiveInClaude:  []string{"figma"},
+		OnlyInHub:       []string{"github"},
+		OnlyInClaude:    []string{"figma"},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("get_sync_status = %+v, expected %+v", report, expected)
+	}
+
+	claude.status.ActiveMCPs = []string{"github"}
+	result, _ = server.CallTool(t.Context(), ToolGetSyncStatus, nil)
+	if !strings.Contains(result.Content[0].Text, `"status": "In Sync"`) {
+		t.Errorf("Matching sets should be in sync, got %s", result.Content[0].Text)
+	}
+}
@@ -0,0 +1,286 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClientClosed is returned for calls made after the connection has ended
+var ErrClientClosed = errors.New("mcp connection closed")
+
+// DefaultClientInfo identifies mcp-hub to MCP servers
+var DefaultClientInfo = Implementation{Name: "mcp-hub", Version: "1.0"}
+
+// NotificationHandler receives notifications sent by the server
+type NotificationHandler func(Notification)
+
+// Client is an MCP client speaking JSON-RPC over a Transport
+type Client struct {
+	transport Transport
+	nextID    atomic.Int64
+
+	mu      sync.Mutex
+	pending map[string]chan *Message
+	onNotif NotificationHandler
+
+	done chan struct{}
+}
+
+// NewClient creates a client over the transport and starts dispatching incoming messages
+func NewClient(transport Transport) *Client {
+	c := &Client{
+		transport: transport,
+		pending:   make(map[string]chan *Message),
+		done:      make(chan struct{}),
+	}
+	go c.dispatchLoop()
+	return c
+}
+
+// SetNotificationHandler registers a handler for server notifications
+func (c *Client) SetNotificationHandler(handler NotificationHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onNotif = handler
+}
+
+// Done is closed when the underlying transport stops delivering messages
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Call sends a request and decodes the response result into result (which may be nil)
+func (c *Client) Call(ctx context.Context, method string, params, result interface{}) error {
+	id := c.nextID.Add(1)
+	request, err := newRequest(id, method, params)
+	if err != nil {
+		return err
+	}
+
+	responseCh := make(chan *Message, 1)
+	key := string(request.ID)
+	c.mu.Lock()
+	c.pending[key] = responseCh
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, key)
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(ctx, request); err != nil {
+		return err
+	}
+
+	select {
+	case response := <-responseCh:
+		if response.Error != nil {
+			return response.Error
+		}
+		if result == nil || len(response.Result) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(response.Result, result); err != nil {
+			return fmt.Errorf("failed to decode %s result: %w", method, err)
+		}
+		return nil
+	case <-c.done:
+		return ErrClientClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Notify sends a notification to the server
+func (c *Client) Notify(ctx context.Context, method string, params interface{}) error {
+	notification, err := NewNotification(method, params)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, notification)
+}
+
+// Initialize performs the MCP initialize / initialized handshake
+func (c *Client) Initialize(ctx context.Context, clientInfo Implementation) (*InitializeResult, error) {
+	params := InitializeParams{
+		ProtocolVersion: LatestProtocolVersion,
+		ClientInfo:      clientInfo,
+	}
+
+	var result InitializeResult
+	if err := c.Call(ctx, MethodInitialize, params, &result); err != nil {
+		return nil, fmt.Errorf("initialize failed: %w", err)
+	}
+
+	if err := c.Notify(ctx, MethodInitialized, nil); err != nil {
+		return nil, fmt.Errorf("initialized notification failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Ping checks that the server is responsive and returns the round-trip time
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	if err := c.Call(ctx, MethodPing, nil, nil); err != nil {
+		return 0, fmt.Errorf("ping failed: %w", err)
+	}
+	return time.Since(start), nil
+}
+
+// ListTools fetches every tool exposed by the server, following pagination cursors
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var tools []Tool
+	err := c.paginate(ctx, MethodToolsList, func(raw json.RawMessage) (string, error) {
+		var result ListToolsResult
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return "", err
+		}
+		tools = append(tools, result.Tools...)
+		return result.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tools, nil
+}
+
+// ListResources fetches every resource exposed by the server, following pagination cursors
+func (c *Client) ListResources(ctx context.Context) ([]Resource, error) {
+	var resources []Resource
+	err := c.paginate(ctx, MethodResourcesList, func(raw json.RawMessage) (string, error) {
+		var result ListResourcesResult
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return "", err
+		}
+		resources = append(resources, result.Resources...)
+		return result.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// ListPrompts fetches every prompt exposed by the server, following pagination cursors
+func (c *Client) ListPrompts(ctx context.Context) ([]Prompt, error) {
+	var prompts []Prompt
+	err := c.paginate(ctx, MethodPromptsList, func(raw json.RawMessage) (string, error) {
+		var result ListPromptsResult
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return "", err
+		}
+		prompts = append(prompts, result.Prompts...)
+		return result.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prompts, nil
+}
+
+// CallTool invokes a tool with the given arguments
+func (c *Client) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*CallToolResult, error) {
+	var result CallToolResult
+	if err := c.Call(ctx, MethodToolsCall, CallToolParams{Name: name, Arguments: arguments}, &result); err != nil {
+		return nil, fmt.Errorf("tools/call failed: %w", err)
+	}
+	return &result, nil
+}
+
+// paginate calls a list method until the server stops returning a new cursor.
+// collect decodes one page and returns its next cursor.
+func (c *Client) paginate(ctx context.Context, method string, collect func(json.RawMessage) (string, error)) error {
+	cursor := ""
+	for {
+		var page json.RawMessage
+		if err := c.Call(ctx, method, PaginatedParams{Cursor: cursor}, &page); err != nil {
+			return fmt.Errorf("%s failed: %w", method, err)
+		}
+		next, err := collect(page)
+		if err != nil {
+			return fmt.Errorf("%s returned an invalid page: %w", method, err)
+		}
+		if next == "" || next == cursor {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// Close closes the underlying transport
+func (c *Client) Close() error {
+	return c.transport.Close()
+}
+
+func (c *Client) send(ctx context.Context, message *Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	return c.transport.Send(ctx, data)
+}
+
+// dispatchLoop routes responses to waiting callers and handles server-initiated messages
+func (c *Client) dispatchLoop() {
+	defer close(c.done)
+
+	for data := range c.transport.Messages() {
+		var message Message
+		if err := json.Unmarshal(data, &message); err != nil {
+			// Servers sometimes print non-protocol output to stdout; ignore it
+			continue
+		}
+
+		switch {
+		case message.IsResponse():
+			c.deliverResponse(&message)
+		case message.IsRequest():
+			c.handleServerRequest(&message)
+		case message.IsNotification():
+			c.mu.Lock()
+			handler := c.onNotif
+			c.mu.Unlock()
+			if handler != nil {
+				handler(Notification{Method: message.Method, Params: message.Params})
+			}
+		}
+	}
+}
+
+func (c *Client) deliverResponse(message *Message) {
+	c.mu.Lock()
+	responseCh, ok := c.pending[string(message.ID)]
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	// A duplicate or late response must not block the dispatch of the others
+	select {
+	case responseCh <- message:
+	default:
+	}
+}
+
+// handleServerRequest answers requests the server sends to the client
+func (c *Client) handleServerRequest(message *Message) {
+	var response *Message
+	var err error
+	if message.Method == MethodPing {
+		response, err = NewResponse(message.ID, nil, nil)
+	} else {
+		response, err = NewResponse(message.ID, nil, &RPCError{
+			Code:    ErrorCodeMethodNotFound,
+			Message: "method not supported by client: " + message.Method,
+		})
+	}
+	if err != nil {
+		return
+	}
+	_ = c.send(context.Background(), response)
+}
@@ -0,0 +1,163 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClient_InitializeAndListTools(t *testing.T) {
+	client := newPipeClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := client.Initialize(ctx, DefaultClientInfo)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if result.ServerInfo.Name != "fake-server" {
+		t.Errorf("ServerInfo.Name = %q, expected fake-server", result.ServerInfo.Name)
+	}
+	if result.Capabilities.Tools == nil {
+		t.Error("Expected tools capability to be advertised")
+	}
+
+	tools, err := client.ListTools(ctx)
+	if err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+	if len(tools) != 2 || tools[0].Name != "echo" || tools[1].Name != "add" {
+		t.Errorf("ListTools should follow pagination, got %+v", tools)
+	}
+}
+
+func TestClient_ListResourcesAndPrompts(t *testing.T) {
+	client := newPipeClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resources, err := client.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if len(resources) != 2 || resources[1].URI != "file:///go.mod" {
+		t.Errorf("ListResources should follow pagination, got %+v", resources)
+	}
+
+	prompts, err := client.ListPrompts(ctx)
+	if err != nil {
+		t.Fatalf("ListPrompts failed: %v", err)
+	}
+	if len(prompts) != 1 || prompts[0].Name != "review" || !prompts[0].Arguments[0].Required {
+		t.Errorf("Unexpected prompts: %+v", prompts)
+	}
+}
+
+func TestClient_CallTool(t *testing.T) {
+	client := newPipeClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := client.CallTool(ctx, "echo", map[string]interface{}{"text": "hello"})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError || len(result.Content) != 1 || result.Content[0].Text != "hello" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	result, err = client.CallTool(ctx, "missing", nil)
+	if err != nil {
+		t.Fatalf("Tool-level errors should not be protocol errors: %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected isError for unknown tool")
+	}
+}
+
+func TestClient_Ping(t *testing.T) {
+	client := newPipeClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	latency, err := client.Ping(ctx)
+	if err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if latency <= 0 {
+		t.Errorf("Expected a positive round-trip time, got %v", latency)
+	}
+}
+
+func TestClient_MethodNotFound(t *testing.T) {
+	client := newPipeClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := client.Call(ctx, "does/not/exist", nil, nil)
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("Expected RPCError, got %v", err)
+	}
+	if rpcErr.Code != ErrorCodeMethodNotFound {
+		t.Errorf("Error code = %d, expected %d", rpcErr.Code, ErrorCodeMethodNotFound)
+	}
+}
+
+func TestClient_ClosedConnection(t *testing.T) {
+	client := newPipeClient(t)
+	_ = client.Close()
+
+	select {
+	case <-client.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Client should stop after the transport closes")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Call(ctx, MethodToolsList, nil, nil); err == nil {
+		t.Error("Call on a closed client should fail")
+	}
+}
+
+func TestClient_DuplicateResponseDoesNotBlock(t *testing.T) {
+	client := newPipeClient(t)
+	responseCh := make(chan *Message, 1)
+	client.mu.Lock()
+	client.pending["1"] = responseCh
+	client.mu.Unlock()
+
+	response, _ := NewResponse([]byte(`1`), nil, nil)
+	delivered := make(chan struct{})
+	go func() {
+		client.deliverResponse(response)
+		client.deliverResponse(response)
+		close(delivered)
+	}()
+	select {
+	case <-delivered:
+	case <-time.After(2 * time.Second):
+		t.Fatal("A second response with the same id should be dropped, not block dispatch")
+	}
+	if len(responseCh) != 1 {
+		t.Errorf("The first response should still be delivered, got %d", len(responseCh))
+	}
+}
+
+func TestMessageClassification(t *testing.T) {
+	request, _ := newRequest(1, MethodPing, nil)
+	notification, _ := NewNotification(MethodInitialized, nil)
+	response, _ := NewResponse(request.ID, nil, nil)
+
+	if !request.IsRequest() || request.IsNotification() || request.IsResponse() {
+		t.Error("Request misclassified")
+	}
+	if !notification.IsNotification() || notification.IsRequest() {
+		t.Error("Notification misclassified")
+	}
+	if !response.IsResponse() || response.IsRequest() {
+		t.Error("Response misclassified")
+	}
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+)
+
+// fakeServerTools are the tools exposed by the fake server, split over two pages
+var fakeServerTools = []Tool{
+	{Name: "echo", Description: "Echo the input back", InputSchema: json.RawMessage(`{"type":"object","properties":{"text":{"type":"string"}}}`)},
+	{Name: "add", Description: "Add two numbers", InputSchema: json.RawMessage(`{"type":"object","properties":{"a":{"type":"number"},"b":{"type":"number"}}}`)},
+}
+
+// fakeServerResources are the resources exposed by the fake server, split over two pages
+var fakeServerResources = []Resource{
+	{URI: "file:///README.md", Name: "README.md", MimeType: "text/markdown"},
+	{URI: "file:///go.mod", Name: "go.mod"},
+}
+
+// fakeServerPrompts are the prompts exposed by the fake server
+var fakeServerPrompts = []Prompt{
+	{Name: "review", Description: "Review a change", Arguments: []PromptArgument{{Name: "diff", Required: true}}},
+}
+
+// serveFake runs a minimal MCP server over newline-delimited JSON until the reader ends
+func serveFake(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	encoder := json.NewEncoder(w)
+	for scanner.Scan() {
+		var request Message
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil || !request.IsRequest() {
+			continue
+		}
+
+		var result interface{}
+		var rpcErr *RPCError
+		switch request.Method {
+		case MethodInitialize:
+			result = InitializeResult{
+				ProtocolVersion: LatestProtocolVersion,
+				ServerInfo:      Implementation{Name: "fake-server", Version: "0.1.0"},
+				Capabilities: ServerCapabilities{
+					Tools:     &ListChangedCapability{},
+					Resources: &ResourcesCapability{},
+					Prompts:   &ListChangedCapability{},
+				},
+			}
+		case MethodPing:
+			result = struct{}{}
+		case MethodToolsList:
+			var params PaginatedParams
+			_ = json.Unmarshal(request.Params, &params)
+			if params.Cursor == "" {
+				result = ListToolsResult{Tools: fakeServerTools[:1], NextCursor: "page-2"}
+			} else {
+				result = ListToolsResult{Tools: fakeServerTools[1:]}
+			}
+		case MethodResourcesList:
+			var params PaginatedParams
+			_ = json.Unmarshal(request.Params, &params)
+			if params.Cursor == "" {
+				result = ListResourcesResult{Resources: fakeServerResources[:1], NextCursor: "page-2"}
+			} else {
+				result = ListResourcesResult{Resources: fakeServerResources[1:]}
+			}
+		case MethodToolsCall:
+			var params CallToolParams
+			_ = json.Unmarshal(request.Params, &params)
+			if params.Name == "echo" {
+				text, _ := params.Arguments["text"].(string)
+				result = CallToolResult{Content: []ContentBlock{{Type: "text", Text: text}}}
+			} else {
+				result = CallToolResult{Content: []ContentBlock{{Type: "text", Text: "unknown tool"}}, IsError: true}
+			}
+		case MethodPromptsList:
+			result = ListPromptsResult{Prompts: fakeServerPrompts}
+		default:
+			rpcErr = &RPCError{Code: ErrorCodeMethodNotFound, Message: "unknown method"}
+		}
+
+		response, _ := NewResponse(request.ID, result, rpcErr)
+		_ = encoder.Encode(response)
+	}
+}
+
+// newPipeClient connects a client to an in-memory fake server
+func newPipeClient(t *testing.T) *Client {
+	t.Helper()
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	go func() {
+		serveFake(serverReader, serverWriter)
+		_ = serverWriter.Close()
+	}()
+
+	client := NewClient(NewStreamTransport(clientReader, clientWriter))
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// TestHelperProcess is not a real test; it runs the fake server when spawned by stdio tests
+func TestHelperProcess(_ *testing.T) {
+	if os.Getenv("MCP_HUB_FAKE_SERVER") != "1" {
+		return
+	}
+	serveFake(os.Stdin, os.Stdout)
+	os.Exit(0)
+}
@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPConfig describes how to reach a URL-based MCP server
+type HTTPConfig struct {
+	URL     string
+	Headers map[string]string
+	// Client is the HTTP client to use; http.DefaultClient when nil
+	Client *http.Client
+}
+
+// httpClient returns the configured HTTP client or the default one
+func (c HTTPConfig) httpClient() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return http.DefaultClient
+}
+
+// newRequest builds a request carrying the configured headers
+func (c HTTPConfig) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MCP server URL %q: %w", url, err)
+	}
+	for key, value := range c.Headers {
+		req.Header.Set(key, value)
+	}
+	return req, nil
+}
+
+// sseEvent is a single server-sent event
+type sseEvent struct {
+	Event string
+	Data  string
+}
+
+// readSSEEvents parses a text/event-stream body and calls handle for each event
+// until the stream ends or handle returns false
+func readSSEEvents(r io.Reader, handle func(sseEvent) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	var event sseEvent
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				if event.Event == "" {
+					event.Event = "message"
+				}
+				if !handle(event) {
+					return nil
+				}
+			}
+			event, data = sseEvent{}, nil
+		case strings.HasPrefix(line, ":"):
+			// Comment, commonly used as a keep-alive
+		default:
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "event":
+				event.Event = value
+			case "data":
+				data = append(data, value)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// httpStatusError describes an unexpected HTTP response
+func httpStatusError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		return fmt.Errorf("MCP server returned HTTP %d", resp.StatusCode)
+	}
+	return fmt.Errorf("MCP server returned HTTP %d: %s", resp.StatusCode, message)
+}
+
+// closeTimeout bounds best-effort requests made while closing a transport
+const closeTimeout = 2 * time.Second
@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadSSEEvents(t *testing.T) {
+	stream := strings.Join([]string{
+		": keep-alive",
+		"event: endpoint",
+		"data: /messages?session=1",
+		"",
+		"data: {\"jsonrpc\":\"2.0\",",
+		"data:  \"id\":1}",
+		"",
+		"event: ignored",
+		"",
+		"data: last",
+		"",
+		"data: incomplete events at EOF are dropped",
+	}, "\n")
+
+	var events []sseEvent
+	if err := readSSEEvents(strings.NewReader(stream), func(event sseEvent) bool {
+		events = append(events, event)
+		return true
+	}); err != nil {
+		t.Fatalf("readSSEEvents failed: %v", err)
+	}
+
+	expected := []sseEvent{
+		{Event: "endpoint", Data: "/messages?session=1"},
+		{Event: "message", Data: "{\"jsonrpc\":\"2.0\",\n \"id\":1}"},
+		{Event: "message", Data: "last"},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("readSSEEvents() = %+v, expected %+v", events, expected)
+	}
+}
+
+func TestReadSSEEvents_StopsEarly(t *testing.T) {
+	count := 0
+	_ = readSSEEvents(strings.NewReader("data: 1\n\ndata: 2\n\n"), func(sseEvent) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("Expected handler to stop after the first event, got %d calls", count)
+	}
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"/messages?session=1":           "http://localhost:8080/messages?session=1",
+		"?session=2":                    "http://localhost:8080/sse?session=2",
+		"http://other:9000/rpc?token=x": "http://other:9000/rpc?token=x",
+	}
+	for endpoint, expected := range tests {
+		resolved, err := resolveEndpoint("http://localhost:8080/sse", endpoint)
+		if err != nil || resolved != expected {
+			t.Errorf("resolveEndpoint(%q) = %q, %v; expected %q", endpoint, resolved, err, expected)
+		}
+	}
+}
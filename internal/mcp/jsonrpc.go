@@ -0,0 +1,119 @@
+// Package mcp provides a minimal Model Context Protocol client built on JSON-RPC 2.0.
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSONRPCVersion is the JSON-RPC protocol version used by MCP
+const JSONRPCVersion = "2.0"
+
+// Standard JSON-RPC error codes
+const (
+	ErrorCodeParseError     = -32700
+	ErrorCodeInvalidRequest = -32600
+	ErrorCodeMethodNotFound = -32601
+	ErrorCodeInvalidParams  = -32602
+	ErrorCodeInternalError  = -32603
+)
+
+// Message represents any JSON-RPC 2.0 message (request, notification or response)
+type Message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// IsRequest reports whether the message is a request expecting a response
+func (m *Message) IsRequest() bool {
+	return m.Method != "" && len(m.ID) > 0
+}
+
+// IsNotification reports whether the message is a notification
+func (m *Message) IsNotification() bool {
+	return m.Method != "" && len(m.ID) == 0
+}
+
+// IsResponse reports whether the message is a response to a request
+func (m *Message) IsResponse() bool {
+	return m.Method == "" && len(m.ID) > 0
+}
+
+// RPCError represents a JSON-RPC error object
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+// Notification represents a JSON-RPC notification received from a server
+type Notification struct {
+	Method string
+	Params json.RawMessage
+}
+
+// newRequest builds a request message with the given id
+func newRequest(id int64, method string, params interface{}) (*Message, error) {
+	msg := &Message{
+		JSONRPC: JSONRPCVersion,
+		ID:      json.RawMessage(fmt.Sprintf("%d", id)),
+		Method:  method,
+	}
+	if err := msg.setParams(params); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// NewNotification builds a notification message
+func NewNotification(method string, params interface{}) (*Message, error) {
+	msg := &Message{
+		JSONRPC: JSONRPCVersion,
+		Method:  method,
+	}
+	if err := msg.setParams(params); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// NewResponse builds a response message for the given request id
+func NewResponse(id json.RawMessage, result interface{}, rpcErr *RPCError) (*Message, error) {
+	msg := &Message{
+		JSONRPC: JSONRPCVersion,
+		ID:      id,
+		Error:   rpcErr,
+	}
+	if rpcErr == nil {
+		if result == nil {
+			result = struct{}{}
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+		msg.Result = data
+	}
+	return msg, nil
+}
+
+func (m *Message) setParams(params interface{}) error {
+	if params == nil {
+		return nil
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal params: %w", err)
+	}
+	m.Params = data
+	return nil
+}
@@ -0,0 +1,155 @@
+package mcptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"mcp-hub/internal/mcp"
+)
+
+// sessionID is the session assigned by the streamable HTTP handler
+const sessionID = "mcptest-session"
+
+// recordHeaders remembers the headers of the latest request
+func (s *Server) recordHeaders(r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastHeaders = r.Header.Clone()
+}
+
+// LastHeader returns a header from the most recent HTTP request the server received
+func (s *Server) LastHeader(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastHeaders.Get(key)
+}
+
+// decodeMessage reads a single JSON-RPC message from a request body
+func decodeMessage(w http.ResponseWriter, r *http.Request) (mcp.Message, bool) {
+	var message mcp.Message
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, &message)
+	}
+	if err != nil {
+		http.Error(w, "invalid JSON-RPC message", http.StatusBadRequest)
+		return message, false
+	}
+	return message, true
+}
+
+// StreamableHTTPHandler serves the server over the streamable HTTP transport.
+// When StreamResponses is set, responses are sent as an event stream instead of JSON.
+func (s *Server) StreamableHTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.recordHeaders(r)
+
+		switch r.Method {
+		case http.MethodPost:
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusOK)
+			return
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		message, ok := decodeMessage(w, r)
+		if !ok {
+			return
+		}
+		if message.Method != mcp.MethodInitialize && r.Header.Get("Mcp-Session-Id") != sessionID {
+			http.Error(w, "missing or unknown session", http.StatusBadRequest)
+			return
+		}
+
+		response := s.Respond(message)
+		if response == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		data, _ := json.Marshal(response)
+
+		w.Header().Set("Mcp-Session-Id", sessionID)
+		if s.StreamResponses {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
+
+// SSEHandler serves the server over the legacy HTTP+SSE transport. GET opens the event
+// stream and POST to the announced endpoint (the same path) delivers client messages.
+func (s *Server) SSEHandler() http.Handler {
+	var mu sync.Mutex
+	sessions := make(map[string]chan []byte)
+	nextSession := 0
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.recordHeaders(r)
+
+		switch r.Method {
+		case http.MethodGet:
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+				return
+			}
+
+			mu.Lock()
+			nextSession++
+			session := strconv.Itoa(nextSession)
+			outbox := make(chan []byte, 16)
+			sessions[session] = outbox
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				delete(sessions, session)
+				mu.Unlock()
+			}()
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprintf(w, "event: endpoint\ndata: %s?session=%s\n\n", r.URL.Path, session)
+			flusher.Flush()
+
+			for {
+				select {
+				case data := <-outbox:
+					_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+					flusher.Flush()
+				case <-r.Context().Done():
+					return
+				}
+			}
+
+		case http.MethodPost:
+			mu.Lock()
+			outbox, ok := sessions[r.URL.Query().Get("session")]
+			mu.Unlock()
+			if !ok {
+				http.Error(w, "unknown session", http.StatusNotFound)
+				return
+			}
+
+			message, ok := decodeMessage(w, r)
+			if !ok {
+				return
+			}
+			if response := s.Respond(message); response != nil {
+				data, _ := json.Marshal(response)
+				outbox <- data
+			}
+			w.WriteHeader(http.StatusAccepted)
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+// Package mcptest provides a scriptable MCP server for tests, served over
+// stdio-style streams or in-process HTTP handlers.
+package mcptest
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"mcp-hub/internal/mcp"
+)
+
+// HandlerFunc answers a request for a single method
+type HandlerFunc func(params json.RawMessage) (interface{}, *mcp.RPCError)
+
+// ToolFunc implements a tool served by tools/call
+type ToolFunc func(arguments map[string]interface{}) mcp.CallToolResult
+
+// Server is an in-memory MCP server that answers initialize, ping and the list
+// methods from its fields; other methods are served by registered handlers
+type Server struct {
+	Info         mcp.Implementation
+	Capabilities mcp.ServerCapabilities
+	Tools        []mcp.Tool
+	Resources    []mcp.Resource
+	Prompts      []mcp.Prompt
+
+	// PageSize splits list results into pages of this size; zero returns everything at once
+	PageSize int
+
+	// LogMessages are sent as notifications/message before the initialize response on stdio
+	LogMessages []mcp.LoggingMessageParams
+
+	// StreamResponses makes the streamable HTTP handler answer with event streams
+	StreamResponses bool
+
+	mu          sync.Mutex
+	handlers    map[string]HandlerFunc
+	tools       map[string]ToolFunc
+	lastHeaders http.Header
+}
+
+// NewServer creates a server advertising the tools capability
+func NewServer(tools ...mcp.Tool) *Server {
+	return &Server{
+		Info:         mcp.Implementation{Name: "mcptest", Version: "0.1.0"},
+		Capabilities: mcp.ServerCapabilities{Tools: &mcp.ListChangedCapability{}},
+		Tools:        tools,
+		handlers:     make(map[string]HandlerFunc),
+		tools:        make(map[string]ToolFunc),
+	}
+}
+
+// Handle registers a handler for a method, overriding the built-in behaviour
+func (s *Server) Handle(method string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// HandleTool registers the implementation of a tool for tools/call
+func (s *Server) HandleTool(name string, tool ToolFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tools[name] = tool
+}
+
+// callTool dispatches tools/call to a registered tool
+func (s *Server) callTool(rawParams json.RawMessage) (interface{}, *mcp.RPCError) {
+	var params mcp.CallToolParams
+	if err := json.Unmarshal(rawParams, &params); err != nil {
+		return nil, &mcp.RPCError{Code: mcp.ErrorCodeInvalidParams, Message: err.Error()}
+	}
+
+	s.mu.Lock()
+	tool, ok := s.tools[params.Name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, &mcp.RPCError{Code: mcp.ErrorCodeInvalidParams, Message: "unknown tool: " + params.Name}
+	}
+	return tool(params.Arguments), nil
+}
+
+// Respond builds the response to a request, or nil for notifications and responses
+func (s *Server) Respond(request mcp.Message) *mcp.Message {
+	if !request.IsRequest() {
+		return nil
+	}
+
+	s.mu.Lock()
+	handler, ok := s.handlers[request.Method]
+	s.mu.Unlock()
+
+	var result interface{}
+	var rpcErr *mcp.RPCError
+	switch {
+	case ok:
+		result, rpcErr = handler(request.Params)
+	case request.Method == mcp.MethodInitialize:
+		result = mcp.InitializeResult{
+			ProtocolVersion: mcp.LatestProtocolVersion,
+			Capabilities:    s.Capabilities,
+			ServerInfo:      s.Info,
+		}
+	case request.Method == mcp.MethodPing:
+		result = struct{}{}
+	case request.Method == mcp.MethodToolsList:
+		start, end, next := s.page(request.Params, len(s.Tools))
+		result = mcp.ListToolsResult{Tools: s.Tools[start:end], NextCursor: next}
+	case request.Method == mcp.MethodToolsCall:
+		result, rpcErr = s.callTool(request.Params)
+	case request.Method == mcp.MethodResourcesList:
+		start, end, next := s.page(request.Params, len(s.Resources))
+		result = mcp.ListResourcesResult{Resources: s.Resources[start:end], NextCursor: next}
+	case request.Method == mcp.MethodPromptsList:
+		start, end, next := s.page(request.Params, len(s.Prompts))
+		result = mcp.ListPromptsResult{Prompts: s.Prompts[start:end], NextCursor: next}
+	default:
+		rpcErr = &mcp.RPCError{Code: mcp.ErrorCodeMethodNotFound, Message: "method not found: " + request.Method}
+	}
+
+	response, err := mcp.NewResponse(request.ID, result, rpcErr)
+	if err != nil {
+		response, _ = mcp.NewResponse(request.ID, nil, &mcp.RPCError{Code: mcp.ErrorCodeInternalError, Message: err.Error()})
+	}
+	return response
+}
+
+// page returns the slice bounds and next cursor for a paginated list request.
+// Cursors are the decimal offset of the next page.
+func (s *Server) page(rawParams json.RawMessage, total int) (start, end int, next string) {
+	var params mcp.PaginatedParams
+	_ = json.Unmarshal(rawParams, &params)
+	if offset, err := strconv.Atoi(params.Cursor); err == nil && offset > 0 && offset <= total {
+		start = offset
+	}
+	end = total
+	if s.PageSize > 0 && start+s.PageSize < total {
+		end = start + s.PageSize
+		next = strconv.Itoa(end)
+	}
+	return start, end, next
+}
+
+// Serve answers newline-delimited JSON-RPC requests until the reader ends
+func (s *Server) Serve(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	encoder := json.NewEncoder(w)
+	for scanner.Scan() {
+		var request mcp.Message
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			continue
+		}
+		if request.Method == mcp.MethodInitialize {
+			for _, params := range s.LogMessages {
+				if notification, err := mcp.NewNotification(mcp.MethodLogMessage, params); err == nil {
+					_ = encoder.Encode(notification)
+				}
+			}
+		}
+		if response := s.Respond(request); response != nil {
+			_ = encoder.Encode(response)
+		}
+	}
+}
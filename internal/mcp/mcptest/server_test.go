@@ -0,0 +1,140 @@
+package mcptest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/mcp"
+)
+
+func newClient(t *testing.T, server *Server) *mcp.Client {
+	t.Helper()
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	go func() {
+		server.Serve(serverReader, serverWriter)
+		_ = serverWriter.Close()
+	}()
+
+	client := mcp.NewClient(mcp.NewStreamTransport(clientReader, clientWriter))
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestServer_BuiltInMethods(t *testing.T) {
+	server := NewServer(mcp.Tool{Name: "echo"})
+	client := newClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := client.Initialize(ctx, mcp.DefaultClientInfo)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if result.ServerInfo.Name != "mcptest" {
+		t.Errorf("ServerInfo.Name = %q, expected mcptest", result.ServerInfo.Name)
+	}
+	if _, err := client.Ping(ctx); err != nil {
+		t.Errorf("Ping failed: %v", err)
+	}
+	tools, err := client.ListTools(ctx)
+	if err != nil || len(tools) != 1 || tools[0].Name != "echo" {
+		t.Errorf("ListTools() = %+v, %v", tools, err)
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	server := NewServer(mcp.Tool{Name: "a"}, mcp.Tool{Name: "b"}, mcp.Tool{Name: "c"})
+	server.Resources = []mcp.Resource{{URI: "file:///1"}, {URI: "file:///2"}, {URI: "file:///3"}}
+	server.Prompts = []mcp.Prompt{{Name: "p1"}}
+	server.PageSize = 2
+	client := newClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tools, err := client.ListTools(ctx)
+	if err != nil || len(tools) != 3 || tools[2].Name != "c" {
+		t.Errorf("ListTools() = %+v, %v", tools, err)
+	}
+	resources, err := client.ListResources(ctx)
+	if err != nil || len(resources) != 3 {
+		t.Errorf("ListResources() = %+v, %v", resources, err)
+	}
+	prompts, err := client.ListPrompts(ctx)
+	if err != nil || len(prompts) != 1 {
+		t.Errorf("ListPrompts() = %+v, %v", prompts, err)
+	}
+}
+
+func TestServer_HandleTool(t *testing.T) {
+	server := NewServer(mcp.Tool{Name: "greet"})
+	server.HandleTool("greet", func(arguments map[string]interface{}) mcp.CallToolResult {
+		return mcp.CallToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: "hi " + arguments["name"].(string)}}}
+	})
+	client := newClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := client.CallTool(ctx, "greet", map[string]interface{}{"name": "ada"})
+	if err != nil || result.Content[0].Text != "hi ada" {
+		t.Errorf("CallTool() = %+v, %v", result, err)
+	}
+	if _, err := client.CallTool(ctx, "missing", nil); err == nil {
+		t.Error("Unknown tools should return a protocol error")
+	}
+}
+
+func TestServer_CustomHandler(t *testing.T) {
+	server := NewServer()
+	server.Handle(mcp.MethodPing, func(json.RawMessage) (interface{}, *mcp.RPCError) {
+		return nil, &mcp.RPCError{Code: mcp.ErrorCodeInternalError, Message: "busy"}
+	})
+	client := newClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.Ping(ctx)
+	var rpcErr *mcp.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Message != "busy" {
+		t.Errorf("Expected handler error, got %v", err)
+	}
+}
+
+func TestServer_IgnoresNotifications(t *testing.T) {
+	server := NewServer()
+	if response := server.Respond(mcp.Message{JSONRPC: mcp.JSONRPCVersion, Method: mcp.MethodInitialized}); response != nil {
+		t.Errorf("Notifications should not get a response, got %+v", response)
+	}
+}
+
+func TestServer_LogMessages(t *testing.T) {
+	server := NewServer()
+	server.LogMessages = []mcp.LoggingMessageParams{{Level: "warning", Logger: "db", Data: json.RawMessage(`"slow query"`)}}
+	client := newClient(t, server)
+
+	received := make(chan mcp.Notification, 1)
+	client.SetNotificationHandler(func(notification mcp.Notification) {
+		received <- notification
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.Initialize(ctx, mcp.DefaultClientInfo); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	select {
+	case notification := <-received:
+		var params mcp.LoggingMessageParams
+		if err := json.Unmarshal(notification.Params, &params); err != nil || notification.Method != mcp.MethodLogMessage || params.Text() != "slow query" {
+			t.Errorf("Unexpected notification %s %s", notification.Method, notification.Params)
+		}
+	default:
+		t.Error("Log messages should arrive before the initialize response")
+	}
+}
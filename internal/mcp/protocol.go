@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// LatestProtocolVersion is the MCP protocol revision requested during initialization
+const LatestProtocolVersion = "2025-06-18"
+
+// MCP method names
+const (
+	MethodInitialize    = "initialize"
+	MethodInitialized   = "notifications/initialized"
+	MethodPing          = "ping"
+	MethodToolsList     = "tools/list"
+	MethodResourcesList = "resources/list"
+	MethodPromptsList   = "prompts/list"
+	MethodToolsCall     = "tools/call"
+	MethodResourcesRead = "resources/read"
+	MethodPromptsGet    = "prompts/get"
+	MethodLogMessage    = "notifications/message"
+	MethodCancelled     = "notifications/cancelled"
+
+	MethodToolsListChanged     = "notifications/tools/list_changed"
+	MethodResourcesListChanged = "notifications/resources/list_changed"
+	MethodPromptsListChanged   = "notifications/prompts/list_changed"
+)
+
+// Implementation describes the name and version of an MCP client or server
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ClientCapabilities describes the optional features supported by the client
+type ClientCapabilities struct {
+	Roots    *struct{} `json:"roots,omitempty"`
+	Sampling *struct{} `json:"sampling,omitempty"`
+}
+
+// ServerCapabilities describes the optional features supported by the server
+type ServerCapabilities struct {
+	Tools     *ListChangedCapability `json:"tools,omitempty"`
+	Resources *ResourcesCapability   `json:"resources,omitempty"`
+	Prompts   *ListChangedCapability `json:"prompts,omitempty"`
+	Logging   *struct{}              `json:"logging,omitempty"`
+}
+
+// Names returns the names of the capabilities the server advertises
+func (c ServerCapabilities) Names() []string {
+	var names []string
+	if c.Tools != nil {
+		names = append(names, "tools")
+	}
+	if c.Resources != nil {
+		names = append(names, "resources")
+	}
+	if c.Prompts != nil {
+		names = append(names, "prompts")
+	}
+	if c.Logging != nil {
+		names = append(names, "logging")
+	}
+	return names
+}
+
+// ListChangedCapability indicates support for list change notifications
+type ListChangedCapability struct {
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// ResourcesCapability describes resource support
+type ResourcesCapability struct {
+	Subscribe   bool `json:"subscribe,omitempty"`
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// InitializeParams are sent by the client in the initialize request
+type InitializeParams struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ClientCapabilities `json:"capabilities"`
+	ClientInfo      Implementation     `json:"clientInfo"`
+}
+
+// InitializeResult is returned by the server in response to initialize
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// PaginatedParams carries the cursor for list requests
+type PaginatedParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// Tool describes a tool exposed by an MCP server
+type Tool struct {
+	Name        string          `json:"name"`
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"inputSchema,omitempty"`
+
+	// OutputSchema and Annotations are carried through unchanged
+	OutputSchema json.RawMessage `json:"outputSchema,omitempty"`
+	Annotations  json.RawMessage `json:"annotations,omitempty"`
+}
+
+// ListToolsResult is returned by tools/list
+type ListToolsResult struct {
+	Tools      []Tool `json:"tools"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Resource describes a resource exposed by an MCP server
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
+
+// ReadResourceParams are sent with resources/read
+type ReadResourceParams struct {
+	URI string `json:"uri"`
+}
+
+// ListResourcesResult is returned by resources/list
+type ListResourcesResult struct {
+	Resources  []Resource `json:"resources"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+// PromptArgument describes an argument accepted by a prompt
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// Prompt describes a prompt template exposed by an MCP server
+type Prompt struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+// ListPromptsResult is returned by prompts/list
+type ListPromptsResult struct {
+	Prompts    []Prompt `json:"prompts"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+}
+
+// GetPromptParams are sent with prompts/get
+type GetPromptParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// CallToolParams are sent with tools/call
+type CallToolParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// ContentBlock is one item of tool output; only text blocks carry Text
+type ContentBlock struct {
+	Type     string          `json:"type"`
+	Text     string          `json:"text,omitempty"`
+	MimeType string          `json:"mimeType,omitempty"`
+	Data     string          `json:"data,omitempty"`
+	Resource json.RawMessage `json:"resource,omitempty"`
+}
+
+// CallToolResult is returned by tools/call. IsError reports a tool-level failure,
+// as opposed to a protocol error.
+type CallToolResult struct {
+	Content           []ContentBlock  `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError,omitempty"`
+}
+
+// CancelledParams are sent with notifications/cancelled to abandon a request
+type CancelledParams struct {
+	RequestID json.RawMessage `json:"requestId"`
+	Reason    string          `json:"reason,omitempty"`
+}
+
+// LoggingMessageParams are sent by the server with notifications/message.
+// Level is a syslog severity name such as "debug", "warning" or "error".
+type LoggingMessageParams struct {
+	Level  string          `json:"level"`
+	Logger string          `json:"logger,omitempty"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Text returns the log data as a single line: strings unquoted, anything else as compact JSON
+func (p LoggingMessageParams) Text() string {
+	var text string
+	if err := json.Unmarshal(p.Data, &text); err == nil {
+		return text
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, p.Data); err != nil {
+		return string(p.Data)
+	}
+	return compacted.String()
+}
@@ -0,0 +1,41 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestServerCapabilities_Names(t *testing.T) {
+	if names := (ServerCapabilities{}).Names(); len(names) != 0 {
+		t.Errorf("Empty capabilities should have no names, got %v", names)
+	}
+
+	capabilities := ServerCapabilities{
+		Tools:   &ListChangedCapability{},
+		Prompts: &ListChangedCapability{ListChanged: true},
+		Logging: &struct{}{},
+	}
+	expected := []string{"tools", "prompts", "logging"}
+	if names := capabilities.Names(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Names() = %v, expected %v", names, expected)
+	}
+}
+
+func TestLoggingMessageParams_Text(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected string
+	}{
+		{`"server started"`, "server started"},
+		{`{ "event": "connect",  "ok": true }`, `{"event":"connect","ok":true}`},
+		{`42`, "42"},
+	}
+
+	for _, tt := range tests {
+		params := LoggingMessageParams{Level: "info", Data: json.RawMessage(tt.data)}
+		if text := params.Text(); text != tt.expected {
+			t.Errorf("Text() for %s = %q, expected %q", tt.data, text, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Directions of a recorded message, seen from mcp-hub as the client
+const (
+	RecordSent     = "send"
+	RecordReceived = "receive"
+)
+
+// RecordEntry is one line of a JSONL recording: a JSON-RPC message with the time and
+// direction it travelled
+type RecordEntry struct {
+	Time      time.Time       `json:"time"`
+	Direction string          `json:"direction"`
+	Message   json.RawMessage `json:"message"`
+}
+
+// RecordingTransport wraps a transport and appends every message it carries to a JSONL recording
+type RecordingTransport struct {
+	inner    Transport
+	messages chan []byte
+	closed   chan struct{}
+	once     sync.Once
+
+	mu        sync.Mutex
+	out       io.WriteCloser
+	encoder   *json.Encoder
+	outClosed bool
+}
+
+// NewRecordingTransport starts recording the traffic of inner to out. Closing the
+// transport closes out as well.
+func NewRecordingTransport(inner Transport, out io.WriteCloser) *RecordingTransport {
+	t := &RecordingTransport{
+		inner:    inner,
+		messages: make(chan []byte, 16),
+		closed:   make(chan struct{}),
+		out:      out,
+		encoder:  json.NewEncoder(out),
+	}
+	go t.forwardLoop()
+	return t
+}
+
+// forwardLoop records incoming messages and passes them on until the inner transport ends
+func (t *RecordingTransport) forwardLoop() {
+	defer close(t.messages)
+	for message := range t.inner.Messages() {
+		t.record(RecordReceived, message)
+		select {
+		case t.messages <- message:
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+// record appends one message to the recording; messages after Close are dropped
+func (t *RecordingTransport) record(direction string, message []byte) {
+	entry := RecordEntry{Time: time.Now(), Direction: direction, Message: message}
+	if !json.Valid(message) {
+		// Keep malformed traffic readable instead of corrupting the recording
+		entry.Message, _ = json.Marshal(string(message))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.outClosed {
+		_ = t.encoder.Encode(entry)
+	}
+}
+
+// Send records a message and writes it to the inner transport
+func (t *RecordingTransport) Send(ctx context.Context, message []byte) error {
+	t.record(RecordSent, message)
+	return t.inner.Send(ctx, message)
+}
+
+// Messages returns the channel of incoming messages
+func (t *RecordingTransport) Messages() <-chan []byte {
+	return t.messages
+}
+
+// Close closes the inner transport and the recording
+func (t *RecordingTransport) Close() error {
+	var err error
+	t.once.Do(func() {
+		close(t.closed)
+		err = t.inner.Close()
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.outClosed = true
+		if closeErr := t.out.Close(); err == nil {
+			err = closeErr
+		}
+	})
+	return err
+}
+
+// ReadRecording parses a JSONL recording
+func ReadRecording(r io.Reader) ([]RecordEntry, error) {
+	var entries []RecordEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry RecordEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
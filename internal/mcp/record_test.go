@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingBuffer collects a recording in memory and notes when it is closed
+type recordingBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+	closed bool
+}
+
+func (b *recordingBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *recordingBuffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return nil
+}
+
+// recordFakeSession runs a short session against the fake server through a recording
+// transport and returns the parsed recording
+func recordFakeSession(t *testing.T) []RecordEntry {
+	t.Helper()
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	go func() {
+		serveFake(serverReader, serverWriter)
+		_ = serverWriter.Close()
+	}()
+
+	out := &recordingBuffer{}
+	client := NewClient(NewRecordingTransport(NewStreamTransport(clientReader, clientWriter), out))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	if _, err := client.Initialize(ctx, DefaultClientInfo); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if _, err := client.ListTools(ctx); err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+	if _, err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if _, err := client.CallTool(ctx, "echo", map[string]interface{}{"text": "hi"}); err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	_ = client.Close()
+
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	if !out.closed {
+		t.Error("Closing the transport should close the recording")
+	}
+	entries, err := ReadRecording(bytes.NewReader(out.buffer.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadRecording failed: %v", err)
+	}
+	return entries
+}
+
+func TestRecordingTransport_RecordsBothDirections(t *testing.T) {
+	entries := recordFakeSession(t)
+
+	// initialize, initialized, two tools/list pages, ping and tools/call; all but initialized are answered
+	var sent, received int
+	for _, entry := range entries {
+		switch entry.Direction {
+		case RecordSent:
+			sent++
+		case RecordReceived:
+			received++
+		default:
+			t.Errorf("Unexpected direction %q", entry.Direction)
+		}
+		if entry.Time.IsZero() {
+			t.Error("Entries should be timestamped")
+		}
+	}
+	if sent != 6 || received != 5 {
+		t.Errorf("Recorded %d sent and %d received messages, expected 6 and 5", sent, received)
+	}
+	if !strings.Contains(string(entries[0].Message), `"method":"initialize"`) || entries[0].Direction != RecordSent {
+		t.Errorf("First entry should be the initialize request, got %s", entries[0].Message)
+	}
+}
+
+func TestReadRecording_Errors(t *testing.T) {
+	if _, err := ReadRecording(strings.NewReader("{\"direction\":\"send\",\"message\":{}}\nnot json\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Malformed line should be reported with its number, got %v", err)
+	}
+}
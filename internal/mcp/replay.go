@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// replayExchange is one recorded request with the server's answer
+type replayExchange struct {
+	method string
+	params string // Compacted JSON, for matching live requests
+	result json.RawMessage
+	err    *RPCError
+
+	// notifications the server sent after the request and before its response
+	notifications []json.RawMessage
+}
+
+// ReplayServer acts as an MCP server by answering requests from a recording. A live
+// request gets the response of the first unused recorded request with the same method
+// and params, falling back to one with the same method only. Once used up, the last
+// match is answered again, so polling methods like ping keep working.
+type ReplayServer struct {
+	mu        sync.Mutex
+	exchanges []*replayExchange
+	used      []bool
+}
+
+// NewReplayServer builds a replay server from recorded entries
+func NewReplayServer(entries []RecordEntry) *ReplayServer {
+	s := &ReplayServer{}
+	byID := make(map[string]*replayExchange)
+	var pending []json.RawMessage
+
+	for _, entry := range entries {
+		var message Message
+		if err := json.Unmarshal(entry.Message, &message); err != nil {
+			continue
+		}
+
+		switch {
+		case entry.Direction == RecordSent && message.IsRequest():
+			exchange := &replayExchange{method: message.Method, params: compactJSON(message.Params)}
+			byID[string(message.ID)] = exchange
+			s.exchanges = append(s.exchanges, exchange)
+		case entry.Direction == RecordReceived && message.IsNotification():
+			pending = append(pending, entry.Message)
+		case entry.Direction == RecordReceived && message.IsResponse():
+			exchange, ok := byID[string(message.ID)]
+			if !ok {
+				continue
+			}
+			delete(byID, string(message.ID))
+			exchange.result, exchange.err = message.Result, message.Error
+			exchange.notifications, pending = pending, nil
+		}
+	}
+
+	// Requests that never got an answer cannot be replayed
+	answered := s.exchanges[:0]
+	for _, exchange := range s.exchanges {
+		if exchange.result != nil || exchange.err != nil {
+			answered = append(answered, exchange)
+		}
+	}
+	s.exchanges = answered
+	s.used = make([]bool, len(answered))
+	return s
+}
+
+// LoadReplayServer reads a JSONL recording from path and builds a replay server from it
+func LoadReplayServer(path string) (*ReplayServer, error) {
+	// #nosec G304 -- replaying a recording the user points at is the purpose of this function
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	entries, err := ReadRecording(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayServer(entries), nil
+}
+
+// compactJSON normalizes JSON so equal params compare equal
+func compactJSON(data json.RawMessage) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return string(data)
+	}
+	return compact.String()
+}
+
+// match picks the recorded exchange answering a live request, or nil
+func (s *ReplayServer) match(request Message) *replayExchange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	params := compactJSON(request.Params)
+	exact, sameMethod, reused := -1, -1, -1
+	for i, exchange := range s.exchanges {
+		if exchange.method != request.Method {
+			continue
+		}
+		switch {
+		case !s.used[i] && exchange.params == params:
+			if exact < 0 {
+				exact = i
+			}
+		case !s.used[i]:
+			if sameMethod < 0 {
+				sameMethod = i
+			}
+		default:
+			reused = i
+		}
+	}
+
+	for _, i := range []int{exact, sameMethod, reused} {
+		if i >= 0 {
+			s.used[i] = true
+			return s.exchanges[i]
+		}
+	}
+	return nil
+}
+
+// Respond returns the recorded notifications and response for a live request, or nil
+// for anything but a request
+func (s *ReplayServer) Respond(request Message) ([]json.RawMessage, *Message) {
+	if !request.IsRequest() {
+		return nil, nil
+	}
+
+	exchange := s.match(request)
+	if exchange == nil {
+		return nil, &Message{
+			JSONRPC: JSONRPCVersion,
+			ID:      request.ID,
+			Error:   &RPCError{Code: ErrorCodeMethodNotFound, Message: "not in recording: " + request.Method},
+		}
+	}
+	return exchange.notifications, &Message{JSONRPC: JSONRPCVersion, ID: request.ID, Result: exchange.result, Error: exchange.err}
+}
+
+// Serve answers newline-delimited JSON-RPC requests from r on w until r ends
+func (s *ReplayServer) Serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	encoder := json.NewEncoder(w)
+	for scanner.Scan() {
+		var request Message
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			continue
+		}
+		notifications, response := s.Respond(request)
+		for _, notification := range notifications {
+			if err := encoder.Encode(notification); err != nil {
+				return err
+			}
+		}
+		if response != nil {
+			if err := encoder.Encode(response); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newReplayClient connects a client to a replay server over in-memory pipes
+func newReplayClient(t *testing.T, server *ReplayServer) *Client {
+	t.Helper()
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	go func() {
+		_ = server.Serve(serverReader, serverWriter)
+		_ = serverWriter.Close()
+	}()
+
+	client := NewClient(NewStreamTransport(clientReader, clientWriter))
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestReplayServer_ReplaysRecordedSession(t *testing.T) {
+	client := newReplayClient(t, NewReplayServer(recordFakeSession(t)))
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	result, err := client.Initialize(ctx, Implementation{Name: "other-client", Version: "9"})
+	if err != nil || result.ServerInfo.Name != "fake-server" {
+		t.Fatalf("Initialize should be answered from the recording, got %+v, %v", result, err)
+	}
+
+	tools, err := client.ListTools(ctx)
+	if err != nil || len(tools) != 2 || tools[1].Name != "add" {
+		t.Errorf("Paginated tools/list should be replayed page by page, got %+v, %v", tools, err)
+	}
+
+	for range 3 {
+		if _, err := client.Ping(ctx); err != nil {
+			t.Errorf("Recorded responses should be reusable, got %v", err)
+		}
+	}
+
+	call, err := client.CallTool(ctx, "echo", map[string]interface{}{"text": "hi"})
+	if err != nil || len(call.Content) != 1 || call.Content[0].Text != "hi" {
+		t.Errorf("tools/call should be replayed, got %+v, %v", call, err)
+	}
+
+	if _, err := client.ListResources(ctx); err == nil || !strings.Contains(err.Error(), "not in recording") {
+		t.Errorf("Unrecorded methods should fail, got %v", err)
+	}
+}
+
+func TestReplayServer_PrefersMatchingParams(t *testing.T) {
+	entries := []RecordEntry{
+		{Direction: RecordSent, Message: json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"text":"a"}}}`)},
+		{Direction: RecordSent, Message: json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"text":"b"}}}`)},
+		{Direction: RecordReceived, Message: json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info","data":"called b"}}`)},
+		{Direction: RecordReceived, Message: json.RawMessage(`{"jsonrpc":"2.0","id":2,"result":{"content":[{"type":"text","text":"b"}]}}`)},
+		{Direction: RecordReceived, Message: json.RawMessage(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"a"}]}}`)},
+		{Direction: RecordSent, Message: json.RawMessage(`{"jsonrpc":"2.0","id":3,"method":"tools/list"}`)},
+	}
+	server := NewReplayServer(entries)
+
+	request := Message{JSONRPC: JSONRPCVersion, ID: json.RawMessage(`"live"`), Method: MethodToolsCall, Params: json.RawMessage(`{"name": "echo", "arguments": {"text": "b"}}`)}
+	notifications, response := server.Respond(request)
+	if string(response.ID) != `"live"` || !strings.Contains(string(response.Result), `"text":"b"`) {
+		t.Errorf("Request with matching params should get its response under the live id, got %+v", response)
+	}
+	if len(notifications) != 1 || !strings.Contains(string(notifications[0]), "called b") {
+		t.Errorf("Notifications sent before the response should be replayed, got %s", notifications)
+	}
+
+	if _, response := server.Respond(Message{JSONRPC: JSONRPCVersion, ID: json.RawMessage(`9`), Method: MethodToolsList}); response.Error == nil {
+		t.Error("Requests recorded without a response cannot be replayed")
+	}
+	if notifications, response := server.Respond(Message{JSONRPC: JSONRPCVersion, Method: MethodInitialized}); notifications != nil || response != nil {
+		t.Error("Notifications from the client should get no answer")
+	}
+}
+
+func TestLoadReplayServer(t *testing.T) {
+	if _, err := LoadReplayServer(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("Missing recording should fail")
+	}
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	data := `{"time":"2026-01-02T03:04:05Z","direction":"send","message":{"jsonrpc":"2.0","id":1,"method":"ping"}}` + "\n" +
+		`{"time":"2026-01-02T03:04:05Z","direction":"receive","message":{"jsonrpc":"2.0","id":1,"result":{}}}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	server, err := LoadReplayServer(path)
+	if err != nil {
+		t.Fatalf("LoadReplayServer failed: %v", err)
+	}
+	if _, response := server.Respond(Message{JSONRPC: JSONRPCVersion, ID: json.RawMessage(`5`), Method: MethodPing}); response.Error != nil {
+		t.Errorf("Recorded ping should be answered, got %v", response.Error)
+	}
+}
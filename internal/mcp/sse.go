@@ -0,0 +1,164 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// SSETransport speaks the legacy HTTP+SSE transport: server messages arrive on a
+// long-lived event stream and client messages are POSTed to the endpoint it announces
+type SSETransport struct {
+	config   HTTPConfig
+	endpoint string
+	messages chan []byte
+	cancel   context.CancelFunc
+	closed   chan struct{}
+	once     sync.Once
+}
+
+// NewSSETransport opens the event stream and waits for the server to announce its message endpoint
+func NewSSETransport(ctx context.Context, config HTTPConfig) (*SSETransport, error) {
+	streamCtx, cancel := context.WithCancel(context.Background())
+	req, err := config.newRequest(streamCtx, http.MethodGet, config.URL, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// The stream outlives ctx, so only the wait for the endpoint is bound by it
+	stop := context.AfterFunc(ctx, cancel)
+	resp, err := config.httpClient().Do(req)
+	if err != nil {
+		stop()
+		cancel()
+		return nil, fmt.Errorf("failed to connect to %s: %w", config.URL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+		stop()
+		cancel()
+		return nil, httpStatusError(resp)
+	}
+
+	t := &SSETransport{
+		config:   config,
+		messages: make(chan []byte, 16),
+		cancel:   cancel,
+		closed:   make(chan struct{}),
+	}
+
+	endpoint := make(chan string, 1)
+	go t.readLoop(resp.Body, endpoint)
+
+	select {
+	case announced, ok := <-endpoint:
+		stop()
+		if !ok {
+			_ = t.Close()
+			return nil, errors.New("event stream ended before the server announced its endpoint")
+		}
+		resolved, err := resolveEndpoint(config.URL, announced)
+		if err != nil {
+			_ = t.Close()
+			return nil, err
+		}
+		t.endpoint = resolved
+		return t, nil
+	case <-ctx.Done():
+		_ = t.Close()
+		return nil, ctx.Err()
+	}
+}
+
+// readLoop forwards message events and reports the endpoint event
+func (t *SSETransport) readLoop(body io.ReadCloser, endpoint chan<- string) {
+	defer close(t.messages)
+	defer func() { _ = body.Close() }()
+
+	announced := false
+	_ = readSSEEvents(body, func(event sseEvent) bool {
+		switch event.Event {
+		case "endpoint":
+			if !announced {
+				announced = true
+				endpoint <- event.Data
+			}
+		case "message":
+			select {
+			case t.messages <- []byte(event.Data):
+			case <-t.closed:
+				return false
+			}
+		}
+		return true
+	})
+	if !announced {
+		close(endpoint)
+	}
+}
+
+// resolveEndpoint resolves the announced endpoint relative to the stream URL
+func resolveEndpoint(streamURL, endpoint string) (string, error) {
+	base, err := url.Parse(streamURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid MCP server URL %q: %w", streamURL, err)
+	}
+	ref, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("server announced an invalid endpoint %q: %w", endpoint, err)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// Endpoint returns the URL client messages are posted to
+func (t *SSETransport) Endpoint() string {
+	return t.endpoint
+}
+
+// Send POSTs a message to the announced endpoint
+func (t *SSETransport) Send(ctx context.Context, message []byte) error {
+	select {
+	case <-t.closed:
+		return ErrTransportClosed
+	default:
+	}
+
+	req, err := t.config.newRequest(ctx, http.MethodPost, t.endpoint, bytes.NewReader(message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.config.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return httpStatusError(resp)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// Messages returns the channel of incoming messages
+func (t *SSETransport) Messages() <-chan []byte {
+	return t.messages
+}
+
+// Close ends the event stream
+func (t *SSETransport) Close() error {
+	t.once.Do(func() {
+		close(t.closed)
+		t.cancel()
+	})
+	return nil
+}
@@ -0,0 +1,94 @@
+package mcp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/mcp/mcptest"
+)
+
+func TestSSETransport_Handshake(t *testing.T) {
+	server := mcptest.NewServer(mcp.Tool{Name: "search"})
+	httpServer := httptest.NewServer(server.SSEHandler())
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	transport, err := mcp.NewSSETransport(ctx, mcp.HTTPConfig{
+		URL:     httpServer.URL + "/sse",
+		Headers: map[string]string{"Authorization": "Bearer test-token"},
+	})
+	if err != nil {
+		t.Fatalf("NewSSETransport failed: %v", err)
+	}
+	client := mcp.NewClient(transport)
+	defer func() { _ = client.Close() }()
+
+	if _, err := client.Initialize(ctx, mcp.DefaultClientInfo); err != nil {
+		t.Fatalf("Initialize over SSE failed: %v", err)
+	}
+	if _, err := client.Ping(ctx); err != nil {
+		t.Errorf("Ping over SSE failed: %v", err)
+	}
+	tools, err := client.ListTools(ctx)
+	if err != nil || len(tools) != 1 {
+		t.Errorf("ListTools over SSE = %+v, %v", tools, err)
+	}
+	if got := server.LastHeader("Authorization"); got != "Bearer test-token" {
+		t.Errorf("Configured headers should be sent, got Authorization=%q", got)
+	}
+}
+
+func TestSSETransport_CloseEndsClient(t *testing.T) {
+	httpServer := httptest.NewServer(mcptest.NewServer().SSEHandler())
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	transport, err := mcp.NewSSETransport(ctx, mcp.HTTPConfig{URL: httpServer.URL})
+	if err != nil {
+		t.Fatalf("NewSSETransport failed: %v", err)
+	}
+	client := mcp.NewClient(transport)
+	_ = client.Close()
+
+	select {
+	case <-client.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Client should stop after the event stream closes")
+	}
+	if err := transport.Send(ctx, []byte(`{}`)); err == nil {
+		t.Error("Send after Close should fail")
+	}
+}
+
+func TestSSETransport_ConnectionErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	if _, err := mcp.NewSSETransport(ctx, mcp.HTTPConfig{URL: notFound.URL}); err == nil {
+		t.Error("Expected error for non-200 response")
+	}
+
+	// A stream that never announces an endpoint is bounded by ctx
+	silent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer silent.Close()
+
+	shortCtx, shortCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer shortCancel()
+	if _, err := mcp.NewSSETransport(shortCtx, mcp.HTTPConfig{URL: silent.URL}); err == nil {
+		t.Error("Expected timeout waiting for the endpoint event")
+	}
+}
@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"sync"
+	"time"
+)
+
+// processShutdownGrace is how long a server gets to exit after stdin closes
+const processShutdownGrace = 2 * time.Second
+
+// StdioConfig describes how to spawn a stdio MCP server
+type StdioConfig struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+	Dir     string
+	// Stderr receives the server's stderr output; it is discarded when nil
+	Stderr io.Writer
+}
+
+// StdioTransport runs an MCP server as a child process and talks to it over stdin/stdout
+type StdioTransport struct {
+	*StreamTransport
+	cmd       *exec.Cmd
+	closeOnce sync.Once
+}
+
+// NewStdioTransport starts the configured command and connects to its stdin and stdout
+func NewStdioTransport(config StdioConfig) (*StdioTransport, error) {
+	if config.Command == "" {
+		return nil, errors.New("stdio transport requires a command")
+	}
+
+	//nolint:gosec // G204: Spawning the user's configured MCP server is the purpose of this transport
+	cmd := exec.Command(config.Command, config.Args...)
+	cmd.Env = MergeEnvironment(os.Environ(), config.Env)
+	cmd.Dir = config.Dir
+	if config.Stderr != nil {
+		cmd.Stderr = config.Stderr
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stdout: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", config.Command, err)
+	}
+
+	return &StdioTransport{
+		StreamTransport: NewStreamTransport(stdout, stdin),
+		cmd:             cmd,
+	}, nil
+}
+
+// Close closes stdin, waits briefly for the process to exit and kills it otherwise
+func (t *StdioTransport) Close() error {
+	t.closeOnce.Do(func() {
+		_ = t.StreamTransport.writer.Close()
+
+		exited := make(chan error, 1)
+		go func() {
+			exited <- t.cmd.Wait()
+		}()
+
+		select {
+		case <-exited:
+		case <-time.After(processShutdownGrace):
+			_ = t.cmd.Process.Kill()
+			<-exited
+		}
+
+		_ = t.StreamTransport.Close()
+	})
+	return nil
+}
+
+// Pid returns the process id of the running server
+func (t *StdioTransport) Pid() int {
+	if t.cmd.Process == nil {
+		return 0
+	}
+	return t.cmd.Process.Pid
+}
+
+// MergeEnvironment overlays the given variables on a base environment in KEY=value form
+func MergeEnvironment(base []string, overrides map[string]string) []string {
+	if len(overrides) == 0 {
+		return base
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	merged := make([]string, 0, len(base)+len(overrides))
+	for _, entry := range base {
+		overridden := false
+		for _, key := range keys {
+			if len(entry) > len(key) && entry[:len(key)+1] == key+"=" {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, entry)
+		}
+	}
+	for _, key := range keys {
+		merged = append(merged, key+"="+overrides[key])
+	}
+	return merged
+}
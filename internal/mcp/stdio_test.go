@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStdioTransport_SpawnsServer(t *testing.T) {
+	transport, err := NewStdioTransport(StdioConfig{
+		Command: os.Args[0],
+		Args:    []string{"-test.run=TestHelperProcess"},
+		Env:     map[string]string{"MCP_HUB_FAKE_SERVER": "1"},
+	})
+	if err != nil {
+		t.Fatalf("NewStdioTransport failed: %v", err)
+	}
+	client := NewClient(transport)
+	defer func() { _ = client.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := client.Initialize(ctx, DefaultClientInfo); err != nil {
+		t.Fatalf("Initialize over stdio failed: %v", err)
+	}
+	tools, err := client.ListTools(ctx)
+	if err != nil {
+		t.Fatalf("ListTools over stdio failed: %v", err)
+	}
+	if len(tools) != len(fakeServerTools) {
+		t.Errorf("Expected %d tools, got %d", len(fakeServerTools), len(tools))
+	}
+	if transport.Pid() == 0 {
+		t.Error("Expected a running process id")
+	}
+}
+
+func TestStdioTransport_MissingCommand(t *testing.T) {
+	if _, err := NewStdioTransport(StdioConfig{}); err == nil {
+		t.Error("Expected error for empty command")
+	}
+	if _, err := NewStdioTransport(StdioConfig{Command: "/nonexistent/mcp-server"}); err == nil {
+		t.Error("Expected error for missing executable")
+	}
+}
+
+func TestMergeEnvironment(t *testing.T) {
+	base := []string{"PATH=/usr/bin", "HOME=/home/me", "TOKEN=old"}
+	merged := MergeEnvironment(base, map[string]string{"TOKEN": "new", "EXTRA": "1"})
+
+	expected := []string{"PATH=/usr/bin", "HOME=/home/me", "EXTRA=1", "TOKEN=new"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("MergeEnvironment() = %v, expected %v", merged, expected)
+	}
+
+	if result := MergeEnvironment(base, nil); !reflect.DeepEqual(result, base) {
+		t.Errorf("MergeEnvironment with no overrides should return base, got %v", result)
+	}
+}
@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+)
+
+const (
+	// sessionIDHeader carries the session assigned by a streamable HTTP server
+	sessionIDHeader = "Mcp-Session-Id"
+	// protocolVersionHeader carries the negotiated protocol version on follow-up requests
+	protocolVersionHeader = "MCP-Protocol-Version"
+)
+
+// StreamableHTTPTransport speaks the streamable HTTP transport: every client message is
+// POSTed to a single endpoint, which answers with JSON or a short-lived event stream
+type StreamableHTTPTransport struct {
+	config   HTTPConfig
+	messages chan []byte
+	ctx      context.Context
+	cancel   context.CancelFunc
+	closed   chan struct{}
+	once     sync.Once
+	inflight sync.WaitGroup
+
+	mu              sync.Mutex
+	isClosed        bool
+	sessionID       string
+	protocolVersion string
+}
+
+// NewStreamableHTTPTransport creates a transport for the server at config.URL
+func NewStreamableHTTPTransport(config HTTPConfig) *StreamableHTTPTransport {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StreamableHTTPTransport{
+		config:   config,
+		messages: make(chan []byte, 16),
+		ctx:      ctx,
+		cancel:   cancel,
+		closed:   make(chan struct{}),
+	}
+}
+
+// SessionID returns the session assigned by the server, if any
+func (t *StreamableHTTPTransport) SessionID() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.sessionID
+}
+
+// Send POSTs a message and forwards whatever the server returns
+func (t *StreamableHTTPTransport) Send(ctx context.Context, message []byte) error {
+	t.mu.Lock()
+	if t.isClosed {
+		t.mu.Unlock()
+		return ErrTransportClosed
+	}
+	t.inflight.Add(1)
+	t.mu.Unlock()
+	defer t.inflight.Done()
+
+	// Close must also abort requests whose response is still streaming
+	reqCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(t.ctx, cancel)
+	defer stop()
+
+	req, err := t.config.newRequest(reqCtx, http.MethodPost, t.config.URL, bytes.NewReader(message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.setSessionHeaders(req)
+
+	resp, err := t.config.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return httpStatusError(resp)
+	}
+	if sessionID := resp.Header.Get(sessionIDHeader); sessionID != "" {
+		t.mu.Lock()
+		t.sessionID = sessionID
+		t.mu.Unlock()
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/event-stream":
+		return readSSEEvents(resp.Body, func(event sseEvent) bool {
+			return event.Event != "message" || t.deliver([]byte(event.Data))
+		})
+	case "application/json":
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxMessageSize))
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+		return t.deliverJSON(body)
+	default:
+		return nil
+	}
+}
+
+// setSessionHeaders adds the session and protocol version learned from earlier responses
+func (t *StreamableHTTPTransport) setSessionHeaders(req *http.Request) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sessionID != "" {
+		req.Header.Set(sessionIDHeader, t.sessionID)
+	}
+	if t.protocolVersion != "" {
+		req.Header.Set(protocolVersionHeader, t.protocolVersion)
+	}
+}
+
+// deliverJSON forwards a JSON body, which may be a single message or a batch
+func (t *StreamableHTTPTransport) deliverJSON(body []byte) error {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil
+	}
+	if body[0] != '[' {
+		t.deliver(body)
+		return nil
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return fmt.Errorf("invalid JSON-RPC batch: %w", err)
+	}
+	for _, message := range batch {
+		if !t.deliver(message) {
+			break
+		}
+	}
+	return nil
+}
+
+// deliver queues an incoming message, noting the negotiated protocol version.
+// It returns false once the transport is closed.
+func (t *StreamableHTTPTransport) deliver(message []byte) bool {
+	t.recordProtocolVersion(message)
+	select {
+	case t.messages <- message:
+		return true
+	case <-t.closed:
+		return false
+	}
+}
+
+// recordProtocolVersion remembers the version from the initialize response
+func (t *StreamableHTTPTransport) recordProtocolVersion(message []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.protocolVersion != "" {
+		return
+	}
+	var response struct {
+		Result struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		} `json:"result"`
+	}
+	if json.Unmarshal(message, &response) == nil {
+		t.protocolVersion = response.Result.ProtocolVersion
+	}
+}
+
+// Messages returns the channel of incoming messages
+func (t *StreamableHTTPTransport) Messages() <-chan []byte {
+	return t.messages
+}
+
+// Close ends the session on the server (best effort) and stops delivering messages
+func (t *StreamableHTTPTransport) Close() error {
+	t.once.Do(func() {
+		t.mu.Lock()
+		t.isClosed = true
+		t.mu.Unlock()
+
+		close(t.closed)
+		t.cancel()
+		t.inflight.Wait()
+		close(t.messages)
+		t.terminateSession()
+	})
+	return nil
+}
+
+// terminateSession asks the server to discard the session
+func (t *StreamableHTTPTransport) terminateSession() {
+	sessionID := t.SessionID()
+	if sessionID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	req, err := t.config.newRequest(ctx, http.MethodDelete, t.config.URL, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set(sessionIDHeader, sessionID)
+	if resp, err := t.config.httpClient().Do(req); err == nil {
+		_ = resp.Body.Close()
+	}
+}
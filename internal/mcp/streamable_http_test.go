@@ -0,0 +1,93 @@
+package mcp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/mcp/mcptest"
+)
+
+func TestStreamableHTTPTransport_Handshake(t *testing.T) {
+	for _, stream := range []bool{false, true} {
+		name := "json"
+		if stream {
+			name = "event-stream"
+		}
+		t.Run(name, func(t *testing.T) {
+			server := mcptest.NewServer(mcp.Tool{Name: "query"}, mcp.Tool{Name: "execute"})
+			server.StreamResponses = stream
+			server.PageSize = 1
+			httpServer := httptest.NewServer(server.StreamableHTTPHandler())
+			defer httpServer.Close()
+
+			transport := mcp.NewStreamableHTTPTransport(mcp.HTTPConfig{
+				URL:     httpServer.URL + "/mcp",
+				Headers: map[string]string{"X-Api-Key": "secret"},
+			})
+			client := mcp.NewClient(transport)
+			defer func() { _ = client.Close() }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			result, err := client.Initialize(ctx, mcp.DefaultClientInfo)
+			if err != nil {
+				t.Fatalf("Initialize over HTTP failed: %v", err)
+			}
+			if transport.SessionID() == "" {
+				t.Error("Session id from the initialize response should be kept")
+			}
+			if _, err := client.Ping(ctx); err != nil {
+				t.Errorf("Ping over HTTP failed: %v", err)
+			}
+			tools, err := client.ListTools(ctx)
+			if err != nil || len(tools) != 2 {
+				t.Errorf("ListTools over HTTP = %+v, %v", tools, err)
+			}
+			if got := server.LastHeader("X-Api-Key"); got != "secret" {
+				t.Errorf("Configured headers should be sent, got X-Api-Key=%q", got)
+			}
+			if got := server.LastHeader("MCP-Protocol-Version"); got != result.ProtocolVersion {
+				t.Errorf("Negotiated protocol version should be sent, got %q", got)
+			}
+		})
+	}
+}
+
+func TestStreamableHTTPTransport_HTTPErrors(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+	}))
+	defer httpServer.Close()
+
+	client := mcp.NewClient(mcp.NewStreamableHTTPTransport(mcp.HTTPConfig{URL: httpServer.URL}))
+	defer func() { _ = client.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.Initialize(ctx, mcp.DefaultClientInfo)
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "invalid token") {
+		t.Errorf("Expected HTTP 401 error with body, got %v", err)
+	}
+}
+
+func TestStreamableHTTPTransport_Close(t *testing.T) {
+	transport := mcp.NewStreamableHTTPTransport(mcp.HTTPConfig{URL: "http://127.0.0.1:1"})
+	client := mcp.NewClient(transport)
+	_ = client.Close()
+
+	select {
+	case <-client.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Client should stop after Close")
+	}
+	if err := transport.Send(context.Background(), []byte(`{}`)); err != mcp.ErrTransportClosed {
+		t.Errorf("Send after Close = %v, expected ErrTransportClosed", err)
+	}
+}
@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// maxMessageSize bounds a single newline-delimited JSON-RPC message
+const maxMessageSize = 16 * 1024 * 1024 // 16MB
+
+// ErrTransportClosed is returned when sending on a closed transport
+var ErrTransportClosed = errors.New("transport closed")
+
+// Transport carries raw JSON-RPC messages between a client and a server
+type Transport interface {
+	// Send writes a single JSON-RPC message
+	Send(ctx context.Context, message []byte) error
+	// Messages returns the channel of incoming messages; it is closed when the transport ends
+	Messages() <-chan []byte
+	// Close releases the transport's resources
+	Close() error
+}
+
+// StreamTransport exchanges newline-delimited JSON-RPC messages over a reader and writer
+type StreamTransport struct {
+	reader   io.ReadCloser
+	writer   io.WriteCloser
+	messages chan []byte
+	writeMu  sync.Mutex
+	closed   chan struct{}
+	once     sync.Once
+}
+
+// NewStreamTransport creates a transport over the given reader and writer and starts reading
+func NewStreamTransport(reader io.ReadCloser, writer io.WriteCloser) *StreamTransport {
+	t := &StreamTransport{
+		reader:   reader,
+		writer:   writer,
+		messages: make(chan []byte, 16),
+		closed:   make(chan struct{}),
+	}
+	go t.readLoop()
+	return t
+}
+
+// readLoop scans newline-delimited messages until the reader is exhausted
+func (t *StreamTransport) readLoop() {
+	defer close(t.messages)
+
+	scanner := bufio.NewScanner(t.reader)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		message := make([]byte, len(line))
+		copy(message, line)
+
+		select {
+		case t.messages <- message:
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+// Send writes a message followed by a newline
+func (t *StreamTransport) Send(ctx context.Context, message []byte) error {
+	select {
+	case <-t.closed:
+		return ErrTransportClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
+	if _, err := t.writer.Write(append(message, '\n')); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
+
+// Messages returns the channel of incoming messages
+func (t *StreamTransport) Messages() <-chan []byte {
+	return t.messages
+}
+
+// Close closes both ends of the stream
+func (t *StreamTransport) Close() error {
+	var err error
+	t.once.Do(func() {
+		close(t.closed)
+		writeErr := t.writer.Close()
+		readErr := t.reader.Close()
+		err = errors.Join(writeErr, readErr)
+	})
+	return err
+}
@@ -2,22 +2,24 @@
 package platform
 
 import (
-	"log"
+	"log/slog"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+
+	"mcp-hub/internal/logging"
 )
 
 // DarwinPlatformService provides macOS-specific implementations of platform operations
 type DarwinPlatformService struct {
-	logger *log.Logger
+	logger *slog.Logger
 }
 
 // NewDarwinPlatformService creates a new Darwin platform service
-func NewDarwinPlatformService(logger *log.Logger) *DarwinPlatformService {
+func NewDarwinPlatformService(logger *slog.Logger) *DarwinPlatformService {
 	if logger == nil {
-		logger = log.Default()
+		logger = logging.For("platform")
 	}
 	return &DarwinPlatformService{
 		logger: logger,
@@ -115,18 +117,22 @@ func (d *DarwinPlatformService) GetEnvironmentVariable(key string) string {
 
 // GetHomeDirectory returns the user's home directory
 func (d *DarwinPlatformService) GetHomeDirectory() string {
-	if homeDir, err := os.UserHomeDir(); err == nil {
+	homeDir, err := os.UserHomeDir()
+	if err == nil {
 		return homeDir
 	}
+	d.logger.Debug("home directory lookup failed, using the environment", "error", err)
 	// Fallback to HOME environment variable
 	return os.Getenv("HOME")
 }
 
 // GetCurrentUser returns the current user's username
 func (d *DarwinPlatformService) GetCurrentUser() string {
-	if currentUser, err := user.Current(); err == nil {
+	currentUser, err := user.Current()
+	if err == nil {
 		return currentUser.Username
 	}
+	d.logger.Debug("user lookup failed, using the environment", "error", err)
 	// Fallback to USER environment variable
 	return os.Getenv("USER")
 }
\ No newline at end of file
@@ -2,8 +2,10 @@
 package platform
 
 import (
-	"log"
+	"log/slog"
 	"runtime"
+
+	"mcp-hub/internal/logging"
 )
 
 // Platform OS constants
@@ -15,11 +17,11 @@ const (
 
 // PlatformServiceFactory creates platform-specific service instances
 type PlatformServiceFactory struct {
-	logger *log.Logger
+	logger *slog.Logger
 }
 
 // NewPlatformServiceFactory creates a new platform service factory
-func NewPlatformServiceFactory(logger *log.Logger) *PlatformServiceFactory {
+func NewPlatformServiceFactory(logger *slog.Logger) *PlatformServiceFactory {
 	return &PlatformServiceFactory{
 		logger: logger,
 	}
@@ -28,7 +30,7 @@ func NewPlatformServiceFactory(logger *log.Logger) *PlatformServiceFactory {
 // NewPlatformServiceFactoryDefault creates a platform service factory with default logger
 func NewPlatformServiceFactoryDefault() *PlatformServiceFactory {
 	return &PlatformServiceFactory{
-		logger: log.Default(),
+		logger: logging.For("platform"),
 	}
 }
 
@@ -47,6 +49,7 @@ func (f *PlatformServiceFactory) CreatePlatformServiceForOS(osName string) Platf
 	case osLinux:
 		return NewLinuxPlatformService(f.logger)
 	default:
+		f.logger.Debug("unsupported operating system, using generic paths", "os", osName)
 		return NewGenericPlatformService(f.logger)
 	}
 }
@@ -1,12 +1,12 @@
 package platform
 
 import (
-	"log"
+	"log/slog"
 	"testing"
 )
 
 func TestNewPlatformServiceFactory(t *testing.T) {
-	logger := log.Default()
+	logger := slog.Default()
 	factory := NewPlatformServiceFactory(logger)
 
 	if factory == nil {
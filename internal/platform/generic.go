@@ -2,22 +2,24 @@
 package platform
 
 import (
-	"log"
+	"log/slog"
 	"os"
 	"os/user"
 	"path/filepath"
+
+	"mcp-hub/internal/logging"
 )
 
 // GenericPlatformService provides generic implementations of platform operations
 // This serves as a fallback for unsupported platforms
 type GenericPlatformService struct {
-	logger *log.Logger
+	logger *slog.Logger
 }
 
 // NewGenericPlatformService creates a new generic platform service
-func NewGenericPlatformService(logger *log.Logger) *GenericPlatformService {
+func NewGenericPlatformService(logger *slog.Logger) *GenericPlatformService {
 	if logger == nil {
-		logger = log.Default()
+		logger = logging.For("platform")
 	}
 	return &GenericPlatformService{
 		logger: logger,
@@ -107,18 +109,22 @@ func (g *GenericPlatformService) GetEnvironmentVariable(key string) string {
 
 // GetHomeDirectory returns the user's home directory
 func (g *GenericPlatformService) GetHomeDirectory() string {
-	if homeDir, err := os.UserHomeDir(); err == nil {
+	homeDir, err := os.UserHomeDir()
+	if err == nil {
 		return homeDir
 	}
+	g.logger.Debug("home directory lookup failed, using the environment", "error", err)
 	// Fallback to HOME environment variable
 	return os.Getenv("HOME")
 }
 
 // GetCurrentUser returns the current user's username
 func (g *GenericPlatformService) GetCurrentUser() string {
-	if currentUser, err := user.Current(); err == nil {
+	currentUser, err := user.Current()
+	if err == nil {
 		return currentUser.Username
 	}
+	g.logger.Debug("user lookup failed, using the environment", "error", err)
 	// Fallback to USER environment variable
 	return os.Getenv("USER")
 }
\ No newline at end of file
@@ -2,22 +2,24 @@
 package platform
 
 import (
-	"log"
+	"log/slog"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+
+	"mcp-hub/internal/logging"
 )
 
 // LinuxPlatformService provides Linux-specific implementations of platform operations
 type LinuxPlatformService struct {
-	logger *log.Logger
+	logger *slog.Logger
 }
 
 // NewLinuxPlatformService creates a new Linux platform service
-func NewLinuxPlatformService(logger *log.Logger) *LinuxPlatformService {
+func NewLinuxPlatformService(logger *slog.Logger) *LinuxPlatformService {
 	if logger == nil {
-		logger = log.Default()
+		logger = logging.For("platform")
 	}
 	return &LinuxPlatformService{
 		logger: logger,
@@ -149,18 +151,22 @@ func (l *LinuxPlatformService) GetEnvironmentVariable(key string) string {
 
 // GetHomeDirectory returns the user's home directory
 func (l *LinuxPlatformService) GetHomeDirectory() string {
-	if homeDir, err := os.UserHomeDir(); err == nil {
+	homeDir, err := os.UserHomeDir()
+	if err == nil {
 		return homeDir
 	}
+	l.logger.Debug("home directory lookup failed, using the environment", "error", err)
 	// Fallback to HOME environment variable
 	return os.Getenv("HOME")
 }
 
 // GetCurrentUser returns the current user's username
 func (l *LinuxPlatformService) GetCurrentUser() string {
-	if currentUser, err := user.Current(); err == nil {
+	currentUser, err := user.Current()
+	if err == nil {
 		return currentUser.Username
 	}
+	l.logger.Debug("user lookup failed, using the environment", "error", err)
 	// Fallback to USER environment variable
 	return os.Getenv("USER")
 }
\ No newline at end of file
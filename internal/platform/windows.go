@@ -2,22 +2,24 @@
 package platform
 
 import (
-	"log"
+	"log/slog"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+
+	"mcp-hub/internal/logging"
 )
 
 // WindowsPlatformService provides Windows-specific implementations of platform operations
 type WindowsPlatformService struct {
-	logger *log.Logger
+	logger *slog.Logger
 }
 
 // NewWindowsPlatformService creates a new Windows platform service
-func NewWindowsPlatformService(logger *log.Logger) *WindowsPlatformService {
+func NewWindowsPlatformService(logger *slog.Logger) *WindowsPlatformService {
 	if logger == nil {
-		logger = log.Default()
+		logger = logging.For("platform")
 	}
 	return &WindowsPlatformService{
 		logger: logger,
@@ -117,9 +119,11 @@ func (w *WindowsPlatformService) GetEnvironmentVariable(key string) string {
 
 // GetHomeDirectory returns the user's home directory
 func (w *WindowsPlatformService) GetHomeDirectory() string {
-	if homeDir, err := os.UserHomeDir(); err == nil {
+	homeDir, err := os.UserHomeDir()
+	if err == nil {
 		return homeDir
 	}
+	w.logger.Debug("home directory lookup failed, using the environment", "error", err)
 	// Fallback to USERPROFILE environment variable
 	userProfile := os.Getenv("USERPROFILE")
 	if userProfile != "" {
@@ -136,9 +140,11 @@ func (w *WindowsPlatformService) GetHomeDirectory() string {
 
 // GetCurrentUser returns the current user's username
 func (w *WindowsPlatformService) GetCurrentUser() string {
-	if currentUser, err := user.Current(); err == nil {
+	currentUser, err := user.Current()
+	if err == nil {
 		return currentUser.Username
 	}
+	w.logger.Debug("user lookup failed, using the environment", "error", err)
 	// Fallback to USERNAME environment variable
 	return os.Getenv("USERNAME")
 }
\ No newline at end of file
@@ -0,0 +1,112 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// NamespaceSeparator joins a backend's prefix and its tool or prompt name
+	NamespaceSeparator = "__"
+
+	// BackendStartTimeout bounds spawning a backend and listing what it exposes
+	BackendStartTimeout = 30 * time.Second
+
+	// logSource marks proxy diagnostics in the server log store
+	logSource = "proxy"
+)
+
+// unsafePrefixChars matches characters not allowed in tool names
+var unsafePrefixChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// Backend is one inventory server connected through the proxy
+type Backend struct {
+	Name       string
+	Prefix     string
+	ConfigHash string
+
+	session   *services.MCPSession
+	toolAllow []string
+	toolDeny  []string
+	tools     []mcp.Tool
+	resources []mcp.Resource
+	prompts   []mcp.Prompt
+}
+
+// NamespacePrefix derives the tool and prompt prefix of a server from its inventory name
+func NamespacePrefix(name string) string {
+	return unsafePrefixChars.ReplaceAllString(name, "_")
+}
+
+// startBackend connects to an inventory item and lists its tools, resources and prompts
+func startBackend(ctx context.Context, item types.MCPItem, logs *types.ServerLogStore) (*Backend, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, BackendStartTimeout)
+	defer cancel()
+
+	session, err := services.OpenMCPSession(timeoutCtx, item, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	backend := &Backend{
+		Name:       item.Name,
+		Prefix:     NamespacePrefix(item.Name),
+		ConfigHash: services.ComputeConfigHash(item),
+		session:    session,
+		toolAllow:  item.ToolAllow,
+		toolDeny:   item.ToolDeny,
+	}
+
+	capabilities := session.InitResult.Capabilities
+	if capabilities.Tools != nil {
+		if backend.tools, err = session.Client.ListTools(timeoutCtx); err != nil {
+			_ = session.Close()
+			return nil, err
+		}
+	}
+	if capabilities.Resources != nil {
+		if backend.resources, err = session.Client.ListResources(timeoutCtx); err != nil {
+			_ = session.Close()
+			return nil, err
+		}
+	}
+	if capabilities.Prompts != nil {
+		if backend.prompts, err = session.Client.ListPrompts(timeoutCtx); err != nil {
+			_ = session.Close()
+			return nil, err
+		}
+	}
+	return backend, nil
+}
+
+// Tools returns the backend's tools as listed at startup
+func (b *Backend) Tools() []mcp.Tool {
+	return b.tools
+}
+
+// exposesTool reports whether the backend's tool filter lets a tool through the proxy
+func (b *Backend) exposesTool(name string) bool {
+	return services.IsToolAllowed(b.toolAllow, b.toolDeny, name)
+}
+
+// Close shuts down the backend's server
+func (b *Backend) Close() error {
+	return b.session.Close()
+}
+
+// logProxyEvent records a proxy diagnostic for a backend in the server log store
+func logProxyEvent(logs *types.ServerLogStore, mcpName string, level types.LogLevel, format string, args ...interface{}) {
+	logs.Append(types.ServerLogEntry{
+		MCPName: mcpName,
+		Time:    time.Now(),
+		Level:   level,
+		Source:  logSource,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
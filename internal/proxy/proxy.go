@@ -0,0 +1,517 @@
+// Package proxy serves the enabled MCP inventory as a single aggregated MCP server.
+// Tools and prompts are namespaced as <server>__<name>; resources keep their URIs.
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// ServerInfo identifies the proxy to MCP clients
+var ServerInfo = mcp.Implementation{Name: "mcp-hub", Version: "1.0"}
+
+// route maps an exposed name or URI to the backend that owns it
+type route struct {
+	backend *Backend
+	name    string // Name or URI as known to the backend
+}
+
+// Server aggregates the enabled inventory servers behind one MCP connection
+type Server struct {
+	logs *types.ServerLogStore
+
+	mu           sync.RWMutex
+	backends     map[string]*Backend
+	failed       map[string]string // Config hash of items that failed to start, so they are not respawned
+	tools        map[string]route
+	prompts      map[string]route
+	resources    map[string]route
+	toolList     []mcp.Tool
+	promptList   []mcp.Prompt
+	resourceList []mcp.Resource
+	closed       bool
+
+	writeMu     sync.Mutex
+	encoder     *json.Encoder
+	initialized atomic.Bool
+
+	inflightMu sync.Mutex
+	inflight   map[string]context.CancelFunc
+
+	// ready is closed after the first Sync, so early list requests see the full set
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+// NewServer creates a proxy with no backends; logs receives backend output and proxy diagnostics
+func NewServer(logs *types.ServerLogStore) *Server {
+	s := &Server{
+		logs:     logs,
+		backends: make(map[string]*Backend),
+		failed:   make(map[string]string),
+		inflight: make(map[string]context.CancelFunc),
+		ready:    make(chan struct{}),
+	}
+	s.rebuildRoutes()
+	return s
+}
+
+// Sync starts backends for served items that are new or whose configuration changed,
+// and stops backends that are no longer served. It reports whether the exposed set changed.
+func (s *Server) Sync(ctx context.Context, items []types.MCPItem) bool {
+	defer s.readyOnce.Do(func() { close(s.ready) })
+
+	wanted := make(map[string]types.MCPItem)
+	for _, item := range items {
+		if item.Served {
+			wanted[item.Name] = item
+		}
+	}
+
+	s.mu.RLock()
+	var toStart []types.MCPItem
+	var toStop []*Backend
+	// Tool filters are not part of the config hash, so changing them does not restart the server
+	refiltered := make(map[string]types.MCPItem)
+	for name, backend := range s.backends {
+		item, ok := wanted[name]
+		switch {
+		case !ok || services.ComputeConfigHash(item) != backend.ConfigHash:
+			toStop = append(toStop, backend)
+		case !slices.Equal(item.ToolAllow, backend.toolAllow) || !slices.Equal(item.ToolDeny, backend.toolDeny):
+			refiltered[name] = item
+		}
+	}
+	for name, item := range wanted {
+		hash := services.ComputeConfigHash(item)
+		if backend, ok := s.backends[name]; ok && backend.ConfigHash == hash {
+			continue
+		}
+		if s.failed[name] == hash {
+			continue
+		}
+		toStart = append(toStart, item)
+	}
+	s.mu.RUnlock()
+
+	if len(toStart) == 0 && len(toStop) == 0 && len(refiltered) == 0 {
+		return false
+	}
+
+	started := make([]*Backend, len(toStart))
+	errs := make([]error, len(toStart))
+	var wg sync.WaitGroup
+	for i, item := range toStart {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started[i], errs[i] = startBackend(ctx, item, s.logs)
+		}()
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		for _, backend := range started {
+			if backend != nil {
+				_ = backend.Close()
+			}
+		}
+		return false
+	}
+	// Backends are closed after unlocking, so closing does not hold up list and call requests
+	var stopped []*Backend
+	for _, backend := range toStop {
+		// A backend that exited meanwhile was already dropped by watchBackend
+		if s.backends[backend.Name] == backend {
+			delete(s.backends, backend.Name)
+			stopped = append(stopped, backend)
+		}
+	}
+	changed := len(stopped) > 0
+	for name, item := range refiltered {
+		backend, ok := s.backends[name]
+		if !ok {
+			continue
+		}
+		backend.toolAllow = item.ToolAllow
+		backend.toolDeny = item.ToolDeny
+		logProxyEvent(s.logs, name, types.LogInfo, "tool filter updated")
+		changed = true
+	}
+	for i, item := range toStart {
+		if errs[i] != nil {
+			s.failed[item.Name] = services.ComputeConfigHash(item)
+			logProxyEvent(s.logs, item.Name, types.LogError, "failed to start: %v", errs[i])
+			continue
+		}
+		delete(s.failed, item.Name)
+		s.backends[item.Name] = started[i]
+		go s.watchBackend(started[i])
+		logProxyEvent(s.logs, item.Name, types.LogInfo, "started with %d tools", len(started[i].tools))
+		changed = true
+	}
+	for name := range s.failed {
+		if _, ok := wanted[name]; !ok {
+			delete(s.failed, name)
+		}
+	}
+	s.rebuildRoutes()
+	s.mu.Unlock()
+
+	for _, backend := range stopped {
+		_ = backend.Close()
+		logProxyEvent(s.logs, backend.Name, types.LogInfo, "stopped")
+	}
+	if changed {
+		s.notifyListChanged()
+	}
+	return changed
+}
+
+// watchBackend drops a backend whose session ends while it is still served, such as when
+// its server crashes, so its tools are no longer listed and the next Sync starts it again
+func (s *Server) watchBackend(backend *Backend) {
+	<-backend.session.Client.Done()
+
+	s.mu.Lock()
+	if s.backends[backend.Name] != backend {
+		// Stopped by Sync or Close
+		s.mu.Unlock()
+		return
+	}
+	delete(s.backends, backend.Name)
+	logProxyEvent(s.logs, backend.Name, types.LogWarning, "exited, restarting on the next sync")
+	s.rebuildRoutes()
+	s.mu.Unlock()
+
+	_ = backend.Close()
+	s.notifyListChanged()
+}
+
+// rebuildRoutes recomputes the exposed lists and routing tables; callers hold mu
+func (s *Server) rebuildRoutes() {
+	names := make([]string, 0, len(s.backends))
+	for name := range s.backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s.tools = make(map[string]route)
+	s.prompts = make(map[string]route)
+	s.resources = make(map[string]route)
+	s.toolList = []mcp.Tool{}
+	s.promptList = []mcp.Prompt{}
+	s.resourceList = []mcp.Resource{}
+
+	// Names such as my.server and my_server share a prefix; the first in sorted order keeps it
+	prefixOwners := make(map[string]string)
+	for _, name := range names {
+		backend := s.backends[name]
+		if owner, ok := prefixOwners[backend.Prefix]; ok {
+			logProxyEvent(s.logs, name, types.LogWarning, "tools and prompts hidden, prefix %s already used by %s", backend.Prefix, owner)
+		} else {
+			prefixOwners[backend.Prefix] = name
+			s.addNamespacedRoutes(backend)
+		}
+		for _, resource := range backend.resources {
+			if owner, ok := s.resources[resource.URI]; ok {
+				logProxyEvent(s.logs, name, types.LogWarning, "resource %s hidden, already exposed by %s", resource.URI, owner.backend.Name)
+				continue
+			}
+			exposed := resource
+			exposed.Name = backend.Prefix + NamespaceSeparator + resource.Name
+			s.resources[resource.URI] = route{backend: backend, name: resource.URI}
+			s.resourceList = append(s.resourceList, exposed)
+		}
+	}
+}
+
+// addNamespacedRoutes exposes the tools and prompts of backend under its prefix
+func (s *Server) addNamespacedRoutes(backend *Backend) {
+	for _, tool := range backend.tools {
+		if !backend.exposesTool(tool.Name) {
+			continue
+		}
+		exposed := tool
+		exposed.Name = backend.Prefix + NamespaceSeparator + tool.Name
+		s.tools[exposed.Name] = route{backend: backend, name: tool.Name}
+		s.toolList = append(s.toolList, exposed)
+	}
+	for _, prompt := range backend.prompts {
+		exposed := prompt
+		exposed.Name = backend.Prefix + NamespaceSeparator + prompt.Name
+		s.prompts[exposed.Name] = route{backend: backend, name: prompt.Name}
+		s.promptList = append(s.promptList, exposed)
+	}
+}
+
+// Backends returns the names of the running backends in sorted order
+func (s *Server) Backends() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.backends))
+	for name := range s.backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Respond answers one request from the MCP client, routing calls to their backend
+func (s *Server) Respond(ctx context.Context, request mcp.Message) *mcp.Message {
+	if !request.IsRequest() {
+		return nil
+	}
+
+	// Everything but the handshake waits for the backends to start
+	if request.Method != mcp.MethodInitialize && request.Method != mcp.MethodPing {
+		select {
+		case <-s.ready:
+		case <-ctx.Done():
+			response, _ := mcp.NewResponse(request.ID, nil, &mcp.RPCError{Code: mcp.ErrorCodeInternalError, Message: ctx.Err().Error()})
+			return response
+		}
+	}
+
+	var result interface{}
+	var rpcErr *mcp.RPCError
+	switch request.Method {
+	case mcp.MethodInitialize:
+		result = s.initializeResult(request.Params)
+	case mcp.MethodPing:
+		result = struct{}{}
+	case mcp.MethodToolsList:
+		s.mu.RLock()
+		result = mcp.ListToolsResult{Tools: s.toolList}
+		s.mu.RUnlock()
+	case mcp.MethodPromptsList:
+		s.mu.RLock()
+		result = mcp.ListPromptsResult{Prompts: s.promptList}
+		s.mu.RUnlock()
+	case mcp.MethodResourcesList:
+		s.mu.RLock()
+		result = mcp.ListResourcesResult{Resources: s.resourceList}
+		s.mu.RUnlock()
+	case mcp.MethodToolsCall:
+		result, rpcErr = s.forward(ctx, request, "name")
+	case mcp.MethodPromptsGet:
+		result, rpcErr = s.forward(ctx, request, "name")
+	case mcp.MethodResourcesRead:
+		result, rpcErr = s.forward(ctx, request, "uri")
+	default:
+		rpcErr = &mcp.RPCError{Code: mcp.ErrorCodeMethodNotFound, Message: "method not found: " + request.Method}
+	}
+
+	response, err := mcp.NewResponse(request.ID, result, rpcErr)
+	if err != nil {
+		response, _ = mcp.NewResponse(request.ID, nil, &mcp.RPCError{Code: mcp.ErrorCodeInternalError, Message: err.Error()})
+	}
+	return response
+}
+
+// initializeResult advertises list-changed support for everything, since the enabled set may change
+func (s *Server) initializeResult(rawParams json.RawMessage) mcp.InitializeResult {
+	var params mcp.InitializeParams
+	_ = json.Unmarshal(rawParams, &params)
+	version := params.ProtocolVersion
+	if version == "" {
+		version = mcp.LatestProtocolVersion
+	}
+
+	return mcp.InitializeResult{
+		ProtocolVersion: version,
+		ServerInfo:      ServerInfo,
+		Capabilities: mcp.ServerCapabilities{
+			Tools:     &mcp.ListChangedCapability{ListChanged: true},
+			Resources: &mcp.ResourcesCapability{ListChanged: true},
+			Prompts:   &mcp.ListChangedCapability{ListChanged: true},
+		},
+		Instructions: "Tools and prompts from the servers enabled in mcp-hub, named <server>__<name>.",
+	}
+}
+
+// forward rewrites the routed parameter back to the backend's own name and relays the request.
+// Other parameters, including _meta, pass through unchanged.
+func (s *Server) forward(ctx context.Context, request mcp.Message, key string) (interface{}, *mcp.RPCError) {
+	var params map[string]json.RawMessage
+	if err := json.Unmarshal(request.Params, &params); err != nil {
+		return nil, &mcp.RPCError{Code: mcp.ErrorCodeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	var exposed string
+	if err := json.Unmarshal(params[key], &exposed); err != nil || exposed == "" {
+		return nil, &mcp.RPCError{Code: mcp.ErrorCodeInvalidParams, Message: "missing " + key}
+	}
+
+	s.mu.RLock()
+	var target route
+	var ok bool
+	switch request.Method {
+	case mcp.MethodToolsCall:
+		target, ok = s.tools[exposed]
+	case mcp.MethodPromptsGet:
+		target, ok = s.prompts[exposed]
+	case mcp.MethodResourcesRead:
+		target, ok = s.resources[exposed]
+	}
+	s.mu.RUnlock()
+	if !ok {
+		return nil, &mcp.RPCError{Code: mcp.ErrorCodeInvalidParams, Message: "unknown " + key + ": " + exposed}
+	}
+	params[key], _ = json.Marshal(target.name)
+
+	var result json.RawMessage
+	if err := target.backend.session.Client.Call(ctx, request.Method, params, &result); err != nil {
+		var rpcErr *mcp.RPCError
+		if errors.As(err, &rpcErr) {
+			return nil, rpcErr
+		}
+		return nil, &mcp.RPCError{Code: mcp.ErrorCodeInternalError, Message: target.backend.Name + ": " + err.Error()}
+	}
+	if len(result) == 0 {
+		return struct{}{}, nil
+	}
+	return result, nil
+}
+
+// Serve answers newline-delimited JSON-RPC from r on w until r ends. Requests run
+// concurrently so a slow tool does not block the others.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.writeMu.Lock()
+	s.encoder = json.NewEncoder(w)
+	s.writeMu.Unlock()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	var wg sync.WaitGroup
+	for scanner.Scan() {
+		var message mcp.Message
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			continue
+		}
+
+		switch {
+		case message.IsRequest():
+			requestCtx, cancel := context.WithCancel(ctx)
+			key := string(message.ID)
+			s.inflightMu.Lock()
+			s.inflight[key] = cancel
+			s.inflightMu.Unlock()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				response := s.Respond(requestCtx, message)
+
+				s.inflightMu.Lock()
+				delete(s.inflight, key)
+				s.inflightMu.Unlock()
+
+				// Cancelled requests get no response
+				if requestCtx.Err() == nil {
+					s.write(response)
+				}
+				cancel()
+			}()
+		case message.IsNotification():
+			s.handleNotification(message)
+		}
+	}
+
+	wg.Wait()
+	return scanner.Err()
+}
+
+// handleNotification tracks the handshake and cancels abandoned requests
+func (s *Server) handleNotification(message mcp.Message) {
+	switch message.Method {
+	case mcp.MethodInitialized:
+		s.initialized.Store(true)
+	case mcp.MethodCancelled:
+		var params mcp.CancelledParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return
+		}
+		s.inflightMu.Lock()
+		cancel, ok := s.inflight[string(params.RequestID)]
+		s.inflightMu.Unlock()
+		if ok {
+			cancel()
+		}
+	}
+}
+
+// notifyListChanged tells an initialized client to re-list tools, resources and prompts
+func (s *Server) notifyListChanged() {
+	if !s.initialized.Load() {
+		return
+	}
+	for _, method := range []string{mcp.MethodToolsListChanged, mcp.MethodResourcesListChanged, mcp.MethodPromptsListChanged} {
+		if notification, err := mcp.NewNotification(method, nil); err == nil {
+			s.write(notification)
+		}
+	}
+}
+
+// write sends one message to the client
+func (s *Server) write(message *mcp.Message) {
+	if message == nil {
+		return
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if s.encoder != nil {
+		_ = s.encoder.Encode(message)
+	}
+}
+
+// InventoryPollInterval is how often serve mode reloads the inventory to follow the enabled set
+const InventoryPollInterval = 2 * time.Second
+
+// Watch reloads the inventory every interval and syncs the backends with its enabled set
+func (s *Server) Watch(ctx context.Context, load func() ([]types.MCPItem, error), interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if items, err := load(); err == nil {
+				s.Sync(ctx, items)
+			}
+		}
+	}
+}
+
+// Close stops every backend
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	stopped := make([]*Backend, 0, len(s.backends))
+	for name, backend := range s.backends {
+		stopped = append(stopped, backend)
+		delete(s.backends, name)
+	}
+	s.rebuildRoutes()
+	s.mu.Unlock()
+
+	for _, backend := range stopped {
+		_ = backend.Close()
+	}
+	return nil
+}
@@ -0,0 +1,362 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/mcp/mcptest"
+	"mcp-hub/internal/ui/types"
+)
+
+// newBackendItem serves an mcptest server over streamable HTTP and returns an enabled item for it
+func newBackendItem(t *testing.T, name string, server *mcptest.Server) types.MCPItem {
+	t.Helper()
+	httpServer := httptest.NewServer(server.StreamableHTTPHandler())
+	t.Cleanup(httpServer.Close)
+	return types.MCPItem{Name: name, Type: "HTTP", URL: httpServer.URL, Served: true}
+}
+
+// newTestBackends returns a github backend with tools and a docs backend with tools, resources and prompts
+func newTestBackends(t *testing.T) []types.MCPItem {
+	t.Helper()
+
+	github := mcptest.NewServer(mcp.Tool{Name: "echo"}, mcp.Tool{Name: "create_issue"})
+	github.HandleTool("echo", func(arguments map[string]interface{}) mcp.CallToolResult {
+		text, _ := arguments["text"].(string)
+		return mcp.CallToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: "github:" + text}}}
+	})
+
+	docs := mcptest.NewServer(mcp.Tool{Name: "search"})
+	docs.Capabilities.Resources = &mcp.ResourcesCapability{}
+	docs.Capabilities.Prompts = &mcp.ListChangedCapability{}
+	docs.Resources = []mcp.Resource{{URI: "file:///guide.md", Name: "guide"}}
+	docs.Prompts = []mcp.Prompt{{Name: "review"}}
+	docs.Handle(mcp.MethodResourcesRead, func(params json.RawMessage) (interface{}, *mcp.RPCError) {
+		var read mcp.ReadResourceParams
+		_ = json.Unmarshal(params, &read)
+		return map[string]interface{}{"contents": []map[string]string{{"uri": read.URI, "text": "# Guide"}}}, nil
+	})
+	docs.Handle(mcp.MethodPromptsGet, func(params json.RawMessage) (interface{}, *mcp.RPCError) {
+		var get mcp.GetPromptParams
+		_ = json.Unmarshal(params, &get)
+		return map[string]interface{}{"description": "prompt " + get.Name + " for " + get.Arguments["diff"]}, nil
+	})
+
+	return []types.MCPItem{
+		newBackendItem(t, "github", github),
+		newBackendItem(t, "my docs", docs),
+		{Name: "disabled", Type: "CMD", Command: "/nonexistent/mcp-server"},
+	}
+}
+
+// respond sends one request to the proxy and decodes the result into result
+func respond(t *testing.T, server *Server, method string, params, result interface{}) *mcp.RPCError {
+	t.Helper()
+	data, _ := json.Marshal(params)
+	response := server.Respond(t.Context(), mcp.Message{JSONRPC: mcp.JSONRPCVersion, ID: json.RawMessage(`1`), Method: method, Params: data})
+	if response.Error != nil {
+		return response.Error
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		t.Fatalf("Failed to decode %s result: %v", method, err)
+	}
+	return nil
+}
+
+func TestNamespacePrefix(t *testing.T) {
+	tests := map[string]string{
+		"github":        "github",
+		"my docs":       "my_docs",
+		"context7.mcp":  "context7_mcp",
+		"server-1_beta": "server-1_beta",
+	}
+	for name, expected := range tests {
+		if prefix := NamespacePrefix(name); prefix != expected {
+			t.Errorf("NamespacePrefix(%q) = %q, expected %q", name, prefix, expected)
+		}
+	}
+}
+
+func TestServer_AggregatesEnabledBackends(t *testing.T) {
+	server := NewServer(types.NewServerLogStore(types.ServerLogCapacity))
+	t.Cleanup(func() { _ = server.Close() })
+
+	if !server.Sync(t.Context(), newTestBackends(t)) {
+		t.Fatal("First sync should start the enabled backends")
+	}
+	if backends := server.Backends(); !reflect.DeepEqual(backends, []string{"github", "my docs"}) {
+		t.Errorf("Only enabled items should be started, got %v", backends)
+	}
+
+	var tools mcp.ListToolsResult
+	respond(t, server, mcp.MethodToolsList, nil, &tools)
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	expected := []string{"github__echo", "github__create_issue", "my_docs__search"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("tools/list = %v, expected %v", names, expected)
+	}
+
+	var call mcp.CallToolResult
+	if rpcErr := respond(t, server, mcp.MethodToolsCall, mcp.CallToolParams{Name: "github__echo", Arguments: map[string]interface{}{"text": "hi"}}, &call); rpcErr != nil {
+		t.Fatalf("tools/call failed: %v", rpcErr)
+	}
+	if len(call.Content) != 1 || call.Content[0].Text != "github:hi" {
+		t.Errorf("Call should be routed to the github backend, got %+v", call)
+	}
+
+	var resources mcp.ListResourcesResult
+	respond(t, server, mcp.MethodResourcesList, nil, &resources)
+	if len(resources.Resources) != 1 || resources.Resources[0].URI != "file:///guide.md" || resources.Resources[0].Name != "my_docs__guide" {
+		t.Errorf("Resources should keep their URI with a namespaced name, got %+v", resources.Resources)
+	}
+
+	var read struct {
+		Contents []struct{ URI, Text string } `json:"contents"`
+	}
+	if rpcErr := respond(t, server, mcp.MethodResourcesRead, mcp.ReadResourceParams{URI: "file:///guide.md"}, &read); rpcErr != nil || len(read.Contents) != 1 || read.Contents[0].Text != "# Guide" {
+		t.Errorf("resources/read should be routed by URI, got %+v, %v", read, rpcErr)
+	}
+
+	var prompt struct{ Description string }
+	params := mcp.GetPromptParams{Name: "my_docs__review", Arguments: map[string]string{"diff": "x"}}
+	if rpcErr := respond(t, server, mcp.MethodPromptsGet, params, &prompt); rpcErr != nil || prompt.Description != "prompt review for x" {
+		t.Errorf("prompts/get should be routed with the original name, got %+v, %v", prompt, rpcErr)
+	}
+}
+
+func TestServer_RoutingErrors(t *testing.T) {
+	server := NewServer(nil)
+	t.Cleanup(func() { _ = server.Close() })
+	server.Sync(t.Context(), newTestBackends(t))
+
+	var ignored json.RawMessage
+	if rpcErr := respond(t, server, mcp.MethodToolsCall, mcp.CallToolParams{Name: "github__missing"}, &ignored); rpcErr == nil || rpcErr.Code != mcp.ErrorCodeInvalidParams {
+		t.Errorf("Unknown tool should be rejected, got %v", rpcErr)
+	}
+
+	// The backend's own error is relayed as is
+	if rpcErr := respond(t, server, mcp.MethodToolsCall, mcp.CallToolParams{Name: "github__create_issue"}, &ignored); rpcErr == nil || rpcErr.Code != mcp.ErrorCodeInvalidParams {
+		t.Errorf("Backend error should be relayed, got %v", rpcErr)
+	}
+
+	if rpcErr := respond(t, server, "completion/complete", nil, &ignored); rpcErr == nil || rpcErr.Code != mcp.ErrorCodeMethodNotFound {
+		t.Errorf("Unsupported method should be rejected, got %v", rpcErr)
+	}
+}
+
+func TestServer_PrefixCollision(t *testing.T) {
+	logs := types.NewServerLogStore(types.ServerLogCapacity)
+	server := NewServer(logs)
+	t.Cleanup(func() { _ = server.Close() })
+
+	dotted := mcptest.NewServer(mcp.Tool{Name: "search"})
+	underscored := mcptest.NewServer(mcp.Tool{Name: "search"}, mcp.Tool{Name: "fetch"})
+	server.Sync(t.Context(), []types.MCPItem{
+		newBackendItem(t, "my_server", underscored),
+		newBackendItem(t, "my.server", dotted),
+	})
+
+	var tools mcp.ListToolsResult
+	respond(t, server, mcp.MethodToolsList, nil, &tools)
+	if len(tools.Tools) != 1 || tools.Tools[0].Name != "my_server__search" {
+		t.Fatalf("Only the first server in sorted order should keep the prefix, got %+v", tools.Tools)
+	}
+	if route := server.tools["my_server__search"]; route.backend.Name != "my.server" {
+		t.Errorf("The prefix should route to my.server, got %s", route.backend.Name)
+	}
+	entries := logs.Entries("my_server")
+	if len(entries) == 0 || entries[len(entries)-1].Level != types.LogWarning {
+		t.Errorf("The hidden server should get a warning, got %+v", entries)
+	}
+}
+
+func TestServer_FailedBackendIsNotRespawned(t *testing.T) {
+	logs := types.NewServerLogStore(types.ServerLogCapacity)
+	server := NewServer(logs)
+	t.Cleanup(func() { _ = server.Close() })
+
+	items := []types.MCPItem{{Name: "broken", Type: "CMD", Command: "/nonexistent/mcp-server", Served: true}}
+	if server.Sync(t.Context(), items) {
+		t.Error("A backend that fails to start should not change the exposed set")
+	}
+	entries := logs.Entries("broken")
+	if len(entries) == 0 || entries[len(entries)-1].Level != types.LogError || entries[len(entries)-1].Source != logSource {
+		t.Errorf("Start failure should be logged, got %+v", entries)
+	}
+
+	server.Sync(t.Context(), items)
+	if len(logs.Entries("broken")) != len(entries) {
+		t.Error("An unchanged failing backend should not be respawned")
+	}
+}
+
+func TestServer_DropsExitedBackend(t *testing.T) {
+	logs := types.NewServerLogStore(types.ServerLogCapacity)
+	server := NewServer(logs)
+	t.Cleanup(func() { _ = server.Close() })
+	items := newTestBackends(t)
+	server.Sync(t.Context(), items)
+
+	// End the session as a crashing server would
+	server.mu.RLock()
+	github := server.backends["github"]
+	server.mu.RUnlock()
+	_ = github.session.Client.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for slices.Contains(server.Backends(), "github") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	var tools mcp.ListToolsResult
+	if err := respond(t, server, mcp.MethodToolsList, nil, &tools); err != nil || len(tools.Tools) != 1 {
+		t.Fatalf("The exited backend's tools should no longer be listed, got %+v %v", tools.Tools, err)
+	}
+	entries := logs.Entries("github")
+	if len(entries) == 0 || entries[len(entries)-1].Level != types.LogWarning {
+		t.Errorf("The exit should be logged, got %+v", entries)
+	}
+
+	if !server.Sync(t.Context(), items) || !slices.Contains(server.Backends(), "github") {
+		t.Errorf("The next sync should start the backend again, got %v", server.Backends())
+	}
+}
+
+// newServeClient connects an MCP client to the proxy over in-memory pipes
+func newServeClient(t *testing.T, server *Server) *mcp.Client {
+	t.Helper()
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	go func() {
+		_ = server.Serve(context.Background(), serverReader, serverWriter)
+		_ = serverWriter.Close()
+	}()
+
+	client := mcp.NewClient(mcp.NewStreamTransport(clientReader, clientWriter))
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestServer_ServeFollowsEnabledSet(t *testing.T) {
+	server := NewServer(nil)
+	t.Cleanup(func() { _ = server.Close() })
+	items := newTestBackends(t)
+	server.Sync(t.Context(), items)
+
+	client := newServeClient(t, server)
+	var mu sync.Mutex
+	var notifications []string
+	client.SetNotificationHandler(func(notification mcp.Notification) {
+		mu.Lock()
+		notifications = append(notifications, notification.Method)
+		mu.Unlock()
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	result, err := client.Initialize(ctx, mcp.DefaultClientInfo)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if result.ServerInfo.Name != "mcp-hub" || result.Capabilities.Tools == nil || !result.Capabilities.Tools.ListChanged {
+		t.Errorf("Unexpected initialize result %+v", result)
+	}
+
+	// Stop serving github, as pressing S in the TUI would
+	items[0].Served = false
+	if !server.Sync(ctx, items) {
+		t.Fatal("Disabling a server should change the exposed set")
+	}
+
+	tools, err := client.ListTools(ctx)
+	if err != nil || len(tools) != 1 || tools[0].Name != "my_docs__search" {
+		t.Errorf("tools/list after disabling github = %+v, %v", tools, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(notifications) != 3 || notifications[0] != mcp.MethodToolsListChanged {
+		t.Errorf("Client should be told to re-list, got %v", notifications)
+	}
+}
+
+func TestServer_CancelledNotification(t *testing.T) {
+	server := NewServer(nil)
+	ctx, cancel := context.WithCancel(t.Context())
+	server.inflight["7"] = cancel
+
+	params, _ := json.Marshal(mcp.CancelledParams{RequestID: json.RawMessage(`7`), Reason: "user aborted"})
+	server.handleNotification(mcp.Message{JSONRPC: mcp.JSONRPCVersion, Method: mcp.MethodCancelled, Params: params})
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Error("notifications/cancelled should cancel the in-flight request")
+	}
+}
+
+func TestServer_WaitsForFirstSync(t *testing.T) {
+	server := NewServer(nil)
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	request := mcp.Message{JSONRPC: mcp.JSONRPCVersion, ID: json.RawMessage(`1`), Method: mcp.MethodToolsList}
+	if response := server.Respond(ctx, request); response.Error == nil {
+		t.Error("Listing before the first sync should wait for it")
+	}
+
+	ping := mcp.Message{JSONRPC: mcp.JSONRPCVersion, ID: json.RawMessage(`2`), Method: mcp.MethodPing}
+	if response := server.Respond(ctx, ping); response.Error != nil {
+		t.Errorf("Ping should not wait for backends, got %v", response.Error)
+	}
+
+	server.Sync(t.Context(), nil)
+	if response := server.Respond(t.Context(), request); response.Error != nil || string(response.Result) != `{"tools":[]}` {
+		t.Errorf("Empty set should list no tools, got %s %v", response.Result, response.Error)
+	}
+}
+
+func TestServer_EnforcesToolFilter(t *testing.T) {
+	server := NewServer(nil)
+	t.Cleanup(func() { _ = server.Close() })
+	items := newTestBackends(t)
+	items[0].ToolDeny = []string{"create_*"}
+	server.Sync(t.Context(), items)
+
+	var tools mcp.ListToolsResult
+	respond(t, server, mcp.MethodToolsList, nil, &tools)
+	for _, tool := range tools.Tools {
+		if tool.Name == "github__create_issue" {
+			t.Error("Denied tool should not be listed")
+		}
+	}
+	var ignored json.RawMessage
+	if rpcErr := respond(t, server, mcp.MethodToolsCall, mcp.CallToolParams{Name: "github__create_issue"}, &ignored); rpcErr == nil || rpcErr.Code != mcp.ErrorCodeInvalidParams {
+		t.Errorf("Denied tool should not be callable, got %v", rpcErr)
+	}
+
+	// Changing only the filter re-exposes the tool without restarting the backend
+	backend := server.backends["github"]
+	items[0].ToolDeny = nil
+	items[0].ToolAllow = []string{"echo", "create_*"}
+	if !server.Sync(t.Context(), items) {
+		t.Fatal("A filter change should change the exposed set")
+	}
+	if server.backends["github"] != backend {
+		t.Error("A filter change should not restart the backend")
+	}
+	respond(t, server, mcp.MethodToolsList, nil, &tools)
+	if len(tools.Tools) != 3 || tools.Tools[1].Name != "github__create_issue" {
+		t.Errorf("Allowed tools = %+v", tools.Tools)
+	}
+}
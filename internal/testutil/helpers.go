@@ -1,8 +1,7 @@
 package testutil
 
 import (
-	"strings"
-
+	"mcp-hub/internal/ui/services"
 	"mcp-hub/internal/ui/types"
 )
 
@@ -26,22 +25,9 @@ func GetExpectedColumns(width int) int {
 	return types.NarrowColumns
 }
 
-// GetFilteredMCPs returns MCPs filtered by search query
+// GetFilteredMCPs returns the MCPs matching the search query, best match first
 func GetFilteredMCPs(model types.Model) []types.MCPItem {
-	// If no search query, return all MCPs
-	if model.SearchQuery == "" {
-		return model.MCPItems
-	}
-
-	// Filter MCPs by search query directly
-	var filtered []types.MCPItem
-	query := strings.ToLower(model.SearchQuery)
-	for _, item := range model.MCPItems {
-		if strings.Contains(strings.ToLower(item.Name), query) {
-			filtered = append(filtered, item)
-		}
-	}
-	return filtered
+	return services.GetFilteredMCPs(model)
 }
 
 // MockMCPItems returns a smaller set of MCPs for testing
@@ -4,23 +4,26 @@ package components
 import (
 	"strings"
 
+	"mcp-hub/internal/ui/types"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
 // RenderAlertOverlay renders a success/error alert as an overlay positioned at the top center
 // without affecting the main content layout flow. This follows the same pattern as the modal
 // overlay system to ensure consistent behavior.
-func RenderAlertOverlay(message string, width, height int, backgroundContent string) string {
+func RenderAlertOverlay(theme types.Theme, message string, width, height int, backgroundContent string) string {
 	// If no message, return background content unchanged
 	if message == "" {
 		return backgroundContent
 	}
 
-	// Alert styling - maintaining the existing green success style
-	// Using exact same colors and styling as the original implementation
+	// Alert styling - the theme's success color behind the accent text
+	styles := ThemeStyles(theme)
 	alertStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#51CF66")).
-		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(Color(styles.Theme.Success)).
+		Foreground(Color(styles.Theme.AccentText)).
+		Reverse(styles.Theme.Monochrome).
 		Bold(true).
 		Padding(0, 2).
 		Align(lipgloss.Center)
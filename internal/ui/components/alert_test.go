@@ -3,6 +3,8 @@ package components
 import (
 	"strings"
 	"testing"
+
+	"mcp-hub/internal/ui/types"
 )
 
 func TestRenderAlertOverlay(t *testing.T) {
@@ -10,7 +12,7 @@ func TestRenderAlertOverlay(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := RenderAlertOverlay(tt.message, tt.width, tt.height, tt.backgroundContent)
+			result := RenderAlertOverlay(types.Theme{}, tt.message, tt.width, tt.height, tt.backgroundContent)
 
 			if !tt.expectOverlay {
 				assertBackgroundUnchanged(t, result, tt.backgroundContent)
@@ -100,7 +102,7 @@ func TestRenderAlertOverlay_Styling(t *testing.T) {
 	height := 24
 	background := "Background content"
 
-	result := RenderAlertOverlay(message, width, height, background)
+	result := RenderAlertOverlay(types.Theme{}, message, width, height, background)
 
 	// The result should be different from background (overlay applied)
 	if result == background {
@@ -145,7 +147,7 @@ func TestRenderAlertOverlay_MinimumWidth(t *testing.T) {
 	height := 5
 	background := "BG"
 
-	result := RenderAlertOverlay(message, width, height, background)
+	result := RenderAlertOverlay(types.Theme{}, message, width, height, background)
 
 	// Should not panic and should produce some result
 	if result == "" {
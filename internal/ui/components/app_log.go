@@ -0,0 +1,81 @@
+package components
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// appLogLineWidth caps the width of a line in the application log viewer
+const appLogLineWidth = 102
+
+// getAppLogTitleAndFooter returns the application log viewer title and key hints
+func getAppLogTitleAndFooter() (string, string) {
+	return "Application Log",
+		"[↑↓/PgUp/PgDn] Scroll • [g/G] Oldest/Newest • [f] Level • [c] Component • ESC Close"
+}
+
+// renderAppLogContent renders the visible window of mcp-hub's own log
+func renderAppLogContent(model types.Model) string {
+	styles := ThemeStyles(model.Theme)
+	viewer := model.AppLog
+	entries := logging.Filter(viewer.Entries, viewer.MinLevel, viewer.Component)
+
+	component := viewer.Component
+	if component == "" {
+		component = "all"
+	}
+	status := fmt.Sprintf("Level: %s and above • Component: %s • %d of %d entries",
+		logging.LevelName(viewer.MinLevel), component, len(entries), len(viewer.Entries))
+	if viewer.Scroll > 0 {
+		status += " • scrolled"
+	} else {
+		status += " • following"
+	}
+	lines := []string{styles.Dim.Render(status), ""}
+
+	if viewer.Error != "" {
+		lines = append(lines, styles.Error.Render(viewer.Error))
+		return strings.Join(lines, "\n")
+	}
+	if len(viewer.Entries) == 0 {
+		lines = append(lines, "The log is empty.",
+			styles.Dim.Render("Entries are written to "+services.GetAppLogPath(model.PlatformService)))
+		return strings.Join(lines, "\n")
+	}
+	if len(entries) == 0 {
+		lines = append(lines, "No entries match. Press f or c to change the filters.")
+		return strings.Join(lines, "\n")
+	}
+
+	start, end := logWindow(len(entries), viewer.Scroll)
+	if start > 0 {
+		lines = append(lines, styles.Dim.Render(fmt.Sprintf("↑ %d older", start)))
+	}
+	for _, entry := range entries[start:end] {
+		lines = append(lines, renderAppLogLine(styles, entry))
+	}
+	if newer := len(entries) - end; newer > 0 {
+		lines = append(lines, styles.Dim.Render(fmt.Sprintf("↓ %d newer", newer)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderAppLogLine formats one entry, colored by level
+func renderAppLogLine(styles *Styles, entry logging.Entry) string {
+	line := truncatePlaygroundText(strings.ReplaceAll(logging.FormatEntry(entry), "\t", "    "), appLogLineWidth)
+	switch {
+	case entry.Level >= slog.LevelError:
+		return styles.Error.Render(line)
+	case entry.Level >= slog.LevelWarn:
+		return styles.Warning.Render(line)
+	case entry.Level < slog.LevelInfo:
+		return styles.Dim.Render(line)
+	default:
+		return line
+	}
+}
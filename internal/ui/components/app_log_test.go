@@ -0,0 +1,54 @@
+package components
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestRenderAppLogContent(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.ActiveModal = types.AppLogModal
+
+	if content := renderAppLogContent(model); !strings.Contains(content, "The log is empty.") {
+		t.Errorf("Expected the empty hint, got:\n%s", content)
+	}
+
+	for i := 0; i < types.LogPaneLines+3; i++ {
+		model.AppLog.Entries = append(model.AppLog.Entries, logging.Entry{
+			Level: slog.LevelWarn, Component: "claude", Message: fmt.Sprintf("line %d", i), Fields: []string{"mcp=github"},
+		})
+	}
+	model.AppLog.Entries = append(model.AppLog.Entries, logging.Entry{Level: slog.LevelInfo, Component: "storage", Message: "saved"})
+	model.AppLog.MinLevel = slog.LevelWarn
+	model.AppLog.Component = "claude"
+	model.AppLog.Scroll = 1
+
+	content := renderAppLogContent(model)
+	for _, fragment := range []string{
+		"Level: warn and above • Component: claude • 23 of 24 entries • scrolled",
+		"↑ 2 older",
+		"[claude] line 21 mcp=github",
+		"↓ 1 newer",
+	} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+	if strings.Contains(content, "saved") {
+		t.Error("Filtered entries should be hidden")
+	}
+
+	model.AppLog.Component = "proxy"
+	if content := renderAppLogContent(model); !strings.Contains(content, "No entries match") {
+		t.Errorf("Expected the no-match hint, got:\n%s", content)
+	}
+	if title, footer := getAppLogTitleAndFooter(); title != "Application Log" || !strings.Contains(footer, "[c] Component") {
+		t.Errorf("Unexpected title %q and footer %q", title, footer)
+	}
+}
@@ -14,8 +14,8 @@ import (
 // RenderFooter creates the application footer with status information including toggle operations
 func RenderFooter(model types.Model) string {
 	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#CCCCCC")).
-		Background(lipgloss.Color("#2D2D3D")).
+		Foreground(Color(model.Theme.OrDefault().FooterText)).
+		Background(Color(model.Theme.OrDefault().FooterBackground)).
 		Padding(0, 2).
 		Width(model.Width)
 
@@ -48,9 +48,11 @@ func getToggleFooterContent(model types.Model) string {
 
 // getSearchActiveFooterContent returns footer content for active search
 func getSearchActiveFooterContent(model types.Model) string {
+	theme := model.Theme.OrDefault()
 	searchStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#000000")).
-		Background(lipgloss.Color("#FFFFFF")).
+		Foreground(Color(theme.InputText)).
+		Background(Color(theme.InputBackground)).
+		Reverse(theme.Monochrome).
 		Padding(0, 1)
 	cursor := "_"
 
@@ -69,7 +71,21 @@ func getSearchActiveFooterContent(model types.Model) string {
 	} else {
 		searchDisplay = model.SearchQuery
 	}
-	return fmt.Sprintf("Search: %s%s", searchStyle.Render(searchDisplay), modeIndicator)
+	content := fmt.Sprintf("Search: %s%s", searchStyle.Render(searchDisplay), modeIndicator)
+	return content + getSearchQueryHint(model)
+}
+
+// getSearchQueryHint returns completions for the filter being typed, or the query's parse error
+func getSearchQueryHint(model types.Model) string {
+	if model.SearchInputActive {
+		if completions := services.SearchCompletions(model.SearchQuery, model.MCPItems); len(completions) > 0 {
+			return " " + ThemeStyles(model.Theme).Dim.Render("Hints: "+strings.Join(completions, " "))
+		}
+	}
+	if _, err := services.ParseSearchQuery(model.SearchQuery); err != nil {
+		return " " + ThemeStyles(model.Theme).Error.Render("⚠ "+err.Error())
+	}
+	return ""
 }
 
 // getSearchResultsFooterContent returns footer content for search results
@@ -109,62 +125,42 @@ func getProjectContextFooterContent(model types.Model) string {
 		}
 	}
 
-	refreshHint := services.GetRefreshKeyHint(model.ClaudeStatus)
+	refreshHint := services.GetRefreshKeyHint(model.ClaudeStatus, model.Keys)
 	return fmt.Sprintf("%s • %s", contextInfo, refreshHint)
 }
 
 // renderToggleStatus renders the current toggle operation status with visual indicators
 func renderToggleStatus(model types.Model) string {
+	styles := ThemeStyles(model.Theme)
 	switch model.ToggleState {
 	case types.ToggleIdle:
 		return ""
 	case types.ToggleLoading:
-		loadingStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFD700")).
-			Bold(true)
+		loadingStyle := styles.Warning.Bold(true)
 		return fmt.Sprintf("%s MCP '%s'... ⏳",
 			loadingStyle.Render("Toggling"), model.ToggleMCPName)
 
 	case types.ToggleRetrying:
-		retryStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF8C00")).
-			Bold(true)
+		retryStyle := styles.Attention.Bold(true)
 		return fmt.Sprintf("%s MCP '%s'... 🔄",
 			retryStyle.Render("Retrying"), model.ToggleMCPName)
 
 	case types.ToggleSuccess:
-		successStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#51CF66")).
-			Bold(true)
+		successStyle := styles.Success.Bold(true)
 		return fmt.Sprintf("%s MCP '%s' ✓",
 			successStyle.Render("Success:"), model.ToggleMCPName)
 
 	case types.ToggleError:
-		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF6B6B")).
-			Bold(true)
+		errorStyle := styles.Error.Bold(true)
 		return fmt.Sprintf("%s %s ✗",
 			errorStyle.Render("Error:"), model.ToggleError)
 	}
 	return ""
 }
 
-// GetFilteredMCPs returns MCPs filtered by search query
+// GetFilteredMCPs returns the MCPs matching the search query, best match first
 func GetFilteredMCPs(model types.Model) []types.MCPItem {
-	// If no search query, return all MCPs
-	if model.SearchQuery == "" {
-		return model.MCPItems
-	}
-
-	// Filter MCPs by search query directly
-	var filtered []types.MCPItem
-	query := strings.ToLower(model.SearchQuery)
-	for _, item := range model.MCPItems {
-		if strings.Contains(strings.ToLower(item.Name), query) {
-			filtered = append(filtered, item)
-		}
-	}
-	return filtered
+	return services.GetFilteredMCPs(model)
 }
 
 // formatDuration formats a duration for display in the footer
@@ -282,6 +282,46 @@ func TestRenderFooter_SearchResultsCount(t *testing.T) {
 	}
 }
 
+func TestRenderFooter_SearchQueryHints(t *testing.T) {
+	items := []types.MCPItem{{Name: "github", Environment: map[string]string{"GITHUB_TOKEN": "x"}}}
+	tests := []struct {
+		name        string
+		query       string
+		inputActive bool
+		contains    string
+		notContains string
+	}{
+		{name: "field name completion", query: "ty", inputActive: true, contains: "Hints: type:"},
+		{name: "value completion", query: "-type:s", inputActive: true, contains: "-type:sse"},
+		{name: "env key completion", query: "env:GIT", inputActive: true, contains: "env:GITHUB_TOKEN"},
+		{name: "parse error", query: "type:grpc", inputActive: true, contains: `⚠ type: unknown type "grpc"`},
+		{name: "error in navigation mode", query: "active:maybe", inputActive: false, contains: "⚠ active:"},
+		{name: "no hints in navigation mode", query: "ty", inputActive: false, notContains: "Hints:"},
+		{name: "plain text", query: "github", inputActive: true, notContains: "⚠"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := testutil.NewTestModel().
+				WithWindowSize(200, 40).
+				WithState(types.SearchActiveNavigation).
+				WithSearchQuery(tt.query).
+				WithSearchActive(true).
+				WithSearchInputActive(tt.inputActive).
+				WithMCPs(items).
+				Build()
+
+			result := RenderFooter(model)
+			if tt.contains != "" && !strings.Contains(result, tt.contains) {
+				t.Errorf("RenderFooter() should contain %q\nActual: %s", tt.contains, result)
+			}
+			if tt.notContains != "" && strings.Contains(result, tt.notContains) {
+				t.Errorf("RenderFooter() should not contain %q\nActual: %s", tt.notContains, result)
+			}
+		})
+	}
+}
+
 func TestRenderFooter_ResponsiveProjectContext(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -18,44 +18,82 @@ func RenderFourColumnGrid(model types.Model) string {
 		return renderNoResultsMessage(model)
 	}
 
-	gridLines := buildGridLines(model, filteredMCPs)
+	// Only the rows that fit are rendered, however large the inventory
+	viewport := services.GetViewport(model)
+	gridLines := buildGridLines(model, filteredMCPs, viewport.Visible(), viewport.Height)
+	if viewport.Scrolls() {
+		gridLines = append(gridLines, RenderScrollIndicator(model, viewport))
+	}
 	gridStyle := createGridStyle(model)
 
-	return gridStyle.Render(fmt.Sprintf("MCP Inventory\n\n%s", strings.Join(gridLines, "\n")))
+	return gridStyle.Render(fmt.Sprintf("MCP Inventory%s\n\n%s", getGridViewSummary(model), strings.Join(gridLines, "\n")))
+}
+
+// getGridViewSummary describes a sort or group mode other than the default, e.g.
+// " • sorted by name • grouped by type"
+func getGridViewSummary(model types.Model) string {
+	var summary string
+	if model.SearchQuery == "" && model.Grid.Sort != "" && model.Grid.Sort != types.SortInventory {
+		summary += " • sorted by " + services.SortModeLabel(model.Grid.Sort)
+	}
+	if model.Grid.Group != "" && model.Grid.Group != types.GroupNone {
+		summary += " • grouped by " + services.GroupModeLabel(model.Grid.Group)
+	}
+	return summary
 }
 
 // renderNoResultsMessage creates the no results display
 func renderNoResultsMessage(model types.Model) string {
 	noResultsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
+		Foreground(Color(model.Theme.OrDefault().Muted)).
 		Align(lipgloss.Center).
 		Width(model.Width).
 		Height(model.Height - 8)
 	return noResultsStyle.Render("No MCPs found matching your search")
 }
 
-// buildGridLines creates the grid content lines
-func buildGridLines(model types.Model, filteredMCPs []types.MCPItem) []string {
-	// Calculate grid dimensions - aim for ~10 rows with 4 columns
-	gridRows := (len(filteredMCPs) + 3) / 4 // Round up division
-	if gridRows < 10 {
-		gridRows = 10 // Minimum 10 rows for consistent layout
-	}
-
+// buildGridLines creates the grid content lines, one per group header or row of items,
+// padded to 10 lines when that many fit in height
+func buildGridLines(model types.Model, filteredMCPs []types.MCPItem, rows []services.GridRow, height int) []string {
 	var gridLines []string
-	for row := 0; row < gridRows; row++ {
+	for _, row := range rows {
+		if row.IsHeader() {
+			gridLines = append(gridLines, ThemeStyles(model.Theme).GroupHeader.Render(row.Header))
+			continue
+		}
 		line := buildGridRow(model, filteredMCPs, row)
 		gridLines = append(gridLines, strings.Join(line, ""))
 	}
+
+	// Minimum 10 rows for consistent layout
+	for len(gridLines) < min(10, height) {
+		gridLines = append(gridLines, strings.Repeat(" ", types.ColumnWidth*types.WideColumns))
+	}
 	return gridLines
 }
 
+// RenderScrollIndicator tells how many MCPs are hidden above and below the viewport
+func RenderScrollIndicator(model types.Model, viewport services.Viewport) string {
+	var parts []string
+	if above := viewport.ItemsAbove(); above > 0 {
+		parts = append(parts, fmt.Sprintf("↑ %d more", above))
+	}
+	if below := viewport.ItemsBelow(); below > 0 {
+		parts = append(parts, fmt.Sprintf("↓ %d more", below))
+	}
+	if len(parts) == 0 {
+		// Only group headers are hidden
+		parts = append(parts, fmt.Sprintf("%d MCPs", viewport.ItemCount()))
+	}
+	return ThemeStyles(model.Theme).Dim.Render(strings.Join(parts, " • "))
+}
+
 // buildGridRow creates a single row of the grid
-func buildGridRow(model types.Model, filteredMCPs []types.MCPItem, row int) []string {
+func buildGridRow(model types.Model, filteredMCPs []types.MCPItem, row services.GridRow) []string {
 	var line []string
-	for col := 0; col < 4; col++ {
-		mcpIndex := row*4 + col
-		if mcpIndex < len(filteredMCPs) {
+	for col := 0; col < types.WideColumns; col++ {
+		mcpIndex := row.Start + col
+		if mcpIndex < row.End {
 			cellContent := renderGridCell(model, filteredMCPs[mcpIndex], mcpIndex)
 			line = append(line, cellContent)
 		} else {
@@ -75,27 +113,92 @@ func renderGridCell(model types.Model, item types.MCPItem, mcpIndex int) string
 	isSelected := isItemSelected(model, mcpIndex)
 
 	// Create base item text (without styling)
-	baseText := fmt.Sprintf("%s %s", status, item.Name)
+	prefix := status + " "
+	suffix := ""
+	if badge := services.GetHealthBadge(model, item); badge != "" {
+		suffix += " " + badge
+	}
+	costText := getContextCostIndicator(model, item)
 
-	// Calculate padding needed BEFORE styling
-	currentWidth := lipgloss.Width(baseText)
-	paddingNeeded := types.ColumnWidth - currentWidth
+	// Calculate padding needed BEFORE styling, keeping the cost right-aligned
+	baseWidth := lipgloss.Width(prefix + item.Name + suffix)
+	paddingNeeded := types.ColumnWidth - baseWidth - lipgloss.Width(costText)
+	if paddingNeeded < 1 && costText != "" {
+		// Not enough room for the cost, drop it rather than break alignment
+		costText = ""
+		paddingNeeded = types.ColumnWidth - baseWidth
+	}
 	if paddingNeeded < 0 {
 		paddingNeeded = 0
 	}
+	suffix += strings.Repeat(" ", paddingNeeded) + costText
 
-	// Apply padding first
-	paddedText := baseText + strings.Repeat(" ", paddingNeeded)
+	// Apply styling based on selection, highlighting the characters the search matched
+	base, match := itemStyles(model, isSelected)
+	return renderItemName(model, item, prefix, suffix, base, match)
+}
 
-	// Apply styling based on selection
-	if isSelected {
-		return lipgloss.NewStyle().
-			Background(lipgloss.Color("#7C3AED")).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Bold(true).
-			Render(paddedText)
+// itemStyles returns the style of an MCP in the grid or list, and of the name characters
+// the search matched
+func itemStyles(model types.Model, selected bool) (lipgloss.Style, lipgloss.Style) {
+	styles := ThemeStyles(model.Theme)
+	if selected {
+		return styles.Selected, styles.Selected.Underline(true)
 	}
-	return paddedText
+	return lipgloss.NewStyle(), styles.Match
+}
+
+// renderItemName renders prefix, the item's name and suffix in base, with the name
+// characters matched by the search query in match
+func renderItemName(model types.Model, item types.MCPItem, prefix, suffix string, base, match lipgloss.Style) string {
+	positions := services.NameMatchPositions(model.SearchQuery, item.Name)
+	if len(positions) == 0 {
+		return base.Render(prefix + item.Name + suffix)
+	}
+	return base.Render(prefix) + highlightMatches(item.Name, positions, base, match) + base.Render(suffix)
+}
+
+// highlightMatches renders text in base with the runes at the sorted positions in match
+func highlightMatches(text string, positions []int, base, match lipgloss.Style) string {
+	var result strings.Builder
+	var run []rune
+	runMatched, next := false, 0
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runMatched {
+			result.WriteString(match.Render(string(run)))
+		} else {
+			result.WriteString(base.Render(string(run)))
+		}
+		run = run[:0]
+	}
+	for i, r := range []rune(text) {
+		matched := next < len(positions) && positions[next] == i
+		if matched {
+			next++
+		}
+		if matched != runMatched {
+			flush()
+			runMatched = matched
+		}
+		run = append(run, r)
+	}
+	flush()
+	return result.String()
+}
+
+// getContextCostIndicator returns the estimated token cost suffix for a grid cell
+func getContextCostIndicator(model types.Model, item types.MCPItem) string {
+	if model.IntrospectingMCPs[item.Name] {
+		return "…  "
+	}
+	result, ok := services.GetIntrospection(model, item)
+	if !ok {
+		return ""
+	}
+	return "~" + services.FormatTokenCount(services.FilteredTokenEstimate(item, result)) + "  "
 }
 
 // isItemSelected determines if an item is currently selected
@@ -110,7 +213,7 @@ func isItemSelected(model types.Model, mcpIndex int) bool {
 func createGridStyle(model types.Model) lipgloss.Style {
 	return lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#7C3AED")).
+		BorderForeground(Color(model.Theme.OrDefault().Accent)).
 		Padding(1).
 		Width(model.Width).
 		Height(model.Height - 8)
@@ -158,23 +261,37 @@ func RenderMCPList(model types.Model) string {
 		return fmt.Sprintf("No MCPs matching filter (total: %d, filtered: %d)", len(model.MCPItems), len(filteredMCPs))
 	}
 
+	// Only the lines that fit are rendered, however large the inventory
+	viewport := services.GetViewport(model)
 	var items []string
-	for i, item := range filteredMCPs {
-		style := lipgloss.NewStyle().Padding(0, 1)
-
-		// Highlight selected item
-		if i == model.SelectedItem {
-			style = style.Background(lipgloss.Color("#7C3AED")).
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Bold(true)
+	for _, row := range viewport.Visible() {
+		if row.IsHeader() {
+			items = append(items, ThemeStyles(model.Theme).GroupHeader.Render(" "+row.Header))
+			continue
 		}
-
-		// Enhanced status indicator with toggle state
-		status := getEnhancedStatusIndicator(model, item)
-
-		itemText := fmt.Sprintf("%s %s", status, item.Name)
-		items = append(items, style.Render(itemText))
+		items = append(items, renderListItem(model, filteredMCPs[row.Start], row.Start == model.SelectedItem))
+	}
+	if viewport.Scrolls() {
+		items = append(items, RenderScrollIndicator(model, viewport))
 	}
 
 	return strings.Join(items, "\n")
 }
+
+// renderListItem renders one line of the MCP list
+func renderListItem(model types.Model, item types.MCPItem, selected bool) string {
+	base, match := itemStyles(model, selected)
+
+	// Enhanced status indicator with toggle state
+	status := getEnhancedStatusIndicator(model, item)
+
+	suffix := ""
+	if badge := services.GetHealthBadge(model, item); badge != "" {
+		suffix += " " + badge
+	}
+	if costText := strings.TrimSpace(getContextCostIndicator(model, item)); costText != "" {
+		suffix += " " + costText
+	}
+	// One space of padding either side, as the list has always had
+	return renderItemName(model, item, " "+status+" ", suffix+" ", base, match)
+}
@@ -1,11 +1,14 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	"mcp-hub/internal/testutil"
 	"mcp-hub/internal/ui/types"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestRenderFourColumnGrid(t *testing.T) {
@@ -425,3 +428,166 @@ func TestRenderFourColumnGrid_MinimumGridRows(t *testing.T) {
 		t.Errorf("RenderFourColumnGrid() should contain header")
 	}
 }
+
+func TestRenderGridCell_HealthBadge(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{{Name: "github", Active: true}, {Name: "docker"}}).
+		Build()
+	model.Health = map[string]types.HealthResult{"github": {MCPName: "github", Status: types.HealthHealthy}}
+
+	if cell := renderGridCell(model, model.MCPItems[0], 0); !strings.Contains(cell, "● github ✔") {
+		t.Errorf("Expected health badge after the name, got %q", cell)
+	}
+	if cell := renderGridCell(model, model.MCPItems[1], 1); strings.Contains(cell, "✔") {
+		t.Errorf("Unprobed MCP should have no badge, got %q", cell)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	match := lipgloss.NewStyle().Transform(strings.ToUpper)
+
+	tests := []struct {
+		text      string
+		positions []int
+		want      string
+	}{
+		{"github", nil, "github"},
+		{"github", []int{0, 1, 2}, "GIThub"},
+		{"github-mcp", []int{0, 7}, "Github-Mcp"},
+		{"github", []int{5}, "githuB"},
+	}
+
+	for _, tt := range tests {
+		if got := highlightMatches(tt.text, tt.positions, lipgloss.NewStyle(), match); got != tt.want {
+			t.Errorf("highlightMatches(%q, %v) = %q, want %q", tt.text, tt.positions, got, tt.want)
+		}
+	}
+}
+
+func TestRenderGridCell_SearchKeepsWidth(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{{Name: "github-mcp", Active: true}}).
+		WithSearchQuery("gm").
+		Build()
+
+	cell := renderGridCell(model, model.MCPItems[0], 0)
+	if !strings.Contains(cell, "● github-mcp") {
+		t.Errorf("Expected the highlighted name to read as plain text, got %q", cell)
+	}
+	if width := lipgloss.Width(cell); width != types.ColumnWidth {
+		t.Errorf("Highlighted cell width = %d, want %d", width, types.ColumnWidth)
+	}
+}
+
+func TestRenderFourColumnGrid_OrdersBySearchScore(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(160, 40).
+		WithMCPs([]types.MCPItem{{Name: "legit-tools"}, {Name: "github"}}).
+		WithSearchQuery("git").
+		Build()
+
+	result := RenderFourColumnGrid(model)
+	if strings.Index(result, "github") > strings.Index(result, "legit-tools") {
+		t.Errorf("Expected the prefix match github before legit-tools:\n%s", result)
+	}
+}
+
+func TestRenderFourColumnGrid_Grouped(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(160, 40).
+		WithMCPs([]types.MCPItem{
+			{Name: "zeta", Type: "SSE", Active: true},
+			{Name: "alpha", Type: "CMD"},
+			{Name: "mid", Type: "CMD", Active: true},
+		}).
+		Build()
+	model.Grid = types.GridViewState{Sort: types.SortName, Group: types.GroupType}
+
+	result := RenderFourColumnGrid(model)
+	if !strings.Contains(result, "MCP Inventory • sorted by name • grouped by type") {
+		t.Errorf("Expected the sort and group modes in the title:\n%s", result)
+	}
+	order := []string{"CMD (2)", "alpha", "mid", "SSE (1)", "zeta"}
+	last := -1
+	for _, text := range order {
+		index := strings.Index(result, text)
+		if index <= last {
+			t.Fatalf("Expected %v in order, %q is out of place:\n%s", order, text, result)
+		}
+		last = index
+	}
+}
+
+func TestRenderFourColumnGrid_DefaultViewHasPlainTitle(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(160, 40).Build()
+	if result := RenderFourColumnGrid(model); strings.Contains(result, "sorted by") || strings.Contains(result, "grouped by") {
+		t.Errorf("The default view should not describe its modes:\n%s", result)
+	}
+}
+
+func TestRenderMCPList_Grouped(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(60, 40).
+		WithMCPs([]types.MCPItem{
+			{Name: "zeta", Active: true},
+			{Name: "alpha"},
+		}).
+		Build()
+	model.Grid.Group = types.GroupStatus
+
+	lines := strings.Split(RenderMCPList(model), "\n")
+	want := []string{"Active (1)", "zeta", "Inactive (1)", "alpha"}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d lines, got %q", len(want), lines)
+	}
+	for i, text := range want {
+		if !strings.Contains(lines[i], text) {
+			t.Errorf("Line %d = %q, want it to contain %q", i, lines[i], text)
+		}
+	}
+}
+
+// manyMCPs returns count inactive MCPs named mcp-000 onwards
+func manyMCPs(count int) []types.MCPItem {
+	items := make([]types.MCPItem, count)
+	for i := range items {
+		items[i] = types.MCPItem{Name: fmt.Sprintf("mcp-%03d", i)}
+	}
+	return items
+}
+
+func TestRenderFourColumnGrid_RendersOnlyVisibleRows(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(140, 40).WithMCPs(manyMCPs(400)).WithSelectedItem(200).Build()
+
+	result := RenderFourColumnGrid(model)
+	if height := lipgloss.Height(result); height != model.Height-6 {
+		t.Errorf("The grid should fit the screen, got %d lines", height)
+	}
+	// The selected row is at the bottom of the 27 rows shown
+	if !strings.Contains(result, "mcp-200") || !strings.Contains(result, "mcp-096") || strings.Contains(result, "mcp-095") || strings.Contains(result, "mcp-204") {
+		t.Errorf("Only the rows up to the selection should be rendered:\n%s", result)
+	}
+	if !strings.Contains(result, "↑ 96 more • ↓ 196 more") {
+		t.Errorf("The scroll indicator should count the hidden MCPs:\n%s", result)
+	}
+
+	model.MCPItems = manyMCPs(8)
+	if result := RenderFourColumnGrid(model); strings.Contains(result, "more") {
+		t.Errorf("A grid that fits should not show the scroll indicator:\n%s", result)
+	}
+}
+
+func TestRenderMCPList_RendersOnlyVisibleLines(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(90, 40).WithMCPs(manyMCPs(100)).WithSelectedItem(50).Build()
+
+	lines := strings.Split(RenderMCPList(model), "\n")
+	// 28 lines: 27 MCPs ending with the selection, and the scroll indicator
+	if len(lines) != 28 || !strings.Contains(lines[0], "mcp-024") || !strings.Contains(lines[26], "mcp-050") {
+		t.Fatalf("The list should end at the selection, got %q", lines)
+	}
+	if !strings.Contains(lines[27], "↑ 24 more • ↓ 49 more") {
+		t.Errorf("The last line should be the scroll indicator, got %q", lines[27])
+	}
+}
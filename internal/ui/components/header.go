@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"strings"
 
 	"mcp-hub/internal/ui/services"
 	"mcp-hub/internal/ui/types"
@@ -9,36 +10,17 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Header string constants
-const (
-	EnterConfirmEscCancel = "Enter=Confirm • ESC=Cancel"
-)
-
 // RenderHeader creates the application header with shortcuts and context
 func RenderHeader(model types.Model) string {
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#7C3AED")).
-		Background(lipgloss.Color("#1E1E2E")).
+		Foreground(Color(model.Theme.OrDefault().Accent)).
+		Background(Color(model.Theme.OrDefault().HeaderBackground)).
 		Padding(0, 2).
 		Width(model.Width)
 
-	// Build shortcuts display based on current state
-	var shortcuts string
-	switch model.State {
-	case types.MainNavigation:
-		shortcuts = "A=Add • D=Delete • E=Edit • /=Search • Tab=Focus Search • R=Refresh Claude • ESC=Exit • ↑↓←→=Navigate"
-	case types.SearchMode:
-		shortcuts = "Type to search • Enter=Apply • ESC=Cancel"
-	case types.SearchActiveNavigation:
-		if model.SearchInputActive {
-			shortcuts = "Type to search • Tab=Navigate Mode • ↑↓←→=Navigate • Space=Toggle • R=Refresh • Enter=Apply • ESC=Cancel"
-		} else {
-			shortcuts = "Navigate Mode • Tab=Input Mode • ↑↓←→=Navigate • Space=Toggle • R=Refresh • Enter=Apply • ESC=Cancel"
-		}
-	case types.ModalActive:
-		shortcuts = EnterConfirmEscCancel
-	}
+	// Build shortcuts display from the key bindings of the current state
+	shortcuts := getHeaderShortcuts(model)
 
 	// Context information
 	activeCount := 0
@@ -54,6 +36,21 @@ func RenderHeader(model types.Model) string {
 	contextInfo := fmt.Sprintf("MCPs: %d/%d Active • Layout: %s • %s",
 		activeCount, len(model.MCPItems), GetLayoutName(model), claudeStatusText)
 
+	// Estimated context cost of the active set
+	if budgetInfo := services.FormatContextBudgetForDisplay(model); budgetInfo != "" {
+		contextInfo += " • " + budgetInfo
+	}
+
+	// Git and .mcp.json project information
+	if projectInfo := services.FormatProjectInfoForDisplay(model.ProjectContext.Project); projectInfo != "" {
+		contextInfo += " • " + projectInfo
+	}
+
+	// Named workspaces are shown so edits are not made to the wrong inventory
+	if model.Workspace != "" && model.Workspace != services.DefaultWorkspace {
+		contextInfo = fmt.Sprintf("Workspace: %s • %s", model.Workspace, contextInfo)
+	}
+
 	title := "MCP Manager v1.0"
 
 	// Create header content with proper spacing
@@ -62,6 +59,52 @@ func RenderHeader(model types.Model) string {
 	return headerStyle.Render(headerContent)
 }
 
+// getHeaderShortcuts returns the key hints of the current state, generated from the
+// key bindings so they always match them
+func getHeaderShortcuts(model types.Model) string {
+	keys := model.Keys
+	back := func(label string) string { return services.KeyHint(keys, types.ActionBack, label) }
+
+	var hints []string
+	switch model.State {
+	case types.MainNavigation:
+		hints = []string{
+			services.KeyHint(keys, types.ActionAdd, "Add"),
+			services.KeyHint(keys, types.ActionDelete, "Delete"),
+			services.KeyHint(keys, types.ActionEdit, "Edit"),
+			services.KeyHint(keys, types.ActionSearch, "Search"),
+			services.KeyHint(keys, types.ActionRefresh, "Refresh Claude"),
+			services.KeyHint(keys, types.ActionHelp, "Help"),
+			back("Exit"),
+			services.NavigateKeyHint(keys),
+		}
+	case types.SearchMode:
+		hints = []string{"Type to search", "Enter=Apply", back("Cancel")}
+	case types.SearchActiveNavigation:
+		if model.SearchInputActive {
+			hints = []string{"Type to search", services.KeyHint(keys, types.ActionSearchMode, "Navigate Mode")}
+		} else {
+			hints = []string{"Navigate Mode", services.KeyHint(keys, types.ActionSearchMode, "Input Mode")}
+		}
+		hints = append(hints,
+			services.NavigateKeyHint(keys),
+			services.KeyHint(keys, types.ActionToggle, "Toggle"),
+			services.KeyHint(keys, types.ActionRefresh, "Refresh"),
+			services.KeyHint(keys, types.ActionSearchApply, "Apply"))
+		if !model.SearchInputActive {
+			hints = append(hints, services.KeyHint(keys, types.ActionHelp, "Help"))
+		}
+		hints = append(hints, back("Cancel"))
+	case types.ModalActive:
+		if model.ActiveModal == types.HelpModal {
+			hints = []string{services.KeyHint(keys, types.ActionHelp, "Close Help"), back("Close")}
+		} else {
+			hints = []string{"Enter=Confirm", back("Cancel")}
+		}
+	}
+	return strings.Join(hints, " • ")
+}
+
 // GetLayoutName returns the current layout name for display
 func GetLayoutName(model types.Model) string {
 	switch model.ColumnCount {
@@ -19,7 +19,7 @@ func TestRenderHeader(t *testing.T) {
 		{
 			name:              "MainNavigation state shows main shortcuts",
 			state:             types.MainNavigation,
-			expectedShortcuts: "A=Add • D=Delete • E=Edit • /=Search • Tab=Focus Search • R=Refresh Claude • ESC=Exit • ↑↓←→=Navigate",
+			expectedShortcuts: "A=Add • D=Delete • E=Edit • /=Search • R=Refresh Claude • ?=Help • ESC=Exit • ↑↓←→=Navigate",
 		},
 		{
 			name:              "SearchMode state shows search shortcuts",
@@ -38,7 +38,7 @@ func TestRenderHeader(t *testing.T) {
 			state:             types.SearchActiveNavigation,
 			searchActive:      true,
 			searchInputActive: false,
-			expectedShortcuts: "Navigate Mode • Tab=Input Mode • ↑↓←→=Navigate • Space=Toggle • R=Refresh • Enter=Apply • ESC=Cancel",
+			expectedShortcuts: "Navigate Mode • Tab=Input Mode • ↑↓←→=Navigate • Space=Toggle • R=Refresh • Enter=Apply • ?=Help • ESC=Cancel",
 		},
 		{
 			name:              "ModalActive state shows modal shortcuts",
@@ -71,6 +71,26 @@ func TestRenderHeader(t *testing.T) {
 	}
 }
 
+func TestRenderHeader_FollowsKeyMap(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(160, 40).Build()
+	model.Keys = types.KeyMap{
+		types.ActionAdd:  {"ctrl+a"},
+		types.ActionHelp: {"f1"},
+		types.ActionUp:   {"w"}, types.ActionDown: {"x"}, types.ActionLeft: {"z"}, types.ActionRight: {"c"},
+	}
+
+	result := RenderHeader(model)
+	if expected := "Ctrl+A=Add • D=Delete • E=Edit • /=Search • R=Refresh Claude • F1=Help • ESC=Exit • WXZC=Navigate"; !strings.Contains(result, expected) {
+		t.Errorf("RenderHeader() should show the bound keys\nExpected to contain: %s\nActual result: %s", expected, result)
+	}
+
+	model.State = types.ModalActive
+	model.ActiveModal = types.HelpModal
+	if result := RenderHeader(model); !strings.Contains(result, "F1=Close Help • ESC=Close") {
+		t.Errorf("RenderHeader() should show how to close the help overlay, got %s", result)
+	}
+}
+
 func TestRenderHeader_ContextInfo(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -263,3 +283,17 @@ func TestRenderHeader_StateTransitions(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderHeader_NamedWorkspace(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(200, 40).WithState(types.MainNavigation).Build()
+
+	model.Workspace = "default"
+	if strings.Contains(RenderHeader(model), "Workspace:") {
+		t.Error("The default workspace should not be shown")
+	}
+
+	model.Workspace = "client-a"
+	if result := RenderHeader(model); !strings.Contains(result, "Workspace: client-a • MCPs:") {
+		t.Errorf("Named workspace should lead the context line, got:\n%s", result)
+	}
+}
@@ -0,0 +1,55 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpKeyWidth is the width of the keys column of the help overlay
+const helpKeyWidth = 12
+
+// getHelpTitleAndFooter returns the help overlay title and key hints
+func getHelpTitleAndFooter(model types.Model) (string, string) {
+	return "Key Bindings", fmt.Sprintf("[%s] Close • %s Close", services.FormatKeys(model.Keys, types.ActionHelp), services.FormatKeys(model.Keys, types.ActionBack))
+}
+
+// renderHelpContent lists every action and its keys in two columns of sections
+func renderHelpContent(model types.Model) string {
+	styles := ThemeStyles(model.Theme)
+	sections := services.GetHelpSections(model.Keys)
+
+	// Split the sections where about half of the lines are on each side
+	total := 0
+	for _, section := range sections {
+		total += len(section.Entries) + 2
+	}
+	var left, right []string
+	for _, section := range sections {
+		lines := []string{styles.Selected.Render(" " + section.Title + " ")}
+		for _, entry := range section.Entries {
+			keys := styles.Key.Render(fmt.Sprintf("%-*s", helpKeyWidth, entry.Keys))
+			lines = append(lines, keys+" "+entry.Label)
+		}
+		lines = append(lines, "")
+		if len(left) < total/2 {
+			left = append(left, lines...)
+		} else {
+			right = append(right, lines...)
+		}
+	}
+
+	columns := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(50).Render(strings.Join(left, "\n")),
+		strings.Join(right, "\n"))
+
+	footer := styles.Dim.Render("Change keys under \"keys\" in " + services.GetSettingsPath(model.PlatformService))
+	if model.KeysError != "" {
+		footer = styles.Error.Render(model.KeysError + "; using the default keys")
+	}
+	return columns + "\n" + footer
+}
@@ -0,0 +1,42 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestRenderHelpContent(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.ActiveModal = types.HelpModal
+	model.Keys = types.KeyMap{types.ActionSort: {"o", "ctrl+o"}}
+
+	content := renderHelpContent(model)
+	for _, fragment := range []string{
+		"General", "Navigation", "View",
+		"↑/k", "Move up",
+		"o/Ctrl+O", "Change the sort order",
+		"I", "Introspect all MCPs",
+		`Change keys under "keys" in`,
+	} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+
+	title, footer := getHelpTitleAndFooter(model)
+	if title != "Key Bindings" || !strings.Contains(footer, "[?] Close") {
+		t.Errorf("Unexpected title %q and footer %q", title, footer)
+	}
+}
+
+func TestRenderHelpContent_ShowsKeyMapError(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.KeysError = "keys: e is bound to both add and edit"
+
+	if content := renderHelpContent(model); !strings.Contains(content, "keys: e is bound to both add and edit; using the default keys") {
+		t.Errorf("Expected the key map error in:\n%s", content)
+	}
+}
@@ -21,10 +21,10 @@ func RenderLoadingOverlay(model types.Model, width, height int, baseContent stri
 	dialogWidth := 32 // Even smaller for minimal footprint
 	dialogStyle := lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("#4B5563")). // More subtle gray
-		Background(lipgloss.Color("#111827")).       // Darker, less prominent
-		Foreground(lipgloss.Color("#D1D5DB")).       // Softer white
-		Padding(1).                                  // Minimal padding
+		BorderForeground(Color(model.Theme.OrDefault().OverlayBorder)). // More subtle than the modals
+		Background(Color(model.Theme.OrDefault().OverlayBackground)).
+		Foreground(Color(model.Theme.OrDefault().OverlayText)).
+		Padding(1). // Minimal padding
 		Width(dialogWidth).
 		Align(lipgloss.Center)
 
@@ -0,0 +1,92 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// logLineWidth caps the width of a log line in the log pane
+const logLineWidth = 92
+
+// getLogsTitleAndFooter returns the log pane title and key hints
+func getLogsTitleAndFooter(model types.Model) (string, string) {
+	return fmt.Sprintf("Server Logs - %s", model.LogViewer.MCPName),
+		"[↑↓/PgUp/PgDn] Scroll • [g/G] Oldest/Newest • [f] Filter • [c] Clear • ESC Close"
+}
+
+// renderLogsContent renders the visible window of a server's captured output
+func renderLogsContent(model types.Model) string {
+	styles := ThemeStyles(model.Theme)
+	viewer := model.LogViewer
+	all := model.ServerLogs.Entries(viewer.MCPName)
+	entries := services.FilterServerLogs(all, viewer.MinLevel)
+
+	var lines []string
+	status := fmt.Sprintf("Level: %s and above • %d of %d entries", viewer.MinLevel, len(entries), len(all))
+	if viewer.Scroll > 0 {
+		status += " • scrolled"
+	} else {
+		status += " • following"
+	}
+	lines = append(lines, styles.Dim.Render(status), "")
+
+	if len(all) == 0 {
+		lines = append(lines, "No output captured yet.",
+			styles.Dim.Render("Output is recorded whenever mcp-hub starts this server (p, i or t)."))
+		return strings.Join(lines, "\n")
+	}
+	if len(entries) == 0 {
+		lines = append(lines, "No entries at this level. Press f to change the filter.")
+		return strings.Join(lines, "\n")
+	}
+
+	start, end := logWindow(len(entries), viewer.Scroll)
+	if start > 0 {
+		lines = append(lines, styles.Dim.Render(fmt.Sprintf("↑ %d older", start)))
+	}
+	for _, entry := range entries[start:end] {
+		lines = append(lines, renderLogLine(styles, entry))
+	}
+	if newer := len(entries) - end; newer > 0 {
+		lines = append(lines, styles.Dim.Render(fmt.Sprintf("↓ %d newer", newer)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// logWindow returns the range of entries visible in a log pane scrolled up by scroll
+// lines from the newest entry
+func logWindow(total, scroll int) (int, int) {
+	end := total - scroll
+	if end > total {
+		end = total
+	}
+	start := end - types.LogPaneLines
+	if start < 0 {
+		start = 0
+		end = min(total, types.LogPaneLines)
+	}
+	return start, end
+}
+
+// renderLogLine formats one entry, colored by level
+func renderLogLine(styles *Styles, entry types.ServerLogEntry) string {
+	line := truncatePlaygroundText(formatLogLineText(entry), logLineWidth)
+	switch {
+	case entry.Level >= types.LogError:
+		return styles.Error.Render(line)
+	case entry.Level == types.LogWarning:
+		return styles.Warning.Render(line)
+	case entry.Level == types.LogDebug:
+		return styles.Dim.Render(line)
+	default:
+		return line
+	}
+}
+
+// formatLogLineText returns the plain text of a log line with tabs expanded
+func formatLogLineText(entry types.ServerLogEntry) string {
+	return strings.ReplaceAll(services.FormatServerLogEntry(entry), "\t", "    ")
+}
@@ -0,0 +1,74 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+func newLogsModel(count int) types.Model {
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.MCPItems = []types.MCPItem{{Name: "github", Type: "CMD", Command: "gh-mcp"}}
+	model.ActiveModal = types.LogsModal
+	model.LogViewer = types.LogViewerState{MCPName: "github"}
+	for i := 0; i < count; i++ {
+		level := types.LogInfo
+		if i == 0 {
+			level = types.LogDebug
+		}
+		model.ServerLogs.Append(types.ServerLogEntry{MCPName: "github", Level: level, Source: types.LogSourceStderr, Message: fmt.Sprintf("line %d", i)})
+	}
+	return model
+}
+
+func TestRenderLogsContent_Empty(t *testing.T) {
+	content := renderLogsContent(newLogsModel(0))
+	if !strings.Contains(content, "No output captured yet.") {
+		t.Errorf("Expected empty state, got:\n%s", content)
+	}
+}
+
+func TestRenderLogsContent_FollowsNewest(t *testing.T) {
+	content := renderLogsContent(newLogsModel(types.LogPaneLines + 5))
+
+	for _, fragment := range []string{"Level: debug and above • 25 of 25 entries • following", "↑ 5 older", "[stderr] line 24"} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+	if strings.Contains(content, "line 4\n") || strings.Contains(content, "newer") {
+		t.Errorf("Following view should only show the newest page, got:\n%s", content)
+	}
+}
+
+func TestRenderLogsContent_ScrolledAndFiltered(t *testing.T) {
+	model := newLogsModel(types.LogPaneLines + 5)
+	model.LogViewer.Scroll = 5
+
+	content := renderLogsContent(model)
+	for _, fragment := range []string{"scrolled", "line 0", "↓ 5 newer"} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+
+	model.LogViewer = types.LogViewerState{MCPName: "github", MinLevel: types.LogInfo}
+	if content := renderLogsContent(model); strings.Contains(content, "line 0\n") || !strings.Contains(content, "24 of 25 entries") {
+		t.Errorf("Info filter should hide the debug entry, got:\n%s", content)
+	}
+
+	model.LogViewer.MinLevel = types.LogError
+	if content := renderLogsContent(model); !strings.Contains(content, "No entries at this level") {
+		t.Errorf("Expected filtered empty state, got:\n%s", content)
+	}
+}
+
+func TestOverlayModal_Logs(t *testing.T) {
+	result := OverlayModal(newLogsModel(3), 120, 40, "")
+	if !strings.Contains(result, "Server Logs - github") || !strings.Contains(result, "[f] Filter") {
+		t.Errorf("Log pane should render title and key hints, got:\n%s", result)
+	}
+}
@@ -16,18 +16,33 @@ const (
 	EscCancelText       = "ESC=Cancel"
 	EditInstructionText = "[Tab] Next Field • [Ctrl+V] Paste • [Enter] Update • ESC Cancel"
 	AddInstructionText  = "[Tab] Next Field • [Ctrl+V] Paste • [Enter] Add • ESC Cancel"
+	MultilineHintText   = "[Enter] New Line • [Ctrl+S] Save"
+
+	// EnvironmentFormatText explains the environment variables field
+	EnvironmentFormatText = "Format: KEY=value, one per line"
 
 	// Form field labels
 	NameRequiredLabel        = "Name: (required)"
 	EnvironmentOptionalLabel = "Environment: (optional)"
+	CommandRequiredLabel     = "Command: (required)"
+	ArgsOptionalLabel        = "Args: (optional)"
+	URLRequiredLabel         = "URL: (required)"
+	JSONRequiredLabel        = "JSON Configuration: (required)"
 )
 
+// formFieldLabels lists the labels of each form's fields in ActiveField order
+var formFieldLabels = map[types.ModalType][]string{
+	types.AddCommandForm: {NameRequiredLabel, CommandRequiredLabel, ArgsOptionalLabel, EnvironmentOptionalLabel},
+	types.AddSSEForm:     {NameRequiredLabel, URLRequiredLabel, EnvironmentOptionalLabel},
+	types.AddJSONForm:    {NameRequiredLabel, JSONRequiredLabel, EnvironmentOptionalLabel},
+}
+
 // OverlayModal renders a modal on top of existing content
 func OverlayModal(model types.Model, width, height int, _ string) string {
 	modalWidth, modalHeight := calculateModalDimensions(model.ActiveModal, width, height)
-	modalStyle := createModalStyle(modalWidth, modalHeight)
+	modalStyle := createModalStyle(model.Theme, modalWidth, modalHeight)
 	title, content, footer := getModalContent(model)
-	modalContent := buildModalContent(title, content, footer)
+	modalContent := buildModalContent(model.Theme, title, content, footer)
 	modal := modalStyle.Render(modalContent)
 
 	return centerModal(modal, width, height)
@@ -46,15 +61,36 @@ func calculateModalDimensions(activeModal types.ModalType, width, height int) (i
 	case types.AddMCPTypeSelection:
 		modalHeight = 18 // Smaller for type selection
 	case types.AddCommandForm:
-		modalHeight = 22 // Slightly larger for 3 fields
+		modalHeight = 26 // Larger for 3 fields and the environment editor
 	case types.AddSSEForm:
-		modalHeight = 20 // Standard form size
+		modalHeight = 24 // Room for the environment editor
 	case types.AddJSONForm:
-		modalHeight = 25 // Larger for JSON text area
+		modalHeight = 34 // Larger for the JSON and environment editors
 	case types.EditModal:
 		modalHeight = 15 // Smaller for edit confirmation
 	case types.DeleteModal:
 		modalHeight = 12 // Smaller for delete confirmation
+	case types.PlaygroundModal:
+		modalWidth = 90 // Wide enough for tool output
+		modalHeight = 30
+	case types.LogsModal:
+		modalWidth = 100 // Wide enough for timestamped log lines
+		modalHeight = types.LogPaneLines + 12
+	case types.ToolFilterModal:
+		modalWidth = 80
+		modalHeight = 30
+	case types.SettingsModal:
+		modalWidth = 80
+		modalHeight = 2*len(services.SettingFields) + 12
+	case types.WorkspaceModal:
+		modalWidth = 70
+		modalHeight = 24
+	case types.AppLogModal:
+		modalWidth = 110 // Wide enough for structured fields
+		modalHeight = types.LogPaneLines + 12
+	case types.HelpModal:
+		modalWidth = 100 // Two columns of actions
+		modalHeight = 30
 	}
 
 	if modalWidth > width-10 {
@@ -67,15 +103,16 @@ func calculateModalDimensions(activeModal types.ModalType, width, height int) (i
 	return modalWidth, modalHeight
 }
 
-func createModalStyle(modalWidth, modalHeight int) lipgloss.Style {
+func createModalStyle(theme types.Theme, modalWidth, modalHeight int) lipgloss.Style {
+	theme = theme.OrDefault()
 	return lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#7C3AED")).
+		BorderForeground(Color(theme.Accent)).
 		Padding(1).
 		Width(modalWidth).
 		Height(modalHeight).
-		Background(lipgloss.Color("#1E1E2E")).
-		Foreground(lipgloss.Color("#FFFFFF"))
+		Background(Color(theme.Background)).
+		Foreground(Color(theme.Text))
 }
 
 func getModalContent(model types.Model) (string, string, string) {
@@ -112,6 +149,27 @@ func getModalContent(model types.Model) (string, string, string) {
 		title = "Delete MCP"
 		content = renderDeleteModalContent(model)
 		footer = "Enter=Confirm • ESC=Cancel"
+	case types.PlaygroundModal:
+		title, footer = getPlaygroundTitleAndFooter(model)
+		content = renderPlaygroundContent(model)
+	case types.LogsModal:
+		title, footer = getLogsTitleAndFooter(model)
+		content = renderLogsContent(model)
+	case types.ToolFilterModal:
+		title, footer = getToolFilterTitleAndFooter(model)
+		content = renderToolFilterContent(model)
+	case types.SettingsModal:
+		title, footer = getSettingsTitleAndFooter()
+		content = renderSettingsContent(model)
+	case types.WorkspaceModal:
+		title, footer = getWorkspaceTitleAndFooter(model)
+		content = renderWorkspaceContent(model)
+	case types.AppLogModal:
+		title, footer = getAppLogTitleAndFooter()
+		content = renderAppLogContent(model)
+	case types.HelpModal:
+		title, footer = getHelpTitleAndFooter(model)
+		content = renderHelpContent(model)
 	default:
 		title = "Unknown Modal"
 		content = "Unknown modal type"
@@ -130,15 +188,10 @@ func getFormTitleAndFooter(formType string, editMode bool, editMCPName string) (
 	return title, AddInstructionText
 }
 
-func buildModalContent(title, content, footer string) string {
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#7C3AED")).
-		MarginBottom(1)
-
-	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
-		MarginTop(1)
+func buildModalContent(theme types.Theme, title, content, footer string) string {
+	styles := ThemeStyles(theme)
+	titleStyle := styles.Title.MarginBottom(1)
+	footerStyle := styles.Dim.MarginTop(1)
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -172,7 +225,7 @@ func renderTypeSelectionContent(model types.Model) string {
 	// Option 1 - Command/Binary
 	option1Style := lipgloss.NewStyle()
 	if selectedOption == 1 {
-		option1Style = option1Style.Background(lipgloss.Color("#7C3AED")).Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+		option1Style = ThemeStyles(model.Theme).Selected
 	}
 	lines = append(lines, option1Style.Render("1. Command/Binary (most common)"))
 	lines = append(lines, "   Execute MCP as a command or binary")
@@ -181,7 +234,7 @@ func renderTypeSelectionContent(model types.Model) string {
 	// Option 2 - SSE Server
 	option2Style := lipgloss.NewStyle()
 	if selectedOption == 2 {
-		option2Style = option2Style.Background(lipgloss.Color("#7C3AED")).Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+		option2Style = ThemeStyles(model.Theme).Selected
 	}
 	lines = append(lines, option2Style.Render("2. SSE Server (HTTP/WebSocket)"))
 	lines = append(lines, "   Connect to an SSE server endpoint")
@@ -190,7 +243,7 @@ func renderTypeSelectionContent(model types.Model) string {
 	// Option 3 - JSON Configuration
 	option3Style := lipgloss.NewStyle()
 	if selectedOption == 3 {
-		option3Style = option3Style.Background(lipgloss.Color("#7C3AED")).Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+		option3Style = ThemeStyles(model.Theme).Selected
 	}
 	lines = append(lines, option3Style.Render("3. JSON Configuration"))
 	lines = append(lines, "   Add MCP with custom JSON configuration")
@@ -207,53 +260,39 @@ func renderCommandFormContent(model types.Model) string {
 
 	// Name field
 	nameLabel := NameRequiredLabel
-	nameValue := model.FormData.Name
 	if model.FormData.ActiveField == 0 {
-		nameValue += "_"             // Show cursor
 		nameLabel = "> " + nameLabel // Show focus
 	}
 	lines = append(lines, nameLabel)
-	lines = append(lines, fmt.Sprintf("[%s]", nameValue))
+	lines = append(lines, renderTextInput(model, model.FormData.Name, model.FormData.ActiveField == 0))
 	if err, exists := model.FormErrors["name"]; exists {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render("  Error: "+err))
+		lines = append(lines, ThemeStyles(model.Theme).Error.Render("  Error: "+err))
 	}
 	lines = append(lines, "")
 
 	// Command field
-	commandLabel := "Command: (required)"
-	commandValue := model.FormData.Command
+	commandLabel := CommandRequiredLabel
 	if model.FormData.ActiveField == 1 {
-		commandValue += "_"
 		commandLabel = "> " + commandLabel
 	}
 	lines = append(lines, commandLabel)
-	lines = append(lines, fmt.Sprintf("[%s]", commandValue))
+	lines = append(lines, renderTextInput(model, model.FormData.Command, model.FormData.ActiveField == 1))
 	if err, exists := model.FormErrors["command"]; exists {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render("  Error: "+err))
+		lines = append(lines, ThemeStyles(model.Theme).Error.Render("  Error: "+err))
 	}
 	lines = append(lines, "")
 
 	// Args field
-	argsLabel := "Args: (optional)"
-	argsValue := model.FormData.Args
+	argsLabel := ArgsOptionalLabel
 	if model.FormData.ActiveField == 2 {
-		argsValue += "_"
 		argsLabel = "> " + argsLabel
 	}
 	lines = append(lines, argsLabel)
-	lines = append(lines, fmt.Sprintf("[%s]", argsValue))
+	lines = append(lines, renderTextInput(model, model.FormData.Args, model.FormData.ActiveField == 2))
 	lines = append(lines, "")
 
 	// Environment Variables field
-	envLabel := EnvironmentOptionalLabel
-	envValue := model.FormData.Environment
-	if model.FormData.ActiveField == 3 {
-		envValue += "_"
-		envLabel = "> " + envLabel
-	}
-	lines = append(lines, envLabel)
-	lines = append(lines, fmt.Sprintf("[%s]", envValue))
-	lines = append(lines, "Format: KEY1=value1,KEY2=value2")
+	lines = append(lines, renderEnvironmentField(model, model.FormData.ActiveField == 3)...)
 
 	return strings.Join(lines, "\n")
 }
@@ -264,42 +303,30 @@ func renderSSEFormContent(model types.Model) string {
 
 	// Name field
 	nameLabel := NameRequiredLabel
-	nameValue := model.FormData.Name
 	if model.FormData.ActiveField == 0 {
-		nameValue += "_"
 		nameLabel = "> " + nameLabel
 	}
 	lines = append(lines, nameLabel)
-	lines = append(lines, fmt.Sprintf("[%s]", nameValue))
+	lines = append(lines, renderTextInput(model, model.FormData.Name, model.FormData.ActiveField == 0))
 	if err, exists := model.FormErrors["name"]; exists {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render("  Error: "+err))
+		lines = append(lines, ThemeStyles(model.Theme).Error.Render("  Error: "+err))
 	}
 	lines = append(lines, "")
 
 	// URL field
-	urlLabel := "URL: (required)"
-	urlValue := model.FormData.URL
+	urlLabel := URLRequiredLabel
 	if model.FormData.ActiveField == 1 {
-		urlValue += "_"
 		urlLabel = "> " + urlLabel
 	}
 	lines = append(lines, urlLabel)
-	lines = append(lines, fmt.Sprintf("[%s]", urlValue))
+	lines = append(lines, renderTextInput(model, model.FormData.URL, model.FormData.ActiveField == 1))
 	if err, exists := model.FormErrors["url"]; exists {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render("  Error: "+err))
+		lines = append(lines, ThemeStyles(model.Theme).Error.Render("  Error: "+err))
 	}
 	lines = append(lines, "")
 
 	// Environment Variables field
-	envLabel := EnvironmentOptionalLabel
-	envValue := model.FormData.Environment
-	if model.FormData.ActiveField == 2 {
-		envValue += "_"
-		envLabel = "> " + envLabel
-	}
-	lines = append(lines, envLabel)
-	lines = append(lines, fmt.Sprintf("[%s]", envValue))
-	lines = append(lines, "Format: KEY1=value1,KEY2=value2")
+	lines = append(lines, renderEnvironmentField(model, model.FormData.ActiveField == 2)...)
 	lines = append(lines, "")
 	lines = append(lines, "Enter a valid HTTP/HTTPS URL for the SSE server.")
 
@@ -312,52 +339,54 @@ func renderJSONFormContent(model types.Model) string {
 
 	// Name field
 	nameLabel := NameRequiredLabel
-	nameValue := model.FormData.Name
 	if model.FormData.ActiveField == 0 {
-		nameValue += "_"
 		nameLabel = "> " + nameLabel
 	}
 	lines = append(lines, nameLabel)
-	lines = append(lines, fmt.Sprintf("[%s]", nameValue))
+	lines = append(lines, renderTextInput(model, model.FormData.Name, model.FormData.ActiveField == 0))
 	if err, exists := model.FormErrors["name"]; exists {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render("  Error: "+err))
+		lines = append(lines, ThemeStyles(model.Theme).Error.Render("  Error: "+err))
 	}
 	lines = append(lines, "")
 
 	// JSON Config field
-	jsonLabel := "JSON Configuration: (required)"
-	jsonValue := model.FormData.JSONConfig
+	jsonLabel := JSONRequiredLabel
 	if model.FormData.ActiveField == 1 {
-		jsonValue += "_"
 		jsonLabel = "> " + jsonLabel
 	}
 	lines = append(lines, jsonLabel)
-
-	// Show JSON in a box with multiple lines
-	jsonLines := strings.Split(jsonValue, "\n")
-	for _, line := range jsonLines {
-		lines = append(lines, fmt.Sprintf("  %s", line))
-	}
+	lines = append(lines, renderTextArea(model, model.FormData.JSONConfig, model.FormData.ActiveField == 1, jsonEditorRows)...)
 
 	if err, exists := model.FormErrors["json"]; exists {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render("  Error: "+err))
-	} else if jsonValue != "" {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#51CF66")).Render("  ✓ Valid JSON"))
+		lines = append(lines, ThemeStyles(model.Theme).Error.Render("  Error: "+err))
+	} else if model.FormData.JSONConfig != "" {
+		lines = append(lines, ThemeStyles(model.Theme).Success.Render("  ✓ Valid JSON"))
+	}
+	if model.FormData.ActiveField == 1 {
+		lines = append(lines, MultilineHintText)
 	}
 	lines = append(lines, "")
 
 	// Environment Variables field
+	lines = append(lines, renderEnvironmentField(model, model.FormData.ActiveField == 2)...)
+
+	return strings.Join(lines, "\n")
+}
+
+// renderEnvironmentField renders the label, editor and format hint of the environment
+// variables field shared by the forms
+func renderEnvironmentField(model types.Model, focused bool) []string {
 	envLabel := EnvironmentOptionalLabel
-	envValue := model.FormData.Environment
-	if model.FormData.ActiveField == 2 {
-		envValue += "_"
+	if focused {
 		envLabel = "> " + envLabel
 	}
-	lines = append(lines, envLabel)
-	lines = append(lines, fmt.Sprintf("[%s]", envValue))
-	lines = append(lines, "Format: KEY1=value1,KEY2=value2")
-
-	return strings.Join(lines, "\n")
+	lines := []string{envLabel}
+	lines = append(lines, renderTextArea(model, model.FormData.Environment, focused, envEditorRows)...)
+	lines = append(lines, EnvironmentFormatText)
+	if focused {
+		lines = append(lines, MultilineHintText)
+	}
+	return lines
 }
 
 func renderEditModalContent(model types.Model) string {
@@ -405,9 +434,7 @@ func renderDeleteModalContent(model types.Model) string {
 
 	item := filteredMCPs[selectedIndex]
 
-	warningStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FF6B6B")).
-		Bold(true)
+	warningStyle := ThemeStyles(model.Theme).Error.Bold(true)
 
 	content := []string{
 		"Are you sure you want to delete this MCP?",
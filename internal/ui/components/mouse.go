@@ -0,0 +1,192 @@
+package components
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// boxInset is the border and padding cell around the grid, the columns and modals
+	boxInset = 2
+	// gridTitleLines are the grid's title and the blank line below it
+	gridTitleLines = 2
+	// modalContentRow is the first content row of a modal, below the title and its margin
+	modalContentRow = 2
+	// typeOptionFirstLine and typeOptionLines place the options of the type selection:
+	// a title, a description and a blank line each, below the prompt
+	typeOptionFirstLine = 2
+	typeOptionLines     = 3
+	typeOptionCount     = 3
+	// footerSeparator separates the key hints of a modal footer
+	footerSeparator = " • "
+)
+
+// GridItemAt returns the position, in display order, of the MCP drawn at x, y of the
+// four-column grid as the viewport shows it, counted from the grid's top-left corner
+func GridItemAt(model types.Model, x, y int) (int, bool) {
+	row := y - boxInset - gridTitleLines
+	if row < 0 || x < boxInset {
+		return 0, false
+	}
+	col := (x - boxInset) / types.ColumnWidth
+	rows := services.GetViewport(model).Visible()
+	if col >= types.WideColumns || row >= len(rows) || rows[row].IsHeader() {
+		return 0, false
+	}
+	if index := rows[row].Start + col; index < rows[row].End {
+		return index, true
+	}
+	return 0, false
+}
+
+// ListItemAt returns the position, in display order, of the MCP on line of the list
+// as the viewport shows it, stepping over group headers
+func ListItemAt(model types.Model, line int) (int, bool) {
+	rows := services.GetViewport(model).Visible()
+	if line < 0 || line >= len(rows) || rows[line].IsHeader() {
+		return 0, false
+	}
+	return rows[line].Start, true
+}
+
+// ModalTargetAt returns the form field, option or footer button of the open modal drawn
+// at x, y of the screen
+func ModalTargetAt(model types.Model, x, y int) types.MouseTarget {
+	// Find the modal's top-left corner where OverlayModal centered it
+	top, left := -1, 0
+	for i, line := range strings.Split(OverlayModal(model, model.Width, model.Height, ""), "\n") {
+		if corner := strings.Index(line, lipgloss.RoundedBorder().TopLeft); corner >= 0 {
+			top, left = i, lipgloss.Width(line[:corner])
+			break
+		}
+	}
+	if top < 0 {
+		return types.MouseTarget{}
+	}
+
+	// Wrap the title, content and footer as the modal's width wraps them
+	modalWidth, _ := calculateModalDimensions(model.ActiveModal, model.Width, model.Height)
+	wrap := lipgloss.NewStyle().Width(modalWidth - boxInset)
+	title, content, footer := getModalContent(model)
+	content = wrap.Render(content)
+
+	row, col := y-top-boxInset-lipgloss.Height(wrap.Render(title))-1, x-left-boxInset
+	contentHeight := lipgloss.Height(content)
+	switch {
+	case row >= 0 && row < contentHeight:
+		return modalContentTargetAt(model, content, row)
+	case row > contentHeight:
+		if key, ok := footerButtonAt(wrap.Render(footer), footer, row-contentHeight-1, col); ok {
+			return types.MouseTarget{Kind: types.MouseTargetButton, Key: key}
+		}
+	}
+	return types.MouseTarget{}
+}
+
+// modalContentTargetAt returns the field or option on line of the modal's content
+func modalContentTargetAt(model types.Model, content string, line int) types.MouseTarget {
+	if model.ActiveModal == types.AddMCPTypeSelection {
+		option := line - typeOptionFirstLine
+		if option >= 0 && option/typeOptionLines < typeOptionCount && option%typeOptionLines < typeOptionLines-1 {
+			return types.MouseTarget{Kind: types.MouseTargetOption, Index: option/typeOptionLines + 1}
+		}
+		return types.MouseTarget{}
+	}
+
+	labels, ok := formFieldLabels[model.ActiveModal]
+	if !ok {
+		return types.MouseTarget{}
+	}
+	// A field is its label and the lines below it up to the next blank line
+	lines := strings.Split(content, "\n")
+	for i := line; i >= 0 && strings.TrimSpace(lines[i]) != ""; i-- {
+		label := strings.TrimPrefix(strings.TrimRight(lines[i], " "), "> ")
+		for field, fieldLabel := range labels {
+			if label == fieldLabel {
+				return types.MouseTarget{Kind: types.MouseTargetField, Index: field}
+			}
+		}
+	}
+	return types.MouseTarget{}
+}
+
+// footerButtonAt returns the key of the footer hint drawn at row and col of wrapped, the
+// footer as wrapped in the modal
+func footerButtonAt(wrapped, footer string, row, col int) (string, bool) {
+	// Number the words of the footer by the hint they belong to; separators belong to none
+	hints := strings.Split(footer, footerSeparator)
+	var owners []int
+	for i, hint := range hints {
+		if i > 0 {
+			owners = append(owners, -1)
+		}
+		for range strings.Fields(hint) {
+			owners = append(owners, i)
+		}
+	}
+
+	// Wrapping keeps the words in order, so count the words of the rows above the click
+	lines := strings.Split(wrapped, "\n")
+	if row >= len(lines) {
+		return "", false
+	}
+	word := 0
+	for _, line := range lines[:row] {
+		word += len(strings.Fields(line))
+	}
+
+	// A hint spans its words on the row and the spaces between them
+	line, end := lines[row], 0
+	first, last := map[int]int{}, map[int]int{}
+	for _, field := range strings.Fields(line) {
+		at := end + strings.Index(line[end:], field)
+		end = at + len(field)
+		if word < len(owners) && owners[word] >= 0 {
+			owner := owners[word]
+			if _, ok := first[owner]; !ok {
+				first[owner] = lipgloss.Width(line[:at])
+			}
+			last[owner] = lipgloss.Width(line[:end])
+		}
+		word++
+	}
+	for owner, start := range first {
+		if col >= start && col < last[owner] {
+			return footerButtonKey(hints[owner])
+		}
+	}
+	return "", false
+}
+
+// footerButtonKey returns the key a hint such as "[Enter] Add", "ESC Cancel" or
+// "Enter=Confirm" stands for. Hints for several keys, such as "[↑↓] Scroll" or
+// "[1-3] Select", are not buttons.
+func footerButtonKey(hint string) (string, bool) {
+	var key string
+	switch {
+	case strings.HasPrefix(hint, "["):
+		key, _, _ = strings.Cut(hint[1:], "]")
+	case strings.Contains(hint, "="):
+		key, _, _ = strings.Cut(hint, "=")
+	default:
+		key, _, _ = strings.Cut(hint, " ")
+	}
+
+	name := strings.ToLower(key)
+	switch {
+	case utf8.RuneCountInString(key) == 1:
+		return key, true
+	case name == "space":
+		return " ", true
+	case name == "enter", name == "esc", name == "tab":
+		return name, true
+	case strings.HasPrefix(name, "ctrl+"), strings.HasPrefix(name, "alt+"):
+		return name, !strings.ContainsAny(name, "/-")
+	}
+	return "", false
+}
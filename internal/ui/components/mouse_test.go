@@ -0,0 +1,110 @@
+package components
+
+import (
+	"testing"
+
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestGridItemAt(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(140, 40).WithMCPs(testutil.MockMCPItems()).Build()
+	tests := []struct {
+		x, y  int
+		index int
+		found bool
+	}{
+		{2, 4, 0, true},
+		{2 + 3*types.ColumnWidth, 4, 3, true},
+		{2 + types.ColumnWidth - 1, 5, 4, true},
+		{2 + types.ColumnWidth, 5, 0, false}, // past the last MCP
+		{2, 3, 0, false},                     // the blank line below the title
+		{0, 4, 0, false},                     // the border
+	}
+	for _, tt := range tests {
+		if index, found := GridItemAt(model, tt.x, tt.y); index != tt.index || found != tt.found {
+			t.Errorf("GridItemAt(%d, %d) = %d, %v, want %d, %v", tt.x, tt.y, index, found, tt.index, tt.found)
+		}
+	}
+
+	model.Grid.Group = types.GroupType
+	if _, found := GridItemAt(model, 2, 4); found {
+		t.Error("A group header should not be an MCP")
+	}
+	if index, found := GridItemAt(model, 2, 5); !found || index != 0 {
+		t.Errorf("The first MCP should be below its group header, got %d, %v", index, found)
+	}
+}
+
+func TestGridItemAt_Scrolled(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(140, 40).WithMCPs(manyMCPs(400)).WithSelectedItem(200).Build()
+	if index, found := GridItemAt(model, 2, 4); !found || index != 96 {
+		t.Errorf("The top row should be the first row shown, got %d, %v", index, found)
+	}
+	if _, found := GridItemAt(model, 2, 4+27); found {
+		t.Error("The scroll indicator should not be an MCP")
+	}
+}
+
+func TestListItemAt(t *testing.T) {
+	model := testutil.NewTestModel().WithMCPs(testutil.MockMCPItems()).Build()
+	if index, found := ListItemAt(model, 2); !found || index != 2 {
+		t.Errorf("Ungrouped lines should be MCPs, got %d, %v", index, found)
+	}
+	if _, found := ListItemAt(model, 5); found {
+		t.Error("Lines below the list should not be MCPs")
+	}
+
+	model.Height, model.SelectedItem, model.ScrollOffset = 14, 4, 0
+	if index, found := ListItemAt(model, 0); !found || index != 4 {
+		t.Errorf("Lines should be counted from the first line shown, got %d, %v", index, found)
+	}
+	model.Height = 0
+
+	model.Grid.Group = types.GroupType
+	groups := services.GetMCPGroups(model)
+	if _, found := ListItemAt(model, 0); found {
+		t.Error("A group header should not be an MCP")
+	}
+	second := len(groups[0].Items) + 2 // the first group, its header and the next header
+	if index, found := ListItemAt(model, second); !found || index != len(groups[0].Items) {
+		t.Errorf("The first MCP of the second group should follow its header, got %d, %v", index, found)
+	}
+}
+
+func TestFooterButtonAt(t *testing.T) {
+	footer := "[Tab] Next Field • [↑↓] Scroll • ESC Cancel"
+	tests := []struct {
+		col   int
+		key   string
+		found bool
+	}{
+		{0, "tab", true},
+		{6, "tab", true}, // the label belongs to the button
+		{17, "", false},  // the separator
+		{20, "", false},  // a hint for several keys
+		{34, "esc", true},
+	}
+	for _, tt := range tests {
+		if key, found := footerButtonAt(footer, footer, 0, tt.col); key != tt.key || found != tt.found {
+			t.Errorf("footerButtonAt(%d) = %q, %v, want %q, %v", tt.col, key, found, tt.key, tt.found)
+		}
+	}
+
+	// Wrapped, the hint continues on the next line
+	if key, found := footerButtonAt("[Tab] Next Field • ESC\nCancel", "[Tab] Next Field • ESC Cancel", 1, 2); !found || key != "esc" {
+		t.Errorf("A wrapped hint should keep its key, got %q, %v", key, found)
+	}
+}
+
+func TestFooterButtonKey(t *testing.T) {
+	for hint, want := range map[string]string{
+		"[Enter] Add": "enter", "ESC Cancel": "esc", "Enter=Confirm": "enter", "[Ctrl+R] Form/JSON": "ctrl+r",
+		"[e] Edit Arguments": "e", "[?] Close": "?", "[Space] Toggle": " ", "[1-3] Select": "", "[g/G] Oldest/Newest": "",
+	} {
+		if key, _ := footerButtonKey(hint); key != want {
+			t.Errorf("footerButtonKey(%q) = %q, want %q", hint, key, want)
+		}
+	}
+}
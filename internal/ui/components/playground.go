@@ -0,0 +1,213 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// playgroundRecentCalls is how many history entries the tool list shows
+	playgroundRecentCalls = 5
+	// playgroundResultLines caps how many result lines are shown
+	playgroundResultLines = 14
+	// playgroundTextWidth caps descriptions and argument previews
+	playgroundTextWidth = 70
+)
+
+// getPlaygroundTitleAndFooter returns the playground title and the key hints for its current step
+func getPlaygroundTitleAndFooter(model types.Model) (string, string) {
+	title := fmt.Sprintf("Tool Playground - %s", model.Playground.MCPName)
+
+	switch model.Playground.Step {
+	case types.PlaygroundEditArguments:
+		return title, "[Tab] Next Field • [Ctrl+R] Form/JSON • [Enter] Call • ESC Close"
+	case types.PlaygroundCalling:
+		return title, "ESC Close"
+	case types.PlaygroundShowResult:
+		return title, "[Enter] Call Again • [e] Edit Arguments • [b] Tools • ESC Close"
+	default:
+		return title, "[↑↓] Select Tool • [Enter] Choose • ESC Close"
+	}
+}
+
+// renderPlaygroundContent renders the body of the playground modal
+func renderPlaygroundContent(model types.Model) string {
+	item := services.FindMCPByName(model, model.Playground.MCPName)
+	if item == nil {
+		return "MCP not found"
+	}
+
+	switch model.Playground.Step {
+	case types.PlaygroundEditArguments:
+		return renderPlaygroundArguments(model, *item)
+	case types.PlaygroundCalling:
+		return fmt.Sprintf("Calling %s...", selectedPlaygroundTool(model, *item).Name)
+	case types.PlaygroundShowResult:
+		return renderPlaygroundResult(model)
+	default:
+		return renderPlaygroundToolList(model, *item)
+	}
+}
+
+// selectedPlaygroundTool returns the tool chosen in the playground
+func selectedPlaygroundTool(model types.Model, item types.MCPItem) types.ToolInfo {
+	tools := services.GetPlaygroundTools(model, item)
+	if model.Playground.ToolIndex < len(tools) {
+		return tools[model.Playground.ToolIndex]
+	}
+	return types.ToolInfo{}
+}
+
+// renderPlaygroundToolList lists the server's tools and its recent calls
+func renderPlaygroundToolList(model types.Model, item types.MCPItem) string {
+	styles := ThemeStyles(model.Theme)
+	if model.IntrospectingMCPs[item.Name] {
+		return "Fetching tools..."
+	}
+
+	tools := services.GetPlaygroundTools(model, item)
+	if _, ok := services.GetIntrospection(model, item); !ok {
+		return "Tools could not be fetched. Press ESC, check the server with p, then try again."
+	}
+	if len(tools) == 0 {
+		return "This server exposes no tools."
+	}
+
+	lines := []string{"Choose a tool:", ""}
+	for i, tool := range tools {
+		line := tool.Name
+		if i == model.Playground.ToolIndex {
+			line = styles.Selected.Render(line)
+		}
+		if tool.Description != "" {
+			line += "  " + styles.Dim.Render(truncatePlaygroundText(firstLine(tool.Description), playgroundTextWidth-len(tool.Name)))
+		}
+		lines = append(lines, line)
+	}
+
+	history := model.ToolCallHistory[item.Name]
+	if len(history) > 0 {
+		lines = append(lines, "", "Recent calls:")
+		for i := len(history) - 1; i >= 0 && i >= len(history)-playgroundRecentCalls; i-- {
+			lines = append(lines, "  "+formatToolCallSummary(styles, history[i]))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderPlaygroundArguments renders the argument form or raw JSON editor
+func renderPlaygroundArguments(model types.Model, item types.MCPItem) string {
+	styles := ThemeStyles(model.Theme)
+	playground := model.Playground
+	tool := selectedPlaygroundTool(model, item)
+
+	lines := []string{"Tool: " + tool.Name}
+	if tool.Description != "" {
+		lines = append(lines, styles.Dim.Render(truncatePlaygroundText(firstLine(tool.Description), playgroundTextWidth)))
+	}
+	lines = append(lines, "")
+
+	switch {
+	case playground.RawMode:
+		lines = append(lines, "Arguments (raw JSON):", fmt.Sprintf("[%s_]", playground.RawJSON))
+	case len(playground.Fields) == 0:
+		lines = append(lines, "This tool takes no arguments.")
+	default:
+		for i, field := range playground.Fields {
+			label := field.Name
+			if field.Required {
+				label += "*"
+			}
+			label += fmt.Sprintf(" (%s)", field.Type)
+			value := field.Value
+			if i == playground.ActiveField {
+				label = "> " + label
+				value += "_"
+			}
+			lines = append(lines, label, fmt.Sprintf("[%s]", value))
+
+			hint := firstLine(field.Description)
+			if len(field.Enum) > 0 {
+				hint = strings.TrimSpace(hint + " one of: " + strings.Join(field.Enum, "|"))
+			}
+			if hint != "" {
+				lines = append(lines, styles.Dim.Render("  "+truncatePlaygroundText(hint, playgroundTextWidth)))
+			}
+		}
+	}
+
+	if playground.Error != "" {
+		lines = append(lines, "", styles.Error.Render("Error: "+playground.Error))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderPlaygroundResult renders the last call's result, errors and timing
+func renderPlaygroundResult(model types.Model) string {
+	styles := ThemeStyles(model.Theme)
+	record := model.Playground.LastCall
+	if record == nil {
+		return "No result"
+	}
+
+	lines := []string{
+		fmt.Sprintf("Tool: %s • %s", record.Tool, formatCallDuration(record.Duration)),
+		"Arguments: " + truncatePlaygroundText(record.Arguments, playgroundTextWidth),
+		"",
+	}
+
+	switch {
+	case record.Error != "":
+		lines = append(lines, styles.Error.Render("Call failed: "+record.Error))
+		return strings.Join(lines, "\n")
+	case record.IsError:
+		lines = append(lines, styles.Error.Render("Tool reported an error:"))
+	default:
+		lines = append(lines, styles.Success.Render("Result:"))
+	}
+
+	resultLines := strings.Split(record.Result, "\n")
+	if len(resultLines) > playgroundResultLines {
+		hidden := len(resultLines) - playgroundResultLines
+		resultLines = append(resultLines[:playgroundResultLines], styles.Dim.Render(fmt.Sprintf("… %d more lines", hidden)))
+	}
+	lines = append(lines, resultLines...)
+	return strings.Join(lines, "\n")
+}
+
+// formatToolCallSummary renders one history entry
+func formatToolCallSummary(styles *Styles, record types.ToolCallRecord) string {
+	status := styles.Success.Render("✔")
+	if record.Error != "" || record.IsError {
+		status = styles.Error.Render("✘")
+	}
+	return fmt.Sprintf("%s %s %s %s", status, record.CalledAt.Format("15:04:05"), record.Tool,
+		styles.Dim.Render(formatCallDuration(record.Duration)))
+}
+
+// formatCallDuration formats a call duration with millisecond precision
+func formatCallDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return "<1ms"
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// firstLine returns the first line of a possibly multi-line text
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return line
+}
+
+// truncatePlaygroundText shortens text to width runes, marking the cut with an ellipsis
+func truncatePlaygroundText(text string, width int) string {
+	runes := []rune(text)
+	if width < 1 || len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,109 @@
+package components
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+func newPlaygroundModel() types.Model {
+	item := types.MCPItem{Name: "search", Type: "CMD", Command: "search-mcp"}
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.MCPItems = []types.MCPItem{item}
+	model.ActiveModal = types.PlaygroundModal
+	model.Playground = types.PlaygroundState{MCPName: "search"}
+	model.Introspection["search"] = types.IntrospectionResult{
+		MCPName:    "search",
+		ConfigHash: services.ComputeConfigHash(item),
+		Tools: []types.ToolInfo{
+			{Name: "query", Description: "Search the index\nMore details", InputSchema: json.RawMessage(`{}`)},
+			{Name: "reindex"},
+		},
+	}
+	return model
+}
+
+func TestRenderPlaygroundToolList(t *testing.T) {
+	model := newPlaygroundModel()
+	model.ToolCallHistory = map[string][]types.ToolCallRecord{
+		"search": {
+			{Tool: "query", Duration: 42 * time.Millisecond, CalledAt: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)},
+			{Tool: "reindex", Error: "timeout"},
+		},
+	}
+
+	content := renderPlaygroundContent(model)
+	for _, fragment := range []string{"Choose a tool:", "query", "Search the index", "reindex", "Recent calls:", "✔ 10:00:00 query", "42ms", "✘"} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+	if strings.Contains(content, "More details") {
+		t.Error("Only the first line of a description should be shown")
+	}
+
+	model.IntrospectingMCPs["search"] = true
+	if content := renderPlaygroundContent(model); content != "Fetching tools..." {
+		t.Errorf("Expected fetching state, got %q", content)
+	}
+}
+
+func TestRenderPlaygroundArguments(t *testing.T) {
+	model := newPlaygroundModel()
+	model.Playground.Step = types.PlaygroundEditArguments
+	model.Playground.Fields = []types.PlaygroundField{
+		{Name: "q", Type: "string", Required: true, Value: "mcp"},
+		{Name: "mode", Type: "string", Enum: []string{"fast", "full"}},
+	}
+	model.Playground.Error = "mode: expected one of fast, full"
+
+	content := renderPlaygroundContent(model)
+	for _, fragment := range []string{"Tool: query", "> q* (string)", "[mcp_]", "mode (string)", "one of: fast|full", "Error: mode"} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+
+	model.Playground.RawMode = true
+	model.Playground.RawJSON = `{"q":"mcp"}`
+	if content := renderPlaygroundContent(model); !strings.Contains(content, `[{"q":"mcp"}_]`) {
+		t.Errorf("Raw mode should show the JSON editor, got:\n%s", content)
+	}
+}
+
+func TestRenderPlaygroundResult(t *testing.T) {
+	model := newPlaygroundModel()
+	model.Playground.Step = types.PlaygroundShowResult
+	model.Playground.LastCall = &types.ToolCallRecord{
+		Tool:      "query",
+		Arguments: `{"q":"mcp"}`,
+		Result:    strings.Repeat("line\n", playgroundResultLines+3) + "end",
+		Duration:  1500 * time.Millisecond,
+	}
+
+	content := renderPlaygroundContent(model)
+	for _, fragment := range []string{"Tool: query • 1.5s", `Arguments: {"q":"mcp"}`, "Result:", "… 4 more lines"} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+
+	model.Playground.LastCall = &types.ToolCallRecord{Tool: "query", Error: "connection refused"}
+	if content := renderPlaygroundContent(model); !strings.Contains(content, "Call failed: connection refused") {
+		t.Errorf("Expected call failure, got:\n%s", content)
+	}
+}
+
+func TestOverlayModal_Playground(t *testing.T) {
+	model := newPlaygroundModel()
+	result := OverlayModal(model, 120, 40, "")
+
+	if !strings.Contains(result, "Tool Playground - search") || !strings.Contains(result, "[Enter] Choose") {
+		t.Errorf("Playground modal should render title and key hints, got:\n%s", result)
+	}
+}
@@ -0,0 +1,41 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// getSettingsTitleAndFooter returns the settings editor title and key hints
+func getSettingsTitleAndFooter() (string, string) {
+	return "Settings", "[↑↓/Tab] Select • [Ctrl+D] Default • [Enter] Save • ESC Cancel"
+}
+
+// renderSettingsContent renders one input per setting with its default and allowed range
+func renderSettingsContent(model types.Model) string {
+	styles := ThemeStyles(model.Theme)
+	editor := model.SettingsEditor
+	defaults := types.DefaultSettings()
+
+	lines := []string{styles.Dim.Render("Durations such as 10s, 1m or 500ms • saved to " + services.GetSettingsPath(model.PlatformService)), ""}
+	for i, field := range services.SettingFields {
+		if i >= len(editor.Inputs) {
+			break
+		}
+		label := field.Label
+		value := fmt.Sprintf("[%s]", editor.Inputs[i])
+		if i == editor.Cursor {
+			label = styles.Selected.Render("> " + label)
+			value = fmt.Sprintf("[%s_]", editor.Inputs[i])
+		}
+		lines = append(lines, label, fmt.Sprintf("%s  %s", value, styles.Dim.Render(fmt.Sprintf(
+			"default %s, %s to %s", field.Get(defaults), field.Min, field.Max))))
+	}
+
+	if editor.Error != "" {
+		lines = append(lines, "", styles.Error.Render(editor.Error))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,36 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestRenderSettingsContent(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.ActiveModal = types.SettingsModal
+	model.SettingsEditor = types.SettingsEditorState{Inputs: make([]string, len(services.SettingFields)), Cursor: 1, Error: "bad value"}
+	for i, field := range services.SettingFields {
+		model.SettingsEditor.Inputs[i] = field.Get(model.Settings).String()
+	}
+
+	content := renderSettingsContent(model)
+	for _, fragment := range []string{
+		"Claude CLI command timeout\n[10s]  default 10s, 1s to 5m0s",
+		"> Retry toggles that time out within",
+		"[8s_]",
+		"bad value",
+	} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+
+	title, footer := getSettingsTitleAndFooter()
+	if title != "Settings" || !strings.Contains(footer, "[Ctrl+D] Default") {
+		t.Errorf("Unexpected title %q and footer %q", title, footer)
+	}
+}
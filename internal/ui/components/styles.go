@@ -0,0 +1,76 @@
+package components
+
+import (
+	"sync"
+
+	"mcp-hub/internal/ui/types"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles are the lipgloss styles of a theme shared by the components
+type Styles struct {
+	Theme types.Theme
+
+	// Selected marks the selected item, option or tab
+	Selected lipgloss.Style
+	// Title is the bold accent text of titles and the header
+	Title lipgloss.Style
+	// GroupHeader marks the section headers of a grouped grid
+	GroupHeader lipgloss.Style
+	// Match marks the characters a search matched
+	Match lipgloss.Style
+	// Key marks keys in the help overlay
+	Key lipgloss.Style
+	// Cursor marks the character under the cursor of a text field
+	Cursor lipgloss.Style
+
+	Dim       lipgloss.Style
+	Success   lipgloss.Style
+	Warning   lipgloss.Style
+	Error     lipgloss.Style
+	Attention lipgloss.Style
+}
+
+// stylesCache holds the styles built for each theme, since they are used for every cell
+var stylesCache sync.Map
+
+// ThemeStyles returns the styles of theme; the zero Theme means the default theme
+func ThemeStyles(theme types.Theme) *Styles {
+	theme = theme.OrDefault()
+	if cached, ok := stylesCache.Load(theme); ok {
+		return cached.(*Styles)
+	}
+
+	styles := &Styles{
+		Theme:       theme,
+		Selected:    lipgloss.NewStyle().Background(Color(theme.Accent)).Foreground(Color(theme.AccentText)).Bold(true),
+		Title:       lipgloss.NewStyle().Foreground(Color(theme.Accent)).Bold(true),
+		GroupHeader: lipgloss.NewStyle().Foreground(Color(theme.Secondary)).Bold(true),
+		Match:       lipgloss.NewStyle().Foreground(Color(theme.Highlight)).Underline(true),
+		Key:         lipgloss.NewStyle().Foreground(Color(theme.Highlight)).Bold(true),
+		Cursor:      lipgloss.NewStyle().Reverse(true),
+		Dim:         lipgloss.NewStyle().Foreground(Color(theme.Muted)),
+		Success:     lipgloss.NewStyle().Foreground(Color(theme.Success)),
+		Warning:     lipgloss.NewStyle().Foreground(Color(theme.Warning)),
+		Error:       lipgloss.NewStyle().Foreground(Color(theme.Error)),
+		Attention:   lipgloss.NewStyle().Foreground(Color(theme.Attention)),
+	}
+	if theme.Monochrome {
+		// Without colors, selections are drawn in reverse video and muted text is faint
+		styles.Selected = styles.Selected.Reverse(true)
+		styles.Match = styles.Match.Bold(true)
+		styles.Dim = styles.Dim.Faint(true)
+		styles.Error = styles.Error.Bold(true)
+	}
+	stylesCache.Store(theme, styles)
+	return styles
+}
+
+// Color converts a theme color; an empty color leaves the terminal's own color
+func Color(color string) lipgloss.TerminalColor {
+	if color == "" {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(color)
+}
@@ -0,0 +1,53 @@
+package components
+
+import (
+	"testing"
+
+	"mcp-hub/internal/ui/types"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestThemeStyles(t *testing.T) {
+	light := types.BuiltinThemes()[types.ThemeLight]
+	styles := ThemeStyles(light)
+	if got := styles.Selected.GetBackground(); got != lipgloss.Color(light.Accent) {
+		t.Errorf("The selection should use the theme's accent, got %v", got)
+	}
+	if got := styles.Error.GetForeground(); got != lipgloss.Color(light.Error) {
+		t.Errorf("Errors should use the theme's error color, got %v", got)
+	}
+	if ThemeStyles(light) != styles {
+		t.Error("The styles of a theme should be built once")
+	}
+
+	if got := ThemeStyles(types.Theme{}).Theme; got != types.DefaultTheme() {
+		t.Errorf("The zero Theme should use the default theme, got %q", got.Name)
+	}
+}
+
+func TestThemeStyles_Monochrome(t *testing.T) {
+	styles := ThemeStyles(types.BuiltinThemes()[types.ThemeMono])
+	if !styles.Selected.GetReverse() {
+		t.Error("Without colors the selection should be drawn in reverse video")
+	}
+	if _, ok := styles.Selected.GetBackground().(lipgloss.NoColor); !ok {
+		t.Errorf("The mono theme should leave the colors to the terminal, got %v", styles.Selected.GetBackground())
+	}
+	if !styles.Match.GetUnderline() || !styles.Dim.GetFaint() {
+		t.Error("Without colors matches should be underlined and muted text faint")
+	}
+}
+
+func TestThemedComponents(t *testing.T) {
+	model := types.Model{Width: 120, Height: 40, Theme: types.BuiltinThemes()[types.ThemeHighContrast]}
+	if got := createGridStyle(model).GetBorderTopForeground(); got != lipgloss.Color("#00FFFF") {
+		t.Errorf("The grid border should use the theme's accent, got %v", got)
+	}
+	if got := createModalStyle(model.Theme, 60, 20).GetBackground(); got != lipgloss.Color("#000000") {
+		t.Errorf("Modals should use the theme's background, got %v", got)
+	}
+	if got := createGridStyle(types.Model{}).GetBorderTopForeground(); got != lipgloss.Color(types.DefaultTheme().Accent) {
+		t.Errorf("A model without a theme should use the default colors, got %v", got)
+	}
+}
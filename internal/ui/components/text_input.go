@@ -0,0 +1,119 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// jsonEditorRows and envEditorRows are how many rows of the multi-line fields are
+	// shown; longer text scrolls to keep the cursor in view
+	jsonEditorRows = 8
+	envEditorRows  = 4
+	// editorGutterWidth is the width of the line number and separator before each row
+	editorGutterWidth = 6
+)
+
+// cursorPosition returns the rune position of the cursor in the value of a form field,
+// or -1 when the field does not have the focus
+func cursorPosition(model types.Model, value string, focused bool) int {
+	if !focused {
+		return -1
+	}
+	return max(len([]rune(value))-model.FormData.CursorFromEnd, 0)
+}
+
+// cursorCells returns the characters of text with the one at cursor in reverse video,
+// or an underscore after them when the cursor is at the end. A negative cursor draws
+// none.
+func cursorCells(model types.Model, text []rune, cursor int) []string {
+	cells := make([]string, 0, len(text)+1)
+	for i, r := range text {
+		if i == cursor {
+			cells = append(cells, ThemeStyles(model.Theme).Cursor.Render(string(r)))
+		} else {
+			cells = append(cells, string(r))
+		}
+	}
+	if cursor == len(text) {
+		cells = append(cells, "_")
+	}
+	return cells
+}
+
+// renderTextInput renders the value of a single-line form field in brackets, with the
+// cursor when the field has the focus
+func renderTextInput(model types.Model, value string, focused bool) string {
+	cursor := cursorPosition(model, value, focused)
+	return "[" + strings.Join(cursorCells(model, []rune(value), cursor), "") + "]"
+}
+
+// renderTextArea renders the value of a multi-line form field with line numbers, wrapping
+// lines wider than the form. At most maxRows rows are shown, scrolled to the cursor, and
+// a status line below gives the cursor's position and the rows out of view.
+func renderTextArea(model types.Model, value string, focused bool, maxRows int) []string {
+	styles := ThemeStyles(model.Theme)
+	width := editorWidth(model)
+	cursor := cursorPosition(model, value, focused)
+
+	var rows []string
+	cursorRow, start := 0, 0
+	for number, line := range strings.Split(value, "\n") {
+		text := []rune(line)
+		lineCursor := -1
+		if cursor >= start && cursor <= start+len(text) {
+			lineCursor = cursor - start
+		}
+		cells := cursorCells(model, text, lineCursor)
+		rowWidth := width
+		if rowWidth <= 0 {
+			rowWidth = max(len(cells), 1)
+		}
+		for i := 0; i == 0 || i < len(cells); i += rowWidth {
+			gutter := strings.Repeat(" ", editorGutterWidth-2) + "│ "
+			if i == 0 {
+				gutter = fmt.Sprintf("%3d │ ", number+1)
+			}
+			if lineCursor >= i && lineCursor < i+rowWidth {
+				cursorRow = len(rows)
+			}
+			rows = append(rows, styles.Dim.Render(gutter)+strings.Join(cells[i:min(i+rowWidth, len(cells))], ""))
+		}
+		start += len(text) + 1
+	}
+
+	first := 0
+	if len(rows) > maxRows {
+		first = max(min(cursorRow-maxRows+1, len(rows)-maxRows), 0)
+	}
+	shown := rows[first:min(first+maxRows, len(rows))]
+
+	var status []string
+	if focused {
+		line, column := services.CursorLineColumn(value, cursor)
+		status = append(status, fmt.Sprintf("Ln %d, Col %d", line+1, column+1))
+	}
+	if first > 0 {
+		status = append(status, fmt.Sprintf("↑ %d more", first))
+	}
+	if below := len(rows) - first - len(shown); below > 0 {
+		status = append(status, fmt.Sprintf("↓ %d more", below))
+	}
+	if len(status) > 0 {
+		shown = append(shown, styles.Dim.Render(strings.Repeat(" ", editorGutterWidth)+strings.Join(status, " • ")))
+	}
+	return shown
+}
+
+// editorWidth returns how many characters fit on a row of a multi-line field of the open
+// form, or 0 before the window size is known
+func editorWidth(model types.Model) int {
+	if model.Width == 0 {
+		return 0
+	}
+	modalWidth, _ := calculateModalDimensions(model.ActiveModal, model.Width, model.Height)
+	return max(modalWidth-boxInset-editorGutterWidth, 1)
+}
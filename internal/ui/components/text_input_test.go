@@ -0,0 +1,83 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestRenderTextInput(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+
+	if got := renderTextInput(model, "npx", false); got != "[npx]" {
+		t.Errorf("An unfocused field should show no cursor, got %q", got)
+	}
+	if got := renderTextInput(model, "npx", true); got != "[npx_]" {
+		t.Errorf("The cursor at the end should be an underscore, got %q", got)
+	}
+
+	model.FormData.CursorFromEnd = 2
+	want := "[n" + ThemeStyles(model.Theme).Cursor.Render("p") + "x]"
+	if got := renderTextInput(model, "npx", true); got != want {
+		t.Errorf("The cursor should highlight the character under it, got %q", got)
+	}
+}
+
+func TestRenderTextArea_LineNumbers(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+
+	rows := renderTextArea(model, "A=1\nB=2", false, envEditorRows)
+	if len(rows) != 2 || !strings.HasPrefix(rows[0], "  1 │ A=1") || !strings.HasPrefix(rows[1], "  2 │ B=2") {
+		t.Errorf("Each line should be numbered, got %q", rows)
+	}
+
+	rows = renderTextArea(model, "", true, envEditorRows)
+	if len(rows) != 2 || rows[0] != "  1 │ _" || !strings.Contains(rows[1], "Ln 1, Col 1") {
+		t.Errorf("An empty focused field should show a row with the cursor and its position, got %q", rows)
+	}
+}
+
+func TestRenderTextArea_Wraps(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.Width, model.Height = 30, 40
+	model.ActiveModal = types.AddJSONForm
+	width := editorWidth(model)
+
+	rows := renderTextArea(model, strings.Repeat("x", width+3), false, jsonEditorRows)
+	if len(rows) != 2 || !strings.HasPrefix(rows[1], "    │ xxx") {
+		t.Errorf("A long line should wrap onto an unnumbered row, got %q", rows)
+	}
+}
+
+func TestRenderTextArea_ScrollsToCursor(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+	value := strings.Repeat("LINE\n", 9) + "LAST"
+
+	rows := renderTextArea(model, value, true, envEditorRows)
+	if len(rows) != envEditorRows+1 || !strings.HasPrefix(rows[envEditorRows-1], " 10 │ LAST_") {
+		t.Fatalf("The rows should scroll to the cursor on the last line, got %q", rows)
+	}
+	if status := rows[envEditorRows]; !strings.Contains(status, "Ln 10, Col 5") || !strings.Contains(status, "↑ 6 more") {
+		t.Errorf("The status should give the position and hidden rows, got %q", status)
+	}
+
+	rows = renderTextArea(model, value, false, envEditorRows)
+	if !strings.HasPrefix(rows[0], "  1 │ LINE") || !strings.Contains(rows[envEditorRows], "↓ 6 more") {
+		t.Errorf("An unfocused field should show its first rows, got %q", rows)
+	}
+}
+
+func TestRenderJSONFormContent_Editor(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.FormData.JSONConfig = "{\n  \"command\": \"npx\"\n}"
+	model.FormData.ActiveField = 1
+
+	result := renderJSONFormContent(model)
+	for _, want := range []string{"  2 │   \"command\": \"npx\"", "  3 │ }_", MultilineHintText} {
+		if !strings.Contains(result, want) {
+			t.Errorf("The JSON field should be a multi-line editor, missing %q in %q", want, result)
+		}
+	}
+}
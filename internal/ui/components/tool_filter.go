@@ -0,0 +1,77 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// getToolFilterTitleAndFooter returns the tool filter title and key hints
+func getToolFilterTitleAndFooter(model types.Model) (string, string) {
+	return fmt.Sprintf("Tool Filter - %s", model.ToolFilter.MCPName),
+		"[↑↓] Select • [Space] Toggle • [Tab] Patterns • [Enter] Save • ESC Cancel"
+}
+
+// renderToolFilterContent renders the introspected tools with the effect of the
+// patterns being edited, followed by the allow and deny inputs
+func renderToolFilterContent(model types.Model) string {
+	styles := ThemeStyles(model.Theme)
+	filter := model.ToolFilter
+	item := services.FindMCPByName(model, filter.MCPName)
+	if item == nil {
+		return "MCP not found"
+	}
+
+	preview := *item
+	preview.ToolAllow = services.ParseToolPatterns(filter.AllowInput)
+	preview.ToolDeny = services.ParseToolPatterns(filter.DenyInput)
+
+	var lines []string
+	result, ok := services.GetIntrospection(model, *item)
+	switch {
+	case model.IntrospectingMCPs[item.Name]:
+		lines = append(lines, "Fetching tools...")
+	case !ok:
+		lines = append(lines, "Tools could not be fetched. Patterns still apply once the server is reachable.")
+	case len(result.Tools) == 0:
+		lines = append(lines, "This server exposes no tools.")
+	default:
+		exposed := services.FilterToolInfos(preview, result.Tools)
+		lines = append(lines, styles.Dim.Render(fmt.Sprintf("Exposed: %d of %d tools • ~%s of ~%s tokens",
+			len(exposed), len(result.Tools),
+			services.FormatTokenCount(services.FilteredTokenEstimate(preview, result)),
+			services.FormatTokenCount(result.TokenEstimate))), "")
+
+		for i, tool := range result.Tools {
+			mark := styles.Success.Render("[✓]")
+			if !services.IsToolAllowed(preview.ToolAllow, preview.ToolDeny, tool.Name) {
+				mark = styles.Error.Render("[✗]")
+			}
+			name := tool.Name
+			if filter.Focus == types.ToolFilterFocusList && i == filter.Cursor {
+				name = styles.Selected.Render(name)
+			}
+			lines = append(lines, fmt.Sprintf("%s %s  %s", mark, name,
+				styles.Dim.Render("~"+services.FormatTokenCount(tool.TokenEstimate))))
+		}
+	}
+
+	lines = append(lines, "",
+		renderToolFilterInput("Allow (only these, comma-separated globs)", filter.AllowInput, filter.Focus == types.ToolFilterFocusAllow),
+		renderToolFilterInput("Deny (comma-separated globs)", filter.DenyInput, filter.Focus == types.ToolFilterFocusDeny))
+
+	if filter.Error != "" {
+		lines = append(lines, "", styles.Error.Render(filter.Error))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderToolFilterInput renders one pattern input with a cursor when focused
+func renderToolFilterInput(label, value string, focused bool) string {
+	if focused {
+		return fmt.Sprintf("> %s\n[%s_]", label, value)
+	}
+	return fmt.Sprintf("%s\n[%s]", label, value)
+}
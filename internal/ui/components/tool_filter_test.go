@@ -0,0 +1,68 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+func newToolFilterModel() types.Model {
+	item := types.MCPItem{Name: "fs", Type: "CMD", Command: "fs-mcp"}
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.MCPItems = []types.MCPItem{item}
+	model.ActiveModal = types.ToolFilterModal
+	model.ToolFilter = types.ToolFilterState{MCPName: "fs", DenyInput: "delete_*"}
+	model.Introspection = map[string]types.IntrospectionResult{
+		"fs": {
+			MCPName:       "fs",
+			ConfigHash:    services.ComputeConfigHash(item),
+			Tools:         []types.ToolInfo{{Name: "read_file", TokenEstimate: 200}, {Name: "delete_file", TokenEstimate: 1800}},
+			TokenEstimate: 2000,
+		},
+	}
+	return model
+}
+
+func TestRenderToolFilterContent(t *testing.T) {
+	content := renderToolFilterContent(newToolFilterModel())
+
+	for _, fragment := range []string{
+		"Exposed: 1 of 2 tools • ~200 of ~2.0k tokens",
+		"[✓] read_file",
+		"[✗] delete_file",
+		"Deny (comma-separated globs)\n[delete_*]",
+	} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+}
+
+func TestRenderToolFilterContent_InputFocusAndError(t *testing.T) {
+	model := newToolFilterModel()
+	model.ToolFilter.Focus = types.ToolFilterFocusAllow
+	model.ToolFilter.AllowInput = "read_"
+	model.ToolFilter.Error = `invalid pattern "["`
+
+	content := renderToolFilterContent(model)
+	for _, fragment := range []string{"> Allow", "[read__]", `invalid pattern "["`} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+
+	model.Introspection = map[string]types.IntrospectionResult{}
+	if content := renderToolFilterContent(model); !strings.Contains(content, "Tools could not be fetched") {
+		t.Errorf("Expected fetch failure hint, got:\n%s", content)
+	}
+}
+
+func TestOverlayModal_ToolFilter(t *testing.T) {
+	result := OverlayModal(newToolFilterModel(), 120, 40, "")
+	if !strings.Contains(result, "Tool Filter - fs") || !strings.Contains(result, "[Space] Toggle") {
+		t.Errorf("Tool filter should render title and key hints, got:\n%s", result)
+	}
+}
@@ -0,0 +1,49 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// getWorkspaceTitleAndFooter returns the workspace switcher title and key hints
+func getWorkspaceTitleAndFooter(model types.Model) (string, string) {
+	if model.WorkspaceSwitcher.Creating {
+		return "New Workspace", "[Enter] Create and switch • ESC Cancel"
+	}
+	return "Workspaces", "[↑↓/jk] Select • [Enter] Switch • [n] New • ESC Cancel"
+}
+
+// renderWorkspaceContent lists the workspaces with the current one marked, or the
+// new workspace's name while it is being typed
+func renderWorkspaceContent(model types.Model) string {
+	styles := ThemeStyles(model.Theme)
+	switcher := model.WorkspaceSwitcher
+
+	var lines []string
+	if switcher.Creating {
+		lines = append(lines,
+			styles.Dim.Render("Letters, numbers, '-' and '_' • created under "+services.ConfigBaseDir(model.PlatformService)),
+			"",
+			fmt.Sprintf("Name: [%s_]", switcher.NewName))
+	} else {
+		lines = append(lines, styles.Dim.Render("Each workspace has its own inventory and settings"), "")
+		for i, name := range switcher.Workspaces {
+			line := "  " + name
+			if name == model.Workspace {
+				line += styles.Dim.Render(" (current)")
+			}
+			if i == switcher.Cursor {
+				line = styles.Selected.Render("> "+name) + strings.TrimPrefix(line, "  "+name)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if switcher.Error != "" {
+		lines = append(lines, "", styles.Error.Render(switcher.Error))
+	}
+	return strings.Join(lines, "\n")
+}
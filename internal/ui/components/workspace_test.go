@@ -0,0 +1,34 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestRenderWorkspaceContent(t *testing.T) {
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.ActiveModal = types.WorkspaceModal
+	model.Workspace = "work"
+	model.WorkspaceSwitcher = types.WorkspaceSwitcherState{Workspaces: []string{"default", "client", "work"}, Cursor: 1, Error: "failed to list"}
+
+	content := renderWorkspaceContent(model)
+	for _, fragment := range []string{"  default", "> client", "  work (current)", "failed to list"} {
+		if !strings.Contains(content, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, content)
+		}
+	}
+	if title, footer := getWorkspaceTitleAndFooter(model); title != "Workspaces" || !strings.Contains(footer, "[n] New") {
+		t.Errorf("Unexpected title %q and footer %q", title, footer)
+	}
+
+	model.WorkspaceSwitcher = types.WorkspaceSwitcherState{Creating: true, NewName: "team"}
+	if content := renderWorkspaceContent(model); !strings.Contains(content, "Name: [team_]") {
+		t.Errorf("Expected the new name input in:\n%s", content)
+	}
+	if title, _ := getWorkspaceTitleAndFooter(model); title != "New Workspace" {
+		t.Errorf("Unexpected title %q", title)
+	}
+}
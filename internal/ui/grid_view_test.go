@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/handlers"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestModel_GridViewPersists(t *testing.T) {
+	t.Setenv(services.ConfigDirEnv, "")
+	t.Setenv(services.WorkspaceEnv, "")
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+	if err := services.SaveInventory([]types.MCPItem{{Name: "github", Type: "CMD", Command: "github-mcp"}}, mockPlatform); err != nil {
+		t.Fatal(err)
+	}
+	if err := services.SaveGridView(mockPlatform, types.GridViewState{Sort: types.SortRecent, Group: types.GroupStatus}); err != nil {
+		t.Fatal(err)
+	}
+
+	model := NewModelForPlatform(mockPlatform)
+	if model.Grid.Sort != types.SortRecent || model.Grid.Group != types.GroupStatus {
+		t.Fatalf("The saved sort and group modes should be loaded, got %+v", model.Grid)
+	}
+
+	updated, _ := model.Update(handlers.ToggleResultMsg{MCPName: "github", Activate: true, Success: true})
+	if _, ok := updated.(Model).Grid.LastUsed["github"]; !ok {
+		t.Error("Activating an MCP should record its use")
+	}
+	state, err := services.LoadGridView(mockPlatform)
+	if _, ok := state.LastUsed["github"]; err != nil || !ok {
+		t.Errorf("The use should be saved for the next session, got %+v, %v", state, err)
+	}
+}
+
+func TestModel_ReorderKeepsSelection(t *testing.T) {
+	t.Setenv(services.ConfigDirEnv, "")
+	t.Setenv(services.WorkspaceEnv, "")
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+
+	model := NewModelForPlatform(mockPlatform)
+	model.MCPItems = []types.MCPItem{{Name: "alpha"}, {Name: "beta"}, {Name: "gamma"}}
+	model.Grid.Sort = types.SortActiveFirst
+	model.SelectedItem = 2
+
+	updated, _ := model.Update(handlers.ToggleResultMsg{MCPName: "gamma", Activate: true, Success: true})
+	model = updated.(Model)
+	if selected := services.GetSelectedMCP(model.Model); selected == nil || selected.Name != "gamma" {
+		t.Fatalf("The toggled MCP should stay selected after moving to the top, got %+v", selected)
+	}
+
+	model.Grid.Sort = types.SortHealth
+	model.Model = services.SelectMCPByName(model.Model, "beta")
+	updated, _ = model.Update(handlers.HealthResultMsg{Result: types.HealthResult{MCPName: "beta", Status: types.HealthUnhealthy}})
+	model = updated.(Model)
+	if selected := services.GetSelectedMCP(model.Model); selected == nil || selected.Name != "beta" {
+		t.Errorf("The selected MCP should stay selected when a probe moves it, got %+v", selected)
+	}
+
+	model.Grid.Sort, model.Grid.Group = types.SortInventory, types.GroupStatus
+	model.Model = services.SelectMCPByName(model.Model, "alpha")
+	updated, _ = model.Update(handlers.ClaudeStatusMsg{Status: types.ClaudeStatus{Available: true, ActiveMCPs: []string{"alpha", "gamma"}}})
+	model = updated.(Model)
+	if selected := services.GetSelectedMCP(model.Model); selected == nil || selected.Name != "alpha" {
+		t.Errorf("The selected MCP should stay selected when syncing moves it, got %+v", selected)
+	}
+}
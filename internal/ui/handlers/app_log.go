@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Application log viewer key constants
+const (
+	KeyAppLogComponent = "c"
+)
+
+// handleOpenAppLog opens the application log viewer, following new entries
+func handleOpenAppLog(model types.Model) (types.Model, tea.Cmd) {
+	model.AppLog = types.AppLogViewerState{MinLevel: slog.LevelDebug}
+	model = RefreshAppLog(model)
+	model.State = types.ModalActive
+	model.ActiveModal = types.AppLogModal
+	return model, LogRefreshCmd()
+}
+
+// RefreshAppLog reloads the newest entries of the application log into the viewer
+func RefreshAppLog(model types.Model) types.Model {
+	entries, err := services.LoadAppLog(model.PlatformService)
+	model.AppLog.Entries = entries
+	model.AppLog.Error = ""
+	if err != nil {
+		model.AppLog.Error = err.Error()
+	}
+	return model
+}
+
+// handleAppLogKeys handles scrolling and the level and component filters of the
+// application log viewer
+func handleAppLogKeys(model types.Model, key string) (types.Model, tea.Cmd) {
+	viewer := &model.AppLog
+	switch key {
+	case KeyLogFilter, KeyTab:
+		viewer.MinLevel = logging.NextLevel(viewer.MinLevel)
+		viewer.Scroll = 0
+	case KeyAppLogComponent:
+		viewer.Component = services.NextAppLogComponent(viewer.Entries, viewer.Component)
+		viewer.Scroll = 0
+	default:
+		entries := logging.Filter(viewer.Entries, viewer.MinLevel, viewer.Component)
+		viewer.Scroll = scrollLogPane(viewer.Scroll, len(entries), key)
+	}
+	return model, nil
+}
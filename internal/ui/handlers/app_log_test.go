@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// newAppLogTestModel returns a model whose log file holds count entries alternating
+// between storage info and claude warnings
+func newAppLogTestModel(t *testing.T, count int) types.Model {
+	t.Helper()
+	model := newSettingsTestModel(t)
+	var lines []string
+	for i := 0; i < count; i++ {
+		level, component := "INFO", "storage"
+		if i%2 == 1 {
+			level, component = "WARN", "claude"
+		}
+		lines = append(lines, fmt.Sprintf(`{"level":%q,"msg":"line %d","component":%q}`, level, i, component))
+	}
+	path := services.GetAppLogPath(model.PlatformService)
+	if err := os.MkdirAll(model.PlatformService.GetLogPath(), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
+
+func TestOpenAppLog(t *testing.T) {
+	model, cmd := HandleMainNavigationKeys(newAppLogTestModel(t, 3), "V")
+	if model.ActiveModal != types.AppLogModal || len(model.AppLog.Entries) != 3 || model.AppLog.MinLevel != slog.LevelDebug {
+		t.Fatalf("App log should open with the file's entries, got %+v", model.AppLog)
+	}
+	if cmd == nil {
+		t.Error("Opening the app log should start the refresh ticker")
+	}
+
+	model, _ = HandleEscKey(model)
+	if model.ActiveModal != types.NoModal || model.AppLog.Entries != nil {
+		t.Error("ESC should close the app log")
+	}
+}
+
+func TestAppLogFilters(t *testing.T) {
+	model, _ := handleOpenAppLog(newAppLogTestModel(t, 4))
+
+	model, _ = HandleModalKeys(model, KeyLogFilter)
+	model, _ = HandleModalKeys(model, KeyLogFilter)
+	if model.AppLog.MinLevel != slog.LevelWarn {
+		t.Fatalf("f should cycle the level filter, got %v", model.AppLog.MinLevel)
+	}
+	if entries := logging.Filter(model.AppLog.Entries, model.AppLog.MinLevel, model.AppLog.Component); len(entries) != 2 {
+		t.Errorf("Only the warnings should be shown, got %+v", entries)
+	}
+
+	model, _ = HandleModalKeys(model, KeyAppLogComponent)
+	if model.AppLog.Component != "claude" {
+		t.Errorf("c should select the first component, got %q", model.AppLog.Component)
+	}
+	model, _ = HandleModalKeys(model, KeyAppLogComponent)
+	model, _ = HandleModalKeys(model, KeyAppLogComponent)
+	if model.AppLog.Component != "" {
+		t.Errorf("c should cycle back to all components, got %q", model.AppLog.Component)
+	}
+}
+
+func TestAppLogScrolling(t *testing.T) {
+	model, _ := handleOpenAppLog(newAppLogTestModel(t, types.LogPaneLines+5))
+
+	model, _ = HandleModalKeys(model, KeyHome)
+	if model.AppLog.Scroll != 5 {
+		t.Errorf("Home should scroll to the oldest page, got %d", model.AppLog.Scroll)
+	}
+	model, _ = HandleModalKeys(model, KeyUp)
+	if model.AppLog.Scroll != 5 {
+		t.Errorf("Scrolling should stop at the oldest page, got %d", model.AppLog.Scroll)
+	}
+	model, _ = HandleModalKeys(model, KeyEnd)
+	if model.AppLog.Scroll != 0 {
+		t.Errorf("End should follow new entries, got %d", model.AppLog.Scroll)
+	}
+}
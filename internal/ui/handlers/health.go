@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HealthResultMsg carries the result of probing one MCP server
+type HealthResultMsg struct {
+	Result types.HealthResult
+}
+
+// ProbeMCPCmd creates a command that spawns the server and checks its health,
+// recording its output in logs
+func ProbeMCPCmd(item types.MCPItem, logs *types.ServerLogStore) tea.Cmd {
+	return func() tea.Msg {
+		result := services.ProbeMCP(context.Background(), item, logs)
+		return HealthResultMsg{Result: result}
+	}
+}
+
+// handleProbeSelected probes the currently selected MCP
+func handleProbeSelected(model types.Model) (types.Model, tea.Cmd) {
+	selectedMCP := services.GetSelectedMCP(model)
+	if selectedMCP == nil {
+		return model, nil
+	}
+	return startHealthProbe(model, []types.MCPItem{*selectedMCP})
+}
+
+// handleProbeAll probes every MCP in the inventory
+func handleProbeAll(model types.Model) (types.Model, tea.Cmd) {
+	return startHealthProbe(model, model.MCPItems)
+}
+
+// startHealthProbe marks the items as being probed and batches one command per item
+func startHealthProbe(model types.Model, items []types.MCPItem) (types.Model, tea.Cmd) {
+	if model.ProbingMCPs == nil {
+		model.ProbingMCPs = make(map[string]bool)
+	}
+
+	var cmds []tea.Cmd
+	var probed []string
+	for _, item := range items {
+		if model.ProbingMCPs[item.Name] {
+			continue
+		}
+		model.ProbingMCPs[item.Name] = true
+		cmds = append(cmds, ProbeMCPCmd(item, model.ServerLogs))
+		probed = append(probed, item.Name)
+	}
+
+	if len(cmds) == 0 {
+		return model, nil
+	}
+
+	if len(probed) == 1 {
+		model.SuccessMessage = fmt.Sprintf("Checking health of %s...", probed[0])
+	} else {
+		model.SuccessMessage = fmt.Sprintf("Checking health of %d MCPs...", len(probed))
+	}
+	model.SuccessTimer = model.Settings.SuccessTicks()
+	cmds = append(cmds, TimerCmd("success_timer"))
+
+	return model, tea.Batch(cmds...)
+}
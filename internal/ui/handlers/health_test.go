@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"testing"
+
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestStartHealthProbe(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{
+			{Name: "gh", Type: "CMD", Command: "gh-mcp"},
+			{Name: "fs", Type: "CMD", Command: "fs-mcp"},
+		}).
+		Build()
+
+	model, cmd := handleProbeAll(model)
+	if cmd == nil {
+		t.Fatal("Expected probe commands")
+	}
+	if !model.ProbingMCPs["gh"] || !model.ProbingMCPs["fs"] {
+		t.Errorf("Both MCPs should be marked as probing, got %v", model.ProbingMCPs)
+	}
+	if model.SuccessMessage != "Checking health of 2 MCPs..." {
+		t.Errorf("Unexpected message: %q", model.SuccessMessage)
+	}
+
+	// Items already being probed are not started again
+	model.SuccessMessage = ""
+	model, cmd = handleProbeSelected(model)
+	if cmd != nil || model.SuccessMessage != "" {
+		t.Error("Probe already in progress should not be restarted")
+	}
+}
+
+func TestProbeKeysInMainNavigation(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{{Name: "gh", Type: "CMD", Command: "gh-mcp"}}).
+		Build()
+
+	updated, cmd := HandleMainNavigationKeys(model, "p")
+	if cmd == nil || !updated.ProbingMCPs["gh"] {
+		t.Error("'p' should probe the selected MCP")
+	}
+	if updated.SuccessMessage != "Checking health of gh..." {
+		t.Errorf("Unexpected message: %q", updated.SuccessMessage)
+	}
+
+	empty := testutil.NewTestModel().WithWindowSize(120, 40).Build()
+	if _, cmd := HandleMainNavigationKeys(empty, "p"); cmd != nil {
+		t.Error("'p' without a selection should do nothing")
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleOpenHelp shows the key bindings
+func handleOpenHelp(model types.Model) types.Model {
+	model.State = types.ModalActive
+	model.ActiveModal = types.HelpModal
+	return model
+}
+
+// handleHelpKeys closes the help overlay with the help key or Enter; ESC is handled globally
+func handleHelpKeys(model types.Model, key string) (types.Model, tea.Cmd) {
+	if model.Keys.Matches(types.ActionHelp, key) || key == KeyEnter {
+		return closeHelp(model), nil
+	}
+	return model, nil
+}
+
+// closeHelp returns to the search bar if help was opened from it, otherwise to main navigation
+func closeHelp(model types.Model) types.Model {
+	model.State = types.MainNavigation
+	if model.SearchActive {
+		model.State = types.SearchActiveNavigation
+	}
+	model.ActiveModal = types.NoModal
+	return model
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"testing"
+
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHelpOverlay_OpenAndClose(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(120, 40).Build()
+
+	model, _ = HandleMainNavigationKeys(model, "?")
+	if model.State != types.ModalActive || model.ActiveModal != types.HelpModal {
+		t.Fatalf("? should open the help overlay, got state %v modal %v", model.State, model.ActiveModal)
+	}
+
+	model, _ = HandleModalKeys(model, "a")
+	if model.ActiveModal != types.HelpModal {
+		t.Error("Other keys should leave the help overlay open")
+	}
+	model, _ = HandleModalKeys(model, "?")
+	if model.State != types.MainNavigation || model.ActiveModal != types.NoModal {
+		t.Errorf("? should close the help overlay, got state %v modal %v", model.State, model.ActiveModal)
+	}
+}
+
+func TestHelpOverlay_FromSearchReturnsToSearch(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithState(types.SearchActiveNavigation).
+		WithSearchActive(true).
+		WithSearchInputActive(true).
+		WithSearchQuery("git").
+		Build()
+
+	if model, _ = HandleSearchNavigationKeys(model, "?"); model.ActiveModal == types.HelpModal || model.SearchQuery != "git?" {
+		t.Fatalf("? should be typed while the search input is active, got query %q", model.SearchQuery)
+	}
+
+	model.SearchQuery = "git"
+	model.SearchInputActive = false
+	model, _ = HandleSearchNavigationKeys(model, "?")
+	if model.ActiveModal != types.HelpModal {
+		t.Fatal("? should open the help overlay in search navigation mode")
+	}
+
+	model, _ = HandleEscKey(model)
+	if model.State != types.SearchActiveNavigation || model.SearchQuery != "git" || model.ActiveModal != types.NoModal {
+		t.Errorf("ESC should return to the search, got state %v query %q", model.State, model.SearchQuery)
+	}
+}
+
+func TestKeyMap_RebindsMainNavigation(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(120, 40).WithMCPs(testutil.MockMCPItems()).Build()
+	model.ColumnCount = 4
+	model.Keys = types.KeyMap{types.ActionAdd: {"n"}, types.ActionRight: {"ctrl+n"}}
+
+	if updated, _ := HandleMainNavigationKeys(model, "a"); updated.State != types.MainNavigation {
+		t.Error("The default add key should do nothing once add is rebound")
+	}
+	if updated, _ := HandleMainNavigationKeys(model, "n"); updated.ActiveModal != types.AddMCPTypeSelection {
+		t.Error("The rebound add key should open the add modal")
+	}
+	if updated, _ := HandleMainNavigationKeys(model, "ctrl+n"); updated.SelectedItem != 1 {
+		t.Errorf("The rebound right key should move the selection, got %d", updated.SelectedItem)
+	}
+	if updated, _ := HandleMainNavigationKeys(model, "l"); updated.SelectedItem != 0 {
+		t.Error("The default right key should do nothing once right is rebound")
+	}
+}
+
+func TestKeyMap_RebindsSearchAndGlobalKeys(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithState(types.SearchActiveNavigation).
+		WithSearchActive(true).
+		WithSearchInputActive(true).
+		Build()
+	model.Keys = types.KeyMap{types.ActionSearchMode: {"ctrl+t"}, types.ActionQuit: {"ctrl+q"}}
+
+	if updated, _ := HandleSearchNavigationKeys(model, "tab"); !updated.SearchInputActive {
+		t.Error("Tab should do nothing once the search mode key is rebound")
+	}
+	if updated, _ := HandleSearchNavigationKeys(model, "ctrl+t"); updated.SearchInputActive {
+		t.Error("The rebound search mode key should switch to navigation mode")
+	}
+
+	if _, cmd := HandleKeyPress(model, tea.KeyMsg{Type: tea.KeyCtrlC}); cmd != nil {
+		t.Error("Ctrl+C should not quit once quit is rebound")
+	}
+	if _, cmd := HandleKeyPress(model, tea.KeyMsg{Type: tea.KeyCtrlQ}); cmd == nil {
+		t.Error("The rebound quit key should quit")
+	}
+}
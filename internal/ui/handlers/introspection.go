@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// IntrospectionResultMsg carries the result of introspecting one MCP server
+type IntrospectionResultMsg struct {
+	Result types.IntrospectionResult
+}
+
+// IntrospectMCPCmd creates a command that starts the server and lists its tools,
+// recording its output in logs
+func IntrospectMCPCmd(item types.MCPItem, logs *types.ServerLogStore) tea.Cmd {
+	return func() tea.Msg {
+		result := services.IntrospectMCP(context.Background(), item, logs)
+		return IntrospectionResultMsg{Result: result}
+	}
+}
+
+// handleIntrospectSelected introspects the currently selected MCP
+func handleIntrospectSelected(model types.Model) (types.Model, tea.Cmd) {
+	selectedMCP := services.GetSelectedMCP(model)
+	if selectedMCP == nil {
+		return model, nil
+	}
+	return startIntrospection(model, []types.MCPItem{*selectedMCP})
+}
+
+// handleIntrospectAll introspects every MCP in the inventory
+func handleIntrospectAll(model types.Model) (types.Model, tea.Cmd) {
+	return startIntrospection(model, model.MCPItems)
+}
+
+// startIntrospection marks the items as in progress and batches one command per item
+func startIntrospection(model types.Model, items []types.MCPItem) (types.Model, tea.Cmd) {
+	if model.IntrospectingMCPs == nil {
+		model.IntrospectingMCPs = make(map[string]bool)
+	}
+
+	var cmds []tea.Cmd
+	for _, item := range items {
+		if model.IntrospectingMCPs[item.Name] {
+			continue
+		}
+		model.IntrospectingMCPs[item.Name] = true
+		cmds = append(cmds, IntrospectMCPCmd(item, model.ServerLogs))
+	}
+
+	if len(cmds) == 0 {
+		return model, nil
+	}
+
+	if len(cmds) == 1 {
+		model.SuccessMessage = fmt.Sprintf("Introspecting %s...", items[0].Name)
+	} else {
+		model.SuccessMessage = fmt.Sprintf("Introspecting %d MCPs...", len(cmds))
+	}
+	model.SuccessTimer = model.Settings.SuccessTicks()
+	cmds = append(cmds, TimerCmd("success_timer"))
+
+	return model, tea.Batch(cmds...)
+}
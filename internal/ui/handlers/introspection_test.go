@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"testing"
+
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestStartIntrospection(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{
+			{Name: "gh", Type: "CMD", Command: "gh-mcp"},
+			{Name: "fs", Type: "CMD", Command: "fs-mcp"},
+		}).
+		Build()
+
+	model, cmd := handleIntrospectAll(model)
+	if cmd == nil {
+		t.Fatal("Expected introspection commands")
+	}
+	if !model.IntrospectingMCPs["gh"] || !model.IntrospectingMCPs["fs"] {
+		t.Errorf("Both MCPs should be marked as introspecting, got %v", model.IntrospectingMCPs)
+	}
+	if model.SuccessMessage != "Introspecting 2 MCPs..." {
+		t.Errorf("Unexpected message: %q", model.SuccessMessage)
+	}
+
+	// Items already in progress are not started again
+	model.SuccessMessage = ""
+	model, cmd = handleIntrospectSelected(model)
+	if cmd != nil || model.SuccessMessage != "" {
+		t.Error("Introspection already in progress should not be restarted")
+	}
+}
+
+func TestIntrospectKeysInMainNavigation(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{{Name: "gh", Type: "CMD", Command: "gh-mcp"}}).
+		Build()
+
+	updated, cmd := HandleMainNavigationKeys(model, "i")
+	if cmd == nil || !updated.IntrospectingMCPs["gh"] {
+		t.Error("'i' should introspect the selected MCP")
+	}
+
+	empty := testutil.NewTestModel().WithWindowSize(120, 40).Build()
+	if _, cmd := HandleMainNavigationKeys(empty, "i"); cmd != nil {
+		t.Error("'i' without a selection should do nothing")
+	}
+}
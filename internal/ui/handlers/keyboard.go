@@ -13,6 +13,7 @@ const (
 	KeyEnter      = "enter"
 	KeyCtrlC      = "ctrl+c"
 	KeyCtrlV      = "ctrl+v"
+	KeyCtrlS      = "ctrl+s"
 	KeyDown       = "down"
 	KeyUp         = "up"
 	KeyBackspace  = "backspace"
@@ -36,12 +37,12 @@ func HandleKeyPress(model types.Model, msg tea.KeyMsg) (types.Model, tea.Cmd) {
 	}
 
 	// Global keys that work in any state
-	switch key {
-	case KeyEsc:
+	switch {
+	case model.Keys.Matches(types.ActionBack, key):
 		return HandleEscKey(model)
-	case KeyCtrlC:
+	case model.Keys.Matches(types.ActionQuit, key):
 		return model, tea.Quit
-	case "ctrl+l":
+	case model.Keys.Matches(types.ActionRedraw, key):
 		// Clear screen and redraw - just return nil cmd as the screen will auto-refresh
 		return model, nil
 	}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"time"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Log pane key constants
+const (
+	KeyLogFilter   = "f"
+	KeyClearLogs   = "c"
+	KeyPageUp      = "pgup"
+	KeyPageDown    = "pgdown"
+	KeyHome        = "home"
+	KeyEnd         = "end"
+	logRefreshRate = 500 * time.Millisecond
+)
+
+// LogRefreshMsg redraws the log pane so new server output shows up live
+type LogRefreshMsg struct{}
+
+// LogRefreshCmd schedules the next redraw of the log pane
+func LogRefreshCmd() tea.Cmd {
+	return tea.Tick(logRefreshRate, func(time.Time) tea.Msg {
+		return LogRefreshMsg{}
+	})
+}
+
+// handleOpenLogs opens the log pane for the selected MCP, following new output
+func handleOpenLogs(model types.Model) (types.Model, tea.Cmd) {
+	selectedMCP := services.GetSelectedMCP(model)
+	if selectedMCP == nil {
+		return model, nil
+	}
+
+	model.LogViewer = types.LogViewerState{MCPName: selectedMCP.Name, MinLevel: types.LogDebug}
+	model.State = types.ModalActive
+	model.ActiveModal = types.LogsModal
+	return model, LogRefreshCmd()
+}
+
+// handleLogsKeys handles scrolling, filtering and clearing in the log pane.
+// Scroll counts lines up from the newest entry, so 0 keeps following new output.
+func handleLogsKeys(model types.Model, key string) (types.Model, tea.Cmd) {
+	viewer := &model.LogViewer
+	entries := services.FilterServerLogs(model.ServerLogs.Entries(viewer.MCPName), viewer.MinLevel)
+
+	switch key {
+	case KeyLogFilter, KeyTab:
+		viewer.MinLevel = services.NextLogLevelFilter(viewer.MinLevel)
+		viewer.Scroll = 0
+	case KeyClearLogs:
+		model.ServerLogs.Clear(viewer.MCPName)
+		viewer.Scroll = 0
+	default:
+		viewer.Scroll = scrollLogPane(viewer.Scroll, len(entries), key)
+	}
+	return model, nil
+}
+
+// scrollLogPane applies a scroll key to a log pane showing the given number of entries
+// and keeps the result between following (0) and the oldest page
+func scrollLogPane(scroll, entries int, key string) int {
+	maxScroll := entries - types.LogPaneLines
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+
+	switch key {
+	case KeyUp, "k":
+		scroll++
+	case KeyDown, "j":
+		scroll--
+	case KeyPageUp:
+		scroll += types.LogPaneLines
+	case KeyPageDown:
+		scroll -= types.LogPaneLines
+	case KeyHome, "g":
+		scroll = maxScroll
+	case KeyEnd, "G":
+		scroll = 0
+	}
+	return max(0, min(scroll, maxScroll))
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"fmt"
+	"testing"
+
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/types"
+)
+
+// newLogsTestModel returns a model whose only MCP has captured the given number of entries,
+// alternating between info and error
+func newLogsTestModel(count int) types.Model {
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{{Name: "github", Type: "CMD", Command: "gh-mcp"}}).
+		Build()
+	for i := 0; i < count; i++ {
+		level := types.LogInfo
+		if i%2 == 1 {
+			level = types.LogError
+		}
+		model.ServerLogs.Append(types.ServerLogEntry{MCPName: "github", Level: level, Message: fmt.Sprintf("line %d", i)})
+	}
+	return model
+}
+
+func TestOpenLogs(t *testing.T) {
+	model, cmd := HandleMainNavigationKeys(newLogsTestModel(0), "L")
+	if model.State != types.ModalActive || model.ActiveModal != types.LogsModal || model.LogViewer.MCPName != "github" {
+		t.Errorf("Log pane should open for the selected MCP, got state=%v modal=%v", model.State, model.ActiveModal)
+	}
+	if cmd == nil {
+		t.Error("Opening the log pane should start the refresh ticker")
+	}
+
+	model, _ = HandleEscKey(model)
+	if model.ActiveModal != types.NoModal || model.LogViewer.MCPName != "" {
+		t.Error("ESC should close the log pane")
+	}
+}
+
+func TestLogsScrolling(t *testing.T) {
+	model, _ := handleOpenLogs(newLogsTestModel(types.LogPaneLines + 10))
+	maxScroll := 10
+
+	model, _ = HandleModalKeys(model, KeyDown)
+	if model.LogViewer.Scroll != 0 {
+		t.Error("Scrolling down while following should stay at the newest entry")
+	}
+
+	model, _ = HandleModalKeys(model, KeyUp)
+	model, _ = HandleModalKeys(model, "k")
+	if model.LogViewer.Scroll != 2 {
+		t.Errorf("Expected scroll 2, got %d", model.LogViewer.Scroll)
+	}
+
+	model, _ = HandleModalKeys(model, KeyPageUp)
+	if model.LogViewer.Scroll != maxScroll {
+		t.Errorf("Page up should stop at the oldest page, got %d", model.LogViewer.Scroll)
+	}
+
+	model, _ = HandleModalKeys(model, "G")
+	if model.LogViewer.Scroll != 0 {
+		t.Error("G should jump back to following new output")
+	}
+
+	model, _ = HandleModalKeys(model, "g")
+	if model.LogViewer.Scroll != maxScroll {
+		t.Errorf("g should jump to the oldest entries, got %d", model.LogViewer.Scroll)
+	}
+}
+
+func TestLogsFilterAndClear(t *testing.T) {
+	model, _ := handleOpenLogs(newLogsTestModel(types.LogPaneLines + 10))
+	model.LogViewer.Scroll = 5
+
+	model, _ = HandleModalKeys(model, KeyLogFilter)
+	if model.LogViewer.MinLevel != types.LogInfo || model.LogViewer.Scroll != 0 {
+		t.Errorf("Filter should advance to info and follow, got %+v", model.LogViewer)
+	}
+
+	model, _ = HandleModalKeys(model, KeyLogFilter)
+	model, _ = HandleModalKeys(model, KeyLogFilter)
+	if model.LogViewer.MinLevel != types.LogError {
+		t.Errorf("Expected error filter, got %v", model.LogViewer.MinLevel)
+	}
+
+	// Only the 15 error entries remain, fewer than a page, so there is nothing to scroll
+	model, _ = HandleModalKeys(model, KeyUp)
+	if model.LogViewer.Scroll != 0 {
+		t.Errorf("Filtered entries fit on one page, scroll should stay 0, got %d", model.LogViewer.Scroll)
+	}
+
+	model, _ = HandleModalKeys(model, KeyClearLogs)
+	if len(model.ServerLogs.Entries("github")) != 0 {
+		t.Error("c should clear the server's captured output")
+	}
+}
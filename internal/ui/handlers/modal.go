@@ -67,6 +67,20 @@ func HandleModalKeys(model types.Model, key string) (types.Model, tea.Cmd) {
 		return model, nil
 	case types.DeleteModal:
 		return handleDeleteModalKeys(model, key)
+	case types.PlaygroundModal:
+		return handlePlaygroundKeys(model, key)
+	case types.LogsModal:
+		return handleLogsKeys(model, key)
+	case types.ToolFilterModal:
+		return handleToolFilterKeys(model, key)
+	case types.SettingsModal:
+		return handleSettingsKeys(model, key)
+	case types.WorkspaceModal:
+		return handleWorkspaceKeys(model, key)
+	case types.AppLogModal:
+		return handleAppLogKeys(model, key)
+	case types.HelpModal:
+		return handleHelpKeys(model, key)
 	default:
 		// Legacy modal handling
 		if key == KeyEnter {
@@ -136,9 +150,13 @@ func handleCommandFormKeys(model types.Model, key string) (types.Model, tea.Cmd)
 	switch key {
 	case KeyTab:
 		// Move to next field
-		model.FormData.ActiveField = (model.FormData.ActiveField + 1) % 4 // 4 fields: Name, Command, Args, Environment
-	case KeyEnter:
-		// Submit form if valid
+		model = focusFormField(model, (model.FormData.ActiveField+1)%4) // 4 fields: Name, Command, Args, Environment
+	case KeyEnter, KeyCtrlS:
+		// Submit form if valid (or newline in the environment field)
+		if key == KeyEnter && isMultilineField(model) {
+			model, _ = editActiveField(model, key)
+			break
+		}
 		var valid bool
 		model, valid = validateCommandForm(model)
 		if !valid {
@@ -178,9 +196,6 @@ func handleCommandFormKeys(model types.Model, key string) (types.Model, tea.Cmd)
 			model.EditMCPName = ""
 			return model, cmd
 		}
-	case KeyBackspaceKey:
-		// Delete character from active field
-		model = deleteCharFromActiveField(model)
 	case KeyCtrlC, KeyCmdCopy, KeyCmdSymbolC, types.KeyCommandC:
 		// Copy active field content to clipboard
 		model = copyActiveFieldToClipboard(model)
@@ -188,10 +203,8 @@ func handleCommandFormKeys(model types.Model, key string) (types.Model, tea.Cmd)
 		// Paste clipboard content to active field
 		model = pasteFromClipboardToActiveField(model)
 	default:
-		// Add character to active field
-		if len(key) == 1 {
-			model = addCharToActiveField(model, key)
-		}
+		// Type, delete or move the cursor in the active field
+		model, _ = editActiveField(model, key)
 	}
 
 	// Validate form after each change
@@ -205,9 +218,13 @@ func handleSSEFormKeys(model types.Model, key string) (types.Model, tea.Cmd) {
 	switch key {
 	case KeyTab:
 		// Move to next field
-		model.FormData.ActiveField = (model.FormData.ActiveField + 1) % 3 // 3 fields: Name, URL, Environment
-	case KeyEnter:
-		// Submit form if valid
+		model = focusFormField(model, (model.FormData.ActiveField+1)%3) // 3 fields: Name, URL, Environment
+	case KeyEnter, KeyCtrlS:
+		// Submit form if valid (or newline in the environment field)
+		if key == KeyEnter && isMultilineField(model) {
+			model, _ = editActiveField(model, key)
+			break
+		}
 		var valid bool
 		model, valid = validateSSEForm(model)
 		if !valid {
@@ -243,9 +260,6 @@ func handleSSEFormKeys(model types.Model, key string) (types.Model, tea.Cmd) {
 			model.EditMCPName = ""
 			return model, cmd
 		}
-	case KeyBackspaceKey:
-		// Delete character from active field
-		model = deleteCharFromActiveField(model)
 	case KeyCtrlC, KeyCmdCopy, KeyCmdSymbolC, types.KeyCommandC:
 		// Copy active field content to clipboard
 		model = copyActiveFieldToClipboard(model)
@@ -253,10 +267,8 @@ func handleSSEFormKeys(model types.Model, key string) (types.Model, tea.Cmd) {
 		// Paste clipboard content to active field
 		model = pasteFromClipboardToActiveField(model)
 	default:
-		// Add character to active field
-		if len(key) == 1 {
-			model = addCharToActiveField(model, key)
-		}
+		// Type, delete or move the cursor in the active field
+		model, _ = editActiveField(model, key)
 	}
 
 	// Validate form after each change
@@ -270,11 +282,11 @@ func handleJSONFormKeys(model types.Model, key string) (types.Model, tea.Cmd) {
 	switch key {
 	case KeyTab:
 		// Move to next field
-		model.FormData.ActiveField = (model.FormData.ActiveField + 1) % 3 // 3 fields: Name, JSONConfig, Environment
-	case KeyEnter:
-		// Submit form if valid (or newline in JSON field)
-		if model.FormData.ActiveField == 1 { // JSON field
-			model = addCharToActiveField(model, "\n")
+		model = focusFormField(model, (model.FormData.ActiveField+1)%3) // 3 fields: Name, JSONConfig, Environment
+	case KeyEnter, KeyCtrlS:
+		// Submit form if valid (or newline in the JSON and environment fields)
+		if key == KeyEnter && isMultilineField(model) {
+			model, _ = editActiveField(model, key)
 		} else {
 			var valid bool
 			model, valid = validateJSONForm(model)
@@ -312,9 +324,6 @@ func handleJSONFormKeys(model types.Model, key string) (types.Model, tea.Cmd) {
 				return model, cmd
 			}
 		}
-	case KeyBackspaceKey:
-		// Delete character from active field
-		model = deleteCharFromActiveField(model)
 	case KeyCtrlC, KeyCmdCopy, KeyCmdSymbolC, types.KeyCommandC:
 		// Copy active field content to clipboard
 		model = copyActiveFieldToClipboard(model)
@@ -322,10 +331,8 @@ func handleJSONFormKeys(model types.Model, key string) (types.Model, tea.Cmd) {
 		// Paste clipboard content to active field
 		model = pasteFromClipboardToActiveField(model)
 	default:
-		// Add character to active field
-		if len(key) == 1 {
-			model = addCharToActiveField(model, key)
-		}
+		// Type, delete or move the cursor in the active field
+		model, _ = editActiveField(model, key)
 	}
 
 	// Validate form after each change
@@ -334,108 +341,55 @@ func handleJSONFormKeys(model types.Model, key string) (types.Model, tea.Cmd) {
 	return model, nil
 }
 
-// Helper functions for form field manipulation
-func addCharToActiveField(model types.Model, char string) types.Model {
-	switch model.ActiveModal {
-	case types.NoModal:
-		// No modal active, do nothing
-	case types.AddModal:
-		// Basic add modal, do nothing
-	case types.AddMCPTypeSelection:
-		// Type selection modal, do nothing
+// activeFieldText returns the text of the focused field of the open form, or nil
+func activeFieldText(modal types.ModalType, form *types.FormData) *string {
+	var fields []*string
+	switch modal {
 	case types.AddCommandForm:
-		switch model.FormData.ActiveField {
-		case 0:
-			model.FormData.Name += char
-		case 1:
-			model.FormData.Command += char
-		case 2:
-			model.FormData.Args += char
-		case 3:
-			model.FormData.Environment += char
-		}
+		fields = []*string{&form.Name, &form.Command, &form.Args, &form.Environment}
 	case types.AddSSEForm:
-		switch model.FormData.ActiveField {
-		case 0:
-			model.FormData.Name += char
-		case 1:
-			model.FormData.URL += char
-		case 2:
-			model.FormData.Environment += char
-		}
+		fields = []*string{&form.Name, &form.URL, &form.Environment}
 	case types.AddJSONForm:
-		switch model.FormData.ActiveField {
-		case 0:
-			model.FormData.Name += char
-		case 1:
-			model.FormData.JSONConfig += char
-		case 2:
-			model.FormData.Environment += char
-		}
-	case types.EditModal:
-		// Edit modal, do nothing
-	case types.DeleteModal:
-		// Delete modal, do nothing
+		fields = []*string{&form.Name, &form.JSONConfig, &form.Environment}
 	}
-	return model
-}
-
-func deleteCharFromActiveField(model types.Model) types.Model {
-	switch model.ActiveModal {
-	case types.NoModal:
-		// No modal active, do nothing
-	case types.AddModal:
-		// Basic add modal, do nothing
-	case types.AddMCPTypeSelection:
-		// Type selection modal, do nothing
-	case types.AddCommandForm:
-		return deleteCharFromCommandForm(model)
-	case types.AddSSEForm:
-		return deleteCharFromSSEForm(model)
-	case types.AddJSONForm:
-		return deleteCharFromJSONForm(model)
-	case types.EditModal:
-		// Edit modal, do nothing
-	case types.DeleteModal:
-		// Delete modal, do nothing
+	if form.ActiveField < 0 || form.ActiveField >= len(fields) {
+		return nil
 	}
-	return model
+	return fields[form.ActiveField]
 }
 
-func deleteCharFromCommandForm(model types.Model) types.Model {
-	switch model.FormData.ActiveField {
-	case 0:
-		model.FormData.Name = deleteLastChar(model.FormData.Name)
-	case 1:
-		model.FormData.Command = deleteLastChar(model.FormData.Command)
-	case 2:
-		model.FormData.Args = deleteLastChar(model.FormData.Args)
-	case 3:
-		model.FormData.Environment = deleteLastChar(model.FormData.Environment)
-	}
-	return model
+// isMultilineField reports whether the focused field is edited over several lines,
+// where enter breaks the line instead of submitting the form
+func isMultilineField(model types.Model) bool {
+	form := &model.FormData
+	text := activeFieldText(model.ActiveModal, form)
+	return text != nil && (text == &form.JSONConfig || text == &form.Environment)
 }
 
-func deleteCharFromSSEForm(model types.Model) types.Model {
-	switch model.FormData.ActiveField {
-	case 0:
-		model.FormData.Name = deleteLastChar(model.FormData.Name)
-	case 1:
-		model.FormData.URL = deleteLastChar(model.FormData.URL)
-	case 2:
-		model.FormData.Environment = deleteLastChar(model.FormData.Environment)
-	}
-	return model
+// editActiveField applies a text editing key to the focused field of the open form. It
+// reports whether key edits text or moves the cursor.
+func editActiveField(model types.Model, key string) (types.Model, bool) {
+	multiline := isMultilineField(model)
+	field := activeFieldText(model.ActiveModal, &model.FormData)
+	if field == nil {
+		return model, false
+	}
+	cursor := len([]rune(*field)) - model.FormData.CursorFromEnd
+	text, cursor, edited := services.EditText(*field, cursor, key, multiline)
+	if !edited {
+		return model, false
+	}
+	*field = text
+	model.FormData.CursorFromEnd = len([]rune(text)) - cursor
+	return model, true
 }
 
-func deleteCharFromJSONForm(model types.Model) types.Model {
-	switch model.FormData.ActiveField {
-	case 0:
-		model.FormData.Name = deleteLastChar(model.FormData.Name)
-	case 1:
-		model.FormData.JSONConfig = deleteLastChar(model.FormData.JSONConfig)
-	case 2:
-		model.FormData.Environment = deleteLastChar(model.FormData.Environment)
+// focusFormField focuses field of the open form, with the cursor at the end of a field
+// that did not have the focus
+func focusFormField(model types.Model, field int) types.Model {
+	if field != model.FormData.ActiveField {
+		model.FormData.ActiveField = field
+		model.FormData.CursorFromEnd = 0
 	}
 	return model
 }
@@ -579,8 +533,8 @@ func addMCPToInventory(model types.Model, mcpItem types.MCPItem) (types.Model, t
 		return model, hideSuccessMsg()
 	}
 
-	// Select the newly added item
-	model.SelectedItem = len(model.MCPItems) - 1
+	// Select the newly added item where the grid's order puts it
+	model = services.SelectMCPByName(model, mcpItem.Name)
 
 	// Show success message
 	model.SuccessMessage = fmt.Sprintf("Added %s successfully", mcpItem.Name)
@@ -596,6 +550,20 @@ func updateMCPInInventory(model types.Model, updatedMCP types.MCPItem) (types.Mo
 		if mcp.Name == model.EditMCPName {
 			// Preserve the original active status
 			updatedMCP.Active = model.MCPItems[i].Active
+			// Serving is toggled from the list, not the forms
+			updatedMCP.Served = model.MCPItems[i].Served
+			// Headers are not part of the forms, keep the configured ones
+			if updatedMCP.Headers == nil {
+				updatedMCP.Headers = model.MCPItems[i].Headers
+			}
+			// Tool filters are edited in their own modal
+			updatedMCP.ToolAllow = model.MCPItems[i].ToolAllow
+			updatedMCP.ToolDeny = model.MCPItems[i].ToolDeny
+			// The Claude scope is set by manifests, not the forms
+			updatedMCP.Scope = model.MCPItems[i].Scope
+			// Tags and description are edited in inventory.json
+			updatedMCP.Tags = model.MCPItems[i].Tags
+			updatedMCP.Description = model.MCPItems[i].Description
 			model.MCPItems[i] = updatedMCP
 			found = true
 			break
@@ -615,16 +583,8 @@ func updateMCPInInventory(model types.Model, updatedMCP types.MCPItem) (types.Mo
 		return model, hideSuccessMsg()
 	}
 
-	// Update selection to the updated item if name changed
-	if updatedMCP.Name != model.EditMCPName {
-		// Find the updated MCP's new position
-		for i, mcp := range model.MCPItems {
-			if mcp.Name == updatedMCP.Name {
-				model.SelectedItem = i
-				break
-			}
-		}
-	}
+	// Keep the updated item selected; a new name or type can move it in the grid's order
+	model = services.SelectMCPByName(model, updatedMCP.Name)
 
 	// Show success message
 	model.SuccessMessage = fmt.Sprintf("Updated %s successfully", updatedMCP.Name)
@@ -812,10 +772,10 @@ func copyActiveFieldToClipboard(model types.Model) types.Model {
 	clipboardService := services.NewClipboardService(model.PlatformService)
 	if err := clipboardService.Copy(content); err != nil {
 		model.SuccessMessage = "Failed to copy to clipboard: " + err.Error()
-		model.SuccessTimer = 180 // Show error message for 3 seconds (60 ticks per second)
+		model.SuccessTimer = 3 * model.Settings.SuccessTicks() / 2 // Errors stay longer than success messages
 	} else {
 		model.SuccessMessage = "Copied to clipboard"
-		model.SuccessTimer = 120 // Show success message for 2 seconds
+		model.SuccessTimer = model.Settings.SuccessTicks()
 	}
 
 	return model
@@ -904,67 +864,26 @@ func getClipboardContent(platformService platform.PlatformService) (string, erro
 
 func handleClipboardError(model types.Model, err error) types.Model {
 	model.SuccessMessage = "Failed to paste from clipboard: " + err.Error()
-	model.SuccessTimer = 240 // Show error message for 4 seconds to allow reading detailed error
+	model.SuccessTimer = 2 * model.Settings.SuccessTicks() // Errors stay twice as long to allow reading the details
 	return model
 }
 
+// pasteContentToActiveField inserts content at the cursor of the focused form field.
+// The cursor ends up after the pasted text, where it stays counted from the end.
 func pasteContentToActiveField(model types.Model, content string) types.Model {
-	switch model.ActiveModal {
-	case types.AddCommandForm:
-		return pasteToCommandForm(model, content)
-	case types.AddSSEForm:
-		return pasteToSSEForm(model, content)
-	case types.AddJSONForm:
-		return pasteToJSONForm(model, content)
-	case types.NoModal, types.AddModal, types.AddMCPTypeSelection, types.EditModal, types.DeleteModal:
+	field := activeFieldText(model.ActiveModal, &model.FormData)
+	if field == nil {
 		// Other modal types don't support pasting
 		return model
-	default:
-		return model
-	}
-}
-
-func pasteToCommandForm(model types.Model, content string) types.Model {
-	switch model.FormData.ActiveField {
-	case 0:
-		model.FormData.Name = content
-	case 1:
-		model.FormData.Command = content
-	case 2:
-		model.FormData.Args = content
-	case 3:
-		model.FormData.Environment = content
-	}
-	return model
-}
-
-func pasteToSSEForm(model types.Model, content string) types.Model {
-	switch model.FormData.ActiveField {
-	case 0:
-		model.FormData.Name = content
-	case 1:
-		model.FormData.URL = content
-	case 2:
-		model.FormData.Environment = content
-	}
-	return model
-}
-
-func pasteToJSONForm(model types.Model, content string) types.Model {
-	switch model.FormData.ActiveField {
-	case 0:
-		model.FormData.Name = content
-	case 1:
-		model.FormData.JSONConfig = content
-	case 2:
-		model.FormData.Environment = content
 	}
+	cursor := len([]rune(*field)) - model.FormData.CursorFromEnd
+	*field, _ = services.InsertText(*field, cursor, content)
 	return model
 }
 
 func addPasteSuccessMessage(model types.Model) types.Model {
 	model.SuccessMessage = "Pasted from clipboard"
-	model.SuccessTimer = 120 // Show success message for 2 seconds
+	model.SuccessTimer = model.Settings.SuccessTicks()
 	return model
 }
 
@@ -1029,24 +948,24 @@ func focusOnFirstErrorField(model types.Model) types.Model {
 	case types.AddCommandForm:
 		// Check field order: Name (0), Command (1), Args (2), Environment (3)
 		if _, exists := model.FormErrors["name"]; exists {
-			model.FormData.ActiveField = 0
+			model = focusFormField(model, 0)
 		} else if _, exists := model.FormErrors["command"]; exists {
-			model.FormData.ActiveField = 1
+			model = focusFormField(model, 1)
 		}
 		// Args and Environment don't have validation errors in current implementation
 	case types.AddSSEForm:
 		// Check field order: Name (0), URL (1), Environment (2)
 		if _, exists := model.FormErrors["name"]; exists {
-			model.FormData.ActiveField = 0
+			model = focusFormField(model, 0)
 		} else if _, exists := model.FormErrors["url"]; exists {
-			model.FormData.ActiveField = 1
+			model = focusFormField(model, 1)
 		}
 	case types.AddJSONForm:
 		// Check field order: Name (0), JSONConfig (1), Environment (2)
 		if _, exists := model.FormErrors["name"]; exists {
-			model.FormData.ActiveField = 0
+			model = focusFormField(model, 0)
 		} else if _, exists := model.FormErrors["json"]; exists {
-			model.FormData.ActiveField = 1
+			model = focusFormField(model, 1)
 		}
 	case types.EditModal:
 		// Edit modal, do nothing
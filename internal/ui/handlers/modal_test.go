@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/services"
 	"mcp-hub/internal/ui/types"
 
 	"github.com/stretchr/testify/assert"
@@ -234,7 +235,7 @@ func TestUpdateMCPInInventory(t *testing.T) {
 	// Create a model with some test MCPs
 	model := testutil.NewTestModel().Build()
 	model.MCPItems = []types.MCPItem{
-		{Name: "test-mcp", Type: "CMD", Command: "old-command", Active: true},
+		{Name: "test-mcp", Type: "CMD", Command: "old-command", Active: true, Served: true},
 		{Name: "other-mcp", Type: "SSE", URL: "https://old.com", Active: false},
 	}
 	model.EditMode = true
@@ -266,6 +267,9 @@ func TestUpdateMCPInInventory(t *testing.T) {
 			if !mcp.Active {
 				t.Errorf("Expected active status to be preserved as true, got %v", mcp.Active)
 			}
+			if !mcp.Served {
+				t.Errorf("Expected served status to be preserved as true, got %v", mcp.Served)
+			}
 		}
 	}
 
@@ -327,6 +331,34 @@ func TestUpdateMCPInInventoryEdgeCases(t *testing.T) {
 		assert.Equal(t, "existing", newModel.MCPItems[0].Name)
 	})
 
+	t.Run("update_preserves_headers", func(t *testing.T) {
+		model := testutil.NewTestModel().
+			WithMCPs([]types.MCPItem{
+				{Name: "remote", Type: "SSE", URL: "https://old.com/sse", Headers: map[string]string{"Authorization": "Bearer abc"}},
+			}).
+			Build()
+		model.EditMode = true
+		model.EditMCPName = "remote"
+
+		newModel, _ := updateMCPInInventory(model, types.MCPItem{Name: "remote", Type: "SSE", URL: "https://new.com/sse"})
+		assert.Equal(t, "https://new.com/sse", newModel.MCPItems[0].URL)
+		assert.Equal(t, "Bearer abc", newModel.MCPItems[0].Headers["Authorization"])
+	})
+
+	t.Run("update_preserves_tags_and_description", func(t *testing.T) {
+		model := testutil.NewTestModel().
+			WithMCPs([]types.MCPItem{
+				{Name: "tagged", Type: "CMD", Command: "old", Tags: []string{"db"}, Description: "Postgres tools"},
+			}).
+			Build()
+		model.EditMode = true
+		model.EditMCPName = "tagged"
+
+		newModel, _ := updateMCPInInventory(model, types.MCPItem{Name: "tagged", Type: "CMD", Command: "new"})
+		assert.Equal(t, []string{"db"}, newModel.MCPItems[0].Tags)
+		assert.Equal(t, "Postgres tools", newModel.MCPItems[0].Description)
+	})
+
 	t.Run("update_preserves_complex_fields", func(t *testing.T) {
 		model := testutil.NewTestModel().
 			WithMCPs([]types.MCPItem{
@@ -989,7 +1021,7 @@ func TestIsValidKeyChar(t *testing.T) {
 	}
 }
 
-func TestAddCharToActiveField(t *testing.T) {
+func TestEditActiveField_Types(t *testing.T) {
 	model := types.Model{
 		ActiveModal: types.AddCommandForm,
 		FormData: types.FormData{
@@ -998,14 +1030,14 @@ func TestAddCharToActiveField(t *testing.T) {
 		},
 	}
 
-	newModel := addCharToActiveField(model, "x")
+	newModel, _ := editActiveField(model, "x")
 
 	if newModel.FormData.Name != "testx" {
 		t.Errorf("Expected name to be 'testx', got %q", newModel.FormData.Name)
 	}
 }
 
-func TestDeleteCharFromActiveField(t *testing.T) {
+func TestEditActiveField_Deletes(t *testing.T) {
 	model := types.Model{
 		ActiveModal: types.AddCommandForm,
 		FormData: types.FormData{
@@ -1014,13 +1046,72 @@ func TestDeleteCharFromActiveField(t *testing.T) {
 		},
 	}
 
-	newModel := deleteCharFromActiveField(model)
+	newModel, _ := editActiveField(model, KeyBackspaceKey)
 
 	if newModel.FormData.Name != "tes" {
 		t.Errorf("Expected name to be 'tes', got %q", newModel.FormData.Name)
 	}
 }
 
+func TestEditActiveField_CursorMovement(t *testing.T) {
+	model := types.Model{
+		State:       types.ModalActive,
+		ActiveModal: types.AddCommandForm,
+		FormData:    types.FormData{Command: "npx server", ActiveField: 1},
+	}
+
+	// Insert before the last word, then delete forward from the start
+	for _, key := range []string{"alt+left", "-", "y", " ", "home", "delete"} {
+		model, _ = HandleModalKeys(model, key)
+	}
+	if model.FormData.Command != "px -y server" || model.FormData.CursorFromEnd != len("px -y server") {
+		t.Errorf("Expected \"px -y server\" with the cursor at the start, got %q %d from the end", model.FormData.Command, model.FormData.CursorFromEnd)
+	}
+
+	model, _ = HandleModalKeys(model, KeyTab)
+	if model.FormData.ActiveField != 2 || model.FormData.CursorFromEnd != 0 {
+		t.Errorf("Tab should move the cursor to the end of the next field, got %d from the end", model.FormData.CursorFromEnd)
+	}
+}
+
+func TestHandleModalKeys_MultilineEnvironment(t *testing.T) {
+	model := testutil.NewTestModel().Build()
+	model.State = types.ModalActive
+	model.ActiveModal = types.AddCommandForm
+	model.FormData = types.FormData{Name: "env-mcp", Command: "npx", Environment: "A=1", ActiveField: 3}
+
+	model, _ = HandleModalKeys(model, KeyEnter)
+	for _, key := range []string{"B", "=", "2"} {
+		model, _ = HandleModalKeys(model, key)
+	}
+	if model.State != types.ModalActive || model.FormData.Environment != "A=1\nB=2" {
+		t.Fatalf("Enter should break the line in the environment field, got %q", model.FormData.Environment)
+	}
+
+	model, _ = HandleModalKeys(model, KeyCtrlS)
+	if model.State != types.MainNavigation || len(model.MCPItems) != 1 {
+		t.Fatalf("Ctrl+S should submit the form, got state %v", model.State)
+	}
+	if env := model.MCPItems[0].Environment; env["A"] != "1" || env["B"] != "2" {
+		t.Errorf("Each line should be a variable, got %v", env)
+	}
+}
+
+func TestHandleModalKeys_MultilineJSON(t *testing.T) {
+	model := types.Model{
+		State:       types.ModalActive,
+		ActiveModal: types.AddJSONForm,
+		FormData:    types.FormData{Name: "json-mcp", JSONConfig: "{}", ActiveField: 1, CursorFromEnd: 1},
+	}
+
+	model, _ = HandleModalKeys(model, KeyEnter)
+	model, _ = HandleModalKeys(model, "up")
+	model, _ = HandleModalKeys(model, "end")
+	if model.FormData.JSONConfig != "{\n}" || model.FormData.CursorFromEnd != 2 {
+		t.Errorf("Expected the cursor after the brace on the first line, got %q %d from the end", model.FormData.JSONConfig, model.FormData.CursorFromEnd)
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr ||
@@ -1412,7 +1503,7 @@ func TestDeleteCharFromSSEForm(t *testing.T) {
 		}
 		model.FormData.ActiveField = 0
 
-		updatedModel := deleteCharFromSSEForm(model)
+		updatedModel, _ := editActiveField(model, KeyBackspaceKey)
 		assert.Equal(t, "tes", updatedModel.FormData.Name)
 	})
 
@@ -1427,7 +1518,7 @@ func TestDeleteCharFromSSEForm(t *testing.T) {
 		}
 		model.FormData.ActiveField = 1
 
-		updatedModel := deleteCharFromSSEForm(model)
+		updatedModel, _ := editActiveField(model, KeyBackspaceKey)
 		assert.Equal(t, "https://example.co", updatedModel.FormData.URL)
 	})
 
@@ -1442,7 +1533,7 @@ func TestDeleteCharFromSSEForm(t *testing.T) {
 		}
 		model.FormData.ActiveField = 2
 
-		updatedModel := deleteCharFromSSEForm(model)
+		updatedModel, _ := editActiveField(model, KeyBackspaceKey)
 		assert.Equal(t, "KEY=valu", updatedModel.FormData.Environment)
 	})
 
@@ -1457,7 +1548,7 @@ func TestDeleteCharFromSSEForm(t *testing.T) {
 		}
 		model.FormData.ActiveField = 0
 
-		updatedModel := deleteCharFromSSEForm(model)
+		updatedModel, _ := editActiveField(model, KeyBackspaceKey)
 		assert.Equal(t, "", updatedModel.FormData.Name)
 	})
 }
@@ -1474,7 +1565,7 @@ func TestDeleteCharFromJSONForm(t *testing.T) {
 		}
 		model.FormData.ActiveField = 0
 
-		updatedModel := deleteCharFromJSONForm(model)
+		updatedModel, _ := editActiveField(model, KeyBackspaceKey)
 		assert.Equal(t, "tes", updatedModel.FormData.Name)
 	})
 
@@ -1489,7 +1580,7 @@ func TestDeleteCharFromJSONForm(t *testing.T) {
 		}
 		model.FormData.ActiveField = 1
 
-		updatedModel := deleteCharFromJSONForm(model)
+		updatedModel, _ := editActiveField(model, KeyBackspaceKey)
 		assert.Equal(t, `{"key": "value"`, updatedModel.FormData.JSONConfig)
 	})
 
@@ -1504,7 +1595,7 @@ func TestDeleteCharFromJSONForm(t *testing.T) {
 		}
 		model.FormData.ActiveField = 2
 
-		updatedModel := deleteCharFromJSONForm(model)
+		updatedModel, _ := editActiveField(model, KeyBackspaceKey)
 		assert.Equal(t, "KEY=valu", updatedModel.FormData.Environment)
 	})
 }
@@ -1548,6 +1639,27 @@ func TestAddMCPToInventory(t *testing.T) {
 		assert.Len(t, updatedModel.MCPItems, 2) // Actually adds duplicate - validation is elsewhere
 		assert.Equal(t, "Added existing successfully", updatedModel.SuccessMessage)
 	})
+
+	t.Run("selects_in_sorted_order", func(t *testing.T) {
+		model := testutil.NewTestModel().
+			WithMCPs([]types.MCPItem{
+				{Name: "github", Type: "CMD", Command: "gh"},
+				{Name: "postgres", Type: "CMD", Command: "pg"},
+			}).
+			Build()
+		model.Grid.Sort = types.SortName
+
+		updatedModel, _ := addMCPToInventory(model, types.MCPItem{Name: "aws", Type: "CMD", Command: "aws"})
+		selected := services.GetSelectedMCP(updatedModel)
+		assert.NotNil(t, selected)
+		assert.Equal(t, "aws", selected.Name, "The new MCP sorts first, so the first position should be selected")
+
+		updatedModel.EditMCPName = "aws"
+		updatedModel, _ = updateMCPInInventory(updatedModel, types.MCPItem{Name: "zed", Type: "CMD", Command: "aws"})
+		selected = services.GetSelectedMCP(updatedModel)
+		assert.NotNil(t, selected)
+		assert.Equal(t, "zed", selected.Name, "A renamed MCP should stay selected at its new position")
+	})
 }
 
 func TestHandleDeleteModalKeys(t *testing.T) {
@@ -1788,6 +1900,27 @@ func TestClipboardOperations(t *testing.T) {
 		assert.Equal(t, content, updatedModel.FormData.Name)
 	})
 
+	t.Run("paste_at_cursor", func(t *testing.T) {
+		model := testutil.NewTestModel().
+			WithState(types.ModalActive).
+			Build()
+		model.ActiveModal = types.AddCommandForm
+		model.FormData.ActiveField = 2
+		model.FormData.Args = "-y --stdio"
+		model.FormData.CursorFromEnd = len("--stdio")
+
+		updatedModel := pasteContentToActiveField(model, "server ")
+		assert.Equal(t, "-y server --stdio", updatedModel.FormData.Args)
+		assert.Equal(t, len("--stdio"), updatedModel.FormData.CursorFromEnd, "The cursor should stay after the pasted text")
+
+		model.ActiveModal = types.AddJSONForm
+		model.FormData.ActiveField = 1
+		model.FormData.JSONConfig = "{\n}"
+		model.FormData.CursorFromEnd = 1
+		updatedModel = pasteContentToActiveField(model, `  "command": "npx"`+"\n")
+		assert.Equal(t, "{\n  \"command\": \"npx\"\n}", updatedModel.FormData.JSONConfig)
+	})
+
 	t.Run("paste_to_command_form", func(t *testing.T) {
 		model := testutil.NewTestModel().
 			WithActiveColumn(0).
@@ -1799,19 +1932,19 @@ func TestClipboardOperations(t *testing.T) {
 
 		// Test pasting to each field
 		model.FormData.ActiveField = 0
-		updatedModel := pasteToCommandForm(model, content)
+		updatedModel := pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.Name)
 
 		model.FormData.ActiveField = 1
-		updatedModel = pasteToCommandForm(model, content)
+		updatedModel = pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.Command)
 
 		model.FormData.ActiveField = 2
-		updatedModel = pasteToCommandForm(model, content)
+		updatedModel = pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.Args)
 
 		model.FormData.ActiveField = 3
-		updatedModel = pasteToCommandForm(model, content)
+		updatedModel = pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.Environment)
 	})
 
@@ -1826,15 +1959,15 @@ func TestClipboardOperations(t *testing.T) {
 
 		// Test pasting to each field
 		model.FormData.ActiveField = 0
-		updatedModel := pasteToSSEForm(model, content)
+		updatedModel := pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.Name)
 
 		model.FormData.ActiveField = 1
-		updatedModel = pasteToSSEForm(model, content)
+		updatedModel = pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.URL)
 
 		model.FormData.ActiveField = 2
-		updatedModel = pasteToSSEForm(model, content)
+		updatedModel = pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.Environment)
 	})
 
@@ -1849,15 +1982,15 @@ func TestClipboardOperations(t *testing.T) {
 
 		// Test pasting to each field
 		model.FormData.ActiveField = 0
-		updatedModel := pasteToJSONForm(model, content)
+		updatedModel := pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.Name)
 
 		model.FormData.ActiveField = 1
-		updatedModel = pasteToJSONForm(model, content)
+		updatedModel = pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.JSONConfig)
 
 		model.FormData.ActiveField = 2
-		updatedModel = pasteToJSONForm(model, content)
+		updatedModel = pasteContentToActiveField(model, content)
 		assert.Equal(t, content, updatedModel.FormData.Environment)
 	})
 
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleMouse applies a mouse event to the target it happened on. The view hit-tests the
+// event, so the target says what was clicked: an MCP, a column, a form field, an option
+// or a footer button.
+func HandleMouse(model types.Model, msg tea.MouseMsg, target types.MouseTarget) (types.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return model, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return handleMouseWheel(model, KeyUp)
+	case tea.MouseButtonWheelDown:
+		return handleMouseWheel(model, KeyDown)
+	case tea.MouseButtonLeft:
+		return handleMouseClick(model, target, time.Now())
+	}
+	return model, nil
+}
+
+// handleMouseWheel moves through the grid or list, or scrolls the open modal like the
+// arrow key
+func handleMouseWheel(model types.Model, key string) (types.Model, tea.Cmd) {
+	switch model.State {
+	case types.ModalActive:
+		return HandleModalKeys(model, key)
+	case types.MainNavigation, types.SearchActiveNavigation:
+		if key == KeyUp {
+			return NavigateUp(model), nil
+		}
+		return NavigateDown(model), nil
+	}
+	return model, nil
+}
+
+// handleMouseClick selects what was clicked; a second click on the same MCP or option
+// within DoubleClickInterval toggles the MCP or opens the option's form
+func handleMouseClick(model types.Model, target types.MouseTarget, now time.Time) (types.Model, tea.Cmd) {
+	double := target.Kind != types.MouseTargetNone && model.LastClick.Target == target &&
+		now.Sub(model.LastClick.At) <= types.DoubleClickInterval
+	model.LastClick = types.MouseClick{Target: target, At: now}
+	if double {
+		// A third click starts a new double-click
+		model.LastClick = types.MouseClick{}
+	}
+
+	switch target.Kind {
+	case types.MouseTargetItem:
+		if model.State != types.MainNavigation && model.State != types.SearchActiveNavigation {
+			return model, nil
+		}
+		model = selectDisplayedItem(model, target.Index)
+		if double {
+			updatedModel, cmd, _ := handleEnhancedToggleMCP(model)
+			return updatedModel, cmd
+		}
+	case types.MouseTargetColumn:
+		if model.State == types.MainNavigation || model.State == types.SearchActiveNavigation {
+			model.ActiveColumn = target.Index
+		}
+	case types.MouseTargetField:
+		model = focusFormField(model, target.Index)
+	case types.MouseTargetOption:
+		model.FormData.ActiveField = target.Index
+		if double {
+			return HandleModalKeys(model, strconv.Itoa(target.Index))
+		}
+	case types.MouseTargetButton:
+		if target.Key == KeyEsc {
+			return HandleEscKey(model)
+		}
+		if target.Key == KeyEnter && isMultilineField(model) {
+			// The button submits the form, where Enter in the field would break the line
+			return HandleModalKeys(model, KeyCtrlS)
+		}
+		return HandleModalKeys(model, target.Key)
+	}
+	return model, nil
+}
+
+// selectDisplayedItem selects the MCP at index of the grid or list as displayed
+func selectDisplayedItem(model types.Model, index int) types.Model {
+	model = services.SelectViewIndex(model, index)
+	if model.ColumnCount != types.WideColumns {
+		// The list is in the first column of the narrow layouts
+		model.ActiveColumn = 0
+	}
+	return model
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHandleMouse_ClickSelects(t *testing.T) {
+	model := testutil.NewTestModel().WithWindowSize(140, 40).WithMCPs(testutil.MockMCPItems()).Build()
+	model.ColumnCount = types.WideColumns
+	item := types.MouseTarget{Kind: types.MouseTargetItem, Index: 2}
+
+	release := tea.MouseMsg{Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft}
+	if updated, _ := HandleMouse(model, release, item); updated.SelectedItem != 0 {
+		t.Error("Only presses should select")
+	}
+	press := tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+	if updated, _ := HandleMouse(model, press, item); updated.SelectedItem != 2 {
+		t.Errorf("A click should select the MCP, got %d", updated.SelectedItem)
+	}
+
+	model.State = types.ModalActive
+	model.ActiveModal = types.HelpModal
+	if updated, _ := HandleMouse(model, press, item); updated.SelectedItem != 0 {
+		t.Error("MCPs behind a modal should not be clickable")
+	}
+}
+
+func TestHandleMouse_ClickSelectsDuringSearch(t *testing.T) {
+	press := tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+	item := types.MouseTarget{Kind: types.MouseTargetItem, Index: 1}
+
+	model := testutil.NewTestModel().WithWindowSize(140, 40).WithMCPs(testutil.MockMCPItems()).Build()
+	model.ColumnCount = types.WideColumns
+	model.SearchQuery = "mcp"
+	if updated, _ := HandleMouse(model, press, item); updated.FilteredSelectedIndex != 1 || updated.SelectedItem != 0 {
+		t.Errorf("The grid should select the search result, got %d", updated.FilteredSelectedIndex)
+	}
+
+	// The narrow lists highlight and move by SelectedItem, search or not
+	model = testutil.NewTestModel().WithWindowSize(60, 40).WithMCPs(testutil.MockMCPItems()).Build()
+	model.SearchQuery = "mcp"
+	if updated, _ := HandleMouse(model, press, item); updated.SelectedItem != 1 || updated.FilteredSelectedIndex != 0 {
+		t.Errorf("The list should select the clicked line, got %d", updated.SelectedItem)
+	}
+}
+
+func TestHandleMouseClick_DoubleClickWindow(t *testing.T) {
+	model := testutil.NewTestModel().WithMCPs(testutil.MockMCPItems()).Build()
+	model.State = types.ModalActive
+	model.ActiveModal = types.AddMCPTypeSelection
+	option := types.MouseTarget{Kind: types.MouseTargetOption, Index: 3}
+	start := time.Now()
+
+	model, _ = handleMouseClick(model, option, start)
+	model, _ = handleMouseClick(model, option, start.Add(types.DoubleClickInterval+time.Millisecond))
+	if model.ActiveModal != types.AddMCPTypeSelection || model.FormData.ActiveField != 3 {
+		t.Fatalf("Slow clicks should only select the option, got modal %v", model.ActiveModal)
+	}
+
+	other := types.MouseTarget{Kind: types.MouseTargetOption, Index: 2}
+	model, _ = handleMouseClick(model, other, start.Add(types.DoubleClickInterval+2*time.Millisecond))
+	if model.ActiveModal != types.AddMCPTypeSelection || model.FormData.ActiveField != 2 {
+		t.Fatalf("Clicks on different options should not double-click, got modal %v", model.ActiveModal)
+	}
+
+	model, _ = handleMouseClick(model, other, start.Add(types.DoubleClickInterval+3*time.Millisecond))
+	if model.ActiveModal != types.AddSSEForm || model.FormData.ActiveField != 0 {
+		t.Errorf("A double-click should open the option's form, got modal %v field %d", model.ActiveModal, model.FormData.ActiveField)
+	}
+}
+
+func TestHandleMouse_FieldsAndButtons(t *testing.T) {
+	model := testutil.NewTestModel().Build()
+	model.State = types.ModalActive
+	model.ActiveModal = types.AddCommandForm
+	press := tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+
+	model, _ = HandleMouse(model, press, types.MouseTarget{Kind: types.MouseTargetField, Index: 2})
+	if model.FormData.ActiveField != 2 {
+		t.Errorf("Clicking a field should focus it, got %d", model.FormData.ActiveField)
+	}
+	model, _ = HandleMouse(model, press, types.MouseTarget{Kind: types.MouseTargetButton, Key: KeyTab})
+	if model.FormData.ActiveField != 3 {
+		t.Errorf("Clicking [Tab] should press Tab, got field %d", model.FormData.ActiveField)
+	}
+	model, _ = HandleMouse(model, press, types.MouseTarget{Kind: types.MouseTargetButton, Key: KeyEsc})
+	if model.State != types.MainNavigation || model.ActiveModal != types.NoModal {
+		t.Errorf("Clicking ESC should close the form, got state %v modal %v", model.State, model.ActiveModal)
+	}
+}
+
+func TestHandleMouse_WheelScrollsModal(t *testing.T) {
+	model := testutil.NewTestModel().Build()
+	model.State = types.ModalActive
+	model.ActiveModal = types.AddMCPTypeSelection
+	model.FormData.ActiveField = 1
+
+	model, _ = HandleMouse(model, tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown}, types.MouseTarget{})
+	if model.FormData.ActiveField != 2 {
+		t.Errorf("Scrolling down should move like the down arrow, got option %d", model.FormData.ActiveField)
+	}
+}
+
+func TestHandleMouse_EnterButtonSubmitsFromMultilineField(t *testing.T) {
+	model := testutil.NewTestModel().Build()
+	model.State = types.ModalActive
+	model.ActiveModal = types.AddCommandForm
+	model.FormData = types.FormData{Name: "env-mcp", Command: "npx", Environment: "A=1", ActiveField: 3}
+	press := tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+
+	model, _ = HandleMouse(model, press, types.MouseTarget{Kind: types.MouseTargetButton, Key: KeyEnter})
+	if model.State != types.MainNavigation {
+		t.Errorf("Clicking [Enter] should submit the form rather than break the line, got state %v", model.State)
+	}
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,18 +16,23 @@ import (
 
 // HandleMainNavigationKeys handles keyboard input in main navigation mode
 func HandleMainNavigationKeys(model types.Model, key string) (types.Model, tea.Cmd) {
+	action, ok := services.ActionForKey(model.Keys, services.KeyContextMain, key)
+	if !ok {
+		return model, nil
+	}
+
 	// Handle navigation keys
-	if updatedModel, handled := handleNavigationKeys(model, key); handled {
+	if updatedModel, handled := handleNavigationKeys(model, action); handled {
 		return updatedModel, nil
 	}
 
 	// Handle search keys
-	if updatedModel, handled := handleSearchKeys(model, key); handled {
+	if updatedModel, handled := handleSearchKeys(model, action); handled {
 		return updatedModel, nil
 	}
 
 	// Handle action keys
-	if updatedModel, cmd, handled := handleActionKeys(model, key); handled {
+	if updatedModel, cmd, handled := handleActionKeys(model, action); handled {
 		return updatedModel, cmd
 	}
 
@@ -33,24 +40,31 @@ func HandleMainNavigationKeys(model types.Model, key string) (types.Model, tea.C
 }
 
 // handleNavigationKeys handles directional navigation keys
-func handleNavigationKeys(model types.Model, key string) (types.Model, bool) {
-	switch key {
-	case "up", "k":
+func handleNavigationKeys(model types.Model, action types.Action) (types.Model, bool) {
+	switch action {
+	case types.ActionUp:
 		return NavigateUp(model), true
-	case "down", "j":
+	case types.ActionDown:
 		return NavigateDown(model), true
-	case "left", "h":
+	case types.ActionLeft:
 		return NavigateLeft(model), true
-	case "right", "l":
+	case types.ActionRight:
 		return NavigateRight(model), true
+	case types.ActionPageUp:
+		return NavigatePage(model, -1), true
+	case types.ActionPageDown:
+		return NavigatePage(model, 1), true
+	case types.ActionHome:
+		return NavigateHome(model), true
+	case types.ActionEnd:
+		return NavigateEnd(model), true
 	}
 	return model, false
 }
 
 // handleSearchKeys handles search activation keys
-func handleSearchKeys(model types.Model, key string) (types.Model, bool) {
-	switch key {
-	case KeyTab, "/":
+func handleSearchKeys(model types.Model, action types.Action) (types.Model, bool) {
+	if action == types.ActionSearch {
 		// Activate search mode with navigation enabled
 		model.State = types.SearchActiveNavigation
 		model.SearchActive = true
@@ -62,24 +76,84 @@ func handleSearchKeys(model types.Model, key string) (types.Model, bool) {
 	return model, false
 }
 
-// handleActionKeys handles action keys (add, edit, delete, toggle, refresh)
-func handleActionKeys(model types.Model, key string) (types.Model, tea.Cmd, bool) {
-	switch key {
-	case "a":
+// handleActionKeys handles action keys (add, edit, delete, toggle, serve, refresh, introspect, probe, playground, logs, tool filter, settings, workspaces, app log, sort, group, help)
+func handleActionKeys(model types.Model, action types.Action) (types.Model, tea.Cmd, bool) {
+	switch action {
+	case types.ActionAdd:
 		return handleAddMCP(model), nil, true
-	case "e":
+	case types.ActionEdit:
 		return handleEditMCP(model)
-	case "d":
+	case types.ActionDelete:
 		return handleDeleteMCP(model), nil, true
-	case " ", "space":
+	case types.ActionToggle:
 		return handleEnhancedToggleMCP(model)
-	case "r", "R":
+	case types.ActionServe:
+		return handleToggleServed(model), nil, true
+	case types.ActionRefresh:
 		updatedModel, cmd := handleRefreshAction(model)
 		return updatedModel, cmd, true
+	case types.ActionIntrospect:
+		updatedModel, cmd := handleIntrospectSelected(model)
+		return updatedModel, cmd, true
+	case types.ActionIntrospectAll:
+		updatedModel, cmd := handleIntrospectAll(model)
+		return updatedModel, cmd, true
+	case types.ActionProbe:
+		updatedModel, cmd := handleProbeSelected(model)
+		return updatedModel, cmd, true
+	case types.ActionProbeAll:
+		updatedModel, cmd := handleProbeAll(model)
+		return updatedModel, cmd, true
+	case types.ActionPlayground:
+		updatedModel, cmd := handleOpenPlayground(model)
+		return updatedModel, cmd, true
+	case types.ActionLogs:
+		updatedModel, cmd := handleOpenLogs(model)
+		return updatedModel, cmd, true
+	case types.ActionToolFilter:
+		updatedModel, cmd := handleOpenToolFilter(model)
+		return updatedModel, cmd, true
+	case types.ActionSettings:
+		return handleOpenSettings(model), nil, true
+	case types.ActionWorkspaces:
+		return handleOpenWorkspaces(model), nil, true
+	case types.ActionAppLog:
+		updatedModel, cmd := handleOpenAppLog(model)
+		return updatedModel, cmd, true
+	case types.ActionSort:
+		updatedModel, cmd := handleCycleSort(model)
+		return updatedModel, cmd, true
+	case types.ActionGroup:
+		updatedModel, cmd := handleCycleGroup(model)
+		return updatedModel, cmd, true
+	case types.ActionHelp:
+		return handleOpenHelp(model), nil, true
 	}
 	return model, nil, false
 }
 
+// handleToggleServed adds the selected MCP to the set mcp-hub serve proxies, or removes
+// it. Only the inventory changes; a running serve picks it up on its next poll.
+func handleToggleServed(model types.Model) types.Model {
+	selectedMCP := services.GetSelectedMCP(model)
+	if selectedMCP == nil {
+		return model
+	}
+	index := services.FindMCPIndex(model.MCPItems, selectedMCP.Name)
+	served := !selectedMCP.Served
+	if err := services.SetMCPServed(model.PlatformService, model.MCPItems, index, served); err != nil {
+		model.MCPItems[index].Served = !served
+		model.SuccessMessage = fmt.Sprintf("Failed to update %s: %v", selectedMCP.Name, err)
+		return model
+	}
+	if served {
+		model.SuccessMessage = fmt.Sprintf("Serving %s through mcp-hub serve", selectedMCP.Name)
+	} else {
+		model.SuccessMessage = fmt.Sprintf("Stopped serving %s through mcp-hub serve", selectedMCP.Name)
+	}
+	return model
+}
+
 // handleEnhancedToggleMCP handles the enhanced MCP toggle operation (Epic 2 Story 2)
 func handleEnhancedToggleMCP(model types.Model) (types.Model, tea.Cmd, bool) {
 	selectedMCP := services.GetSelectedMCP(model)
@@ -165,42 +239,43 @@ func getEditModalType(mcpType string) types.ModalType {
 
 // HandleSearchNavigationKeys handles keyboard input in search + navigation mode
 func HandleSearchNavigationKeys(model types.Model, key string) (types.Model, tea.Cmd) {
-	// Priority 1: Navigation keys (always work)
-	switch key {
-	case "up", "k":
-		model = NavigateUp(model)
-		return model, nil
-	case "down", "j":
-		model = NavigateDown(model)
-		return model, nil
-	case "left", "h":
-		model = NavigateLeft(model)
-		return model, nil
-	case "right", "l":
-		model = NavigateRight(model)
-		return model, nil
-	case " ", "space":
-		// Enhanced toggle MCP active status
-		updatedModel, cmd, _ := handleEnhancedToggleMCP(model)
-		return updatedModel, cmd
-	case "r", "R":
-		// Refresh with loading overlay
-		updatedModel, cmd := handleRefreshAction(model)
-		return updatedModel, cmd
-	}
-
-	// Priority 2: Search control keys (mode switching)
-	switch key {
-	case KeyEnter:
-		// Return to main navigation with search query preserved
-		model.State = types.MainNavigation
-		model.SearchActive = false
-		model.SearchInputActive = false
-		return model, nil
-	case KeyTab:
-		// Toggle between input and navigation modes
-		model.SearchInputActive = !model.SearchInputActive
-		return model, nil
+	// Priority 1: Navigation and action keys (always work); Priority 2: search control
+	// keys (mode switching)
+	if action, ok := services.ActionForKey(model.Keys, services.KeyContextSearch, key); ok {
+		switch action {
+		case types.ActionUp:
+			return NavigateUp(model), nil
+		case types.ActionDown:
+			return NavigateDown(model), nil
+		case types.ActionLeft:
+			return NavigateLeft(model), nil
+		case types.ActionRight:
+			return NavigateRight(model), nil
+		case types.ActionPageUp, types.ActionPageDown, types.ActionHome, types.ActionEnd:
+			updatedModel, _ := handleNavigationKeys(model, action)
+			return updatedModel, nil
+		case types.ActionToggle:
+			// Enhanced toggle MCP active status
+			updatedModel, cmd, _ := handleEnhancedToggleMCP(model)
+			return updatedModel, cmd
+		case types.ActionRefresh:
+			// Refresh with loading overlay
+			return handleRefreshAction(model)
+		case types.ActionSearchApply:
+			// Return to main navigation with search query preserved
+			model.State = types.MainNavigation
+			model.SearchActive = false
+			model.SearchInputActive = false
+			return model, nil
+		case types.ActionSearchMode:
+			// Toggle between input and navigation modes
+			model.SearchInputActive = !model.SearchInputActive
+			return model, nil
+		case types.ActionHelp:
+			if !model.SearchInputActive {
+				return handleOpenHelp(model), nil
+			}
+		}
 	}
 
 	// Priority 3: Text input (only when searchInputActive = true)
@@ -229,33 +304,8 @@ func HandleSearchNavigationKeys(model types.Model, key string) (types.Model, tea
 // NavigateUp moves selection up within the grid
 func NavigateUp(model types.Model) types.Model {
 	if model.ColumnCount == 4 {
-		// In 4-column grid - move up one row (subtract 4 from index)
-		filteredMCPs := services.GetFilteredMCPs(model)
-
-		// Use appropriate index based on search state
-		if model.SearchQuery != "" {
-			if model.FilteredSelectedIndex >= 4 {
-				model.FilteredSelectedIndex -= 4
-			}
-			// Ensure we don't go below 0 and stay within filtered results
-			if model.FilteredSelectedIndex < 0 {
-				model.FilteredSelectedIndex = 0
-			}
-			if model.FilteredSelectedIndex >= len(filteredMCPs) && len(filteredMCPs) > 0 {
-				model.FilteredSelectedIndex = len(filteredMCPs) - 1
-			}
-		} else {
-			if model.SelectedItem >= 4 {
-				model.SelectedItem -= 4
-			}
-			// Ensure we don't go below 0
-			if model.SelectedItem < 0 {
-				model.SelectedItem = 0
-			}
-			if model.SelectedItem >= len(filteredMCPs) && len(filteredMCPs) > 0 {
-				model.SelectedItem = len(filteredMCPs) - 1
-			}
-		}
+		// In 4-column grid - move up one row of the displayed layout
+		return moveGridSelection(model, -1, 0)
 	} else if model.ActiveColumn == 0 {
 		// In MCP list column for other layouts
 		if model.SelectedItem > 0 {
@@ -269,23 +319,8 @@ func NavigateUp(model types.Model) types.Model {
 // NavigateDown moves selection down within the grid
 func NavigateDown(model types.Model) types.Model {
 	if model.ColumnCount == 4 {
-		// In 4-column grid - move down one row (add 4 to index)
-		filteredMCPs := services.GetFilteredMCPs(model)
-
-		// Use appropriate index based on search state
-		if model.SearchQuery != "" {
-			newIndex := model.FilteredSelectedIndex + 4
-			// Only move if there's actually an item 4 positions down
-			if newIndex < len(filteredMCPs) {
-				model.FilteredSelectedIndex = newIndex
-			}
-		} else {
-			newIndex := model.SelectedItem + 4
-			// Only move if there's actually an item 4 positions down
-			if newIndex < len(filteredMCPs) {
-				model.SelectedItem = newIndex
-			}
-		}
+		// In 4-column grid - move down one row of the displayed layout
+		return moveGridSelection(model, 1, 0)
 	} else if model.ActiveColumn == 0 {
 		// In MCP list column for other layouts
 		maxItems := len(model.MCPItems) - 1
@@ -301,20 +336,11 @@ func NavigateDown(model types.Model) types.Model {
 func NavigateLeft(model types.Model) types.Model {
 	if model.ColumnCount == 4 {
 		// In 4-column grid - move left within current row
-		if model.SearchQuery != "" {
-			if model.FilteredSelectedIndex%4 > 0 {
-				model.FilteredSelectedIndex--
-			}
-		} else {
-			if model.SelectedItem%4 > 0 {
-				model.SelectedItem--
-			}
-		}
-	} else {
-		// For other layouts, move between columns
-		if model.ActiveColumn > 0 {
-			model.ActiveColumn--
-		}
+		return moveGridSelection(model, 0, -1)
+	}
+	// For other layouts, move between columns
+	if model.ActiveColumn > 0 {
+		model.ActiveColumn--
 	}
 	return model
 }
@@ -323,24 +349,58 @@ func NavigateLeft(model types.Model) types.Model {
 func NavigateRight(model types.Model) types.Model {
 	if model.ColumnCount == 4 {
 		// In 4-column grid - move right within current row
-		filteredMCPs := services.GetFilteredMCPs(model)
-
-		if model.SearchQuery != "" {
-			if model.FilteredSelectedIndex%4 < 3 && model.FilteredSelectedIndex+1 < len(filteredMCPs) {
-				model.FilteredSelectedIndex++
-			}
-		} else {
-			if model.SelectedItem%4 < 3 && model.SelectedItem+1 < len(filteredMCPs) {
-				model.SelectedItem++
-			}
-		}
-	} else if model.ActiveColumn < model.ColumnCount-1 {
+		return moveGridSelection(model, 0, 1)
+	}
+	if model.ActiveColumn < model.ColumnCount-1 {
 		// For other layouts, move between columns
 		model.ActiveColumn++
 	}
 	return model
 }
 
+// NavigatePage moves the selection up (pages < 0) or down a screen of the grid or list
+func NavigatePage(model types.Model, pages int) types.Model {
+	if model.ColumnCount != types.WideColumns && model.ActiveColumn != 0 {
+		return model
+	}
+	viewport := services.GetViewport(model)
+	index := services.MoveGridSelection(viewport.Rows, services.SelectedViewIndex(model), pages*viewport.Height, 0)
+	return services.SelectViewIndex(model, index)
+}
+
+// NavigateHome selects the first MCP of the grid or list
+func NavigateHome(model types.Model) types.Model {
+	if model.ColumnCount != types.WideColumns && model.ActiveColumn != 0 {
+		return model
+	}
+	return services.SelectViewIndex(model, 0)
+}
+
+// NavigateEnd selects the last MCP of the grid or list
+func NavigateEnd(model types.Model) types.Model {
+	if model.ColumnCount != types.WideColumns && model.ActiveColumn != 0 {
+		return model
+	}
+	if count := services.GetViewport(model).ItemCount(); count > 0 {
+		return services.SelectViewIndex(model, count-1)
+	}
+	return model
+}
+
+// moveGridSelection moves the selection through the grid as displayed, so group headers
+// and short last rows of a group are stepped over
+func moveGridSelection(model types.Model, rows, cols int) types.Model {
+	layout := services.GetGridRows(services.GetMCPGroups(model), types.WideColumns)
+
+	// Use appropriate index based on search state
+	if model.SearchQuery != "" {
+		model.FilteredSelectedIndex = services.MoveGridSelection(layout, model.FilteredSelectedIndex, rows, cols)
+	} else {
+		model.SelectedItem = services.MoveGridSelection(layout, model.SelectedItem, rows, cols)
+	}
+	return model
+}
+
 // pasteToSearchQuery pastes clipboard content to the search query
 func pasteToSearchQuery(model types.Model) types.Model {
 	clipboardService := services.NewClipboardService(model.PlatformService)
@@ -350,7 +410,7 @@ func pasteToSearchQuery(model types.Model) types.Model {
 	if err != nil {
 		// Add user feedback for clipboard paste failure with enhanced error information
 		model.SuccessMessage = "Failed to paste from clipboard: " + err.Error()
-		model.SuccessTimer = 240 // Show error message for 4 seconds to allow reading detailed error
+		model.SuccessTimer = 2 * model.Settings.SuccessTicks() // Errors stay twice as long to allow reading the details
 		return model
 	}
 
@@ -369,7 +429,7 @@ func pasteToSearchQuery(model types.Model) types.Model {
 
 	// Add success feedback for successful paste operation
 	model.SuccessMessage = "Pasted from clipboard"
-	model.SuccessTimer = 120 // Show success message for 2 seconds
+	model.SuccessTimer = model.Settings.SuccessTicks()
 
 	return model
 }
@@ -422,12 +482,14 @@ func formatEnvironmentForDisplay(env map[string]string) string {
 		return ""
 	}
 
+	// One variable per line of the environment editor, in a stable order
 	var pairs []string
 	for key, value := range env {
 		pairs = append(pairs, key+"="+value)
 	}
+	sort.Strings(pairs)
 
-	return strings.Join(pairs, ",")
+	return strings.Join(pairs, "\n")
 }
 
 // ClaudeStatusMsg represents a Claude status update message (Epic 2 Story 1)
@@ -444,11 +506,20 @@ type ToggleResultMsg struct {
 	Retrying bool
 }
 
+// workspacePlatformService creates the platform service for the workspace selected by
+// MCP_HUB_WORKSPACE, falling back to the default workspace
+func workspacePlatformService() platform.PlatformService {
+	platformService := platform.NewPlatformServiceFactoryDefault().CreatePlatformService()
+	if workspace, err := services.OpenWorkspaceFromEnv(platformService); err == nil {
+		return workspace
+	}
+	return platformService
+}
+
 // RefreshClaudeStatusCmd creates a command to refresh Claude status (Epic 2 Story 1)
 func RefreshClaudeStatusCmd() tea.Cmd {
 	return func() tea.Msg {
-		platformService := platform.NewPlatformServiceFactoryDefault().CreatePlatformService()
-		claudeService := services.NewClaudeService(platformService)
+		claudeService := services.NewClaudeService(workspacePlatformService())
 		ctx := context.Background()
 		status := claudeService.RefreshClaudeStatus(ctx)
 		return ClaudeStatusMsg{Status: status}
@@ -458,8 +529,7 @@ func RefreshClaudeStatusCmd() tea.Cmd {
 // EnhancedToggleMCPCmd creates a command to perform enhanced MCP toggle (Epic 2 Story 2)
 func EnhancedToggleMCPCmd(mcpName string, activate bool, mcpConfig *types.MCPItem) tea.Cmd {
 	return func() tea.Msg {
-		platformService := platform.NewPlatformServiceFactoryDefault().CreatePlatformService()
-		claudeService := services.NewClaudeService(platformService)
+		claudeService := services.NewClaudeService(workspacePlatformService())
 		ctx := context.Background()
 
 		// Pass the MCP configuration for add operations
@@ -487,7 +557,7 @@ func EnhancedToggleMCPCmd(mcpName string, activate bool, mcpConfig *types.MCPIte
 
 // TimerCmd creates a command that sends timer tick messages every ~50ms
 func TimerCmd(timerID string) tea.Cmd {
-	return tea.Tick(50*time.Millisecond, func(time.Time) tea.Msg {
+	return tea.Tick(types.TimerTick, func(time.Time) tea.Msg {
 		return types.TimerTickMsg{ID: timerID}
 	})
 }
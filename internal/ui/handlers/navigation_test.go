@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"mcp-hub/internal/platform"
 	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/services"
 	"mcp-hub/internal/ui/types"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -204,6 +208,41 @@ func TestNavigationMethods(t *testing.T) {
 	})
 }
 
+func TestNavigatePageHomeEnd(t *testing.T) {
+	items := make([]types.MCPItem, 200)
+	for i := range items {
+		items[i] = types.MCPItem{Name: fmt.Sprintf("mcp-%03d", i), Type: "CMD"}
+	}
+
+	// 27 of the 50 rows fit on the screen
+	grid := testutil.NewTestModel().WithWindowSize(140, 40).WithMCPs(items).WithSelectedItem(1).Build()
+	grid = NavigatePage(grid, 1)
+	assert.Equal(t, 1+27*4, grid.SelectedItem)
+	grid = NavigatePage(grid, 1)
+	assert.Equal(t, 49*4+1, grid.SelectedItem) // Stops on the last row, in the same column
+	grid = NavigatePage(grid, -1)
+	assert.Equal(t, 22*4+1, grid.SelectedItem)
+	assert.Equal(t, 199, NavigateEnd(grid).SelectedItem)
+	assert.Equal(t, 0, NavigateHome(grid).SelectedItem)
+
+	list := testutil.NewTestModel().WithWindowSize(90, 40).WithMCPs(items).Build()
+	updated, _ := HandleMainNavigationKeys(list, "pgdown")
+	assert.Equal(t, 27, updated.SelectedItem)
+	updated, _ = HandleMainNavigationKeys(updated, "end")
+	assert.Equal(t, 199, updated.SelectedItem)
+	updated, _ = HandleMainNavigationKeys(updated, "home")
+	assert.Equal(t, 0, updated.SelectedItem)
+
+	list.ActiveColumn = 1
+	assert.Equal(t, 0, NavigateEnd(list).SelectedItem, "Only the MCP column should move")
+
+	search := grid
+	search.SearchQuery, search.State = "mcp-1", types.SearchActiveNavigation
+	updated, _ = HandleSearchNavigationKeys(search, "end")
+	assert.Equal(t, len(services.GetFilteredMCPs(search))-1, updated.FilteredSelectedIndex)
+	assert.Equal(t, grid.SelectedItem, updated.SelectedItem, "The search selects among its results")
+}
+
 func TestCommandGenerators(t *testing.T) {
 	t.Run("RefreshClaudeStatusCmd", func(t *testing.T) {
 		cmd := RefreshClaudeStatusCmd()
@@ -394,3 +433,28 @@ func TestSearchHandling(t *testing.T) {
 		assert.Equal(t, "", model.SearchQuery)
 	})
 }
+
+func TestHandleToggleServed(t *testing.T) {
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{{Name: "fs", Type: "CMD", Command: "fs-mcp", Active: true}}).
+		Build()
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+	model.PlatformService = mockPlatform
+
+	model, _ = HandleMainNavigationKeys(model, "S")
+	if !model.MCPItems[0].Served || !model.MCPItems[0].Active || !strings.Contains(model.SuccessMessage, "Serving fs") {
+		t.Errorf("S should serve the MCP without touching its Claude state, got %+v %q", model.MCPItems[0], model.SuccessMessage)
+	}
+	saved, err := services.LoadInventory(mockPlatform)
+	if err != nil || len(saved) != 1 || !saved[0].Served {
+		t.Errorf("The served state should be saved, got %+v, %v", saved, err)
+	}
+
+	model, _ = HandleMainNavigationKeys(model, "S")
+	if model.MCPItems[0].Served {
+		t.Error("Pressing S again should stop serving the MCP")
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Playground key constants
+const (
+	KeyShiftTab   = "shift+tab"
+	KeyToggleRaw  = "ctrl+r"
+	KeyEditArgs   = "e"
+	KeyBackToList = "b"
+)
+
+// ToolCallResultMsg carries the outcome of a playground tool call
+type ToolCallResultMsg struct {
+	Record types.ToolCallRecord
+}
+
+// CallToolCmd creates a command that connects to the server and calls one tool,
+// recording its output in logs
+func CallToolCmd(item types.MCPItem, tool string, arguments map[string]interface{}, logs *types.ServerLogStore) tea.Cmd {
+	return func() tea.Msg {
+		return ToolCallResultMsg{Record: services.CallTool(context.Background(), item, tool, arguments, logs)}
+	}
+}
+
+// handleOpenPlayground opens the tool-call playground for the selected MCP,
+// introspecting it first when its tools are not known yet
+func handleOpenPlayground(model types.Model) (types.Model, tea.Cmd) {
+	selectedMCP := services.GetSelectedMCP(model)
+	if selectedMCP == nil {
+		return model, nil
+	}
+
+	model.Playground = types.PlaygroundState{MCPName: selectedMCP.Name}
+	model.State = types.ModalActive
+	model.ActiveModal = types.PlaygroundModal
+	model = services.RecordMCPUse(model, selectedMCP.Name, time.Now())
+	// The recently used sort moves the MCP, keep it selected
+	model = services.SelectMCPByName(model, selectedMCP.Name)
+
+	if _, ok := services.GetIntrospection(model, *selectedMCP); ok || model.IntrospectingMCPs[selectedMCP.Name] {
+		return model, nil
+	}
+	return startIntrospection(model, []types.MCPItem{*selectedMCP})
+}
+
+// handlePlaygroundKeys handles keyboard input in the playground modal
+func handlePlaygroundKeys(model types.Model, key string) (types.Model, tea.Cmd) {
+	item := services.FindMCPByName(model, model.Playground.MCPName)
+	if item == nil {
+		return closePlayground(model), nil
+	}
+
+	switch model.Playground.Step {
+	case types.PlaygroundSelectTool:
+		return handlePlaygroundToolListKeys(model, *item, key), nil
+	case types.PlaygroundEditArguments:
+		return handlePlaygroundArgumentKeys(model, *item, key)
+	case types.PlaygroundShowResult:
+		return handlePlaygroundResultKeys(model, *item, key)
+	case types.PlaygroundCalling:
+		// Wait for the call to finish; ESC still closes the modal
+	}
+	return model, nil
+}
+
+// handlePlaygroundToolListKeys moves through the tool list and picks a tool
+func handlePlaygroundToolListKeys(model types.Model, item types.MCPItem, key string) types.Model {
+	tools := services.GetPlaygroundTools(model, item)
+	playground := &model.Playground
+
+	switch key {
+	case KeyUp, "k":
+		if playground.ToolIndex > 0 {
+			playground.ToolIndex--
+		}
+	case KeyDown, "j":
+		if playground.ToolIndex < len(tools)-1 {
+			playground.ToolIndex++
+		}
+	case KeyEnter:
+		if playground.ToolIndex >= len(tools) {
+			return model
+		}
+		fields, err := services.BuildArgumentFields(tools[playground.ToolIndex].InputSchema)
+		playground.Fields = fields
+		playground.ActiveField = 0
+		playground.RawJSON = "{}"
+		// Fall back to raw JSON when the schema cannot be turned into a form
+		playground.RawMode = err != nil
+		playground.Error = ""
+		if err != nil {
+			playground.Error = err.Error()
+		}
+		playground.Step = types.PlaygroundEditArguments
+	}
+	return model
+}
+
+// handlePlaygroundArgumentKeys edits arguments and submits the call
+func handlePlaygroundArgumentKeys(model types.Model, item types.MCPItem, key string) (types.Model, tea.Cmd) {
+	playground := &model.Playground
+
+	switch key {
+	case KeyEnter:
+		return submitPlaygroundCall(model, item)
+	case KeyToggleRaw:
+		if playground.RawMode {
+			playground.Fields = services.RawJSONToFields(playground.RawJSON, playground.Fields)
+		} else {
+			playground.RawJSON = services.FieldsToRawJSON(playground.Fields)
+		}
+		playground.RawMode = !playground.RawMode
+		playground.Error = ""
+	case KeyTab, KeyDown:
+		if !playground.RawMode && len(playground.Fields) > 0 {
+			playground.ActiveField = (playground.ActiveField + 1) % len(playground.Fields)
+		}
+	case KeyShiftTab, KeyUp:
+		if !playground.RawMode && len(playground.Fields) > 0 {
+			playground.ActiveField = (playground.ActiveField + len(playground.Fields) - 1) % len(playground.Fields)
+		}
+	case KeyBackspace:
+		editPlaygroundInput(playground, func(value string) string {
+			if len(value) == 0 {
+				return value
+			}
+			runes := []rune(value)
+			return string(runes[:len(runes)-1])
+		})
+	default:
+		if len([]rune(key)) == 1 {
+			editPlaygroundInput(playground, func(value string) string { return value + key })
+		}
+	}
+	return model, nil
+}
+
+// editPlaygroundInput applies an edit to the raw JSON or the focused form field
+func editPlaygroundInput(playground *types.PlaygroundState, edit func(string) string) {
+	if playground.RawMode {
+		playground.RawJSON = edit(playground.RawJSON)
+		return
+	}
+	if playground.ActiveField < len(playground.Fields) {
+		field := &playground.Fields[playground.ActiveField]
+		field.Value = edit(field.Value)
+	}
+}
+
+// submitPlaygroundCall validates the arguments and starts the tool call
+func submitPlaygroundCall(model types.Model, item types.MCPItem) (types.Model, tea.Cmd) {
+	playground := &model.Playground
+	tools := services.GetPlaygroundTools(model, item)
+	if playground.ToolIndex >= len(tools) {
+		return model, nil
+	}
+
+	var arguments map[string]interface{}
+	var err error
+	if playground.RawMode {
+		arguments, err = services.ParseRawArguments(playground.RawJSON)
+	} else {
+		arguments, err = services.BuildArguments(playground.Fields)
+	}
+	if err != nil {
+		playground.Error = err.Error()
+		return model, nil
+	}
+
+	playground.Error = ""
+	playground.Step = types.PlaygroundCalling
+	return model, CallToolCmd(item, tools[playground.ToolIndex].Name, arguments, model.ServerLogs)
+}
+
+// handlePlaygroundResultKeys re-runs the call or navigates back
+func handlePlaygroundResultKeys(model types.Model, item types.MCPItem, key string) (types.Model, tea.Cmd) {
+	switch key {
+	case KeyEnter:
+		return submitPlaygroundCall(model, item)
+	case KeyEditArgs:
+		model.Playground.Step = types.PlaygroundEditArguments
+	case KeyBackToList:
+		model.Playground.Step = types.PlaygroundSelectTool
+	}
+	return model, nil
+}
+
+// closePlayground returns to main navigation
+func closePlayground(model types.Model) types.Model {
+	model.State = types.MainNavigation
+	model.ActiveModal = types.NoModal
+	model.Playground = types.PlaygroundState{}
+	return model
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// newPlaygroundTestModel returns a model whose only MCP has been introspected
+func newPlaygroundTestModel() types.Model {
+	item := types.MCPItem{Name: "search", Type: "CMD", Command: "search-mcp"}
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{item}).
+		Build()
+	model.Introspection = map[string]types.IntrospectionResult{
+		"search": {
+			MCPName:    "search",
+			ConfigHash: services.ComputeConfigHash(item),
+			Tools: []types.ToolInfo{
+				{Name: "ping"},
+				{Name: "query", InputSchema: json.RawMessage(`{"properties": {"q": {"type": "string"}, "limit": {"type": "integer"}}, "required": ["q"]}`)},
+			},
+		},
+	}
+	return model
+}
+
+func typeKeys(model types.Model, text string) types.Model {
+	for _, r := range text {
+		model, _ = HandleModalKeys(model, string(r))
+	}
+	return model
+}
+
+func TestOpenPlayground(t *testing.T) {
+	model, cmd := HandleMainNavigationKeys(newPlaygroundTestModel(), "t")
+	if cmd != nil {
+		t.Error("Introspected MCP should open without fetching tools")
+	}
+	if model.State != types.ModalActive || model.ActiveModal != types.PlaygroundModal || model.Playground.MCPName != "search" {
+		t.Errorf("Playground should open for the selected MCP, got state=%v modal=%v", model.State, model.ActiveModal)
+	}
+
+	unfetched := newPlaygroundTestModel()
+	unfetched.Introspection = map[string]types.IntrospectionResult{}
+	unfetched, cmd = HandleMainNavigationKeys(unfetched, "t")
+	if cmd == nil || !unfetched.IntrospectingMCPs["search"] {
+		t.Error("Opening the playground should fetch unknown tools")
+	}
+}
+
+func TestPlaygroundFormFlow(t *testing.T) {
+	model, _ := handleOpenPlayground(newPlaygroundTestModel())
+
+	model, _ = HandleModalKeys(model, KeyDown)
+	model, _ = HandleModalKeys(model, KeyEnter)
+	if model.Playground.Step != types.PlaygroundEditArguments || len(model.Playground.Fields) != 2 {
+		t.Fatalf("Choosing a tool should build its form, got %+v", model.Playground)
+	}
+
+	// Submitting without the required field fails validation
+	model, cmd := HandleModalKeys(model, KeyEnter)
+	if cmd != nil || model.Playground.Error != "q is required" {
+		t.Errorf("Expected validation error, got %q", model.Playground.Error)
+	}
+
+	model = typeKeys(model, "mcp hub")
+	model, _ = HandleModalKeys(model, KeyTab)
+	model = typeKeys(model, "50")
+	model, _ = HandleModalKeys(model, KeyBackspace)
+	if model.Playground.Fields[0].Value != "mcp hub" || model.Playground.Fields[1].Value != "5" {
+		t.Errorf("Unexpected field values: %+v", model.Playground.Fields)
+	}
+
+	model, cmd = HandleModalKeys(model, KeyEnter)
+	if cmd == nil || model.Playground.Step != types.PlaygroundCalling || model.Playground.Error != "" {
+		t.Errorf("Valid arguments should start the call, got %+v", model.Playground)
+	}
+}
+
+func TestPlaygroundRawJSONMode(t *testing.T) {
+	model, _ := handleOpenPlayground(newPlaygroundTestModel())
+	model, _ = HandleModalKeys(model, KeyDown)
+	model, _ = HandleModalKeys(model, KeyEnter)
+	model = typeKeys(model, "abc")
+
+	model, _ = HandleModalKeys(model, KeyToggleRaw)
+	if !model.Playground.RawMode || model.Playground.RawJSON != `{"q":"abc"}` {
+		t.Fatalf("Switching to raw JSON should carry the form values, got %q", model.Playground.RawJSON)
+	}
+
+	model.Playground.RawJSON = `{"q": "xyz", "limit": 3}`
+	model, _ = HandleModalKeys(model, KeyToggleRaw)
+	if model.Playground.RawMode || model.Playground.Fields[0].Value != "xyz" || model.Playground.Fields[1].Value != "3" {
+		t.Errorf("Switching back should fill the form, got %+v", model.Playground.Fields)
+	}
+
+	model, _ = HandleModalKeys(model, KeyToggleRaw)
+	model.Playground.RawJSON = `{"q": `
+	model, cmd := HandleModalKeys(model, KeyEnter)
+	if cmd != nil || model.Playground.Error == "" {
+		t.Error("Invalid raw JSON should not be submitted")
+	}
+}
+
+func TestPlaygroundResultKeys(t *testing.T) {
+	model, _ := handleOpenPlayground(newPlaygroundTestModel())
+	model.Playground.Step = types.PlaygroundShowResult
+	model.Playground.LastCall = &types.ToolCallRecord{MCPName: "search", Tool: "ping"}
+
+	model, _ = HandleModalKeys(model, KeyEditArgs)
+	if model.Playground.Step != types.PlaygroundEditArguments {
+		t.Error("'e' should return to the arguments")
+	}
+
+	model.Playground.Step = types.PlaygroundShowResult
+	model, cmd := HandleModalKeys(model, KeyEnter)
+	if cmd == nil || model.Playground.Step != types.PlaygroundCalling {
+		t.Error("Enter should call the tool again")
+	}
+
+	model.Playground.Step = types.PlaygroundShowResult
+	model, _ = HandleModalKeys(model, KeyBackToList)
+	if model.Playground.Step != types.PlaygroundSelectTool {
+		t.Error("'b' should return to the tool list")
+	}
+
+	model, _ = HandleEscKey(model)
+	if model.State != types.MainNavigation || model.Playground.MCPName != "" {
+		t.Error("ESC should close the playground")
+	}
+}
@@ -30,6 +30,9 @@ func HandleEscKey(model types.Model) (types.Model, tea.Cmd) {
 		model.State = types.MainNavigation
 		return model, nil
 	case types.ModalActive:
+		if model.ActiveModal == types.HelpModal {
+			return closeHelp(model), nil
+		}
 		// Close modal and return to main navigation
 		model.State = types.MainNavigation
 		model.ActiveModal = types.NoModal
@@ -39,6 +42,12 @@ func HandleEscKey(model types.Model) (types.Model, tea.Cmd) {
 		// Clear form data and errors
 		model.FormData = types.FormData{}
 		model.FormErrors = make(map[string]string)
+		model.Playground = types.PlaygroundState{}
+		model.LogViewer = types.LogViewerState{}
+		model.ToolFilter = types.ToolFilterState{}
+		model.SettingsEditor = types.SettingsEditorState{}
+		model.WorkspaceSwitcher = types.WorkspaceSwitcherState{}
+		model.AppLog = types.AppLogViewerState{}
 		return model, nil
 	case types.MainNavigation:
 		// Clear search if active, otherwise exit application
@@ -74,12 +83,12 @@ func handleLoadingCancellation(model types.Model) (types.Model, tea.Cmd) {
 	case types.LoadingRefresh:
 		// For refresh cancellation, return to current state
 		model.SuccessMessage = "Refresh operation canceled"
-		model.SuccessTimer = 120
+		model.SuccessTimer = model.Settings.SuccessTicks()
 		return model, TimerCmd("success_timer")
 	case types.LoadingClaude:
 		// For Claude sync cancellation, return to current state
 		model.SuccessMessage = "Claude sync canceled"
-		model.SuccessTimer = 120
+		model.SuccessTimer = model.Settings.SuccessTicks()
 		return model, TimerCmd("success_timer")
 	default:
 		// Unknown loading type, just stop loading
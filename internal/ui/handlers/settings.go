@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"fmt"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyResetSetting restores the selected setting's default value
+const KeyResetSetting = "ctrl+d"
+
+// handleOpenSettings opens the settings editor with the values saved in settings.json
+func handleOpenSettings(model types.Model) types.Model {
+	settings, err := services.LoadSettings(model.PlatformService)
+	editor := types.SettingsEditorState{Inputs: make([]string, len(services.SettingFields))}
+	for i, field := range services.SettingFields {
+		editor.Inputs[i] = field.Get(settings).String()
+	}
+	if err != nil {
+		editor.Error = fmt.Sprintf("%v; showing the defaults", err)
+	}
+
+	model.SettingsEditor = editor
+	model.State = types.ModalActive
+	model.ActiveModal = types.SettingsModal
+	return model
+}
+
+// handleSettingsKeys handles keyboard input in the settings editor
+func handleSettingsKeys(model types.Model, key string) (types.Model, tea.Cmd) {
+	editor := &model.SettingsEditor
+	if len(editor.Inputs) == 0 {
+		return closeSettings(model), nil
+	}
+
+	input := &editor.Inputs[editor.Cursor]
+	switch {
+	case key == KeyEnter:
+		return saveSettings(model)
+	case key == KeyTab || key == KeyDown:
+		editor.Cursor = (editor.Cursor + 1) % len(editor.Inputs)
+	case key == KeyShiftTab || key == KeyUp:
+		editor.Cursor = (editor.Cursor + len(editor.Inputs) - 1) % len(editor.Inputs)
+	case key == KeyResetSetting:
+		*input = services.SettingFields[editor.Cursor].Get(types.DefaultSettings()).String()
+	case key == KeyBackspace:
+		*input = deleteLastChar(*input)
+	case len([]rune(key)) == 1:
+		*input += key
+	}
+	editor.Error = ""
+	return model, nil
+}
+
+// saveSettings parses the inputs, writes settings.json and applies the new values
+func saveSettings(model types.Model) (types.Model, tea.Cmd) {
+	editor := &model.SettingsEditor
+	settings := types.DefaultSettings()
+	for i, field := range services.SettingFields {
+		value, err := services.ParseSettingValue(field, editor.Inputs[i])
+		if err != nil {
+			editor.Cursor = i
+			editor.Error = fmt.Sprintf("%s: %v", field.Label, err)
+			return model, nil
+		}
+		field.Set(&settings, value)
+	}
+	if err := services.SaveSettings(model.PlatformService, settings); err != nil {
+		editor.Error = err.Error()
+		return model, nil
+	}
+
+	model = closeSettings(model)
+	model.Settings = settings
+	model.SuccessMessage = "Settings saved"
+	model.SuccessTimer = model.Settings.SuccessTicks()
+	return model, TimerCmd("success_timer")
+}
+
+// closeSettings returns to main navigation
+func closeSettings(model types.Model) types.Model {
+	model.State = types.MainNavigation
+	model.ActiveModal = types.NoModal
+	model.SettingsEditor = types.SettingsEditorState{}
+	return model
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// newSettingsTestModel returns a model whose settings file lives in a temporary directory
+func newSettingsTestModel(t *testing.T) types.Model {
+	t.Helper()
+	model := testutil.NewTestModel().WithWindowSize(120, 40).Build()
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+	model.PlatformService = mockPlatform
+	return model
+}
+
+func TestOpenSettings(t *testing.T) {
+	model := newSettingsTestModel(t)
+
+	model, _ = HandleMainNavigationKeys(model, ",")
+	if model.ActiveModal != types.SettingsModal || len(model.SettingsEditor.Inputs) != len(services.SettingFields) {
+		t.Fatalf("Settings editor should open with one input per setting, got %+v", model.SettingsEditor)
+	}
+	if model.SettingsEditor.Inputs[0] != "10s" || model.SettingsEditor.Error != "" {
+		t.Errorf("Inputs should show the defaults, got %+v", model.SettingsEditor)
+	}
+
+	model, _ = HandleEscKey(model)
+	if model.State != types.MainNavigation || model.SettingsEditor.Inputs != nil {
+		t.Error("ESC should close the settings editor")
+	}
+}
+
+func TestSettingsEditAndSave(t *testing.T) {
+	model := newSettingsTestModel(t)
+	model, _ = HandleMainNavigationKeys(model, ",")
+
+	model, _ = HandleModalKeys(model, KeyBackspace)
+	model, _ = HandleModalKeys(model, KeyBackspace)
+	model, _ = HandleModalKeys(model, KeyBackspace)
+	model = typeKeys(model, "30s")
+	model, _ = HandleModalKeys(model, KeyUp)
+	if model.SettingsEditor.Cursor != len(services.SettingFields)-1 {
+		t.Fatalf("Up from the first setting should wrap to the last, got %d", model.SettingsEditor.Cursor)
+	}
+	model, _ = HandleModalKeys(model, KeyBackspace)
+	model, _ = HandleModalKeys(model, KeyBackspace)
+	model = typeKeys(model, "1s")
+
+	model, cmd := HandleModalKeys(model, KeyEnter)
+	if model.ActiveModal != types.NoModal || cmd == nil || model.SuccessMessage != "Settings saved" {
+		t.Fatalf("Enter should save and close the editor, got %+v", model.SettingsEditor)
+	}
+	if model.Settings.ClaudeTimeout != 30*time.Second || model.Settings.SuccessMessageDuration != time.Second || model.SuccessTimer != 20 {
+		t.Errorf("New settings should apply immediately, got %+v timer=%d", model.Settings, model.SuccessTimer)
+	}
+	saved, err := services.LoadSettings(model.PlatformService)
+	if err != nil || saved != model.Settings {
+		t.Errorf("Settings should be saved, got %+v, %v", saved, err)
+	}
+}
+
+func TestSettingsValidationAndReset(t *testing.T) {
+	model := newSettingsTestModel(t)
+	model, _ = HandleMainNavigationKeys(model, ",")
+
+	model, _ = HandleModalKeys(model, KeyTab)
+	model = typeKeys(model, "x")
+	model, _ = HandleModalKeys(model, KeyTab)
+	model, _ = HandleModalKeys(model, KeyEnter)
+	if model.ActiveModal != types.SettingsModal || model.SettingsEditor.Cursor != 1 || !strings.Contains(model.SettingsEditor.Error, "invalid duration") {
+		t.Fatalf("Invalid input should keep the editor open on that field, got %+v", model.SettingsEditor)
+	}
+
+	model, _ = HandleModalKeys(model, KeyResetSetting)
+	if model.SettingsEditor.Inputs[1] != "8s" || model.SettingsEditor.Error != "" {
+		t.Errorf("Ctrl+D should restore the default, got %+v", model.SettingsEditor)
+	}
+
+	model.SettingsEditor.Inputs[1] = "19s"
+	model, _ = HandleModalKeys(model, KeyEnter)
+	if model.ActiveModal != types.SettingsModal || !strings.Contains(model.SettingsEditor.Error, "toggle_retry_window") {
+		t.Errorf("Cross-field validation should block saving, got %+v", model.SettingsEditor)
+	}
+}
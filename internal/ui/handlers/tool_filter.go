@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"fmt"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleOpenToolFilter opens the tool filter editor for the selected MCP,
+// introspecting it first when its tools are not known yet
+func handleOpenToolFilter(model types.Model) (types.Model, tea.Cmd) {
+	selectedMCP := services.GetSelectedMCP(model)
+	if selectedMCP == nil {
+		return model, nil
+	}
+
+	model.ToolFilter = types.ToolFilterState{
+		MCPName:    selectedMCP.Name,
+		AllowInput: services.FormatToolPatterns(selectedMCP.ToolAllow),
+		DenyInput:  services.FormatToolPatterns(selectedMCP.ToolDeny),
+	}
+	model.State = types.ModalActive
+	model.ActiveModal = types.ToolFilterModal
+
+	if _, ok := services.GetIntrospection(model, *selectedMCP); ok || model.IntrospectingMCPs[selectedMCP.Name] {
+		return model, nil
+	}
+	return startIntrospection(model, []types.MCPItem{*selectedMCP})
+}
+
+// handleToolFilterKeys handles keyboard input in the tool filter editor
+func handleToolFilterKeys(model types.Model, key string) (types.Model, tea.Cmd) {
+	item := services.FindMCPByName(model, model.ToolFilter.MCPName)
+	if item == nil {
+		return closeToolFilter(model), nil
+	}
+
+	filter := &model.ToolFilter
+	switch key {
+	case KeyEnter:
+		return saveToolFilter(model, *item)
+	case KeyTab:
+		filter.Focus = (filter.Focus + 1) % 3
+		return model, nil
+	case KeyShiftTab:
+		filter.Focus = (filter.Focus + 2) % 3
+		return model, nil
+	}
+
+	if filter.Focus == types.ToolFilterFocusList {
+		return handleToolFilterListKeys(model, *item, key), nil
+	}
+
+	input := &filter.AllowInput
+	if filter.Focus == types.ToolFilterFocusDeny {
+		input = &filter.DenyInput
+	}
+	switch {
+	case key == KeyBackspace:
+		*input = deleteLastChar(*input)
+	case len([]rune(key)) == 1:
+		*input += key
+	case key == "space":
+		*input += " "
+	}
+	filter.Error = ""
+	return model, nil
+}
+
+// handleToolFilterListKeys moves through the introspected tools and toggles the selected one
+func handleToolFilterListKeys(model types.Model, item types.MCPItem, key string) types.Model {
+	filter := &model.ToolFilter
+	tools := services.GetPlaygroundTools(model, item)
+
+	switch key {
+	case KeyUp, "k":
+		if filter.Cursor > 0 {
+			filter.Cursor--
+		}
+	case KeyDown, "j":
+		if filter.Cursor < len(tools)-1 {
+			filter.Cursor++
+		}
+	case " ", "space":
+		if filter.Cursor >= len(tools) {
+			return model
+		}
+		allow, deny, err := services.ToggleToolInPatterns(
+			services.ParseToolPatterns(filter.AllowInput),
+			services.ParseToolPatterns(filter.DenyInput),
+			tools[filter.Cursor].Name,
+		)
+		if err != nil {
+			filter.Error = err.Error()
+			return model
+		}
+		filter.AllowInput = services.FormatToolPatterns(allow)
+		filter.DenyInput = services.FormatToolPatterns(deny)
+		filter.Error = ""
+	}
+	return model
+}
+
+// saveToolFilter validates the patterns, stores them in the inventory and, when the
+// tools are known, mirrors the hidden ones into Claude's permission deny list
+func saveToolFilter(model types.Model, item types.MCPItem) (types.Model, tea.Cmd) {
+	filter := &model.ToolFilter
+	allow := services.ParseToolPatterns(filter.AllowInput)
+	deny := services.ParseToolPatterns(filter.DenyInput)
+	if err := services.ValidateToolPatterns(append(append([]string(nil), allow...), deny...)); err != nil {
+		filter.Error = err.Error()
+		return model, nil
+	}
+
+	item.ToolAllow = allow
+	item.ToolDeny = deny
+	items := make([]types.MCPItem, len(model.MCPItems))
+	copy(items, model.MCPItems)
+	for i := range items {
+		if items[i].Name == item.Name {
+			items[i] = item
+		}
+	}
+	if err := services.SaveInventory(items, model.PlatformService); err != nil {
+		filter.Error = fmt.Sprintf("Failed to save %s: %v", item.Name, err)
+		return model, nil
+	}
+	model.MCPItems = items
+
+	message := fmt.Sprintf("Tool filter saved for %s", item.Name)
+	rules, known, err := services.SyncClaudeToolPermissions(model.PlatformService, item, model.Introspection)
+	switch {
+	case err != nil:
+		message = fmt.Sprintf("%s, but Claude permissions were not updated: %v", message, err)
+	case known:
+		message = fmt.Sprintf("%s • %d tools denied in Claude settings", message, len(rules))
+	}
+
+	model = closeToolFilter(model)
+	model.SuccessMessage = message
+	model.SuccessTimer = model.Settings.SuccessTicks()
+	return model, TimerCmd("success_timer")
+}
+
+// closeToolFilter returns to main navigation
+func closeToolFilter(model types.Model) types.Model {
+	model.State = types.MainNavigation
+	model.ActiveModal = types.NoModal
+	model.ToolFilter = types.ToolFilterState{}
+	return model
+}
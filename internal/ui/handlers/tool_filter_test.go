@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// newToolFilterTestModel returns a model with one introspected MCP whose settings
+// and Claude permissions are written to temporary directories
+func newToolFilterTestModel(t *testing.T) types.Model {
+	t.Helper()
+	item := types.MCPItem{Name: "fs", Type: "CMD", Command: "fs-mcp"}
+	model := testutil.NewTestModel().
+		WithWindowSize(120, 40).
+		WithMCPs([]types.MCPItem{item}).
+		Build()
+
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+	mockPlatform.SetHomeDirectory(tempDir + "/home")
+	model.PlatformService = mockPlatform
+
+	model.Introspection = map[string]types.IntrospectionResult{
+		"fs": {
+			MCPName:    "fs",
+			ConfigHash: services.ComputeConfigHash(item),
+			Tools:      []types.ToolInfo{{Name: "read_file"}, {Name: "write_file"}, {Name: "delete_file"}},
+		},
+	}
+	return model
+}
+
+func TestOpenToolFilter(t *testing.T) {
+	model := newToolFilterTestModel(t)
+	model.MCPItems[0].ToolDeny = []string{"delete_*", "exec"}
+
+	model, cmd := HandleMainNavigationKeys(model, "f")
+	if cmd != nil {
+		t.Error("Introspected MCP should open without fetching tools")
+	}
+	if model.ActiveModal != types.ToolFilterModal || model.ToolFilter.MCPName != "fs" || model.ToolFilter.DenyInput != "delete_*, exec" {
+		t.Errorf("Tool filter should open with the saved patterns, got %+v", model.ToolFilter)
+	}
+
+	unfetched := newToolFilterTestModel(t)
+	unfetched.Introspection = map[string]types.IntrospectionResult{}
+	unfetched, cmd = HandleMainNavigationKeys(unfetched, "f")
+	if cmd == nil || !unfetched.IntrospectingMCPs["fs"] {
+		t.Error("Opening the tool filter should fetch unknown tools")
+	}
+
+	model, _ = HandleEscKey(model)
+	if model.ActiveModal != types.NoModal || model.ToolFilter.MCPName != "" {
+		t.Error("ESC should close the tool filter and discard edits")
+	}
+}
+
+func TestToolFilterToggleAndSave(t *testing.T) {
+	model, _ := handleOpenToolFilter(newToolFilterTestModel(t))
+
+	model, _ = HandleModalKeys(model, KeyDown)
+	model, _ = HandleModalKeys(model, KeyDown)
+	model, _ = HandleModalKeys(model, " ")
+	if model.ToolFilter.DenyInput != "delete_file" {
+		t.Fatalf("Space should deny the selected tool, got %q", model.ToolFilter.DenyInput)
+	}
+
+	model, cmd := HandleModalKeys(model, KeyEnter)
+	if cmd == nil || model.ActiveModal != types.NoModal {
+		t.Fatal("Enter should save and close the tool filter")
+	}
+	if !reflect.DeepEqual(model.MCPItems[0].ToolDeny, []string{"delete_file"}) {
+		t.Errorf("Deny patterns should be stored on the item, got %v", model.MCPItems[0].ToolDeny)
+	}
+	if !strings.Contains(model.SuccessMessage, "1 tools denied") {
+		t.Errorf("Unexpected success message %q", model.SuccessMessage)
+	}
+
+	saved, err := services.LoadInventory(model.PlatformService)
+	if err != nil || len(saved) != 1 || !reflect.DeepEqual(saved[0].ToolDeny, []string{"delete_file"}) {
+		t.Errorf("Tool filter should be persisted, got %+v, %v", saved, err)
+	}
+
+	data, err := os.ReadFile(services.GetClaudeSettingsPath(model.PlatformService))
+	if err != nil || !strings.Contains(string(data), "mcp__fs__delete_file") {
+		t.Errorf("Claude permissions should deny the hidden tool, got %s, %v", data, err)
+	}
+}
+
+func TestToolFilterPatternInputs(t *testing.T) {
+	model, _ := handleOpenToolFilter(newToolFilterTestModel(t))
+
+	model, _ = HandleModalKeys(model, KeyTab)
+	if model.ToolFilter.Focus != types.ToolFilterFocusAllow {
+		t.Fatalf("Tab should focus the allow input, got %v", model.ToolFilter.Focus)
+	}
+	model = typeKeys(model, "read_[")
+	model, _ = HandleModalKeys(model, KeyEnter)
+	if model.ActiveModal != types.ToolFilterModal || !strings.Contains(model.ToolFilter.Error, "invalid pattern") {
+		t.Fatalf("Malformed pattern should keep the editor open with an error, got %q", model.ToolFilter.Error)
+	}
+
+	model, _ = HandleModalKeys(model, KeyBackspace)
+	model = typeKeys(model, "*")
+	if model.ToolFilter.Error != "" {
+		t.Error("Editing should clear the error")
+	}
+	model, _ = HandleModalKeys(model, KeyEnter)
+	if !reflect.DeepEqual(model.MCPItems[0].ToolAllow, []string{"read_*"}) {
+		t.Errorf("Allow patterns should be saved, got %v", model.MCPItems[0].ToolAllow)
+	}
+}
+
+func TestUpdateMCPInInventory_PreservesToolFilter(t *testing.T) {
+	model := newToolFilterTestModel(t)
+	model.MCPItems[0].ToolDeny = []string{"exec"}
+	model.EditMCPName = "fs"
+
+	model, _ = updateMCPInInventory(model, types.MCPItem{Name: "fs", Type: "CMD", Command: "fs-mcp-v2"})
+	if !reflect.DeepEqual(model.MCPItems[0].ToolDeny, []string{"exec"}) {
+		t.Errorf("Editing the configuration should keep the tool filter, got %v", model.MCPItems[0].ToolDeny)
+	}
+}
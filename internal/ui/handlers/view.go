@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"fmt"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleCycleSort switches to the next sort mode and saves it
+func handleCycleSort(model types.Model) (types.Model, tea.Cmd) {
+	view := model.Grid
+	view.Sort = services.NextSortMode(view.Sort)
+	return applyGridView(model, view, "Sorted by "+services.SortModeLabel(view.Sort))
+}
+
+// handleCycleGroup switches to the next group mode and saves it
+func handleCycleGroup(model types.Model) (types.Model, tea.Cmd) {
+	view := model.Grid
+	view.Group = services.NextGroupMode(view.Group)
+	message := "Grouped by " + services.GroupModeLabel(view.Group)
+	if view.Group == types.GroupNone {
+		message = "Grouping off"
+	}
+	return applyGridView(model, view, message)
+}
+
+// applyGridView shows the grid in the new order, keeping the selected MCP selected,
+// and saves the modes to view.json
+func applyGridView(model types.Model, view types.GridViewState, message string) (types.Model, tea.Cmd) {
+	selected := services.GetSelectedMCP(model)
+	model.Grid = view
+	if selected != nil {
+		model = services.SelectMCPByName(model, selected.Name)
+	}
+
+	if err := services.SaveGridView(model.PlatformService, view); err != nil {
+		message = fmt.Sprintf("%s, but failed to save it: %v", message, err)
+	}
+	model.SuccessMessage = message
+	model.SuccessTimer = model.Settings.SuccessTicks()
+	return model, TimerCmd("success_timer")
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"testing"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+func newViewTestModel(t *testing.T) types.Model {
+	t.Helper()
+	model := newSettingsTestModel(t)
+	model.MCPItems = []types.MCPItem{
+		{Name: "zeta", Type: "SSE", Active: true},
+		{Name: "alpha", Type: "CMD"},
+		{Name: "mid", Type: "CMD", Active: true},
+	}
+	return model
+}
+
+func TestCycleSort_ReordersAndPersists(t *testing.T) {
+	model := newViewTestModel(t)
+	model.SelectedItem = 0 // zeta
+
+	model, cmd := HandleMainNavigationKeys(model, "s")
+	if model.Grid.Sort != types.SortName || cmd == nil {
+		t.Fatalf("s should switch to the name sort, got %s", model.Grid.Sort)
+	}
+	if model.SuccessMessage != "Sorted by name" {
+		t.Errorf("SuccessMessage = %q", model.SuccessMessage)
+	}
+	if selected := services.GetSelectedMCP(model); selected == nil || selected.Name != "zeta" {
+		t.Errorf("The selected MCP should stay selected after sorting, got %v", selected)
+	}
+	if model.SelectedItem != 2 {
+		t.Errorf("zeta should be last when sorted by name, SelectedItem = %d", model.SelectedItem)
+	}
+
+	state, err := services.LoadGridView(model.PlatformService)
+	if err != nil || state.Sort != types.SortName {
+		t.Errorf("The sort mode should be saved, got %+v, %v", state, err)
+	}
+}
+
+func TestCycleGroup(t *testing.T) {
+	model := newViewTestModel(t)
+
+	model, _ = HandleMainNavigationKeys(model, "g")
+	if model.Grid.Group != types.GroupType || model.SuccessMessage != "Grouped by type" {
+		t.Errorf("g should group by type, got %s, %q", model.Grid.Group, model.SuccessMessage)
+	}
+	for range types.GroupModes[1:] {
+		model, _ = HandleMainNavigationKeys(model, "g")
+	}
+	if model.Grid.Group != types.GroupNone || model.SuccessMessage != "Grouping off" {
+		t.Errorf("g should cycle back to no grouping, got %s, %q", model.Grid.Group, model.SuccessMessage)
+	}
+}
+
+func TestCycleSort_KeepsSearchSelection(t *testing.T) {
+	model := newViewTestModel(t)
+	model.SearchQuery = "a"
+	model.FilteredSelectedIndex = 1
+
+	before := services.GetSelectedMCP(model)
+	model, _ = HandleMainNavigationKeys(model, "g")
+	if after := services.GetSelectedMCP(model); before == nil || after == nil || after.Name != before.Name {
+		t.Errorf("Grouping should keep the selected search result, got %v then %v", before, after)
+	}
+}
+
+func TestNavigation_FollowsGroupedLayout(t *testing.T) {
+	model := newViewTestModel(t)
+	model.Width, model.ColumnCount = 120, 4
+	model.Grid.Group = types.GroupType // CMD: alpha, mid; SSE: zeta
+
+	model = NavigateDown(model)
+	if selected := services.GetSelectedMCP(model); selected == nil || selected.Name != "zeta" {
+		t.Errorf("Down should step over the SSE header to zeta, got %v", selected)
+	}
+	model = NavigateRight(model)
+	if model.SelectedItem != 2 {
+		t.Errorf("zeta is alone in its row; right should not move, got %d", model.SelectedItem)
+	}
+	model = NavigateUp(model)
+	if selected := services.GetSelectedMCP(model); selected == nil || selected.Name != "alpha" {
+		t.Errorf("Up should return to the CMD row, got %v", selected)
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"fmt"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyNewWorkspace starts typing the name of a new workspace in the switcher
+const KeyNewWorkspace = "n"
+
+// WorkspaceSwitchMsg asks the application to reload itself for another workspace
+type WorkspaceSwitchMsg struct {
+	Name string
+}
+
+// WorkspaceSwitchCmd returns a command that switches to the named workspace
+func WorkspaceSwitchCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		return WorkspaceSwitchMsg{Name: name}
+	}
+}
+
+// handleOpenWorkspaces opens the workspace switcher with the current workspace selected
+func handleOpenWorkspaces(model types.Model) types.Model {
+	workspaces, err := services.ListWorkspaces(model.PlatformService)
+	switcher := types.WorkspaceSwitcherState{Workspaces: workspaces}
+	for i, name := range workspaces {
+		if name == model.Workspace {
+			switcher.Cursor = i
+		}
+	}
+	if err != nil {
+		switcher.Error = err.Error()
+	}
+
+	model.WorkspaceSwitcher = switcher
+	model.State = types.ModalActive
+	model.ActiveModal = types.WorkspaceModal
+	return model
+}
+
+// handleWorkspaceKeys handles keyboard input in the workspace switcher
+func handleWorkspaceKeys(model types.Model, key string) (types.Model, tea.Cmd) {
+	switcher := &model.WorkspaceSwitcher
+	if switcher.Creating {
+		return handleNewWorkspaceKeys(model, key)
+	}
+
+	switch key {
+	case KeyEnter:
+		if len(switcher.Workspaces) == 0 {
+			return closeWorkspaces(model), nil
+		}
+		return switchWorkspace(model, switcher.Workspaces[switcher.Cursor])
+	case KeyDown, "j":
+		if len(switcher.Workspaces) > 0 {
+			switcher.Cursor = (switcher.Cursor + 1) % len(switcher.Workspaces)
+		}
+	case KeyUp, "k":
+		if len(switcher.Workspaces) > 0 {
+			switcher.Cursor = (switcher.Cursor + len(switcher.Workspaces) - 1) % len(switcher.Workspaces)
+		}
+	case KeyNewWorkspace:
+		switcher.Creating = true
+		switcher.NewName = ""
+	}
+	switcher.Error = ""
+	return model, nil
+}
+
+// handleNewWorkspaceKeys edits the name of a new workspace; Enter switches to it
+func handleNewWorkspaceKeys(model types.Model, key string) (types.Model, tea.Cmd) {
+	switcher := &model.WorkspaceSwitcher
+	switch {
+	case key == KeyEnter:
+		if err := services.ValidateWorkspaceName(switcher.NewName); err != nil {
+			switcher.Error = err.Error()
+			return model, nil
+		}
+		return switchWorkspace(model, switcher.NewName)
+	case key == KeyBackspace:
+		switcher.NewName = deleteLastChar(switcher.NewName)
+	case len([]rune(key)) == 1:
+		switcher.NewName += key
+	}
+	switcher.Error = ""
+	return model, nil
+}
+
+// switchWorkspace closes the switcher and asks for the named workspace to be loaded.
+// Choosing the current workspace just closes the switcher.
+func switchWorkspace(model types.Model, name string) (types.Model, tea.Cmd) {
+	current := model.Workspace
+	model = closeWorkspaces(model)
+	if name == current {
+		model.SuccessMessage = fmt.Sprintf("Already in workspace %s", name)
+		model.SuccessTimer = model.Settings.SuccessTicks()
+		return model, TimerCmd("success_timer")
+	}
+	return model, WorkspaceSwitchCmd(name)
+}
+
+// closeWorkspaces returns to main navigation
+func closeWorkspaces(model types.Model) types.Model {
+	model.State = types.MainNavigation
+	model.ActiveModal = types.NoModal
+	model.WorkspaceSwitcher = types.WorkspaceSwitcherState{}
+	return model
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+)
+
+// newWorkspaceTestModel returns a model in the default workspace of a temporary config directory
+func newWorkspaceTestModel(t *testing.T, named ...string) types.Model {
+	t.Helper()
+	t.Setenv(services.ConfigDirEnv, "")
+	model := newSettingsTestModel(t)
+	model.Workspace = services.DefaultWorkspace
+	for _, name := range named {
+		dir := filepath.Join(model.PlatformService.GetConfigPath(), "workspaces", name)
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return model
+}
+
+func TestOpenWorkspaces(t *testing.T) {
+	model := newWorkspaceTestModel(t, "work", "client")
+	model.Workspace = "work"
+
+	model, _ = HandleMainNavigationKeys(model, "W")
+	switcher := model.WorkspaceSwitcher
+	if model.ActiveModal != types.WorkspaceModal || !reflect.DeepEqual(switcher.Workspaces, []string{"default", "client", "work"}) {
+		t.Fatalf("Switcher should list the workspaces, got %+v", switcher)
+	}
+	if switcher.Cursor != 2 {
+		t.Errorf("Current workspace should be selected, got cursor %d", switcher.Cursor)
+	}
+
+	model, _ = HandleEscKey(model)
+	if model.State != types.MainNavigation || model.WorkspaceSwitcher.Workspaces != nil {
+		t.Error("ESC should close the workspace switcher")
+	}
+}
+
+func TestWorkspaceSwitcher_SwitchesToSelection(t *testing.T) {
+	model := newWorkspaceTestModel(t, "work")
+	model, _ = HandleMainNavigationKeys(model, "W")
+
+	model, _ = HandleModalKeys(model, KeyUp)
+	if model.WorkspaceSwitcher.Cursor != 1 {
+		t.Fatalf("Up should wrap to the last workspace, got %d", model.WorkspaceSwitcher.Cursor)
+	}
+	model, _ = HandleModalKeys(model, "j")
+	model, _ = HandleModalKeys(model, "k")
+
+	model, cmd := HandleModalKeys(model, KeyEnter)
+	if model.ActiveModal != types.NoModal || cmd == nil {
+		t.Fatal("Enter should close the switcher and request the switch")
+	}
+	if msg, ok := cmd().(WorkspaceSwitchMsg); !ok || msg.Name != "work" {
+		t.Errorf("Expected a switch to work, got %#v", cmd())
+	}
+}
+
+func TestWorkspaceSwitcher_CurrentWorkspaceOnlyCloses(t *testing.T) {
+	model := newWorkspaceTestModel(t)
+	model, _ = HandleMainNavigationKeys(model, "W")
+
+	model, cmd := HandleModalKeys(model, KeyEnter)
+	if model.ActiveModal != types.NoModal || model.SuccessMessage != "Already in workspace default" || cmd == nil {
+		t.Errorf("Choosing the current workspace should just close, got %q", model.SuccessMessage)
+	}
+	if _, ok := cmd().(WorkspaceSwitchMsg); ok {
+		t.Error("Choosing the current workspace should not reload")
+	}
+}
+
+func TestWorkspaceSwitcher_NewWorkspace(t *testing.T) {
+	model := newWorkspaceTestModel(t)
+	model, _ = HandleMainNavigationKeys(model, "W")
+	model, _ = HandleModalKeys(model, KeyNewWorkspace)
+	if !model.WorkspaceSwitcher.Creating {
+		t.Fatal("n should start typing a new workspace name")
+	}
+
+	model = typeKeys(model, "bad name")
+	model, cmd := HandleModalKeys(model, KeyEnter)
+	if cmd != nil || model.WorkspaceSwitcher.Error == "" {
+		t.Fatalf("Invalid names should be reported, got %+v", model.WorkspaceSwitcher)
+	}
+
+	for range "name" {
+		model, _ = HandleModalKeys(model, KeyBackspace)
+	}
+	model, _ = HandleModalKeys(model, KeyBackspace)
+	model = typeKeys(model, "-2")
+	if model.WorkspaceSwitcher.NewName != "bad-2" || model.WorkspaceSwitcher.Error != "" {
+		t.Fatalf("Expected name bad-2, got %+v", model.WorkspaceSwitcher)
+	}
+
+	_, cmd = HandleModalKeys(model, KeyEnter)
+	if msg, ok := cmd().(WorkspaceSwitchMsg); !ok || msg.Name != "bad-2" {
+		t.Errorf("Expected a switch to the new workspace, got %#v", cmd())
+	}
+}
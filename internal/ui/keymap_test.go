@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"os"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModel_LoadsKeyBindings(t *testing.T) {
+	t.Setenv(services.ConfigDirEnv, "")
+	t.Setenv(services.WorkspaceEnv, "")
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+	if err := os.MkdirAll(mockPlatform.GetConfigPath(), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	writeSettings := func(data string) {
+		if err := os.WriteFile(services.GetSettingsPath(mockPlatform), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeSettings(`{"keys": {"help": "f1"}}`)
+	model := NewModelForPlatform(mockPlatform)
+	model.State = types.MainNavigation
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyF1})
+	if updated.(Model).ActiveModal != types.HelpModal || model.KeysError != "" {
+		t.Errorf("The key bound in settings.json should open the help overlay, got modal %v, error %q", updated.(Model).ActiveModal, model.KeysError)
+	}
+
+	writeSettings(`{"keys": {"help": "a"}}`)
+	model = NewModelForPlatform(mockPlatform)
+	if model.KeysError == "" || !model.Keys.Matches(types.ActionHelp, "?") {
+		t.Errorf("Conflicting bindings should keep the default keys and report why, got %q", model.KeysError)
+	}
+}
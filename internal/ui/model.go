@@ -4,6 +4,7 @@ package ui
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"mcp-hub/internal/platform"
@@ -12,6 +13,8 @@ import (
 	"mcp-hub/internal/ui/types"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // Model is a wrapper around the types.Model to provide UI-specific methods
@@ -24,7 +27,18 @@ type Model struct {
 func NewModel() Model {
 	// Create platform service
 	platformService := platform.NewPlatformServiceFactoryDefault().CreatePlatformService()
-	
+
+	// Use the workspace selected by MCP_HUB_WORKSPACE; main rejects invalid names before
+	// the TUI starts, so an error here only happens in tests and keeps the default
+	if workspace, err := services.OpenWorkspaceFromEnv(platformService); err == nil {
+		platformService = workspace
+	}
+	return NewModelForPlatform(platformService)
+}
+
+// NewModelForPlatform creates a new application model with the inventory and settings
+// of the given platform service's config directory
+func NewModelForPlatform(platformService platform.PlatformService) Model {
 	// Try to load inventory from storage
 	mcpItems, err := services.LoadInventory(platformService)
 	var model Model
@@ -57,6 +71,28 @@ func NewModel() Model {
 		}
 	}
 
+	// Load the user settings; an invalid file keeps the defaults and is reported by the settings editor
+	model.Settings, _ = services.LoadSettings(platformService)
+	// Load the grid's sort and group modes; an unreadable file keeps the defaults
+	model.Grid, _ = services.LoadGridView(platformService)
+	// Load the key bindings; invalid ones keep the defaults and are reported in the help overlay
+	var keysErr error
+	if model.Keys, keysErr = services.LoadKeyMap(platformService); keysErr != nil {
+		model.KeysError = keysErr.Error()
+	}
+	// Load the theme; NO_COLOR and terminals without colors get the mono theme
+	model.Theme, _ = services.LoadTheme(platformService, services.ThemeTerminal{
+		NoColor:        os.Getenv("NO_COLOR") != "" || lipgloss.ColorProfile() == termenv.Ascii,
+		DarkBackground: lipgloss.HasDarkBackground,
+	})
+	model.Workspace = services.WorkspaceName(platformService)
+
+	// Load cached introspection results that still match the inventory
+	model.Introspection = services.LoadIntrospectionCache(platformService, model.MCPItems)
+
+	// Capture spawned servers' output, persisted under the log directory
+	model.ServerLogs = services.NewPersistentServerLogStore(platformService)
+
 	// Initialize project context
 	model.Model = services.UpdateProjectContext(model.Model)
 
@@ -73,13 +109,27 @@ func (m Model) Init() tea.Cmd {
 		handlers.StartupLoadingCmd(),
 		handlers.StartupLoadingTimerCmd(0),
 		handlers.LoadingSpinnerCmd(types.LoadingStartup),
-		ProjectContextCheckCmd(),        // Start project context monitoring
-		DelayedClaudeStatusRefreshCmd(), // Auto-detect Claude CLI after UI loads
+		ProjectContextCheckCmd(m.Settings.ProjectCheckInterval),        // Start project context monitoring
+		DelayedClaudeStatusRefreshCmd(m.Settings.ClaudeDetectionDelay), // Auto-detect Claude CLI after UI loads
 	)
 }
 
 // Update handles messages and updates the model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.update(msg)
+	switch msg.(type) {
+	case tea.WindowSizeMsg, tea.KeyMsg, tea.MouseMsg:
+		// Scroll the grid or list after the selection or window size may have changed
+		if next, ok := updated.(Model); ok {
+			next.Model = services.ScrollToSelection(next.Model)
+			return next, cmd
+		}
+	}
+	return updated, cmd
+}
+
+// update dispatches a message to its handler
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		return m.handleWindowSizeMsg(msg), nil
@@ -87,18 +137,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Block input when loading overlay is active, except for exit keys
 		if m.IsLoadingOverlayActive() {
 			// Allow exit even during loading
-			if msg.String() == "esc" || msg.String() == "ctrl+c" {
+			if m.Keys.Matches(types.ActionBack, msg.String()) || m.Keys.Matches(types.ActionQuit, msg.String()) {
 				return m.handleKeyMsg(msg)
 			}
 			return m, nil
 		}
 		return m.handleKeyMsg(msg)
+	case tea.MouseMsg:
+		return m.handleMouseMsg(msg)
 	case handlers.SuccessMsg:
 		return m.handleSuccessMsg(msg), nil
 	case handlers.ClaudeStatusMsg:
 		return m.handleClaudeStatusMsg(msg)
 	case handlers.ToggleResultMsg:
 		return m.handleToggleResultMsg(msg)
+	case handlers.IntrospectionResultMsg:
+		return m.handleIntrospectionResultMsg(msg)
+	case handlers.HealthResultMsg:
+		return m.handleHealthResultMsg(msg)
+	case handlers.ToolCallResultMsg:
+		return m.handleToolCallResultMsg(msg), nil
+	case handlers.LogRefreshMsg:
+		return m.handleLogRefreshMsg()
 	case types.TimerTickMsg:
 		return m.handleTimerTickMsg(msg)
 	case types.LoadingProgressMsg:
@@ -113,6 +173,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleDirectoryChangeMsg(msg)
 	case StartClaudeDetectionMsg:
 		return m.handleStartClaudeDetectionMsg(msg)
+	case handlers.WorkspaceSwitchMsg:
+		return m.handleWorkspaceSwitchMsg(msg)
 	}
 	return m, nil
 }
@@ -127,6 +189,28 @@ func (m Model) handleWindowSizeMsg(msg tea.WindowSizeMsg) Model {
 	return m
 }
 
+// handleWorkspaceSwitchMsg reloads the inventory, settings and caches of another workspace
+// without restarting. The window size, server logs and Claude status carry over.
+func (m Model) handleWorkspaceSwitchMsg(msg handlers.WorkspaceSwitchMsg) (tea.Model, tea.Cmd) {
+	workspace, err := services.OpenWorkspace(m.PlatformService, msg.Name)
+	if err != nil {
+		m.SuccessMessage = fmt.Sprintf("Failed to switch workspace: %v", err)
+		m.SuccessTimer = 2 * m.Settings.SuccessTicks() // Errors stay twice as long
+		return m, handlers.TimerCmd("success_timer")
+	}
+	// Commands that build their own platform service open the workspace from the environment
+	_ = os.Setenv(services.WorkspaceEnv, workspace.Workspace)
+
+	next := NewModelForPlatform(workspace)
+	next.ServerLogs = m.ServerLogs
+	next = next.handleWindowSizeMsg(tea.WindowSizeMsg{Width: m.Width, Height: m.Height})
+	next.Model = services.UpdateModelWithClaudeStatus(next.Model, m.ClaudeStatus)
+
+	next.SuccessMessage = fmt.Sprintf("Switched to workspace %s", workspace.Workspace)
+	next.SuccessTimer = next.Settings.SuccessTicks()
+	return next, tea.Batch(handlers.TimerCmd("success_timer"), RefreshClaudeStatusCmd())
+}
+
 // handleKeyMsg handles keyboard input messages
 func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -153,22 +237,27 @@ func (m Model) handleClaudeStatusMsg(msg handlers.ClaudeStatusMsg) (tea.Model, t
 	// Sync MCP status if Claude is available and has active MCPs
 	switch {
 	case msg.Status.Available && len(msg.Status.ActiveMCPs) > 0:
+		// Syncing can move MCPs sorted or grouped by status, keep the selected one selected
+		selected := services.GetSelectedMCP(m.Model)
 		m.Model = services.SyncMCPStatus(m.Model, msg.Status.ActiveMCPs)
+		if selected != nil {
+			m.Model = services.SelectMCPByName(m.Model, selected.Name)
+		}
 		// Save updated inventory after sync
 		if err := services.SaveModelInventory(m.Model, m.PlatformService); err != nil {
 			// Set error message but don't fail
 			m.SuccessMessage = fmt.Sprintf("Claude status updated, but failed to save inventory: %v", err)
-			m.SuccessTimer = 240 // Show error for 4 seconds
+			m.SuccessTimer = 2 * m.Settings.SuccessTicks() // Errors stay twice as long
 		} else {
 			m.SuccessMessage = "Claude status refreshed and MCPs synced"
-			m.SuccessTimer = 120 // Show success for 2 seconds
+			m.SuccessTimer = m.Settings.SuccessTicks()
 		}
 	case msg.Status.Available:
 		m.SuccessMessage = "Claude status refreshed"
-		m.SuccessTimer = 120
+		m.SuccessTimer = m.Settings.SuccessTicks()
 	default:
 		m.SuccessMessage = "Claude CLI not available"
-		m.SuccessTimer = 180 // Show message for 3 seconds
+		m.SuccessTimer = 3 * m.Settings.SuccessTicks() / 2
 	}
 
 	// Update project context after Claude status update
@@ -200,11 +289,13 @@ func (m Model) handleToggleSuccess(msg handlers.ToggleResultMsg) (Model, tea.Cmd
 			break
 		}
 	}
+	// The new status can move the MCP in the sort or grouping, keep it selected
+	m.Model = services.SelectMCPByName(m.Model, msg.MCPName)
 
 	if err := services.SaveInventory(m.MCPItems, m.PlatformService); err != nil {
 		m.ToggleState = types.ToggleError
 		m.ToggleError = "MCP toggled but failed to save to storage"
-		m.SuccessTimer = 240
+		m.SuccessTimer = 2 * m.Settings.SuccessTicks()
 		// Start timer for error state
 		return m, handlers.TimerCmd("success_timer")
 	}
@@ -213,9 +304,11 @@ func (m Model) handleToggleSuccess(msg handlers.ToggleResultMsg) (Model, tea.Cmd
 	activationState := "deactivated"
 	if msg.Activate {
 		activationState = "activated"
+		m.Model = services.RecordMCPUse(m.Model, msg.MCPName, time.Now())
+		m.Model = services.SelectMCPByName(m.Model, msg.MCPName)
 	}
 	m.SuccessMessage = fmt.Sprintf("MCP '%s' %s successfully", msg.MCPName, activationState)
-	m.SuccessTimer = 120
+	m.SuccessTimer = m.Settings.SuccessTicks()
 	// Update project context after successful toggle
 	m.Model = services.UpdateProjectContext(m.Model)
 	// Start timer for success state
@@ -227,17 +320,109 @@ func (m Model) handleToggleError(msg handlers.ToggleResultMsg) (Model, tea.Cmd)
 	if msg.Retrying {
 		m.ToggleState = types.ToggleRetrying
 		m.SuccessMessage = fmt.Sprintf("MCP toggle failed, retrying: %s", msg.Error)
-		m.SuccessTimer = 180
+		m.SuccessTimer = 3 * m.Settings.SuccessTicks() / 2
 	} else {
 		m.ToggleState = types.ToggleError
 		m.SuccessMessage = fmt.Sprintf("MCP toggle failed: %s", msg.Error)
-		m.SuccessTimer = 240
+		m.SuccessTimer = 2 * m.Settings.SuccessTicks()
 	}
 	m.ToggleError = msg.Error
 	// Start timer for error/retry state
 	return m, handlers.TimerCmd("success_timer")
 }
 
+// handleIntrospectionResultMsg stores an introspection result and persists the cache
+func (m Model) handleIntrospectionResultMsg(msg handlers.IntrospectionResultMsg) (tea.Model, tea.Cmd) {
+	result := msg.Result
+	delete(m.IntrospectingMCPs, result.MCPName)
+
+	if m.Introspection == nil {
+		m.Introspection = make(map[string]types.IntrospectionResult)
+	}
+
+	if result.Error != "" {
+		// Keep the last good result, but surface the failure
+		m.SuccessMessage = fmt.Sprintf("Introspection of '%s' failed: %s", result.MCPName, result.Error)
+		m.SuccessTimer = 2 * m.Settings.SuccessTicks()
+		return m, handlers.TimerCmd("success_timer")
+	}
+
+	m.Introspection[result.MCPName] = result
+	if err := services.SaveIntrospectionCache(m.PlatformService, m.Introspection); err != nil {
+		m.SuccessMessage = fmt.Sprintf("Introspected '%s', but failed to save cache: %v", result.MCPName, err)
+		m.SuccessTimer = 2 * m.Settings.SuccessTicks()
+		return m, handlers.TimerCmd("success_timer")
+	}
+
+	m.SuccessMessage = fmt.Sprintf("'%s': %d tools • ~%s tokens",
+		result.MCPName, len(result.Tools), services.FormatTokenCount(result.TokenEstimate))
+	// A tool filter saved before the tools were known can now reach Claude's settings
+	if item := services.FindMCPByName(m.Model, result.MCPName); item != nil && services.HasToolFilter(*item) {
+		rules, known, err := services.SyncClaudeToolPermissions(m.PlatformService, *item, m.Introspection)
+		switch {
+		case err != nil:
+			m.SuccessMessage = fmt.Sprintf("%s, but Claude permissions were not updated: %v", m.SuccessMessage, err)
+		case known:
+			m.SuccessMessage = fmt.Sprintf("%s • %d tools denied in Claude settings", m.SuccessMessage, len(rules))
+		}
+	}
+	m.SuccessTimer = m.Settings.SuccessTicks()
+	return m, handlers.TimerCmd("success_timer")
+}
+
+// handleHealthResultMsg records a health probe result
+func (m Model) handleHealthResultMsg(msg handlers.HealthResultMsg) (tea.Model, tea.Cmd) {
+	result := msg.Result
+	delete(m.ProbingMCPs, result.MCPName)
+
+	if m.Health == nil {
+		m.Health = make(map[string]types.HealthResult)
+	}
+	// The health sort can move MCPs, keep the selected one selected
+	selected := services.GetSelectedMCP(m.Model)
+	m.Health[result.MCPName] = result
+	if selected != nil {
+		m.Model = services.SelectMCPByName(m.Model, selected.Name)
+	}
+
+	status := services.FormatHealthStatus(result.Status)
+	switch {
+	case result.Error != "":
+		m.SuccessMessage = fmt.Sprintf("'%s' is %s: %s", result.MCPName, strings.ToLower(status), result.Error)
+		m.SuccessTimer = 2 * m.Settings.SuccessTicks()
+	default:
+		m.SuccessMessage = fmt.Sprintf("'%s' is healthy • started in %s", result.MCPName, result.StartupLatency.Round(time.Millisecond))
+		m.SuccessTimer = m.Settings.SuccessTicks()
+	}
+	return m, handlers.TimerCmd("success_timer")
+}
+
+// handleToolCallResultMsg records a playground call and shows it if the playground is still open
+func (m Model) handleToolCallResultMsg(msg handlers.ToolCallResultMsg) Model {
+	record := msg.Record
+	m.ToolCallHistory = services.AppendToolCallHistory(m.ToolCallHistory, record)
+
+	if m.ActiveModal == types.PlaygroundModal && m.Playground.MCPName == record.MCPName &&
+		m.Playground.Step == types.PlaygroundCalling {
+		m.Playground.LastCall = &record
+		m.Playground.Step = types.PlaygroundShowResult
+	}
+	return m
+}
+
+// handleLogRefreshMsg keeps redrawing a log pane while it is open
+func (m Model) handleLogRefreshMsg() (tea.Model, tea.Cmd) {
+	switch m.ActiveModal {
+	case types.LogsModal:
+		return m, handlers.LogRefreshCmd()
+	case types.AppLogModal:
+		// The application log is tailed from its file, which other processes also write
+		m.Model = handlers.RefreshAppLog(m.Model)
+		return m, handlers.LogRefreshCmd()
+	}
+	return m, nil
+}
+
 // handleTimerTickMsg handles timer tick messages for countdown functionality
 func (m Model) handleTimerTickMsg(msg types.TimerTickMsg) (tea.Model, tea.Cmd) {
 	// Only handle success timer ticks
@@ -374,8 +559,8 @@ func (m Model) handleProjectContextCheckMsg(_ types.ProjectContextCheckMsg) (tea
 	// Update project context regardless to refresh sync status and timestamps
 	m.Model = services.UpdateProjectContext(m.Model)
 
-	// Schedule next check in 5 seconds
-	return m, ProjectContextCheckCmd()
+	// Schedule the next check
+	return m, ProjectContextCheckCmd(m.Settings.ProjectCheckInterval)
 }
 
 // handleDirectoryChangeMsg handles directory change events
@@ -392,9 +577,12 @@ func (m Model) handleDirectoryChangeMsg(_ types.DirectoryChangeMsg) (tea.Model,
 	return m, nil
 }
 
-// ProjectContextCheckCmd returns a command to check project context
-func ProjectContextCheckCmd() tea.Cmd {
-	return tea.Tick(time.Second*5, func(_ time.Time) tea.Msg {
+// ProjectContextCheckCmd returns a command to check project context after interval
+func ProjectContextCheckCmd(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		interval = types.DefaultProjectCheckInterval
+	}
+	return tea.Tick(interval, func(_ time.Time) tea.Msg {
 		return types.ProjectContextCheckMsg{}
 	})
 }
@@ -413,9 +601,12 @@ func RefreshClaudeStatusCmd() tea.Cmd {
 
 // DelayedClaudeStatusRefreshCmd returns a command to refresh Claude status after a short delay
 // This allows the UI to load first before detecting Claude CLI
-func DelayedClaudeStatusRefreshCmd() tea.Cmd {
-	return tea.Tick(time.Millisecond*500, func(_ time.Time) tea.Msg {
-		// After 500ms, start the Claude detection process
+func DelayedClaudeStatusRefreshCmd(delay time.Duration) tea.Cmd {
+	if delay <= 0 {
+		delay = types.DefaultClaudeDetectionDelay
+	}
+	return tea.Tick(delay, func(_ time.Time) tea.Msg {
+		// After the delay, start the Claude detection process
 		return StartClaudeDetectionMsg{}
 	})
 }
@@ -1,14 +1,20 @@
 package ui
 
 import (
+	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"mcp-hub/internal/platform"
 	"mcp-hub/internal/testutil"
 	"mcp-hub/internal/ui/handlers"
+	"mcp-hub/internal/ui/services"
 	"mcp-hub/internal/ui/types"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // Test constants
@@ -580,7 +586,7 @@ func TestModel_HandleDirectoryChangeMsg(_ *testing.T) {
 
 func TestPackageCommandGenerators(t *testing.T) {
 	t.Run("ProjectContextCheckCmd", func(_ *testing.T) {
-		cmd := ProjectContextCheckCmd()
+		cmd := ProjectContextCheckCmd(0)
 
 		if cmd == nil {
 			t.Errorf("ProjectContextCheckCmd should return a valid command")
@@ -723,3 +729,150 @@ func TestModel_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestModel_HandleHealthResultMsg(t *testing.T) {
+	model := NewModel()
+	model.ProbingMCPs = map[string]bool{"github": true}
+
+	updatedModel, cmd := model.Update(handlers.HealthResultMsg{Result: types.HealthResult{
+		MCPName:        "github",
+		Status:         types.HealthHealthy,
+		StartupLatency: 250 * time.Millisecond,
+	}})
+	m := updatedModel.(Model)
+
+	if m.ProbingMCPs["github"] {
+		t.Error("Probe should no longer be in progress")
+	}
+	if m.Health["github"].Status != types.HealthHealthy {
+		t.Errorf("Health result should be stored, got %+v", m.Health["github"])
+	}
+	if m.SuccessMessage != "'github' is healthy • started in 250ms" || cmd == nil {
+		t.Errorf("Unexpected message %q", m.SuccessMessage)
+	}
+
+	updatedModel, _ = m.Update(handlers.HealthResultMsg{Result: types.HealthResult{
+		MCPName: "github",
+		Status:  types.HealthUnhealthy,
+		Error:   "exec: not found",
+	}})
+	m = updatedModel.(Model)
+	if m.SuccessMessage != "'github' is unhealthy: exec: not found" {
+		t.Errorf("Unexpected failure message %q", m.SuccessMessage)
+	}
+}
+
+func TestModel_HandleToolCallResultMsg(t *testing.T) {
+	model := NewModel()
+	model.ActiveModal = types.PlaygroundModal
+	model.Playground = types.PlaygroundState{MCPName: "search", Step: types.PlaygroundCalling}
+
+	updatedModel, _ := model.Update(handlers.ToolCallResultMsg{Record: types.ToolCallRecord{MCPName: "search", Tool: "query", Result: "ok"}})
+	m := updatedModel.(Model)
+
+	if m.Playground.Step != types.PlaygroundShowResult || m.Playground.LastCall == nil || m.Playground.LastCall.Result != "ok" {
+		t.Errorf("Result should be shown in the playground, got %+v", m.Playground)
+	}
+	if len(m.ToolCallHistory["search"]) != 1 {
+		t.Error("Call should be recorded in the history")
+	}
+
+	// Results for a closed playground are only recorded
+	m.ActiveModal = types.NoModal
+	m.Playground = types.PlaygroundState{}
+	updatedModel, _ = m.Update(handlers.ToolCallResultMsg{Record: types.ToolCallRecord{MCPName: "search", Tool: "query"}})
+	m = updatedModel.(Model)
+	if m.Playground.LastCall != nil || len(m.ToolCallHistory["search"]) != 2 {
+		t.Errorf("Unexpected state after late result: %+v", m.Playground)
+	}
+}
+
+func TestModel_HandleLogRefreshMsg(t *testing.T) {
+	model := NewModel()
+	model.ActiveModal = types.LogsModal
+
+	if _, cmd := model.Update(handlers.LogRefreshMsg{}); cmd == nil {
+		t.Error("Refresh should keep ticking while the log pane is open")
+	}
+
+	model.ActiveModal = types.NoModal
+	if _, cmd := model.Update(handlers.LogRefreshMsg{}); cmd != nil {
+		t.Error("Refresh should stop once the log pane is closed")
+	}
+}
+
+func TestModel_HandleLogRefreshMsg_ReloadsAppLog(t *testing.T) {
+	mockPlatform := platform.NewMockPlatformService()
+	logDir := t.TempDir()
+	mockPlatform.SetPaths(logDir, logDir+"/mcp-hub", logDir, logDir)
+	model := Model{Model: types.NewModel(mockPlatform), PlatformService: mockPlatform}
+	model.ActiveModal = types.AppLogModal
+
+	if err := os.WriteFile(services.GetAppLogPath(mockPlatform), []byte(`{"level":"INFO","msg":"written by serve","component":"serve"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	updated, cmd := model.Update(handlers.LogRefreshMsg{})
+	if entries := updated.(Model).AppLog.Entries; cmd == nil || len(entries) != 1 || entries[0].Message != "written by serve" {
+		t.Errorf("Refresh should reload the log file and keep ticking, got %+v", entries)
+	}
+}
+
+func TestModel_UpdateScrollsToSelection(t *testing.T) {
+	items := make([]types.MCPItem, 400)
+	for i := range items {
+		items[i] = types.MCPItem{Name: fmt.Sprintf("mcp-%03d", i), Type: "CMD"}
+	}
+	model := Model{Model: testutil.NewTestModel().WithMCPs(items).Build()}
+	model = model.handleWindowSizeMsg(tea.WindowSizeMsg{Width: 140, Height: 40})
+	press := func(key tea.KeyType) {
+		updated, _ := model.Update(tea.KeyMsg{Type: key})
+		model = updated.(Model)
+	}
+
+	press(tea.KeyEnd)
+	if model.SelectedItem != 399 || model.ScrollOffset != 100-27 || !strings.Contains(model.View(), "mcp-399") {
+		t.Fatalf("End should scroll to the last MCP, got item %d offset %d", model.SelectedItem, model.ScrollOffset)
+	}
+	if height := lipgloss.Height(model.View()); height > model.Height {
+		t.Errorf("The view should fit the window, got %d lines", height)
+	}
+
+	press(tea.KeyUp)
+	if model.ScrollOffset != 100-27 {
+		t.Errorf("Moving within the viewport should not scroll, got offset %d", model.ScrollOffset)
+	}
+	press(tea.KeyPgUp)
+	// The selection moves from row 98 to 71, which becomes the top row
+	if model.ScrollOffset != 98-27 {
+		t.Errorf("Page up should scroll a page, got offset %d", model.ScrollOffset)
+	}
+	press(tea.KeyHome)
+	if model.SelectedItem != 0 || model.ScrollOffset != 0 {
+		t.Errorf("Home should scroll to the top, got item %d offset %d", model.SelectedItem, model.ScrollOffset)
+	}
+}
+
+func TestModel_IntrospectionAppliesSavedToolFilter(t *testing.T) {
+	t.Setenv(services.ConfigDirEnv, "")
+	t.Setenv(services.WorkspaceEnv, "")
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+	mockPlatform.SetHomeDirectory(tempDir + "/home")
+
+	model := NewModelForPlatform(mockPlatform)
+	item := types.MCPItem{Name: "github", Type: "CMD", Command: "github-mcp", ToolDeny: []string{"delete_*"}}
+	model.MCPItems = []types.MCPItem{item}
+
+	updated, _ := model.Update(handlers.IntrospectionResultMsg{Result: types.IntrospectionResult{
+		MCPName: "github", ConfigHash: services.ComputeConfigHash(item),
+		Tools: []types.ToolInfo{{Name: "create_issue"}, {Name: "delete_repo"}},
+	}})
+	if message := updated.(Model).SuccessMessage; !strings.Contains(message, "1 tools denied in Claude settings") {
+		t.Errorf("The saved filter should reach Claude once the tools are known, got %q", message)
+	}
+	data, err := os.ReadFile(services.GetClaudeSettingsPath(mockPlatform))
+	if err != nil || !strings.Contains(string(data), "mcp__github__delete_repo") {
+		t.Errorf("Expected the hidden tool in Claude's settings, got %s, %v", data, err)
+	}
+}
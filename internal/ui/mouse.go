@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"mcp-hub/internal/ui/components"
+	"mcp-hub/internal/ui/handlers"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// columnInset is the border and padding cell around each column of the narrow layouts
+	columnInset = 2
+	// columnTitleLines are a column's title and the blank line below it
+	columnTitleLines = 2
+	// singleColumnTitleLines add the debug line of the single column layout
+	singleColumnTitleLines = 3
+)
+
+// handleMouseMsg hit-tests a mouse event against the rendered view and applies it
+func (m Model) handleMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.IsLoadingOverlayActive() {
+		return m, nil
+	}
+	// Motion and releases do nothing, and only clicks need the view rendered to hit-test
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	var target types.MouseTarget
+	if msg.Button == tea.MouseButtonLeft {
+		target = m.mouseTargetAt(msg.X, msg.Y)
+	}
+	var cmd tea.Cmd
+	m.Model, cmd = handlers.HandleMouse(m.Model, msg, target)
+	return m, cmd
+}
+
+// mouseTargetAt returns what the view draws at x, y of the screen
+func (m Model) mouseTargetAt(x, y int) types.MouseTarget {
+	if m.State == types.ModalActive {
+		return components.ModalTargetAt(m.Model, x, y)
+	}
+
+	// The terminal shows the bottom of a view that is taller than the window
+	if overflow := lipgloss.Height(m.View()) - m.Height; overflow > 0 {
+		y += overflow
+	}
+	y -= lipgloss.Height(components.RenderHeader(m.Model))
+	if y < 0 {
+		return types.MouseTarget{}
+	}
+
+	switch m.ColumnCount {
+	case types.WideColumns:
+		if index, ok := components.GridItemAt(m.Model, x, y); ok {
+			return types.MouseTarget{Kind: types.MouseTargetItem, Index: index}
+		}
+		return types.MouseTarget{}
+	case 1:
+		return m.listTargetAt(0, y-columnInset-singleColumnTitleLines)
+	}
+
+	// Each column is its content width plus the border on either side
+	columnWidth := (m.Width-6)/2 + 2
+	if m.ColumnCount == 3 {
+		columnWidth = (m.Width-8)/3 + 2
+	}
+	column := x / columnWidth
+	if column >= m.ColumnCount {
+		return types.MouseTarget{}
+	}
+	return m.listTargetAt(column, y-columnInset-columnTitleLines)
+}
+
+// listTargetAt returns the MCP on line of the list in the first column, or the column
+func (m Model) listTargetAt(column, line int) types.MouseTarget {
+	if column == 0 {
+		if index, ok := components.ListItemAt(m.Model, line); ok {
+			return types.MouseTarget{Kind: types.MouseTargetItem, Index: index}
+		}
+	}
+	return types.MouseTarget{Kind: types.MouseTargetColumn, Index: column}
+}
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/testutil"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// newMouseTestModel returns a model with the mock MCPs laid out for a terminal of width
+func newMouseTestModel(width int) Model {
+	model := Model{Model: testutil.NewTestModel().WithMCPs(testutil.MockMCPItems()).Build()}
+	return model.handleWindowSizeMsg(tea.WindowSizeMsg{Width: width, Height: 40})
+}
+
+// findOnScreen returns the screen cell where text is first drawn
+func findOnScreen(t *testing.T, screen, text string) (int, int) {
+	t.Helper()
+	for y, line := range strings.Split(screen, "\n") {
+		if x := strings.Index(line, text); x >= 0 {
+			return lipgloss.Width(line[:x]), y
+		}
+	}
+	t.Fatalf("%q is not on the screen:\n%s", text, screen)
+	return 0, 0
+}
+
+// click sends a left click at x, y
+func click(m Model, x, y int) Model {
+	updated, _ := m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	return updated.(Model)
+}
+
+func TestMouse_ClickSelectsGridCell(t *testing.T) {
+	model := newMouseTestModel(140)
+	if model.ColumnCount != types.WideColumns {
+		t.Fatalf("Expected the grid layout, got %d columns", model.ColumnCount)
+	}
+
+	x, y := findOnScreen(t, model.View(), "docker-mcp")
+	model = click(model, x+2, y)
+	if model.SelectedItem != 4 {
+		t.Errorf("Clicking docker-mcp should select it, got %d", model.SelectedItem)
+	}
+
+	x, y = findOnScreen(t, model.View(), "filesystem")
+	if model = click(model, x, y); model.SelectedItem != 3 {
+		t.Errorf("Clicking filesystem should select it, got %d", model.SelectedItem)
+	}
+
+	if model = click(model, x, 0); model.SelectedItem != 3 {
+		t.Error("Clicking the header should keep the selection")
+	}
+}
+
+func TestMouse_ClickSelectsFilteredCell(t *testing.T) {
+	model := newMouseTestModel(140)
+	model.SearchQuery = "mcp"
+	model.State = types.SearchActiveNavigation
+	model.SearchActive = true
+
+	x, y := findOnScreen(t, model.View(), "docker-mcp")
+	model = click(model, x, y)
+	if filtered := model.GetFilteredMCPs(); model.FilteredSelectedIndex >= len(filtered) || filtered[model.FilteredSelectedIndex].Name != "docker-mcp" {
+		t.Errorf("Clicking a search result should select it, got index %d", model.FilteredSelectedIndex)
+	}
+	if model.SelectedItem != 0 {
+		t.Error("Clicking a search result should not change the unfiltered selection")
+	}
+}
+
+func TestMouse_ClickSelectsScrolledCell(t *testing.T) {
+	model := newMouseTestModel(140)
+	for i := 0; i < 200; i++ {
+		model.MCPItems = append(model.MCPItems, types.MCPItem{Name: fmt.Sprintf("extra-%03d", i), Type: "CMD"})
+	}
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	model = updated.(Model)
+
+	x, y := findOnScreen(t, model.View(), "extra-150")
+	if model = click(model, x, y); model.SelectedItem != 155 {
+		t.Errorf("Clicking extra-150 in the scrolled grid should select it, got %d", model.SelectedItem)
+	}
+}
+
+func TestMouse_DoubleClickToggles(t *testing.T) {
+	model := newMouseTestModel(140)
+	x, y := findOnScreen(t, model.View(), "filesystem")
+
+	model = click(model, x, y)
+	if model.ToggleState != types.ToggleIdle {
+		t.Fatal("A single click should only select")
+	}
+	updated, _ := model.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	// Without Claude the toggle fails at once, but it was started for the clicked MCP
+	if model = updated.(Model); model.ToggleMCPName != "filesystem" || model.ToggleState == types.ToggleIdle {
+		t.Errorf("A double-click should toggle the MCP, got state %v for %q", model.ToggleState, model.ToggleMCPName)
+	}
+}
+
+func TestMouse_WheelMovesSelection(t *testing.T) {
+	model := newMouseTestModel(140)
+	updated, _ := model.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	if model = updated.(Model); model.SelectedItem != types.WideColumns {
+		t.Errorf("Scrolling down should move down a row, got %d", model.SelectedItem)
+	}
+	updated, _ = model.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelUp})
+	if model = updated.(Model); model.SelectedItem != 0 {
+		t.Errorf("Scrolling up should move up a row, got %d", model.SelectedItem)
+	}
+}
+
+func TestMouse_ClickSelectsListItemAndColumn(t *testing.T) {
+	for _, width := range []int{90, 110} {
+		model := newMouseTestModel(width)
+		model.ActiveColumn = 1
+
+		x, y := findOnScreen(t, model.View(), "ht-mcp")
+		if model = click(model, x, y); model.SelectedItem != 2 || model.ActiveColumn != 0 {
+			t.Errorf("Width %d: clicking ht-mcp should select it, got item %d column %d", width, model.SelectedItem, model.ActiveColumn)
+		}
+
+		x, y = findOnScreen(t, model.View(), "Status")
+		if model = click(model, x, y); model.ActiveColumn != 1 {
+			t.Errorf("Width %d: clicking the second column should focus it, got %d", width, model.ActiveColumn)
+		}
+	}
+}
+
+func TestMouse_ClickFormFieldsAndButtons(t *testing.T) {
+	model := newMouseTestModel(140)
+	model.State = types.ModalActive
+	model.ActiveModal = types.AddMCPTypeSelection
+
+	x, y := findOnScreen(t, model.View(), "2. SSE Server")
+	if model = click(model, x, y); model.ActiveModal != types.AddMCPTypeSelection || model.FormData.ActiveField != 2 {
+		t.Fatalf("Clicking an option should select it, got modal %v option %d", model.ActiveModal, model.FormData.ActiveField)
+	}
+	if model = click(model, x, y); model.ActiveModal != types.AddSSEForm {
+		t.Fatalf("Double-clicking an option should open its form, got %v", model.ActiveModal)
+	}
+
+	x, y = findOnScreen(t, model.View(), "URL: (required)")
+	if model = click(model, x, y+1); model.FormData.ActiveField != 1 {
+		t.Errorf("Clicking the URL input should focus it, got field %d", model.FormData.ActiveField)
+	}
+
+	// The footer wraps in the form, leaving "Cancel" of "ESC Cancel" on a line of its own
+	x, y = findOnScreen(t, model.View(), "Cancel")
+	if model = click(model, x+1, y); model.State != types.MainNavigation || model.ActiveModal != types.NoModal {
+		t.Errorf("Clicking ESC Cancel should close the form, got state %v modal %v", model.State, model.ActiveModal)
+	}
+}
+
+func TestMouse_IgnoredWhileLoading(t *testing.T) {
+	model := newMouseTestModel(140)
+	model.StartLoadingOverlay(types.LoadingRefresh)
+	updated, _ := model.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	if updated.(Model).SelectedItem != 0 {
+		t.Error("The mouse should be ignored while the loading overlay is shown")
+	}
+}
+
+func TestMouse_MotionSkipsHitTesting(t *testing.T) {
+	model := newMouseTestModel(140)
+	motion := tea.MouseMsg{X: 10, Y: 10, Action: tea.MouseActionMotion, Button: tea.MouseButtonNone}
+
+	// Rendering the view to hit-test it allocates far more than this
+	allocs := testing.AllocsPerRun(10, func() { model.handleMouseMsg(motion) })
+	if allocs > 5 {
+		t.Errorf("Mouse motion should not render the view, got %.0f allocations", allocs)
+	}
+}
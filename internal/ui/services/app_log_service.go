@@ -0,0 +1,37 @@
+package services
+
+import (
+	"path/filepath"
+
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// GetAppLogPath returns the path of mcp-hub's own log file
+func GetAppLogPath(platformService platform.PlatformService) string {
+	return filepath.Join(platformService.GetLogPath(), logging.FileName)
+}
+
+// LoadAppLog reads the newest entries of the application log
+func LoadAppLog(platformService platform.PlatformService) ([]logging.Entry, error) {
+	return logging.ReadTail(GetAppLogPath(platformService), types.AppLogCapacity)
+}
+
+// NextAppLogComponent cycles the component filter from all components through each
+// component that wrote entries and back to all
+func NextAppLogComponent(entries []logging.Entry, current string) string {
+	components := logging.Components(entries)
+	if current == "" {
+		if len(components) == 0 {
+			return ""
+		}
+		return components[0]
+	}
+	for i, component := range components {
+		if component == current && i+1 < len(components) {
+			return components[i+1]
+		}
+	}
+	return ""
+}
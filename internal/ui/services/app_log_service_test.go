@@ -0,0 +1,48 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"mcp-hub/internal/logging"
+)
+
+func TestLoadAppLog(t *testing.T) {
+	mockPlatform := newSettingsTestPlatform(t)
+	if entries, err := LoadAppLog(mockPlatform); err != nil || len(entries) != 0 {
+		t.Errorf("Missing log should have no entries, got %v, %v", entries, err)
+	}
+
+	path := GetAppLogPath(mockPlatform)
+	if filepath.Base(path) != logging.FileName {
+		t.Errorf("Unexpected log path %s", path)
+	}
+	data := `{"level":"INFO","msg":"inventory saved","component":"storage"}` + "\n" + `{"level":"WARN","msg":"slow","component":"claude"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := LoadAppLog(mockPlatform)
+	if err != nil || len(entries) != 2 || entries[1].Component != "claude" {
+		t.Errorf("Expected both entries, got %+v, %v", entries, err)
+	}
+}
+
+func TestNextAppLogComponent(t *testing.T) {
+	entries := []logging.Entry{{Component: "storage"}, {Component: "claude"}, {Message: "plain"}}
+	var cycle []string
+	component := ""
+	for range 3 {
+		component = NextAppLogComponent(entries, component)
+		cycle = append(cycle, component)
+	}
+	if cycle[0] != "claude" || cycle[1] != "storage" || cycle[2] != "" {
+		t.Errorf("Expected claude, storage, all; got %q", cycle)
+	}
+	if NextAppLogComponent(entries, "gone") != "" {
+		t.Error("A component that no longer logs should reset the filter")
+	}
+	if NextAppLogComponent(nil, "") != "" {
+		t.Error("Without components the filter stays on all")
+	}
+}
@@ -12,12 +12,17 @@ import (
 	"strings"
 	"time"
 
+	"mcp-hub/internal/logging"
 	"mcp-hub/internal/platform"
 	"mcp-hub/internal/ui/types"
 )
 
 // Use types.ClaudeStatus for consistency
 
+// claudeLog records Claude CLI detection and toggles. Command lines are not logged
+// because they can carry environment values.
+var claudeLog = logging.For("claude")
+
 const (
 	// ClaudeCommand is the command name for the Claude CLI
 	ClaudeCommand = "claude"
@@ -26,6 +31,22 @@ const (
 	TestActiveStatus = "active"
 )
 
+// Claude scopes an MCP can be added in; an empty scope leaves the choice to Claude, which uses local
+const (
+	ScopeLocal   = "local"
+	ScopeProject = "project"
+	ScopeUser    = "user"
+)
+
+// IsClaudeScope reports whether scope is empty or one of the scopes Claude understands
+func IsClaudeScope(scope string) bool {
+	switch scope {
+	case "", ScopeLocal, ScopeProject, ScopeUser:
+		return true
+	}
+	return false
+}
+
 // allowedCommands defines the commands that are allowed to be executed
 var allowedCommands = map[string]bool{
 	"claude": true,
@@ -71,14 +92,30 @@ var ErrorMessages = map[string]string{
 type ClaudeService struct {
 	timeout         time.Duration
 	platformService platform.PlatformService
+
+	// Toggle retry limits, see types.Settings
+	retryWindow time.Duration
+	retryCutoff time.Duration
+	retryBudget time.Duration
 }
 
-// NewClaudeService creates a new Claude service instance with platform abstraction
+// NewClaudeService creates a new Claude service instance with platform abstraction,
+// using the timeouts from the user settings
 func NewClaudeService(platformService platform.PlatformService) *ClaudeService {
-	return &ClaudeService{
-		timeout:         10 * time.Second, // 10 second timeout for commands
-		platformService: platformService,
-	}
+	// An invalid settings file falls back to the defaults; the settings editor shows the error
+	settings, _ := LoadSettings(platformService)
+	cs := &ClaudeService{platformService: platformService}
+	cs.ApplySettings(settings)
+	return cs
+}
+
+// ApplySettings sets the command timeout and toggle retry limits
+func (cs *ClaudeService) ApplySettings(settings types.Settings) {
+	settings = settings.WithDefaults()
+	cs.timeout = settings.ClaudeTimeout
+	cs.retryWindow = settings.ToggleRetryWindow
+	cs.retryCutoff = settings.ToggleRetryCutoff
+	cs.retryBudget = settings.ToggleRetryBudget
 }
 
 // DetectClaudeCLI checks if Claude CLI is available on the system
@@ -101,6 +138,7 @@ func (cs *ClaudeService) DetectClaudeCLI(ctx context.Context) types.ClaudeStatus
 
 	output, err := cmd.Output()
 	if err != nil {
+		claudeLog.Info("claude CLI not found", "detection", detectionCmd, "error", err)
 		status.Error = "Claude CLI not found in system PATH"
 		status.InstallGuide = cs.getInstallationGuide()
 		return status
@@ -112,8 +150,10 @@ func (cs *ClaudeService) DetectClaudeCLI(ctx context.Context) types.ClaudeStatus
 		status.Available = true
 		version, err := cs.getClaudeVersion(timeoutCtx)
 		if err != nil {
+			claudeLog.Warn("claude CLI version check failed", "path", claudePath, "error", err)
 			status.Error = fmt.Sprintf("Found Claude CLI but failed to get version: %v", err)
 		} else {
+			claudeLog.Debug("claude CLI detected", "path", claudePath, "version", version)
 			status.Version = version
 		}
 	}
@@ -351,11 +391,11 @@ func FormatClaudeStatusForDisplay(status types.ClaudeStatus) string {
 }
 
 // GetRefreshKeyHint returns the refresh key hint for the UI
-func GetRefreshKeyHint(status types.ClaudeStatus) string {
+func GetRefreshKeyHint(status types.ClaudeStatus, keys types.KeyMap) string {
 	if status.Available {
-		return "R=Refresh Claude Status"
+		return KeyHint(keys, types.ActionRefresh, "Refresh Claude Status")
 	}
-	return "R=Retry Claude Detection"
+	return KeyHint(keys, types.ActionRefresh, "Retry Claude Detection")
 }
 
 // ToggleMCPStatus toggles the active status of an MCP using Claude CLI add/remove commands
@@ -411,7 +451,7 @@ func (cs *ClaudeService) buildToggleCommand(ctx context.Context, mcpName string,
 	if activate {
 		return cs.buildActivateCommand(ctx, mcpConfig, result, start)
 	}
-	return cs.buildDeactivateCommand(ctx, mcpName, result), nil
+	return cs.buildDeactivateCommand(ctx, mcpName, mcpConfig, result), nil
 }
 
 func (cs *ClaudeService) buildActivateCommand(ctx context.Context, mcpConfig *types.MCPItem, result *ToggleResult, start time.Time) (*exec.Cmd, error) {
@@ -436,9 +476,18 @@ func (cs *ClaudeService) buildActivateCommand(ctx context.Context, mcpConfig *ty
 	return cmd, nil
 }
 
-func (cs *ClaudeService) buildDeactivateCommand(ctx context.Context, mcpName string, result *ToggleResult) *exec.Cmd {
+func (cs *ClaudeService) buildDeactivateCommand(ctx context.Context, mcpName string, mcpConfig *types.MCPItem, result *ToggleResult) *exec.Cmd {
 	result.NewState = "inactive"
-	return exec.CommandContext(ctx, ClaudeCommand, "mcp", "remove", mcpName)
+	return exec.CommandContext(ctx, ClaudeCommand, removeCommandArgs(mcpName, mcpConfig)...)
+}
+
+// removeCommandArgs builds the claude mcp remove arguments, limited to the MCP's scope when set
+func removeCommandArgs(mcpName string, mcpConfig *types.MCPItem) []string {
+	args := []string{"mcp", "remove"}
+	if mcpConfig != nil && mcpConfig.Scope != "" {
+		args = append(args, "-s", mcpConfig.Scope)
+	}
+	return append(args, mcpName)
 }
 
 func (cs *ClaudeService) executeToggleCommand(cmd *exec.Cmd, result *ToggleResult, start time.Time) (*ToggleResult, error) {
@@ -446,10 +495,14 @@ func (cs *ClaudeService) executeToggleCommand(cmd *exec.Cmd, result *ToggleResul
 	result.Duration = time.Since(start)
 
 	if err != nil {
-		return cs.handleToggleError(err, string(output), result)
+		result, err = cs.handleToggleError(err, string(output), result)
+		claudeLog.Warn("MCP toggle failed", "mcp", result.MCPName, "state", result.NewState,
+			"error_type", result.ErrorType, "retrying", result.Retrying, "duration", result.Duration)
+		return result, err
 	}
 
 	result.Success = true
+	claudeLog.Info("MCP toggled", "mcp", result.MCPName, "state", result.NewState, "duration", result.Duration)
 	return result, nil
 }
 
@@ -461,7 +514,7 @@ func (cs *ClaudeService) handleToggleError(err error, output string, result *Tog
 
 	// If retryable and within time budget, mark as retrying
 	// The actual retry will be handled by the UI layer with proper delay
-	if result.Retryable && result.Duration < 8*time.Second {
+	if result.Retryable && result.Duration < cs.retryWindow {
 		result.Retrying = true
 		result.ErrorMsg = "MCP toggle timed out. Retrying..."
 	}
@@ -480,9 +533,9 @@ func (cs *ClaudeService) retryToggleOperation(ctx context.Context, mcpName strin
 		result.NewState = "activating"
 	}
 
-	// Check remaining time budget (max 20 seconds total)
+	// Check remaining time budget
 	elapsed := time.Since(originalStart)
-	if elapsed > 18*time.Second {
+	if elapsed > cs.retryCutoff {
 		result.Success = false
 		result.ErrorType = ErrorTypeNetworkTimeout
 		result.ErrorMsg = "Operation timed out"
@@ -491,7 +544,7 @@ func (cs *ClaudeService) retryToggleOperation(ctx context.Context, mcpName strin
 	}
 
 	// Perform retry with remaining time
-	remainingTime := 20*time.Second - elapsed
+	remainingTime := cs.retryBudget - elapsed
 	timeoutCtx, cancel := context.WithTimeout(ctx, remainingTime)
 	defer cancel()
 
@@ -517,7 +570,7 @@ func (cs *ClaudeService) retryToggleOperation(ctx context.Context, mcpName strin
 		result.NewState = TestActiveStatus
 	} else {
 		// Use 'claude mcp remove' command
-		cmd = exec.CommandContext(timeoutCtx, ClaudeCommand, "mcp", "remove", mcpName)
+		cmd = exec.CommandContext(timeoutCtx, ClaudeCommand, removeCommandArgs(mcpName, mcpConfig)...)
 		result.NewState = "inactive"
 	}
 
@@ -525,6 +578,7 @@ func (cs *ClaudeService) retryToggleOperation(ctx context.Context, mcpName strin
 	result.Duration = time.Since(originalStart)
 
 	if err != nil {
+		claudeLog.Warn("MCP toggle retry failed", "mcp", mcpName, "state", result.NewState, "duration", result.Duration, "error", err)
 		result.Success = false
 		result.ErrorType = ErrorTypeUnknownError
 		result.ErrorMsg = ErrorMessages[ErrorTypeUnknownError]
@@ -532,6 +586,7 @@ func (cs *ClaudeService) retryToggleOperation(ctx context.Context, mcpName strin
 	}
 
 	result.Success = true
+	claudeLog.Info("MCP toggled on retry", "mcp", mcpName, "state", result.NewState, "duration", result.Duration)
 	return result, nil
 }
 
@@ -551,6 +606,18 @@ func (cs *ClaudeService) validateMCPConfig(mcpConfig *types.MCPItem) error {
 		}
 	}
 
+	// Validate header names; values are passed as single arguments and need no escaping
+	headerKeyRegex := regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+	for key := range mcpConfig.Headers {
+		if !headerKeyRegex.MatchString(key) {
+			return fmt.Errorf("invalid header name: %s", key)
+		}
+	}
+
+	if !IsClaudeScope(mcpConfig.Scope) {
+		return fmt.Errorf("invalid scope: %s", mcpConfig.Scope)
+	}
+
 	return nil
 }
 
@@ -561,7 +628,11 @@ func (cs *ClaudeService) buildAddCommand(ctx context.Context, mcpConfig *types.M
 		return nil, err
 	}
 
-	args := []string{"mcp", "add", mcpConfig.Name}
+	args := []string{"mcp", "add"}
+	if mcpConfig.Scope != "" {
+		args = append(args, "-s", mcpConfig.Scope)
+	}
+	args = append(args, mcpConfig.Name)
 
 	// Add the command or URL
 	if mcpConfig.URL != "" {
@@ -592,6 +663,11 @@ func (cs *ClaudeService) buildAddCommand(ctx context.Context, mcpConfig *types.M
 		}
 	}
 
+	// Add HTTP headers for URL-based servers
+	for _, key := range sortedKeys(mcpConfig.Headers) {
+		args = append(args, "-H", fmt.Sprintf("%s: %s", key, mcpConfig.Headers[key]))
+	}
+
 	// Validate command name to prevent command injection
 	if ClaudeCommand != "claude" {
 		return nil, fmt.Errorf("invalid command name")
@@ -721,6 +797,7 @@ func GetProjectContext(model types.Model) types.ProjectContext {
 		SyncStatus:     syncStatus,
 		DisplayPath:    displayPath,
 		SyncStatusText: syncStatusText,
+		Project:        ResolveProjectInfo(currentPath),
 	}
 }
 
@@ -432,6 +432,7 @@ func TestGetRefreshKeyHint(t *testing.T) {
 	tests := []struct {
 		name     string
 		status   types.ClaudeStatus
+		keys     types.KeyMap
 		expected string
 	}{
 		{
@@ -448,11 +449,17 @@ func TestGetRefreshKeyHint(t *testing.T) {
 			},
 			expected: "R=Retry Claude Detection",
 		},
+		{
+			name:     "rebound refresh key",
+			status:   types.ClaudeStatus{Available: true},
+			keys:     types.KeyMap{types.ActionRefresh: {"ctrl+r"}},
+			expected: "Ctrl+R=Refresh Claude Status",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := GetRefreshKeyHint(tt.status)
+			result := GetRefreshKeyHint(tt.status, tt.keys)
 			if result != tt.expected {
 				t.Errorf("GetRefreshKeyHint() = %q, want %q", result, tt.expected)
 			}
@@ -1322,3 +1329,79 @@ func BenchmarkGetSyncStatus(b *testing.B) {
 		_ = GetSyncStatus(model)
 	}
 }
+
+func TestBuildAddCommand_Headers(t *testing.T) {
+	service := NewClaudeService(platform.GetMockPlatformService())
+	item := &types.MCPItem{
+		Name:    "remote",
+		Type:    "HTTP",
+		URL:     "https://mcp.example.com/mcp",
+		Headers: map[string]string{"X-Team": "core", "Authorization": "Bearer abc"},
+	}
+
+	cmd, err := service.buildAddCommand(context.Background(), item)
+	if err != nil {
+		t.Fatalf("buildAddCommand failed: %v", err)
+	}
+	expected := []string{"claude", "mcp", "add", "remote", "https://mcp.example.com/mcp", "-t", "http",
+		"-H", "Authorization: Bearer abc", "-H", "X-Team: core"}
+	if !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("Args = %q, expected %q", cmd.Args, expected)
+	}
+
+	item.Headers = map[string]string{"Bad Header": "x"}
+	if _, err := service.buildAddCommand(context.Background(), item); err == nil {
+		t.Error("Expected error for invalid header name")
+	}
+}
+
+func TestBuildAddCommand_Scope(t *testing.T) {
+	service := NewClaudeService(platform.GetMockPlatformService())
+	item := &types.MCPItem{Name: "github", Type: "CMD", Command: "github-mcp", Scope: ScopeUser}
+
+	cmd, err := service.buildAddCommand(context.Background(), item)
+	if err != nil {
+		t.Fatalf("buildAddCommand failed: %v", err)
+	}
+	expected := []string{"claude", "mcp", "add", "-s", "user", "github", "github-mcp"}
+	if !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("Args = %q, expected %q", cmd.Args, expected)
+	}
+	if got := removeCommandArgs("github", item); !reflect.DeepEqual(got, []string{"mcp", "remove", "-s", "user", "github"}) {
+		t.Errorf("removeCommandArgs = %q, expected the scope to be passed", got)
+	}
+	if got := removeCommandArgs("github", nil); !reflect.DeepEqual(got, []string{"mcp", "remove", "github"}) {
+		t.Errorf("removeCommandArgs without a config = %q", got)
+	}
+
+	item.Scope = "global"
+	if _, err := service.buildAddCommand(context.Background(), item); err == nil {
+		t.Error("Expected error for an unknown scope")
+	}
+}
+
+func TestClaudeService_UsesSettings(t *testing.T) {
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir, tempDir+"/mcp-hub", tempDir, tempDir)
+	settings := types.DefaultSettings()
+	settings.ClaudeTimeout = 30 * time.Second
+	settings.ToggleRetryWindow = 2 * time.Second
+	if err := SaveSettings(mockPlatform, settings); err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewClaudeService(mockPlatform)
+	if service.timeout != 30*time.Second || service.retryBudget != types.DefaultToggleRetryBudget {
+		t.Errorf("Unexpected limits timeout=%v budget=%v", service.timeout, service.retryBudget)
+	}
+
+	result, _ := service.handleToggleError(context.DeadlineExceeded, "", &ToggleResult{Duration: 3 * time.Second})
+	if result.ErrorType != ErrorTypeNetworkTimeout || result.Retrying {
+		t.Errorf("A timeout after the retry window should not be retried, got %+v", result)
+	}
+	result, _ = service.handleToggleError(context.DeadlineExceeded, "", &ToggleResult{Duration: time.Second})
+	if !result.Retrying {
+		t.Errorf("A timeout within the retry window should be retried, got %+v", result)
+	}
+}
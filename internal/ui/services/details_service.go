@@ -0,0 +1,148 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// detailsListLimit caps how many tools, resources or prompts are listed per section
+	detailsListLimit = 6
+
+	// detailsValueWidth caps the width of long configuration values
+	detailsValueWidth = 60
+)
+
+// FormatMCPConfigForDisplay describes an item's configuration for the details pane.
+// Environment and header values are omitted since they commonly hold secrets.
+func FormatMCPConfigForDisplay(item types.MCPItem) []string {
+	lines := []string{"Configuration:", detailsLine("Type", item.Type)}
+	if item.Served {
+		lines = append(lines, detailsLine("Served", "through mcp-hub serve"))
+	}
+
+	if item.Command != "" {
+		lines = append(lines, detailsLine("Command", item.Command))
+	}
+	if len(item.Args) > 0 {
+		lines = append(lines, detailsLine("Args", strings.Join(item.Args, " ")))
+	}
+	if item.URL != "" {
+		lines = append(lines, detailsLine("URL", item.URL))
+	}
+	if len(item.Environment) > 0 {
+		lines = append(lines, detailsLine("Env", strings.Join(sortedKeys(item.Environment), ", ")))
+	}
+	if len(item.Headers) > 0 {
+		lines = append(lines, detailsLine("Headers", strings.Join(sortedKeys(item.Headers), ", ")))
+	}
+	if item.JSONConfig != "" {
+		var compact bytes.Buffer
+		config := item.JSONConfig
+		if err := json.Compact(&compact, []byte(config)); err == nil {
+			config = compact.String()
+		}
+		lines = append(lines, detailsLine("JSON", config))
+	}
+	if len(item.ToolAllow) > 0 {
+		lines = append(lines, detailsLine("Tool allow", FormatToolPatterns(item.ToolAllow)))
+	}
+	if len(item.ToolDeny) > 0 {
+		lines = append(lines, detailsLine("Tool deny", FormatToolPatterns(item.ToolDeny)))
+	}
+	return lines
+}
+
+// FormatCapabilitiesForDisplay describes the tools, resources and prompts fetched from the server
+func FormatCapabilitiesForDisplay(model types.Model, item types.MCPItem) []string {
+	if model.IntrospectingMCPs[item.Name] {
+		return []string{"Capabilities: fetching..."}
+	}
+
+	result, ok := GetIntrospection(model, item)
+	if !ok {
+		return []string{"Capabilities: not fetched (press i)"}
+	}
+
+	var lines []string
+	if result.ServerName != "" {
+		lines = append(lines, strings.TrimSpace(fmt.Sprintf("Server: %s %s", result.ServerName, result.ServerVersion)))
+	}
+
+	toolNames := make([]string, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		name := tool.Name
+		if !IsToolAllowed(item.ToolAllow, item.ToolDeny, tool.Name) {
+			name += " (hidden)"
+		}
+		toolNames = append(toolNames, name)
+	}
+	toolsTitle := fmt.Sprintf("Tools (%d, ~%s tokens):", len(result.Tools), FormatTokenCount(result.TokenEstimate))
+	if HasToolFilter(item) {
+		toolsTitle = fmt.Sprintf("Tools (%d of %d exposed, ~%s tokens):",
+			len(FilterToolInfos(item, result.Tools)), len(result.Tools), FormatTokenCount(FilteredTokenEstimate(item, result)))
+	}
+	lines = append(lines, formatDetailsSection(toolsTitle, toolNames)...)
+
+	if hasCapability(result, "resources") || len(result.Resources) > 0 {
+		resourceNames := make([]string, 0, len(result.Resources))
+		for _, resource := range result.Resources {
+			name := resource.Name
+			if name == "" {
+				name = resource.URI
+			}
+			resourceNames = append(resourceNames, name)
+		}
+		lines = append(lines, formatDetailsSection(fmt.Sprintf("Resources (%d):", len(result.Resources)), resourceNames)...)
+	}
+
+	if hasCapability(result, "prompts") || len(result.Prompts) > 0 {
+		promptNames := make([]string, 0, len(result.Prompts))
+		for _, prompt := range result.Prompts {
+			name := prompt.Name
+			if len(prompt.Arguments) > 0 {
+				name += "(" + strings.Join(prompt.Arguments, ", ") + ")"
+			}
+			promptNames = append(promptNames, name)
+		}
+		lines = append(lines, formatDetailsSection(fmt.Sprintf("Prompts (%d):", len(result.Prompts)), promptNames)...)
+	}
+
+	lines = append(lines, "Fetched: "+result.FetchedAt.Format("2006-01-02 15:04:05")+" (i to refresh)")
+	return lines
+}
+
+// formatDetailsSection renders a titled list, truncated to detailsListLimit entries
+func formatDetailsSection(title string, names []string) []string {
+	lines := []string{title}
+	for i, name := range names {
+		if i == detailsListLimit {
+			lines = append(lines, fmt.Sprintf("  … and %d more", len(names)-detailsListLimit))
+			break
+		}
+		lines = append(lines, "  "+types.BulletChar+" "+name)
+	}
+	return lines
+}
+
+// detailsLine renders a bulleted "label: value" line, truncating long values
+func detailsLine(label, value string) string {
+	if runes := []rune(value); len(runes) > detailsValueWidth {
+		value = string(runes[:detailsValueWidth-1]) + "…"
+	}
+	return fmt.Sprintf("  %s %s: %s", types.BulletChar, label, value)
+}
+
+// hasCapability reports whether the server advertised a capability during introspection
+func hasCapability(result types.IntrospectionResult, name string) bool {
+	for _, capability := range result.Capabilities {
+		if capability == name {
+			return true
+		}
+	}
+	return false
+}
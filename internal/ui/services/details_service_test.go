@@ -0,0 +1,137 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func TestFormatMCPConfigForDisplay(t *testing.T) {
+	item := types.MCPItem{
+		Name:        "github",
+		Type:        "CMD",
+		Command:     "npx",
+		Args:        []string{"-y", "@modelcontextprotocol/server-github"},
+		Environment: map[string]string{"GITHUB_TOKEN": "secret-value", "API_URL": "https://api.github.com"},
+	}
+
+	text := strings.Join(FormatMCPConfigForDisplay(item), "\n")
+
+	for _, fragment := range []string{"Type: CMD", "Command: npx", "Args: -y @modelcontextprotocol/server-github", "Env: API_URL, GITHUB_TOKEN"} {
+		if !strings.Contains(text, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, text)
+		}
+	}
+	if strings.Contains(text, "secret-value") {
+		t.Error("Environment values must not be displayed")
+	}
+}
+
+func TestFormatMCPConfigForDisplay_JSONAndURL(t *testing.T) {
+	sse := strings.Join(FormatMCPConfigForDisplay(types.MCPItem{Type: "SSE", URL: "https://mcp.example.com/sse", Headers: map[string]string{"Authorization": "Bearer abc"}}), "\n")
+	if !strings.Contains(sse, "URL: https://mcp.example.com/sse") || strings.Contains(sse, "Command:") {
+		t.Errorf("Unexpected SSE configuration:\n%s", sse)
+	}
+	if !strings.Contains(sse, "Headers: Authorization") || strings.Contains(sse, "Bearer abc") {
+		t.Errorf("Unexpected SSE configuration:\n%s", sse)
+	}
+
+	jsonItem := types.MCPItem{Type: "JSON", JSONConfig: "{\n  \"command\": \"node\",\n  \"args\": [\"server.js\"]\n}"}
+	text := strings.Join(FormatMCPConfigForDisplay(jsonItem), "\n")
+	if !strings.Contains(text, `JSON: {"command":"node","args":["server.js"]}`) {
+		t.Errorf("JSON configuration should be compacted, got:\n%s", text)
+	}
+
+	long := strings.Join(FormatMCPConfigForDisplay(types.MCPItem{Type: "CMD", Command: strings.Repeat("x", 100)}), "\n")
+	if !strings.Contains(long, "…") {
+		t.Error("Long values should be truncated")
+	}
+}
+
+func TestFormatCapabilitiesForDisplay(t *testing.T) {
+	item := types.MCPItem{Name: "fs", Type: "CMD", Command: "fs-mcp"}
+	model := types.Model{
+		Introspection:     map[string]types.IntrospectionResult{},
+		IntrospectingMCPs: map[string]bool{},
+	}
+
+	if lines := FormatCapabilitiesForDisplay(model, item); !strings.Contains(lines[0], "not fetched") {
+		t.Errorf("Expected fetch hint, got %v", lines)
+	}
+
+	model.IntrospectingMCPs["fs"] = true
+	if lines := FormatCapabilitiesForDisplay(model, item); !strings.Contains(lines[0], "fetching") {
+		t.Errorf("Expected in-progress state, got %v", lines)
+	}
+	model.IntrospectingMCPs["fs"] = false
+
+	var tools []types.ToolInfo
+	for i := 0; i < detailsListLimit+2; i++ {
+		tools = append(tools, types.ToolInfo{Name: fmt.Sprintf("tool_%d", i), TokenEstimate: 100})
+	}
+	model.Introspection["fs"] = types.IntrospectionResult{
+		MCPName:       "fs",
+		ConfigHash:    ComputeConfigHash(item),
+		ServerName:    "filesystem",
+		ServerVersion: "2.0.1",
+		Capabilities:  []string{"tools", "resources", "prompts"},
+		Tools:         tools,
+		TokenEstimate: 800,
+		Resources:     []types.ResourceInfo{{URI: "file:///notes.md"}},
+		Prompts:       []types.PromptInfo{{Name: "summarize", Arguments: []string{"path"}}},
+		FetchedAt:     time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+	}
+
+	text := strings.Join(FormatCapabilitiesForDisplay(model, item), "\n")
+	for _, fragment := range []string{
+		"Server: filesystem 2.0.1",
+		"Tools (8, ~800 tokens):",
+		"tool_5",
+		"… and 2 more",
+		"Resources (1):",
+		"file:///notes.md",
+		"Prompts (1):",
+		"summarize(path)",
+		"Fetched: 2026-03-01 09:30:00",
+	} {
+		if !strings.Contains(text, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, text)
+		}
+	}
+	if strings.Contains(text, "tool_6") {
+		t.Error("Tool list should be truncated")
+	}
+}
+
+func TestFormatCapabilitiesForDisplay_ToolFilter(t *testing.T) {
+	item := types.MCPItem{Name: "fs", Type: "CMD", Command: "fs-mcp", ToolDeny: []string{"delete_*"}}
+	model := types.Model{
+		Introspection: map[string]types.IntrospectionResult{
+			"fs": {
+				MCPName:       "fs",
+				ConfigHash:    ComputeConfigHash(item),
+				Tools:         []types.ToolInfo{{Name: "read_file", TokenEstimate: 100}, {Name: "delete_file", TokenEstimate: 300}},
+				TokenEstimate: 400,
+			},
+		},
+		IntrospectingMCPs: map[string]bool{},
+	}
+
+	text := strings.Join(FormatCapabilitiesForDisplay(model, item), "\n")
+	for _, fragment := range []string{"Tools (1 of 2 exposed, ~100 tokens):", "delete_file (hidden)"} {
+		if !strings.Contains(text, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, text)
+		}
+	}
+	if strings.Contains(text, "read_file (hidden)") {
+		t.Error("Allowed tools should not be marked hidden")
+	}
+
+	config := strings.Join(FormatMCPConfigForDisplay(item), "\n")
+	if !strings.Contains(config, "Tool deny: delete_*") {
+		t.Errorf("Configuration should list the deny patterns, got:\n%s", config)
+	}
+}
@@ -0,0 +1,440 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// CheckStatus is the outcome of one doctor check
+type CheckStatus string
+
+// Doctor check outcomes, from best to worst
+const (
+	CheckPass CheckStatus = "pass"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+)
+
+// Doctor check categories, in report order
+const (
+	CheckCategoryClaude    = "Claude CLI"
+	CheckCategoryPaths     = "Paths"
+	CheckCategoryInventory = "Inventory"
+	CheckCategoryServers   = "Servers"
+	CheckCategoryClipboard = "Clipboard"
+)
+
+// envReference matches ${NAME} references; ${NAME:-default} carries its own fallback
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+// DoctorCheck is one line of the doctor report
+type DoctorCheck struct {
+	Category string      `json:"category"`
+	Name     string      `json:"name"`
+	Status   CheckStatus `json:"status"`
+	Message  string      `json:"message"`
+	Hint     string      `json:"hint,omitempty"` // How to fix a warning or failure
+}
+
+// DoctorReport lists the results of every check
+type DoctorReport struct {
+	Checks   []DoctorCheck `json:"checks"`
+	Passed   int           `json:"passed"`
+	Warnings int           `json:"warnings"`
+	Failures int           `json:"failures"`
+}
+
+// DoctorClaude is the part of ClaudeService the doctor uses
+type DoctorClaude interface {
+	DetectClaudeCLI(ctx context.Context) types.ClaudeStatus
+}
+
+// Doctor diagnoses mcp-hub's environment. The lookups are fields so tests can replace them.
+type Doctor struct {
+	PlatformService    platform.PlatformService
+	Claude             DoctorClaude
+	LookPath           func(file string) (string, error)
+	ClipboardAvailable func() bool
+}
+
+// NewDoctor creates a doctor that inspects the real system
+func NewDoctor(platformService platform.PlatformService) *Doctor {
+	return &Doctor{
+		PlatformService:    platformService,
+		Claude:             NewClaudeService(platformService),
+		LookPath:           exec.LookPath,
+		ClipboardAvailable: NewClipboardService(platformService).IsAvailable,
+	}
+}
+
+// Run performs every check
+func (d *Doctor) Run(ctx context.Context) DoctorReport {
+	var report DoctorReport
+	add := func(check DoctorCheck) {
+		report.Checks = append(report.Checks, check)
+		switch check.Status {
+		case CheckPass:
+			report.Passed++
+		case CheckWarn:
+			report.Warnings++
+		case CheckFail:
+			report.Failures++
+		}
+	}
+
+	for _, check := range d.checkClaude(ctx) {
+		add(check)
+	}
+	for _, check := range d.checkPaths() {
+		add(check)
+	}
+	items, inventoryChecks := d.checkInventory()
+	for _, check := range inventoryChecks {
+		add(check)
+	}
+	add(d.checkSettings())
+	for _, check := range d.checkServers(items) {
+		add(check)
+	}
+	add(d.checkClipboard())
+	return report
+}
+
+// checkClaude locates the Claude CLI and reads its version
+func (d *Doctor) checkClaude(ctx context.Context) []DoctorCheck {
+	location := DoctorCheck{Category: CheckCategoryClaude, Name: "location"}
+	path, err := d.LookPath(ClaudeCommand)
+	if err != nil {
+		location.Status = CheckFail
+		location.Message = "claude was not found in PATH"
+		location.Hint = NewClaudeService(d.PlatformService).getInstallationGuide()
+		return []DoctorCheck{location}
+	}
+	location.Status, location.Message = CheckPass, path
+
+	version := DoctorCheck{Category: CheckCategoryClaude, Name: "version"}
+	status := d.Claude.DetectClaudeCLI(ctx)
+	switch {
+	case !status.Available:
+		version.Status, version.Message = CheckFail, status.Error
+		version.Hint = "Check that " + path + " is executable and runs `claude --version`"
+	case status.Version == "":
+		version.Status, version.Message = CheckWarn, status.Error
+		version.Hint = "Run `claude --version` to see why it fails"
+	default:
+		version.Status, version.Message = CheckPass, status.Version
+	}
+	return []DoctorCheck{location, version}
+}
+
+// checkPaths inspects the directories mcp-hub writes to
+func (d *Doctor) checkPaths() []DoctorCheck {
+	paths := []struct{ name, path string }{
+		{"config directory", d.PlatformService.GetConfigPath()},
+		{"log directory", d.PlatformService.GetLogPath()},
+		{"cache directory", d.PlatformService.GetCachePath()},
+	}
+
+	checks := make([]DoctorCheck, 0, len(paths))
+	for _, entry := range paths {
+		checks = append(checks, d.checkDirectory(entry.name, entry.path))
+	}
+	return checks
+}
+
+// checkDirectory checks that a directory is usable and not open to other users
+func (d *Doctor) checkDirectory(name, path string) DoctorCheck {
+	check := DoctorCheck{Category: CheckCategoryPaths, Name: name}
+	expected := d.PlatformService.GetDefaultDirectoryPermissions()
+
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		check.Status, check.Message = CheckWarn, path+" does not exist yet"
+		check.Hint = "mcp-hub creates it on first use; create it yourself with `mkdir -p " + path + "`"
+		return check
+	case err != nil:
+		check.Status, check.Message = CheckFail, err.Error()
+		return check
+	case !info.IsDir():
+		check.Status, check.Message = CheckFail, path+" is not a directory"
+		check.Hint = "Move the file away so mcp-hub can create the directory"
+		return check
+	}
+
+	if err := probeWritable(path); err != nil {
+		check.Status, check.Message = CheckFail, fmt.Sprintf("%s is not writable: %v", path, err)
+		check.Hint = fmt.Sprintf("Fix the ownership or run `chmod u+rwx %s`", path)
+		return check
+	}
+
+	mode := info.Mode().Perm()
+	check.Message = fmt.Sprintf("%s (%04o)", path, mode)
+	if d.checksPermissions() && mode&^expected != 0 {
+		check.Status = CheckWarn
+		check.Message += fmt.Sprintf(" is more open than %04o", expected)
+		check.Hint = fmt.Sprintf("Run `chmod %o %s`", expected, path)
+		return check
+	}
+	check.Status = CheckPass
+	return check
+}
+
+// checksPermissions reports whether Unix permission bits are meaningful on this platform
+func (d *Doctor) checksPermissions() bool {
+	return d.PlatformService.GetPlatform() != platform.PlatformWindows
+}
+
+// probeWritable creates and removes a file in dir
+func probeWritable(dir string) error {
+	file, err := os.CreateTemp(dir, ".mcp-hub-doctor-*")
+	if err != nil {
+		return err
+	}
+	name := file.Name()
+	_ = file.Close()
+	return os.Remove(name)
+}
+
+// checkInventory validates inventory.json and returns the items it holds
+func (d *Doctor) checkInventory() ([]types.MCPItem, []DoctorCheck) {
+	check := DoctorCheck{Category: CheckCategoryInventory, Name: "inventory.json"}
+	path, _ := GetConfigPath(d.PlatformService)
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		check.Status, check.Message = CheckWarn, path+" does not exist"
+		check.Hint = "Add an MCP with `mcp-hub add` or the TUI to create it"
+		return nil, []DoctorCheck{check}
+	}
+	var data []byte
+	if err == nil {
+		data, err = readSecureFile(path)
+	}
+	if err != nil {
+		check.Status, check.Message = CheckFail, err.Error()
+		check.Hint = "Make the file readable by your user"
+		return nil, []DoctorCheck{check}
+	}
+
+	var inventory InventoryData
+	if err := json.Unmarshal(data, &inventory); err != nil {
+		check.Status, check.Message = CheckFail, fmt.Sprintf("%s is not valid JSON: %v", path, err)
+		check.Hint = "Fix the file by hand or restore a backup; mcp-hub moves unreadable inventories aside and starts empty"
+		return nil, []DoctorCheck{check}
+	}
+	check.Status, check.Message = CheckPass, fmt.Sprintf("%s (%d MCPs)", path, len(inventory.Inventory))
+	checks := []DoctorCheck{check}
+
+	version := DoctorCheck{Category: CheckCategoryInventory, Name: "schema version", Status: CheckPass, Message: inventory.Version}
+	if inventory.Version != configVersion {
+		version.Status = CheckWarn
+		version.Message = fmt.Sprintf("version %q, expected %q", inventory.Version, configVersion)
+		version.Hint = "Save the inventory from this mcp-hub version to rewrite it, for example with `mcp-hub edit`"
+	}
+	checks = append(checks, version)
+
+	if d.checksPermissions() && info.Mode().Perm()&^d.PlatformService.GetDefaultFilePermissions() != 0 {
+		checks = append(checks, DoctorCheck{
+			Category: CheckCategoryInventory, Name: "file permissions", Status: CheckWarn,
+			Message: fmt.Sprintf("%04o is more open than %04o, and the inventory may hold secrets", info.Mode().Perm(), d.PlatformService.GetDefaultFilePermissions()),
+			Hint:    fmt.Sprintf("Run `chmod %o %s`", d.PlatformService.GetDefaultFilePermissions(), path),
+		})
+	}
+	return inventory.Inventory, checks
+}
+
+// checkSettings reports a settings file the app ignores because it is invalid
+func (d *Doctor) checkSettings() DoctorCheck {
+	check := DoctorCheck{Category: CheckCategoryInventory, Name: "settings.json", Status: CheckPass}
+	path := GetSettingsPath(d.PlatformService)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		check.Message = "not present, using the defaults"
+		return check
+	}
+	if _, err := LoadSettings(d.PlatformService); err != nil {
+		check.Status, check.Message = CheckWarn, fmt.Sprintf("%v; the defaults are used instead", err)
+		check.Hint = "Press , in the TUI to review and save the settings, or fix " + path + " by hand"
+		return check
+	}
+	if _, err := LoadKeyMap(d.PlatformService); err != nil {
+		check.Status, check.Message = CheckWarn, fmt.Sprintf("%v; the default keys are used instead", err)
+		check.Hint = "Fix the \"keys\" entry of " + path + "; press ? in the TUI to see the actions"
+		return check
+	}
+	if err := checkThemeSetting(d.PlatformService); err != nil {
+		check.Status, check.Message = CheckWarn, fmt.Sprintf("%v; the dark theme is used instead", err)
+		check.Hint = "Fix the \"theme\" entry of " + path + " or the theme file under " + GetThemesDir(d.PlatformService)
+		return check
+	}
+	check.Message = path
+	return check
+}
+
+// checkServers looks for problems in the inventory entries themselves
+func (d *Doctor) checkServers(items []types.MCPItem) []DoctorCheck {
+	var checks []DoctorCheck
+	seen := make(map[string]int)
+	for _, item := range items {
+		seen[item.Name]++
+	}
+	for _, name := range sortedCountKeys(seen) {
+		if seen[name] > 1 {
+			checks = append(checks, DoctorCheck{
+				Category: CheckCategoryServers, Name: name, Status: CheckFail,
+				Message: fmt.Sprintf("name is used by %d entries", seen[name]),
+				Hint:    "Rename or remove the duplicates with `mcp-hub edit` or `mcp-hub remove`; only one of them can be enabled in Claude",
+			})
+		}
+	}
+
+	for _, item := range items {
+		if err := ValidateMCPItem(item, nil, ""); err != nil {
+			checks = append(checks, DoctorCheck{
+				Category: CheckCategoryServers, Name: item.Name, Status: CheckFail, Message: err.Error(),
+				Hint: fmt.Sprintf("Fix it with `mcp-hub edit %s`", item.Name),
+			})
+			continue
+		}
+		if check, ok := d.checkExecutable(item); ok {
+			checks = append(checks, check)
+		}
+		if missing := unresolvedEnvReferences(item, d.PlatformService); len(missing) > 0 {
+			checks = append(checks, DoctorCheck{
+				Category: CheckCategoryServers, Name: item.Name, Status: CheckWarn,
+				Message: "unresolved environment references: " + strings.Join(missing, ", "),
+				Hint:    "Export the variables before starting Claude, or use ${NAME:-default}",
+			})
+		}
+	}
+
+	if len(checks) == 0 {
+		checks = append(checks, DoctorCheck{
+			Category: CheckCategoryServers, Name: "inventory entries", Status: CheckPass,
+			Message: fmt.Sprintf("%d MCPs checked", len(items)),
+		})
+	}
+	return checks
+}
+
+// checkExecutable reports a stdio server whose command cannot be found. ok is false
+// when the item is fine or does not spawn a process.
+func (d *Doctor) checkExecutable(item types.MCPItem) (DoctorCheck, bool) {
+	kind, err := ResolveTransportKind(item)
+	if err != nil || kind != TransportStdio {
+		return DoctorCheck{}, false
+	}
+	config, err := ResolveStdioConfig(item)
+	if err != nil {
+		return DoctorCheck{}, false
+	}
+
+	command := config.Command
+	if envReference.MatchString(command) {
+		// Reported with the unresolved references instead
+		return DoctorCheck{}, false
+	}
+	if strings.ContainsRune(command, filepath.Separator) || strings.ContainsRune(command, '/') {
+		info, statErr := os.Stat(command)
+		if statErr == nil && !info.IsDir() && (!d.checksPermissions() || info.Mode()&0111 != 0) {
+			return DoctorCheck{}, false
+		}
+	} else if _, err := d.LookPath(command); err == nil {
+		return DoctorCheck{}, false
+	}
+
+	return DoctorCheck{
+		Category: CheckCategoryServers, Name: item.Name, Status: CheckFail,
+		Message: fmt.Sprintf("command %q was not found or is not executable", command),
+		Hint:    fmt.Sprintf("Install %s or point the MCP at it with `mcp-hub edit %s --command /path/to/%s`", filepath.Base(command), item.Name, filepath.Base(command)),
+	}, true
+}
+
+// unresolvedEnvReferences lists ${NAME} references in an item that have no value
+// in the environment and no default
+func unresolvedEnvReferences(item types.MCPItem, platformService platform.PlatformService) []string {
+	values := append([]string{item.Command, item.URL, item.JSONConfig}, item.Args...)
+	for _, value := range item.Environment {
+		values = append(values, value)
+	}
+	for _, value := range item.Headers {
+		values = append(values, value)
+	}
+
+	missing := make(map[string]int)
+	for _, value := range values {
+		for _, match := range envReference.FindAllStringSubmatch(value, -1) {
+			if match[2] == "" && platformService.GetEnvironmentVariable(match[1]) == "" {
+				missing[match[1]]++
+			}
+		}
+	}
+	return sortedCountKeys(missing)
+}
+
+// sortedCountKeys returns the keys of a count map in order
+func sortedCountKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkClipboard reports whether copy and paste in the forms will work
+func (d *Doctor) checkClipboard() DoctorCheck {
+	check := DoctorCheck{Category: CheckCategoryClipboard, Name: "clipboard"}
+	method := clipboardMethodName(d.PlatformService.GetClipboardMethod())
+	switch {
+	case !d.PlatformService.SupportsClipboard():
+		check.Status, check.Message = CheckWarn, "no clipboard tool found on "+d.PlatformService.GetPlatformName()+", so copy and paste in the forms will fail"
+		check.Hint = clipboardHint(d.PlatformService.GetPlatform())
+	case !d.ClipboardAvailable():
+		check.Status, check.Message = CheckWarn, method+" is configured but the clipboard could not be read"
+		check.Hint = clipboardHint(d.PlatformService.GetPlatform())
+	default:
+		check.Status, check.Message = CheckPass, method
+	}
+	return check
+}
+
+// clipboardMethodName names a clipboard access method for the report
+func clipboardMethodName(method platform.ClipboardMethod) string {
+	switch method {
+	case platform.ClipboardNative:
+		return "native clipboard"
+	case platform.ClipboardPbcopy:
+		return "pbcopy/pbpaste"
+	case platform.ClipboardXclip:
+		return "xclip"
+	case platform.ClipboardPowershell:
+		return "PowerShell clipboard"
+	case platform.ClipboardUnsupported:
+		return "no clipboard"
+	}
+	return "unknown clipboard method"
+}
+
+// clipboardHint suggests how to make the clipboard work on a platform
+func clipboardHint(platformType platform.PlatformType) string {
+	switch platformType {
+	case platform.PlatformLinux:
+		return "Install xclip or xsel (X11) or wl-clipboard (Wayland), and run mcp-hub inside a graphical session"
+	case platform.PlatformDarwin:
+		return "Check that pbcopy and pbpaste work in this terminal"
+	case platform.PlatformWindows:
+		return "Check that PowerShell's Get-Clipboard works in this terminal"
+	}
+	return "Clipboard access depends on the terminal and desktop environment"
+}
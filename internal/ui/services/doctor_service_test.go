@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// fakeDetector returns a fixed Claude status
+type fakeDetector struct {
+	status types.ClaudeStatus
+}
+
+func (f fakeDetector) DetectClaudeCLI(context.Context) types.ClaudeStatus {
+	return f.status
+}
+
+// newTestDoctor returns a doctor over temporary directories where every lookup succeeds
+func newTestDoctor(t *testing.T) (*Doctor, *platform.MockPlatformService) {
+	t.Helper()
+	tempDir := t.TempDir()
+	mockPlatform := platform.NewMockPlatformService()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+	mockPlatform.SetPlatform(platform.PlatformLinux)
+	mockPlatform.SetSupportsClipboard(true)
+	mockPlatform.SetClipboardMethod(platform.ClipboardXclip)
+	for _, dir := range []string{"logs", "mcp-hub", "cache"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	doctor := &Doctor{
+		PlatformService:    mockPlatform,
+		Claude:             fakeDetector{types.ClaudeStatus{Available: true, Version: "1.0.0"}},
+		LookPath:           func(file string) (string, error) { return "/usr/bin/" + file, nil },
+		ClipboardAvailable: func() bool { return true },
+	}
+	return doctor, mockPlatform
+}
+
+// findCheck returns the first check with the given category and name
+func findCheck(t *testing.T, report DoctorReport, category, name string) DoctorCheck {
+	t.Helper()
+	for _, check := range report.Checks {
+		if check.Category == category && check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("No %s check named %q in %+v", category, name, report.Checks)
+	return DoctorCheck{}
+}
+
+func TestDoctor_HealthySetup(t *testing.T) {
+	doctor, mockPlatform := newTestDoctor(t)
+	items := []types.MCPItem{
+		{Name: "github", Type: "CMD", Command: "github-mcp", Args: []string{"--token", "${GITHUB_TOKEN:-none}"}},
+		{Name: "docs", Type: "HTTP", URL: "https://docs.example.com/mcp"},
+	}
+	if err := SaveInventory(items, mockPlatform); err != nil {
+		t.Fatal(err)
+	}
+
+	report := doctor.Run(context.Background())
+	if report.Warnings != 0 || report.Failures != 0 || report.Passed != len(report.Checks) {
+		t.Errorf("Healthy setup should pass every check, got %+v", report)
+	}
+	if check := findCheck(t, report, CheckCategoryClaude, "version"); check.Message != "1.0.0" {
+		t.Errorf("Version check should report the version, got %+v", check)
+	}
+	if check := findCheck(t, report, CheckCategoryInventory, "inventory.json"); !strings.Contains(check.Message, "2 MCPs") {
+		t.Errorf("Inventory check should count the MCPs, got %+v", check)
+	}
+}
+
+func TestDoctor_ClaudeMissing(t *testing.T) {
+	doctor, _ := newTestDoctor(t)
+	doctor.LookPath = func(string) (string, error) { return "", errors.New("not found") }
+
+	report := doctor.Run(context.Background())
+	check := findCheck(t, report, CheckCategoryClaude, "location")
+	if check.Status != CheckFail || !strings.Contains(check.Hint, "Install Claude CLI") {
+		t.Errorf("Missing Claude should fail with the install guide, got %+v", check)
+	}
+	for _, check := range report.Checks {
+		if check.Name == "version" {
+			t.Error("Version should not be checked without a Claude binary")
+		}
+	}
+}
+
+func TestDoctor_PathProblems(t *testing.T) {
+	doctor, mockPlatform := newTestDoctor(t)
+	if err := os.Chmod(mockPlatform.GetLogPath(), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(mockPlatform.GetCachePath()); err != nil {
+		t.Fatal(err)
+	}
+
+	report := doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryPaths, "log directory"); check.Status != CheckWarn || !strings.Contains(check.Hint, fmt.Sprintf("chmod %o", mockPlatform.GetDefaultDirectoryPermissions())) {
+		t.Errorf("Open directory should warn with a chmod hint, got %+v", check)
+	}
+	if check := findCheck(t, report, CheckCategoryPaths, "cache directory"); check.Status != CheckWarn || !strings.Contains(check.Message, "does not exist") {
+		t.Errorf("Missing directory should warn, got %+v", check)
+	}
+	if check := findCheck(t, report, CheckCategoryInventory, "inventory.json"); check.Status != CheckWarn {
+		t.Errorf("Missing inventory should warn, got %+v", check)
+	}
+
+	mockPlatform.SetPlatform(platform.PlatformWindows)
+	report = doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryPaths, "log directory"); check.Status != CheckPass {
+		t.Errorf("Permission bits should be ignored on Windows, got %+v", check)
+	}
+}
+
+func TestDoctor_InventoryProblems(t *testing.T) {
+	doctor, mockPlatform := newTestDoctor(t)
+	path, _ := GetConfigPath(mockPlatform)
+
+	if err := os.WriteFile(path, []byte(`{"inventory": [`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	report := doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryInventory, "inventory.json"); check.Status != CheckFail || !strings.Contains(check.Message, "not valid JSON") {
+		t.Errorf("Corrupted inventory should fail, got %+v", check)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": "0.9", "inventory": []}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0666); err != nil {
+		t.Fatal(err)
+	}
+	report = doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryInventory, "schema version"); check.Status != CheckWarn || !strings.Contains(check.Message, "0.9") {
+		t.Errorf("Old schema version should warn, got %+v", check)
+	}
+	if check := findCheck(t, report, CheckCategoryInventory, "file permissions"); check.Status != CheckWarn {
+		t.Errorf("World-writable inventory should warn, got %+v", check)
+	}
+}
+
+func TestDoctor_Settings(t *testing.T) {
+	doctor, mockPlatform := newTestDoctor(t)
+
+	report := doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryInventory, "settings.json"); check.Status != CheckPass || !strings.Contains(check.Message, "defaults") {
+		t.Errorf("A missing settings file should pass, got %+v", check)
+	}
+
+	if err := os.WriteFile(GetSettingsPath(mockPlatform), []byte(`{"claude_timeout": "forever"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	report = doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryInventory, "settings.json"); check.Status != CheckWarn || !strings.Contains(check.Message, "forever") {
+		t.Errorf("An invalid settings file should warn, got %+v", check)
+	}
+
+	if err := os.WriteFile(GetSettingsPath(mockPlatform), []byte(`{"keys": {"add": "e"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	report = doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryInventory, "settings.json"); check.Status != CheckWarn || !strings.Contains(check.Message, "bound to both") {
+		t.Errorf("Conflicting key bindings should warn, got %+v", check)
+	}
+
+	if err := os.WriteFile(GetSettingsPath(mockPlatform), []byte(`{"theme": "solarized"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	report = doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryInventory, "settings.json"); check.Status != CheckWarn || !strings.Contains(check.Message, `unknown theme "solarized"`) {
+		t.Errorf("An unknown theme should warn, got %+v", check)
+	}
+}
+
+func TestDoctor_ServerProblems(t *testing.T) {
+	doctor, mockPlatform := newTestDoctor(t)
+	doctor.LookPath = func(file string) (string, error) {
+		if file == "missing-mcp" {
+			return "", errors.New("not found")
+		}
+		return "/usr/bin/" + file, nil
+	}
+	t.Setenv("MCP_HUB_DOCTOR_SET", "1")
+	items := []types.MCPItem{
+		{Name: "dup", Type: "CMD", Command: "a"},
+		{Name: "dup", Type: "CMD", Command: "b"},
+		{Name: "gone", Type: "CMD", Command: "missing-mcp"},
+		{Name: "abs", Type: "CMD", Command: filepath.Join(t.TempDir(), "nothing")},
+		{Name: "json", Type: "JSON", JSONConfig: `{"command": "missing-mcp"}`},
+		{Name: "broken", Type: "SSE"},
+		{Name: "env", Type: "HTTP", URL: "https://docs.example.com/mcp", Headers: map[string]string{"X": "${MCP_HUB_DOCTOR_SET}", "Y": "Bearer ${MCP_HUB_DOCTOR_UNSET}"}},
+	}
+	if err := SaveInventory(items, mockPlatform); err != nil {
+		t.Fatal(err)
+	}
+
+	report := doctor.Run(context.Background())
+	expected := map[string]struct {
+		status CheckStatus
+		text   string
+	}{
+		"dup":    {CheckFail, "used by 2 entries"},
+		"gone":   {CheckFail, `"missing-mcp" was not found`},
+		"abs":    {CheckFail, "not found or is not executable"},
+		"json":   {CheckFail, `"missing-mcp" was not found`},
+		"broken": {CheckFail, "URL is required"},
+		"env":    {CheckWarn, "MCP_HUB_DOCTOR_UNSET"},
+	}
+	for name, want := range expected {
+		check := findCheck(t, report, CheckCategoryServers, name)
+		if check.Status != want.status || !strings.Contains(check.Message, want.text) || check.Hint == "" {
+			t.Errorf("%s: got %+v, expected %s containing %q with a hint", name, check, want.status, want.text)
+		}
+	}
+	if check := findCheck(t, report, CheckCategoryServers, "env"); strings.Contains(check.Message, "MCP_HUB_DOCTOR_SET") {
+		t.Errorf("Set variables should not be reported, got %+v", check)
+	}
+}
+
+func TestDoctor_Clipboard(t *testing.T) {
+	doctor, mockPlatform := newTestDoctor(t)
+
+	doctor.ClipboardAvailable = func() bool { return false }
+	report := doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryClipboard, "clipboard"); check.Status != CheckWarn || !strings.Contains(check.Hint, "xclip") {
+		t.Errorf("Unreadable clipboard should warn with a Linux hint, got %+v", check)
+	}
+
+	mockPlatform.SetSupportsClipboard(false)
+	report = doctor.Run(context.Background())
+	if check := findCheck(t, report, CheckCategoryClipboard, "clipboard"); check.Status != CheckWarn || !strings.Contains(check.Message, "no clipboard tool") {
+		t.Errorf("Unsupported clipboard should warn, got %+v", check)
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"mcp-hub/internal/ui/types"
+)
+
+// HealthProbeTimeout bounds starting a server, the handshake and ping
+const HealthProbeTimeout = 15 * time.Second
+
+// ProbeMCP spawns the server, performs the initialize/initialized handshake and a ping
+func ProbeMCP(ctx context.Context, item types.MCPItem, logs *types.ServerLogStore) types.HealthResult {
+	result := types.HealthResult{
+		MCPName:   item.Name,
+		CheckedAt: time.Now(),
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, HealthProbeTimeout)
+	defer cancel()
+
+	session, err := OpenMCPSession(timeoutCtx, item, logs)
+	if err != nil {
+		result.Status = types.HealthUnhealthy
+		if errors.Is(err, ErrUnsupportedTransport) {
+			result.Status = types.HealthUnknown
+		}
+		result.Error = err.Error()
+		return result
+	}
+	defer func() {
+		_ = session.Close()
+	}()
+
+	result.StartupLatency = session.StartupLatency
+	result.ServerName = session.InitResult.ServerInfo.Name
+	result.ServerVersion = session.InitResult.ServerInfo.Version
+	result.ProtocolVersion = session.InitResult.ProtocolVersion
+	result.Capabilities = session.InitResult.Capabilities.Names()
+
+	pingLatency, err := session.Client.Ping(timeoutCtx)
+	if err != nil {
+		result.Status = types.HealthDegraded
+		result.Error = err.Error()
+		return result
+	}
+
+	result.PingLatency = pingLatency
+	result.Status = types.HealthHealthy
+	return result
+}
+
+// GetHealthBadge returns the grid badge for an MCP's last health probe, or "" if never probed
+func GetHealthBadge(model types.Model, item types.MCPItem) string {
+	if model.ProbingMCPs[item.Name] {
+		return "⋯"
+	}
+	result, ok := model.Health[item.Name]
+	if !ok {
+		return ""
+	}
+	switch result.Status {
+	case types.HealthHealthy:
+		return "✔"
+	case types.HealthDegraded:
+		return "!"
+	case types.HealthUnhealthy:
+		return "✘"
+	default:
+		return "?"
+	}
+}
+
+// FormatHealthStatus returns a human-readable name for a health status
+func FormatHealthStatus(status types.HealthStatus) string {
+	switch status {
+	case types.HealthHealthy:
+		return "Healthy"
+	case types.HealthDegraded:
+		return "Degraded"
+	case types.HealthUnhealthy:
+		return "Unhealthy"
+	default:
+		return "Unknown"
+	}
+}
+
+// FormatHealthForDisplay formats a health result as detail lines for the status column
+func FormatHealthForDisplay(result types.HealthResult) []string {
+	lines := []string{fmt.Sprintf("Health: %s", FormatHealthStatus(result.Status))}
+
+	if result.ServerName != "" {
+		server := result.ServerName
+		if result.ServerVersion != "" {
+			server += " " + result.ServerVersion
+		}
+		lines = append(lines, "Server: "+server)
+	}
+	if result.ProtocolVersion != "" {
+		lines = append(lines, "Protocol: "+result.ProtocolVersion)
+	}
+	if len(result.Capabilities) > 0 {
+		lines = append(lines, "Capabilities: "+strings.Join(result.Capabilities, ", "))
+	}
+	if result.StartupLatency > 0 {
+		lines = append(lines, "Startup: "+formatLatency(result.StartupLatency))
+	}
+	if result.PingLatency > 0 {
+		lines = append(lines, "Ping: "+formatLatency(result.PingLatency))
+	}
+	if result.Error != "" {
+		lines = append(lines, "Error: "+result.Error)
+	}
+	if !result.CheckedAt.IsZero() {
+		lines = append(lines, "Checked: "+result.CheckedAt.Format("15:04:05"))
+	}
+	return lines
+}
+
+// formatLatency formats a duration with millisecond precision
+func formatLatency(d time.Duration) string {
+	if d < time.Millisecond {
+		return "<1ms"
+	}
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/mcp/mcptest"
+	"mcp-hub/internal/ui/types"
+)
+
+// TestHelperProcess is not a real test; it runs a fake MCP server when spawned by probe tests
+func TestHelperProcess(_ *testing.T) {
+	mode := os.Getenv("MCP_HUB_FAKE_SERVER")
+	if mode == "" {
+		return
+	}
+	if mode == "replay" {
+		replay, err := mcp.LoadReplayServer(os.Getenv("MCP_HUB_REPLAY_FILE"))
+		if err != nil {
+			os.Exit(1)
+		}
+		_ = replay.Serve(os.Stdin, os.Stdout)
+		os.Exit(0)
+	}
+	server := mcptest.NewServer(mcp.Tool{Name: "echo", Description: "Echo the input back"})
+	server.HandleTool("echo", func(arguments map[string]interface{}) mcp.CallToolResult {
+		text, ok := arguments["text"].(string)
+		if !ok {
+			return mcp.CallToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: "text is required"}}, IsError: true}
+		}
+		return mcp.CallToolResult{Content: []mcp.ContentBlock{{Type: "text", Text: text}}}
+	})
+	if mode == "full" {
+		server.Capabilities.Resources = &mcp.ResourcesCapability{}
+		server.Capabilities.Prompts = &mcp.ListChangedCapability{}
+		server.Resources = []mcp.Resource{{URI: "file:///a", Name: "a"}, {URI: "file:///b", Name: "b"}, {URI: "file:///c", Name: "c"}}
+		server.Prompts = []mcp.Prompt{{Name: "review", Arguments: []mcp.PromptArgument{{Name: "diff"}}}}
+		server.PageSize = 2
+	}
+	if mode == "prompts-only" {
+		server.Capabilities = mcp.ServerCapabilities{Prompts: &mcp.ListChangedCapability{}}
+		server.Prompts = []mcp.Prompt{{Name: "review"}}
+		server.Handle(mcp.MethodToolsList, func(json.RawMessage) (interface{}, *mcp.RPCError) {
+			return nil, &mcp.RPCError{Code: mcp.ErrorCodeMethodNotFound, Message: "method not found"}
+		})
+	}
+	if mode == "noisy" {
+		_, _ = os.Stderr.WriteString("starting fake server\nWARN no config file found")
+		server.LogMessages = []mcp.LoggingMessageParams{{Level: "error", Logger: "db", Data: json.RawMessage(`"connection lost"`)}}
+	}
+	if mode == "no-ping" {
+		server.Handle(mcp.MethodPing, func(json.RawMessage) (interface{}, *mcp.RPCError) {
+			return nil, &mcp.RPCError{Code: mcp.ErrorCodeInternalError, Message: "ping disabled"}
+		})
+	}
+	server.Serve(os.Stdin, os.Stdout)
+	os.Exit(0)
+}
+
+// fakeServerItem returns an inventory item that spawns the test binary as an MCP server
+func fakeServerItem(mode string) types.MCPItem {
+	return types.MCPItem{
+		Name:        "fake",
+		Type:        "CMD",
+		Command:     os.Args[0],
+		Args:        []string{"-test.run=TestHelperProcess"},
+		Environment: map[string]string{"MCP_HUB_FAKE_SERVER": mode},
+	}
+}
+
+func TestProbeMCP_Healthy(t *testing.T) {
+	result := ProbeMCP(context.Background(), fakeServerItem("1"), nil)
+
+	if result.Status != types.HealthHealthy {
+		t.Fatalf("Status = %v, expected healthy (error: %s)", result.Status, result.Error)
+	}
+	if result.ServerName != "mcptest" || result.ProtocolVersion != mcp.LatestProtocolVersion {
+		t.Errorf("Unexpected server info: %+v", result)
+	}
+	if len(result.Capabilities) != 1 || result.Capabilities[0] != "tools" {
+		t.Errorf("Capabilities = %v, expected [tools]", result.Capabilities)
+	}
+	if result.StartupLatency <= 0 || result.PingLatency <= 0 {
+		t.Errorf("Expected latencies to be recorded, got startup=%v ping=%v", result.StartupLatency, result.PingLatency)
+	}
+}
+
+func TestProbeMCP_Degraded(t *testing.T) {
+	result := ProbeMCP(context.Background(), fakeServerItem("no-ping"), nil)
+
+	if result.Status != types.HealthDegraded {
+		t.Fatalf("Status = %v, expected degraded", result.Status)
+	}
+	if !strings.Contains(result.Error, "ping disabled") {
+		t.Errorf("Error = %q, expected ping failure", result.Error)
+	}
+	if result.ServerName == "" {
+		t.Error("Server info should be recorded even when ping fails")
+	}
+}
+
+func TestProbeMCP_FailuresAndUnsupported(t *testing.T) {
+	missing := ProbeMCP(context.Background(), types.MCPItem{Name: "missing", Type: "CMD", Command: "/nonexistent/mcp-server"}, nil)
+	if missing.Status != types.HealthUnhealthy || missing.Error == "" {
+		t.Errorf("Missing executable should be unhealthy, got %+v", missing)
+	}
+
+	noTransport := ProbeMCP(context.Background(), types.MCPItem{Name: "empty", Type: "JSON", JSONConfig: `{"env": {}}`}, nil)
+	if noTransport.Status != types.HealthUnknown {
+		t.Errorf("JSON item without command or URL should be unknown, got %v", noTransport.Status)
+	}
+
+	unreachable := ProbeMCP(context.Background(), types.MCPItem{Name: "remote", Type: "HTTP", URL: "http://127.0.0.1:1/mcp"}, nil)
+	if unreachable.Status != types.HealthUnhealthy {
+		t.Errorf("Unreachable HTTP server should be unhealthy, got %v", unreachable.Status)
+	}
+}
+
+func TestProbeMCP_URLTransports(t *testing.T) {
+	server := mcptest.NewServer(mcp.Tool{Name: "search"})
+	sseServer := httptest.NewServer(server.SSEHandler())
+	defer sseServer.Close()
+	httpServer := httptest.NewServer(server.StreamableHTTPHandler())
+	defer httpServer.Close()
+
+	items := []types.MCPItem{
+		{Name: "sse", Type: "SSE", URL: sseServer.URL + "/sse", Headers: map[string]string{"Authorization": "Bearer abc"}},
+		{Name: "http", Type: "HTTP", URL: httpServer.URL + "/mcp", Headers: map[string]string{"Authorization": "Bearer abc"}},
+		{Name: "json-http", Type: "JSON", JSONConfig: `{"type": "http", "url": "` + httpServer.URL + `", "headers": {"Authorization": "Bearer abc"}}`},
+	}
+	for _, item := range items {
+		t.Run(item.Name, func(t *testing.T) {
+			result := ProbeMCP(context.Background(), item, nil)
+			if result.Status != types.HealthHealthy {
+				t.Fatalf("Status = %v, expected healthy (error: %s)", result.Status, result.Error)
+			}
+			if result.ServerName != "mcptest" {
+				t.Errorf("ServerName = %q, expected mcptest", result.ServerName)
+			}
+			if got := server.LastHeader("Authorization"); got != "Bearer abc" {
+				t.Errorf("Configured headers should be sent, got %q", got)
+			}
+		})
+	}
+}
+
+func TestGetHealthBadge(t *testing.T) {
+	model := types.Model{
+		Health: map[string]types.HealthResult{
+			"ok":       {Status: types.HealthHealthy},
+			"degraded": {Status: types.HealthDegraded},
+			"down":     {Status: types.HealthUnhealthy},
+			"remote":   {Status: types.HealthUnknown},
+			"busy":     {Status: types.HealthHealthy},
+		},
+		ProbingMCPs: map[string]bool{"busy": true},
+	}
+
+	expected := map[string]string{"ok": "✔", "degraded": "!", "down": "✘", "remote": "?", "busy": "⋯", "never": ""}
+	for name, badge := range expected {
+		if got := GetHealthBadge(model, types.MCPItem{Name: name}); got != badge {
+			t.Errorf("GetHealthBadge(%s) = %q, expected %q", name, got, badge)
+		}
+	}
+}
+
+func TestFormatHealthForDisplay(t *testing.T) {
+	lines := FormatHealthForDisplay(types.HealthResult{
+		Status:          types.HealthHealthy,
+		ServerName:      "github",
+		ServerVersion:   "1.2.0",
+		ProtocolVersion: "2025-06-18",
+		Capabilities:    []string{"tools", "resources"},
+		StartupLatency:  320 * time.Millisecond,
+		PingLatency:     200 * time.Microsecond,
+	})
+	text := strings.Join(lines, "\n")
+
+	for _, fragment := range []string{"Health: Healthy", "Server: github 1.2.0", "Protocol: 2025-06-18", "Capabilities: tools, resources", "Startup: 320ms", "Ping: <1ms"} {
+		if !strings.Contains(text, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, text)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// IntrospectionTimeout bounds starting a server and listing its tools, resources and prompts
+	IntrospectionTimeout = 30 * time.Second
+
+	introspectionCacheFile    = "introspection.json"
+	introspectionCacheVersion = "1.1"
+
+	// charsPerToken approximates how many characters of JSON a model token covers
+	charsPerToken = 4
+)
+
+// ErrUnsupportedTransport is returned for MCP configurations mcp-hub cannot connect to
+var ErrUnsupportedTransport = errors.New("introspection is not supported for this MCP type")
+
+// introspectionCache is the on-disk format of the introspection cache
+type introspectionCache struct {
+	Version string                               `json:"version"`
+	Entries map[string]types.IntrospectionResult `json:"entries"`
+}
+
+// jsonServerConfig mirrors the command fields of a JSON-type MCP configuration
+type jsonServerConfig struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+}
+
+// ResolveStdioConfig converts an inventory item into the configuration needed to spawn it
+func ResolveStdioConfig(item types.MCPItem) (mcp.StdioConfig, error) {
+	switch strings.ToUpper(item.Type) {
+	case "SSE", "HTTP":
+		return mcp.StdioConfig{}, ErrUnsupportedTransport
+	case "JSON":
+		var config jsonServerConfig
+		if err := json.Unmarshal([]byte(item.JSONConfig), &config); err != nil {
+			return mcp.StdioConfig{}, fmt.Errorf("invalid JSON configuration: %w", err)
+		}
+		if config.Command == "" {
+			return mcp.StdioConfig{}, ErrUnsupportedTransport
+		}
+		env := make(map[string]string, len(config.Env)+len(item.Environment))
+		for key, value := range config.Env {
+			env[key] = value
+		}
+		for key, value := range item.Environment {
+			env[key] = value
+		}
+		return mcp.StdioConfig{Command: config.Command, Args: config.Args, Env: env}, nil
+	default:
+		command, args := strings.TrimSpace(item.Command), item.Args
+		// Older inventories store the whole command line in Command
+		if len(args) == 0 && strings.ContainsAny(command, " \t") {
+			fields := strings.Fields(command)
+			command, args = fields[0], fields[1:]
+		}
+		if command == "" {
+			return mcp.StdioConfig{}, fmt.Errorf("MCP '%s' has no command configured", item.Name)
+		}
+		return mcp.StdioConfig{Command: command, Args: args, Env: item.Environment}, nil
+	}
+}
+
+// IntrospectMCP starts the server, performs the MCP handshake and lists its tools,
+// plus its resources and prompts when the server advertises them
+func IntrospectMCP(ctx context.Context, item types.MCPItem, logs *types.ServerLogStore) types.IntrospectionResult {
+	result := types.IntrospectionResult{
+		MCPName:    item.Name,
+		ConfigHash: ComputeConfigHash(item),
+		FetchedAt:  time.Now(),
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, IntrospectionTimeout)
+	defer cancel()
+
+	session, err := OpenMCPSession(timeoutCtx, item, logs)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer func() {
+		_ = session.Close()
+	}()
+
+	result.ServerName = session.InitResult.ServerInfo.Name
+	result.ServerVersion = session.InitResult.ServerInfo.Version
+	result.ProtocolVersion = session.InitResult.ProtocolVersion
+	capabilities := session.InitResult.Capabilities
+	result.Capabilities = capabilities.Names()
+
+	// Servers may offer only prompts or resources
+	if capabilities.Tools != nil {
+		tools, err := session.Client.ListTools(timeoutCtx)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+
+		result.Tools = ConvertTools(tools)
+		for _, tool := range result.Tools {
+			result.TokenEstimate += tool.TokenEstimate
+		}
+	}
+
+	if capabilities.Resources != nil {
+		resources, err := session.Client.ListResources(timeoutCtx)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Resources = ConvertResources(resources)
+	}
+
+	if capabilities.Prompts != nil {
+		prompts, err := session.Client.ListPrompts(timeoutCtx)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Prompts = ConvertPrompts(prompts)
+	}
+
+	return result
+}
+
+// ConvertResources converts protocol resources into UI resource info
+func ConvertResources(resources []mcp.Resource) []types.ResourceInfo {
+	infos := make([]types.ResourceInfo, 0, len(resources))
+	for _, resource := range resources {
+		infos = append(infos, types.ResourceInfo{
+			URI:         resource.URI,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MimeType:    resource.MimeType,
+		})
+	}
+	return infos
+}
+
+// ConvertPrompts converts protocol prompts into UI prompt info
+func ConvertPrompts(prompts []mcp.Prompt) []types.PromptInfo {
+	infos := make([]types.PromptInfo, 0, len(prompts))
+	for _, prompt := range prompts {
+		info := types.PromptInfo{Name: prompt.Name, Description: prompt.Description}
+		for _, argument := range prompt.Arguments {
+			info.Arguments = append(info.Arguments, argument.Name)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// ConvertTools converts protocol tools into UI tool info with token estimates
+func ConvertTools(tools []mcp.Tool) []types.ToolInfo {
+	infos := make([]types.ToolInfo, 0, len(tools))
+	for _, tool := range tools {
+		infos = append(infos, types.ToolInfo{
+			Name:          tool.Name,
+			Description:   tool.Description,
+			InputSchema:   tool.InputSchema,
+			TokenEstimate: EstimateToolTokens(tool),
+		})
+	}
+	return infos
+}
+
+// EstimateToolTokens estimates the context cost of a tool definition as the model sees it
+func EstimateToolTokens(tool mcp.Tool) int {
+	definition := struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		InputSchema json.RawMessage `json:"input_schema,omitempty"`
+	}{tool.Name, tool.Description, tool.InputSchema}
+
+	data, err := json.Marshal(definition)
+	if err != nil {
+		return EstimateTokens(tool.Name + tool.Description + string(tool.InputSchema))
+	}
+	return EstimateTokens(string(data))
+}
+
+// EstimateTokens approximates the token count of text using a characters-per-token heuristic
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// ComputeConfigHash fingerprints the parts of an MCP configuration that affect its tools
+func ComputeConfigHash(item types.MCPItem) string {
+	hasher := sha256.New()
+	fields := []string{item.Type, item.Command, strings.Join(item.Args, "\x00"), item.URL, item.JSONConfig}
+	for _, key := range sortedKeys(item.Environment) {
+		fields = append(fields, key+"="+item.Environment[key])
+	}
+	// Headers are appended only when present so hashes of existing items stay stable
+	for _, key := range sortedKeys(item.Headers) {
+		fields = append(fields, "header:"+key+"="+item.Headers[key])
+	}
+	for _, field := range fields {
+		hasher.Write([]byte(field))
+		hasher.Write([]byte{0})
+	}
+	return hex.EncodeToString(hasher.Sum(nil))[:16]
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getIntrospectionCachePath returns the path of the introspection cache file
+func getIntrospectionCachePath(platformService platform.PlatformService) string {
+	return filepath.Join(platformService.GetCachePath(), introspectionCacheFile)
+}
+
+// LoadIntrospectionCache loads cached introspection results, dropping entries whose
+// configuration no longer matches the inventory
+func LoadIntrospectionCache(platformService platform.PlatformService, mcpItems []types.MCPItem) map[string]types.IntrospectionResult {
+	entries := make(map[string]types.IntrospectionResult)
+
+	data, err := readSecureFile(getIntrospectionCachePath(platformService))
+	if err != nil {
+		return entries
+	}
+
+	var cache introspectionCache
+	if err := json.Unmarshal(data, &cache); err != nil || cache.Version != introspectionCacheVersion {
+		return entries
+	}
+
+	for _, item := range mcpItems {
+		entry, ok := cache.Entries[item.Name]
+		if ok && entry.ConfigHash == ComputeConfigHash(item) {
+			entries[item.Name] = entry
+		}
+	}
+	return entries
+}
+
+// SaveIntrospectionCache writes introspection results to the cache directory
+func SaveIntrospectionCache(platformService platform.PlatformService, entries map[string]types.IntrospectionResult) error {
+	cacheDir := platformService.GetCachePath()
+	if err := os.MkdirAll(cacheDir, platformService.GetDefaultDirectoryPermissions()); err != nil {
+		return fmt.Errorf("failed to create cache directory %s: %w", cacheDir, err)
+	}
+
+	data, err := json.MarshalIndent(introspectionCache{
+		Version: introspectionCacheVersion,
+		Entries: entries,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal introspection cache: %w", err)
+	}
+
+	cachePath := getIntrospectionCachePath(platformService)
+	tempPath := cachePath + ".tmp"
+	if err := os.WriteFile(tempPath, data, platformService.GetDefaultFilePermissions()); err != nil {
+		return fmt.Errorf("failed to write introspection cache: %w", err)
+	}
+	if err := os.Rename(tempPath, cachePath); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename introspection cache: %w", err)
+	}
+	return nil
+}
+
+// GetIntrospection returns the current introspection result for an item, if still valid
+func GetIntrospection(model types.Model, item types.MCPItem) (types.IntrospectionResult, bool) {
+	return validIntrospection(model.Introspection, item)
+}
+
+// validIntrospection returns the successful result in results for the item's current configuration
+func validIntrospection(results map[string]types.IntrospectionResult, item types.MCPItem) (types.IntrospectionResult, bool) {
+	result, ok := results[item.Name]
+	if !ok || result.Error != "" || result.ConfigHash != ComputeConfigHash(item) {
+		return types.IntrospectionResult{}, false
+	}
+	return result, true
+}
+
+// GetContextBudget sums the estimated token cost of active MCPs.
+// unknown counts active MCPs that have not been introspected yet.
+func GetContextBudget(model types.Model) (total int, unknown int) {
+	for _, item := range model.MCPItems {
+		if !item.Active {
+			continue
+		}
+		if result, ok := GetIntrospection(model, item); ok {
+			total += FilteredTokenEstimate(item, result)
+		} else {
+			unknown++
+		}
+	}
+	return total, unknown
+}
+
+// FormatTokenCount formats a token estimate compactly, e.g. 850, 1.2k, 14k
+func FormatTokenCount(tokens int) string {
+	switch {
+	case tokens < 1000:
+		return fmt.Sprintf("%d", tokens)
+	case tokens < 10000:
+		return fmt.Sprintf("%.1fk", float64(tokens)/1000)
+	default:
+		return fmt.Sprintf("%dk", (tokens+500)/1000)
+	}
+}
+
+// FormatContextBudgetForDisplay formats the active set's estimated context cost for the header
+func FormatContextBudgetForDisplay(model types.Model) string {
+	total, unknown := GetContextBudget(model)
+	if total == 0 && unknown == 0 {
+		return ""
+	}
+
+	text := fmt.Sprintf("Context: ~%s tokens", FormatTokenCount(total))
+	if unknown > 0 {
+		text += fmt.Sprintf(" (+%d unmeasured)", unknown)
+	}
+	if total > types.ContextBudgetWarningTokens {
+		text = "⚠ " + text
+	}
+	return text
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+func newTempCachePlatform(t *testing.T) *platform.MockPlatformService {
+	t.Helper()
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/config", tempDir+"/tmp", tempDir+"/cache")
+	return mockPlatform
+}
+
+func TestResolveStdioConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		item            types.MCPItem
+		expectedCommand string
+		expectedArgs    []string
+		expectedErr     error
+	}{
+		{
+			name:            "Command with args",
+			item:            types.MCPItem{Name: "gh", Type: "CMD", Command: "npx", Args: []string{"-y", "gh-mcp"}},
+			expectedCommand: "npx",
+			expectedArgs:    []string{"-y", "gh-mcp"},
+		},
+		{
+			name:            "Legacy command line in Command",
+			item:            types.MCPItem{Name: "ctx", Type: "CMD", Command: "npx @context7/mcp-server"},
+			expectedCommand: "npx",
+			expectedArgs:    []string{"@context7/mcp-server"},
+		},
+		{
+			name:            "JSON configuration",
+			item:            types.MCPItem{Name: "js", Type: "JSON", JSONConfig: `{"command":"node","args":["server.js"]}`},
+			expectedCommand: "node",
+			expectedArgs:    []string{"server.js"},
+		},
+		{
+			name:        "SSE is not spawnable",
+			item:        types.MCPItem{Name: "sse", Type: "SSE", URL: "http://localhost:8080/sse"},
+			expectedErr: ErrUnsupportedTransport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ResolveStdioConfig(tt.item)
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Fatalf("Expected error %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if config.Command != tt.expectedCommand || !reflect.DeepEqual(config.Args, tt.expectedArgs) {
+				t.Errorf("Got %s %v, expected %s %v", config.Command, config.Args, tt.expectedCommand, tt.expectedArgs)
+			}
+		})
+	}
+}
+
+func TestResolveStdioConfig_BlankCommand(t *testing.T) {
+	for _, command := range []string{"", " ", "\t "} {
+		if _, err := ResolveStdioConfig(types.MCPItem{Name: "blank", Type: "CMD", Command: command}); err == nil {
+			t.Errorf("Command %q should be rejected", command)
+		}
+	}
+}
+
+func TestResolveStdioConfig_JSONEnvironmentMerge(t *testing.T) {
+	item := types.MCPItem{
+		Name:        "js",
+		Type:        "JSON",
+		JSONConfig:  `{"command":"node","env":{"A":"json","B":"json"}}`,
+		Environment: map[string]string{"B": "item"},
+	}
+
+	config, err := ResolveStdioConfig(item)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Env["A"] != "json" || config.Env["B"] != "item" {
+		t.Errorf("Item environment should override JSON env, got %v", config.Env)
+	}
+}
+
+func TestIntrospectMCP_UnsupportedAndMissing(t *testing.T) {
+	result := IntrospectMCP(t.Context(), types.MCPItem{Name: "sse", Type: "SSE", URL: "http://localhost"}, nil)
+	if result.Error == "" {
+		t.Error("Expected error for SSE item")
+	}
+
+	result = IntrospectMCP(t.Context(), types.MCPItem{Name: "missing", Type: "CMD", Command: "/nonexistent/mcp-server"}, nil)
+	if result.Error == "" {
+		t.Error("Expected error for missing executable")
+	}
+	if result.ConfigHash == "" {
+		t.Error("ConfigHash should be set even on failure")
+	}
+}
+
+func TestIntrospectMCP_ToolsResourcesAndPrompts(t *testing.T) {
+	item := fakeServerItem("full")
+	result := IntrospectMCP(t.Context(), item, nil)
+
+	if result.Error != "" {
+		t.Fatalf("IntrospectMCP failed: %s", result.Error)
+	}
+	if len(result.Tools) != 1 || result.Tools[0].Name != "echo" || result.TokenEstimate == 0 {
+		t.Errorf("Unexpected tools: %+v (estimate %d)", result.Tools, result.TokenEstimate)
+	}
+	if len(result.Resources) != 3 || result.Resources[2].URI != "file:///c" {
+		t.Errorf("Resources should be fetched across pages, got %+v", result.Resources)
+	}
+	if len(result.Prompts) != 1 || result.Prompts[0].Arguments[0] != "diff" {
+		t.Errorf("Unexpected prompts: %+v", result.Prompts)
+	}
+	if result.ConfigHash != ComputeConfigHash(item) {
+		t.Error("ConfigHash should match the item")
+	}
+}
+
+func TestIntrospectMCP_SkipsUnadvertisedLists(t *testing.T) {
+	result := IntrospectMCP(t.Context(), fakeServerItem("1"), nil)
+
+	if result.Error != "" {
+		t.Fatalf("IntrospectMCP failed: %s", result.Error)
+	}
+	if len(result.Resources) != 0 || len(result.Prompts) != 0 {
+		t.Errorf("Resources and prompts should not be listed without the capability, got %+v", result)
+	}
+}
+
+func TestIntrospectMCP_WithoutTools(t *testing.T) {
+	result := IntrospectMCP(t.Context(), fakeServerItem("prompts-only"), nil)
+
+	if result.Error != "" {
+		t.Fatalf("A server without tools should introspect cleanly, got %s", result.Error)
+	}
+	if len(result.Tools) != 0 || len(result.Prompts) != 1 {
+		t.Errorf("Only the prompts should be listed, got %+v", result)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if EstimateTokens("") != 0 {
+		t.Error("Empty text should cost 0 tokens")
+	}
+	if got := EstimateTokens("abcd"); got != 1 {
+		t.Errorf("EstimateTokens(4 chars) = %d, expected 1", got)
+	}
+	if got := EstimateTokens("abcde"); got != 2 {
+		t.Errorf("EstimateTokens(5 chars) = %d, expected 2", got)
+	}
+
+	small := EstimateToolTokens(mcp.Tool{Name: "a"})
+	large := EstimateToolTokens(mcp.Tool{
+		Name:        "a",
+		Description: strings.Repeat("long description ", 20),
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"x":{"type":"string"}}}`),
+	})
+	if large <= small {
+		t.Errorf("Tool with description and schema should cost more (%d <= %d)", large, small)
+	}
+}
+
+func TestComputeConfigHash(t *testing.T) {
+	item := types.MCPItem{Name: "gh", Type: "CMD", Command: "gh-mcp", Environment: map[string]string{"A": "1", "B": "2"}}
+	hash := ComputeConfigHash(item)
+
+	same := item
+	same.Active = true
+	same.Environment = map[string]string{"B": "2", "A": "1"}
+	if ComputeConfigHash(same) != hash {
+		t.Error("Hash should ignore active state and map ordering")
+	}
+
+	changed := item
+	changed.Args = []string{"--verbose"}
+	if ComputeConfigHash(changed) == hash {
+		t.Error("Hash should change when args change")
+	}
+
+	withHeaders := item
+	withHeaders.Headers = map[string]string{"Authorization": "Bearer abc"}
+	if ComputeConfigHash(withHeaders) == hash {
+		t.Error("Hash should change when headers change")
+	}
+	withHeaders.Headers = map[string]string{}
+	if ComputeConfigHash(withHeaders) != hash {
+		t.Error("Empty headers should not change the hash")
+	}
+}
+
+func TestIntrospectionCache_RoundTripAndInvalidation(t *testing.T) {
+	mockPlatform := newTempCachePlatform(t)
+	items := []types.MCPItem{
+		{Name: "gh", Type: "CMD", Command: "gh-mcp"},
+		{Name: "fs", Type: "CMD", Command: "fs-mcp"},
+	}
+
+	entries := map[string]types.IntrospectionResult{
+		"gh": {MCPName: "gh", ConfigHash: ComputeConfigHash(items[0]), TokenEstimate: 1200, FetchedAt: time.Now()},
+		"fs": {MCPName: "fs", ConfigHash: ComputeConfigHash(items[1]), TokenEstimate: 300, FetchedAt: time.Now()},
+	}
+	if err := SaveIntrospectionCache(mockPlatform, entries); err != nil {
+		t.Fatalf("SaveIntrospectionCache failed: %v", err)
+	}
+
+	loaded := LoadIntrospectionCache(mockPlatform, items)
+	if len(loaded) != 2 || loaded["gh"].TokenEstimate != 1200 {
+		t.Fatalf("Expected both entries to load, got %+v", loaded)
+	}
+
+	// Changing the configuration invalidates the cached entry
+	items[1].Args = []string{"--root", "/tmp"}
+	loaded = LoadIntrospectionCache(mockPlatform, items)
+	if _, ok := loaded["fs"]; ok {
+		t.Error("Entry for changed configuration should be invalidated")
+	}
+	if _, ok := loaded["gh"]; !ok {
+		t.Error("Entry for unchanged configuration should survive")
+	}
+}
+
+func TestLoadIntrospectionCache_Missing(t *testing.T) {
+	loaded := LoadIntrospectionCache(newTempCachePlatform(t), []types.MCPItem{{Name: "gh"}})
+	if len(loaded) != 0 {
+		t.Errorf("Expected empty cache, got %+v", loaded)
+	}
+}
+
+func TestGetContextBudget(t *testing.T) {
+	model := createTestModel()
+	model.Introspection = map[string]types.IntrospectionResult{
+		"context7":   {MCPName: "context7", ConfigHash: ComputeConfigHash(model.MCPItems[0]), TokenEstimate: 15000},
+		"github-mcp": {MCPName: "github-mcp", ConfigHash: "stale", TokenEstimate: 9000},
+		"ht-mcp":     {MCPName: "ht-mcp", ConfigHash: ComputeConfigHash(model.MCPItems[2]), TokenEstimate: 500},
+	}
+
+	total, unknown := GetContextBudget(model)
+	if total != 15000 {
+		t.Errorf("Total = %d, expected only the active, valid entry (15000)", total)
+	}
+	if unknown != 1 {
+		t.Errorf("Unknown = %d, expected 1 (stale github-mcp)", unknown)
+	}
+
+	display := FormatContextBudgetForDisplay(model)
+	if !strings.Contains(display, "~15k tokens") || !strings.Contains(display, "+1 unmeasured") {
+		t.Errorf("Unexpected budget display: %q", display)
+	}
+	if strings.Contains(display, "⚠") {
+		t.Error("Budget under threshold should not warn")
+	}
+
+	model.Introspection["github-mcp"] = types.IntrospectionResult{ConfigHash: ComputeConfigHash(model.MCPItems[1]), TokenEstimate: 9000}
+	if display := FormatContextBudgetForDisplay(model); !strings.HasPrefix(display, "⚠") {
+		t.Errorf("Budget over threshold should warn, got %q", display)
+	}
+}
+
+func TestGetContextBudget_CountsOnlyExposedTools(t *testing.T) {
+	model := createTestModel()
+	item := model.MCPItems[0]
+	model.Introspection = map[string]types.IntrospectionResult{
+		"context7": {
+			MCPName:       "context7",
+			ConfigHash:    ComputeConfigHash(item),
+			Tools:         []types.ToolInfo{{Name: "resolve", TokenEstimate: 1000}, {Name: "get_docs", TokenEstimate: 14000}},
+			TokenEstimate: 15000,
+		},
+	}
+	model.MCPItems[0].ToolDeny = []string{"get_*"}
+
+	if total, _ := GetContextBudget(model); total != 1000 {
+		t.Errorf("Total = %d, expected only the allowed tool (1000)", total)
+	}
+}
+
+func TestFormatTokenCount(t *testing.T) {
+	tests := map[int]string{0: "0", 850: "850", 1234: "1.2k", 9999: "10.0k", 14400: "14k", 15600: "16k"}
+	for tokens, expected := range tests {
+		if got := FormatTokenCount(tokens); got != expected {
+			t.Errorf("FormatTokenCount(%d) = %q, expected %q", tokens, got, expected)
+		}
+	}
+}
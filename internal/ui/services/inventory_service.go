@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// Export formats understood by ExportInventory and ParseInventoryImport
+const (
+	// FormatInventory is mcp-hub's own inventory file format
+	FormatInventory = "inventory"
+	// FormatClaude is the mcpServers object used by Claude and other MCP clients
+	FormatClaude = "claude"
+)
+
+// ErrMCPNameExists reports an add or rename onto a name already in the inventory
+var ErrMCPNameExists = errors.New("name already exists")
+
+// claudeServersFile is the mcpServers document used by Claude's .mcp.json and settings files
+type claudeServersFile struct {
+	MCPServers map[string]json.RawMessage `json:"mcpServers"`
+}
+
+// ImportResult lists what merging imported items changed, by name
+type ImportResult struct {
+	Added    []string `json:"added"`
+	Replaced []string `json:"replaced"`
+	Skipped  []string `json:"skipped"`
+}
+
+// MCPToggler is the part of ClaudeService that adds and removes MCPs in Claude
+type MCPToggler interface {
+	ToggleMCPStatus(ctx context.Context, mcpName string, activate bool, mcpConfig *types.MCPItem) (*ToggleResult, error)
+}
+
+// SetMCPActive changes items[index] in Claude, then saves its new state in the inventory
+func SetMCPActive(ctx context.Context, toggler MCPToggler, platformService platform.PlatformService, items []types.MCPItem, index int, enable bool) error {
+	item := &items[index]
+	result, err := toggler.ToggleMCPStatus(ctx, item.Name, enable, item)
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		return fmt.Errorf("%s", result.ErrorMsg)
+	}
+	item.Active = enable
+	if err := SaveInventory(items, platformService); err != nil {
+		return fmt.Errorf("changed in Claude but failed to save the inventory: %w", err)
+	}
+	return nil
+}
+
+// SetMCPServed changes whether mcp-hub serve proxies items[index] and saves the inventory.
+// Claude is not involved: a running serve picks the change up when it re-reads the inventory.
+func SetMCPServed(platformService platform.PlatformService, items []types.MCPItem, index int, served bool) error {
+	items[index].Served = served
+	if err := SaveInventory(items, platformService); err != nil {
+		return fmt.Errorf("failed to save the inventory: %w", err)
+	}
+	return nil
+}
+
+// InferMCPType returns the type of an item given without one: CMD for a command, SSE
+// for a bare URL, as the TUI's URL form creates, and JSON for a JSON configuration
+func InferMCPType(item types.MCPItem) string {
+	switch {
+	case item.Command != "":
+		return "CMD"
+	case item.URL != "":
+		return "SSE"
+	case item.JSONConfig != "":
+		return "JSON"
+	}
+	return ""
+}
+
+// FindMCPIndex returns the position of the named item in items, or -1
+func FindMCPIndex(items []types.MCPItem, name string) int {
+	for i, item := range items {
+		if item.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ValidateMCPItem checks an item with the same rules as the add and edit forms.
+// originalName is the item's current name when editing and empty when adding.
+func ValidateMCPItem(item types.MCPItem, items []types.MCPItem, originalName string) error {
+	if strings.TrimSpace(item.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if item.Name != originalName && FindMCPIndex(items, item.Name) >= 0 {
+		return fmt.Errorf("%s: %w", item.Name, ErrMCPNameExists)
+	}
+
+	switch strings.ToUpper(item.Type) {
+	case "CMD":
+		if item.Command == "" {
+			return fmt.Errorf("command is required")
+		}
+	case "SSE", "HTTP":
+		if item.URL == "" {
+			return fmt.Errorf("URL is required")
+		}
+		parsedURL, err := url.Parse(item.URL)
+		if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+			return fmt.Errorf("invalid URL %q - must include protocol (http:// or https://)", item.URL)
+		}
+	case "JSON":
+		if item.JSONConfig == "" {
+			return fmt.Errorf("JSON configuration is required")
+		}
+		var config interface{}
+		if err := json.Unmarshal([]byte(item.JSONConfig), &config); err != nil {
+			return fmt.Errorf("invalid JSON configuration: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown type %q (expected CMD, SSE, HTTP or JSON)", item.Type)
+	}
+
+	for key := range item.Environment {
+		if !isEnvironmentKey(key) {
+			return fmt.Errorf("invalid environment key '%s' - use letters, numbers, and underscores only", key)
+		}
+	}
+	if !IsClaudeScope(item.Scope) {
+		return fmt.Errorf("unknown scope %q (expected %s, %s or %s)", item.Scope, ScopeLocal, ScopeProject, ScopeUser)
+	}
+	if err := ValidateToolPatterns(item.ToolAllow); err != nil {
+		return err
+	}
+	return ValidateToolPatterns(item.ToolDeny)
+}
+
+// isEnvironmentKey reports whether key is a usable environment variable name
+func isEnvironmentKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// ExportInventory renders items in the given format
+func ExportInventory(items []types.MCPItem, format string) ([]byte, error) {
+	switch format {
+	case "", FormatInventory:
+		return json.MarshalIndent(InventoryData{
+			Version:   configVersion,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Inventory: items,
+		}, "", "  ")
+	case FormatClaude:
+		servers := make(map[string]json.RawMessage, len(items))
+		for _, item := range items {
+			server, err := claudeServerConfig(item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", item.Name, err)
+			}
+			servers[item.Name] = server
+		}
+		return json.MarshalIndent(claudeServersFile{MCPServers: servers}, "", "  ")
+	}
+	return nil, fmt.Errorf("unknown export format %q (expected %s or %s)", format, FormatInventory, FormatClaude)
+}
+
+// claudeServerConfig converts an item into its mcpServers entry
+func claudeServerConfig(item types.MCPItem) (json.RawMessage, error) {
+	var config jsonServerConfig
+	switch strings.ToUpper(item.Type) {
+	case "JSON":
+		if err := json.Unmarshal([]byte(item.JSONConfig), &config); err != nil {
+			return nil, fmt.Errorf("invalid JSON configuration: %w", err)
+		}
+		if len(item.Environment) > 0 && config.Env == nil {
+			config.Env = make(map[string]string, len(item.Environment))
+		}
+		for key, value := range item.Environment {
+			config.Env[key] = value
+		}
+	case "SSE", "HTTP":
+		config = jsonServerConfig{Type: strings.ToLower(item.Type), URL: item.URL, Headers: item.Headers}
+	default:
+		config = jsonServerConfig{Command: item.Command, Args: item.Args, Env: item.Environment}
+	}
+
+	// Leave out empty fields so the entry reads like a hand-written one
+	entry := make(map[string]interface{})
+	for key, value := range map[string]interface{}{"type": config.Type, "url": config.URL, "command": config.Command} {
+		if value != "" {
+			entry[key] = value
+		}
+	}
+	if len(config.Args) > 0 {
+		entry["args"] = config.Args
+	}
+	if len(config.Env) > 0 {
+		entry["env"] = config.Env
+	}
+	if len(config.Headers) > 0 {
+		entry["headers"] = config.Headers
+	}
+	return json.Marshal(entry)
+}
+
+// ParseInventoryImport reads items from an inventory file, a bare array of items or
+// an mcpServers document
+func ParseInventoryImport(data []byte) ([]types.MCPItem, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(data, &document); err != nil {
+		var items []types.MCPItem
+		if arrayErr := json.Unmarshal(data, &items); arrayErr != nil {
+			return nil, fmt.Errorf("failed to parse import: %w", err)
+		}
+		return items, nil
+	}
+
+	switch {
+	case document["inventory"] != nil:
+		var inventory InventoryData
+		if err := json.Unmarshal(data, &inventory); err != nil {
+			return nil, fmt.Errorf("failed to parse inventory: %w", err)
+		}
+		return inventory.Inventory, nil
+	case document["mcpServers"] != nil:
+		var servers claudeServersFile
+		if err := json.Unmarshal(data, &servers); err != nil {
+			return nil, fmt.Errorf("failed to parse mcpServers: %w", err)
+		}
+		names := make([]string, 0, len(servers.MCPServers))
+		for name := range servers.MCPServers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		items := make([]types.MCPItem, 0, len(names))
+		for _, name := range names {
+			item, err := itemFromClaudeServer(name, servers.MCPServers[name])
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unrecognized import format: expected an inventory or an mcpServers object")
+}
+
+// itemFromClaudeServer converts one mcpServers entry into an inventory item
+func itemFromClaudeServer(name string, raw json.RawMessage) (types.MCPItem, error) {
+	var config jsonServerConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return types.MCPItem{}, fmt.Errorf("%s: %w", name, err)
+	}
+
+	item := types.MCPItem{Name: name}
+	switch {
+	case config.Command != "":
+		item.Type, item.Command, item.Args, item.Environment = "CMD", config.Command, config.Args, config.Env
+	case config.URL != "" && strings.EqualFold(config.Type, TransportSSE):
+		item.Type, item.URL, item.Headers = "SSE", config.URL, config.Headers
+	case config.URL != "":
+		item.Type, item.URL, item.Headers = "HTTP", config.URL, config.Headers
+	default:
+		// Keep entries mcp-hub cannot model as raw JSON
+		item.Type, item.JSONConfig = "JSON", string(raw)
+	}
+	return item, nil
+}
+
+// MergeInventory adds imported items to items. Items whose name is taken replace the
+// existing entry when replace is set, keeping its active state, and are skipped otherwise.
+func MergeInventory(items, imported []types.MCPItem, replace bool) ([]types.MCPItem, ImportResult) {
+	merged := append([]types.MCPItem{}, items...)
+	result := ImportResult{Added: []string{}, Replaced: []string{}, Skipped: []string{}}
+	for _, item := range imported {
+		index := FindMCPIndex(merged, item.Name)
+		switch {
+		case index < 0:
+			item.Active = false
+			merged = append(merged, item)
+			result.Added = append(result.Added, item.Name)
+		case replace:
+			item.Active = merged[index].Active
+			merged[index] = item
+			result.Replaced = append(result.Replaced, item.Name)
+		default:
+			result.Skipped = append(result.Skipped, item.Name)
+		}
+	}
+	return merged, result
+}
+
+// SyncReport compares the inventory's enabled set with what Claude reports
+type SyncReport struct {
+	Status          string   `json:"status"`
+	InSync          bool     `json:"in_sync"`
+	ClaudeAvailable bool     `json:"claude_available"`
+	ClaudeVersion   string   `json:"claude_version,omitempty"`
+	EnabledInHub    []string `json:"enabled_in_hub"`
+	ActiveInClaude  []string `json:"active_in_claude"`
+	OnlyInHub       []string `json:"only_in_hub,omitempty"`
+	OnlyInClaude    []string `json:"only_in_claude,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// BuildSyncReport compares items with a freshly refreshed Claude status
+func BuildSyncReport(items []types.MCPItem, status types.ClaudeStatus) SyncReport {
+	model := UpdateModelWithClaudeStatus(types.Model{MCPItems: items}, status)
+	syncStatus := GetSyncStatus(model)
+
+	report := SyncReport{
+		Status:          FormatSyncStatusText(syncStatus),
+		InSync:          syncStatus == types.SyncStatusInSync,
+		ClaudeAvailable: status.Available,
+		ClaudeVersion:   status.Version,
+		EnabledInHub:    ActiveMCPNames(items),
+		ActiveInClaude:  append([]string{}, status.ActiveMCPs...),
+		Error:           status.Error,
+	}
+	sort.Strings(report.ActiveInClaude)
+	report.OnlyInHub = stringDifference(report.EnabledInHub, report.ActiveInClaude)
+	report.OnlyInClaude = stringDifference(report.ActiveInClaude, report.EnabledInHub)
+	if report.EnabledInHub == nil {
+		report.EnabledInHub = []string{}
+	}
+	return report
+}
+
+// stringDifference returns the entries of a missing from b, keeping a's order
+func stringDifference(a, b []string) []string {
+	present := make(map[string]bool, len(b))
+	for _, name := range b {
+		present[name] = true
+	}
+	var missing []string
+	for _, name := range a {
+		if !present[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
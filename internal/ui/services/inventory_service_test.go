@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestInferMCPType(t *testing.T) {
+	tests := map[string]types.MCPItem{
+		"CMD":  {Command: "github-mcp", URL: "https://example.com"},
+		"SSE":  {URL: "https://example.com/sse"},
+		"JSON": {JSONConfig: `{"command":"raw"}`},
+		"":     {},
+	}
+	for expected, item := range tests {
+		if got := InferMCPType(item); got != expected {
+			t.Errorf("InferMCPType(%+v) = %q, expected %q", item, got, expected)
+		}
+	}
+}
+
+func TestValidateMCPItem(t *testing.T) {
+	items := []types.MCPItem{{Name: "github", Type: "CMD", Command: "github-mcp"}}
+
+	valid := []types.MCPItem{
+		{Name: "fs", Type: "CMD", Command: "fs-mcp", Environment: map[string]string{"ROOT_DIR": "/"}},
+		{Name: "search", Type: "sse", URL: "https://search.example.com/sse"},
+		{Name: "docs", Type: "HTTP", URL: "http://localhost:3000/mcp"},
+		{Name: "raw", Type: "JSON", JSONConfig: `{"command": "x"}`},
+	}
+	for _, item := range valid {
+		if err := ValidateMCPItem(item, items, ""); err != nil {
+			t.Errorf("%s should be valid, got %v", item.Name, err)
+		}
+	}
+
+	if err := ValidateMCPItem(types.MCPItem{Name: "github", Type: "CMD", Command: "x"}, items, ""); !errors.Is(err, ErrMCPNameExists) {
+		t.Errorf("Taken name should be rejected, got %v", err)
+	}
+	if err := ValidateMCPItem(types.MCPItem{Name: "github", Type: "CMD", Command: "x"}, items, "github"); err != nil {
+		t.Errorf("Editing should allow keeping the name, got %v", err)
+	}
+
+	invalid := map[string]types.MCPItem{
+		"name is required":        {Type: "CMD", Command: "x"},
+		"command is required":     {Name: "a", Type: "CMD"},
+		"invalid URL":             {Name: "a", Type: "SSE", URL: "localhost:3000"},
+		"invalid JSON":            {Name: "a", Type: "JSON", JSONConfig: "{"},
+		"unknown type":            {Name: "a", Type: "FTP"},
+		"invalid environment key": {Name: "a", Type: "CMD", Command: "x", Environment: map[string]string{"BAD-KEY": "1"}},
+		"invalid pattern":         {Name: "a", Type: "CMD", Command: "x", ToolDeny: []string{"["}},
+		"unknown scope":           {Name: "a", Type: "CMD", Command: "x", Scope: "global"},
+	}
+	for expected, item := range invalid {
+		if err := ValidateMCPItem(item, items, ""); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestExportInventory_ClaudeFormatRoundTrips(t *testing.T) {
+	items := []types.MCPItem{
+		{Name: "fs", Type: "CMD", Command: "fs-mcp", Args: []string{"--root", "/"}, Environment: map[string]string{"DEBUG": "1"}},
+		{Name: "search", Type: "SSE", URL: "https://search.example.com/sse", Headers: map[string]string{"Authorization": "Bearer x"}},
+		{Name: "docs", Type: "HTTP", URL: "https://docs.example.com/mcp"},
+	}
+
+	data, err := ExportInventory(items, FormatClaude)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"mcpServers"`) || strings.Contains(string(data), `"url": ""`) {
+		t.Errorf("Claude export should hold only the used fields, got %s", data)
+	}
+
+	imported, err := ParseInventoryImport(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []types.MCPItem{items[2], items[0], items[1]}
+	if !reflect.DeepEqual(imported, expected) {
+		t.Errorf("Round trip = %+v, expected %+v", imported, expected)
+	}
+}
+
+func TestExportInventory_JSONItemsMergeEnvironment(t *testing.T) {
+	item := types.MCPItem{Name: "raw", Type: "JSON", JSONConfig: `{"command": "x", "env": {"A": "1"}}`, Environment: map[string]string{"B": "2"}}
+	data, err := ExportInventory([]types.MCPItem{item}, FormatClaude)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document struct {
+		MCPServers map[string]jsonServerConfig `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatal(err)
+	}
+	if config := document.MCPServers["raw"]; config.Command != "x" || !reflect.DeepEqual(config.Env, map[string]string{"A": "1", "B": "2"}) {
+		t.Errorf("Unexpected JSON server export %+v", config)
+	}
+
+	if _, err := ExportInventory([]types.MCPItem{{Name: "bad", Type: "JSON", JSONConfig: "{"}}, FormatClaude); err == nil {
+		t.Error("Invalid JSON configuration should fail the export")
+	}
+	if _, err := ExportInventory(nil, "yaml"); err == nil {
+		t.Error("Unknown format should be rejected")
+	}
+}
+
+func TestParseInventoryImport_Formats(t *testing.T) {
+	items := []types.MCPItem{{Name: "github", Type: "CMD", Command: "github-mcp", Active: true}}
+	inventory, err := ExportInventory(items, FormatInventory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	array, _ := json.Marshal(items)
+
+	for name, data := range map[string][]byte{"inventory": inventory, "array": array} {
+		imported, err := ParseInventoryImport(data)
+		if err != nil || !reflect.DeepEqual(imported, items) {
+			t.Errorf("%s: got %+v, %v", name, imported, err)
+		}
+	}
+
+	imported, err := ParseInventoryImport([]byte(`{"mcpServers": {"odd": {"transport": "websocket"}}}`))
+	if err != nil || len(imported) != 1 || imported[0].Type != "JSON" || !strings.Contains(imported[0].JSONConfig, "websocket") {
+		t.Errorf("Unknown entries should be kept as JSON servers, got %+v, %v", imported, err)
+	}
+
+	for _, data := range []string{`not json`, `{"servers": {}}`, `{"mcpServers": {"a": 1}}`} {
+		if _, err := ParseInventoryImport([]byte(data)); err == nil {
+			t.Errorf("%s should be rejected", data)
+		}
+	}
+}
+
+func TestMergeInventory(t *testing.T) {
+	items := []types.MCPItem{{Name: "github", Type: "CMD", Command: "old", Active: true}}
+	imported := []types.MCPItem{
+		{Name: "github", Type: "CMD", Command: "new"},
+		{Name: "fs", Type: "CMD", Command: "fs-mcp", Active: true},
+	}
+
+	merged, result := MergeInventory(items, imported, false)
+	if len(merged) != 2 || merged[0].Command != "old" || merged[1].Active {
+		t.Errorf("Without replace, existing MCPs stay and new ones start disabled, got %+v", merged)
+	}
+	if !reflect.DeepEqual(result, ImportResult{Added: []string{"fs"}, Replaced: []string{}, Skipped: []string{"github"}}) {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if items[0].Command != "old" || len(items) != 1 {
+		t.Error("MergeInventory should not modify its input")
+	}
+
+	merged, result = MergeInventory(items, imported, true)
+	if merged[0].Command != "new" || !merged[0].Active || !reflect.DeepEqual(result.Replaced, []string{"github"}) {
+		t.Errorf("Replace should overwrite the configuration and keep the active state, got %+v %+v", merged, result)
+	}
+}
+
+// recordingToggler records toggles for SetMCPActive
+type recordingToggler struct {
+	toggles []string
+	result  *ToggleResult
+}
+
+func (r *recordingToggler) ToggleMCPStatus(_ context.Context, mcpName string, _ bool, _ *types.MCPItem) (*ToggleResult, error) {
+	r.toggles = append(r.toggles, mcpName)
+	return r.result, nil
+}
+
+func TestSetMCPActive(t *testing.T) {
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir, tempDir+"/mcp-hub", tempDir, tempDir)
+	items := []types.MCPItem{{Name: "github", Type: "CMD", Command: "github-mcp"}}
+
+	toggler := &recordingToggler{result: &ToggleResult{ErrorMsg: "claude not found"}}
+	if err := SetMCPActive(context.Background(), toggler, mockPlatform, items, 0, true); err == nil || items[0].Active {
+		t.Errorf("A failed toggle should leave the item unchanged, got %v", err)
+	}
+
+	toggler.result = &ToggleResult{Success: true}
+	if err := SetMCPActive(context.Background(), toggler, mockPlatform, items, 0, true); err != nil || !items[0].Active {
+		t.Fatalf("Toggle should activate the item, got %v", err)
+	}
+	saved, err := LoadInventory(mockPlatform)
+	if err != nil || len(saved) != 1 || !saved[0].Active {
+		t.Errorf("The new state should be saved, got %+v, %v", saved, err)
+	}
+}
+
+func TestSetMCPServed(t *testing.T) {
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir, tempDir+"/mcp-hub", tempDir, tempDir)
+	items := []types.MCPItem{{Name: "github", Type: "CMD", Command: "github-mcp"}}
+
+	if err := SetMCPServed(mockPlatform, items, 0, true); err != nil || !items[0].Served || items[0].Active {
+		t.Fatalf("The item should be served without activating it, got %+v, %v", items[0], err)
+	}
+	saved, err := LoadInventory(mockPlatform)
+	if err != nil || len(saved) != 1 || !saved[0].Served {
+		t.Errorf("The served state should be saved, got %+v, %v", saved, err)
+	}
+}
+
+func TestBuildSyncReport(t *testing.T) {
+	items := []types.MCPItem{{Name: "github", Active: true}, {Name: "fs"}}
+
+	report := BuildSyncReport(items, types.ClaudeStatus{Available: true, ActiveMCPs: []string{"github"}, LastCheck: time.Now()})
+	if !report.InSync || report.OnlyInHub != nil || report.OnlyInClaude != nil {
+		t.Errorf("Matching sets should be in sync, got %+v", report)
+	}
+
+	report = BuildSyncReport(nil, types.ClaudeStatus{Available: true, ActiveMCPs: []string{"b", "a"}, LastCheck: time.Now()})
+	if report.InSync || !reflect.DeepEqual(report.ActiveInClaude, []string{"a", "b"}) || !reflect.DeepEqual(report.OnlyInClaude, []string{"a", "b"}) ||
+		report.EnabledInHub == nil {
+		t.Errorf("Unexpected out-of-sync report %+v", report)
+	}
+}
@@ -0,0 +1,330 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// keysSettingKey is the settings.json entry holding the key bindings
+const keysSettingKey = "keys"
+
+// KeyContext is an input mode in which a set of bindings is active; a key may only be
+// bound to one action per context
+type KeyContext int
+
+const (
+	// KeyContextMain is the MCP grid without an active search bar
+	KeyContextMain KeyContext = 1 << iota
+	// KeyContextSearch is the search bar in navigation mode
+	KeyContextSearch
+)
+
+// KeyBinding describes an action for the help overlay and the contexts it is active in
+type KeyBinding struct {
+	Action   types.Action
+	Label    string
+	Section  string
+	Contexts KeyContext
+}
+
+// KeyBindings lists the actions in the order the help overlay shows them
+var KeyBindings = []KeyBinding{
+	{types.ActionHelp, "Show or hide this help", "General", KeyContextMain | KeyContextSearch},
+	{types.ActionBack, "Close, clear the search or exit", "General", KeyContextMain | KeyContextSearch},
+	{types.ActionQuit, "Quit", "General", KeyContextMain | KeyContextSearch},
+	{types.ActionRedraw, "Redraw the screen", "General", KeyContextMain | KeyContextSearch},
+	{types.ActionUp, "Move up", "Navigation", KeyContextMain | KeyContextSearch},
+	{types.ActionDown, "Move down", "Navigation", KeyContextMain | KeyContextSearch},
+	{types.ActionLeft, "Move left", "Navigation", KeyContextMain | KeyContextSearch},
+	{types.ActionRight, "Move right", "Navigation", KeyContextMain | KeyContextSearch},
+	{types.ActionPageUp, "Move up a page", "Navigation", KeyContextMain | KeyContextSearch},
+	{types.ActionPageDown, "Move down a page", "Navigation", KeyContextMain | KeyContextSearch},
+	{types.ActionHome, "Move to the first MCP", "Navigation", KeyContextMain | KeyContextSearch},
+	{types.ActionEnd, "Move to the last MCP", "Navigation", KeyContextMain | KeyContextSearch},
+	{types.ActionSearch, "Search", "Search", KeyContextMain},
+	{types.ActionSearchMode, "Switch between typing and navigating", "Search", KeyContextSearch},
+	{types.ActionSearchApply, "Keep the results and close the search bar", "Search", KeyContextSearch},
+	{types.ActionAdd, "Add an MCP", "MCPs", KeyContextMain},
+	{types.ActionEdit, "Edit the selected MCP", "MCPs", KeyContextMain},
+	{types.ActionDelete, "Delete the selected MCP", "MCPs", KeyContextMain},
+	{types.ActionToggle, "Activate or deactivate in Claude", "MCPs", KeyContextMain | KeyContextSearch},
+	{types.ActionServe, "Serve through mcp-hub serve or stop", "MCPs", KeyContextMain | KeyContextSearch},
+	{types.ActionRefresh, "Refresh the Claude status", "MCPs", KeyContextMain | KeyContextSearch},
+	{types.ActionIntrospect, "Introspect the selected MCP", "Servers", KeyContextMain},
+	{types.ActionIntrospectAll, "Introspect all MCPs", "Servers", KeyContextMain},
+	{types.ActionProbe, "Health-check the selected MCP", "Servers", KeyContextMain},
+	{types.ActionProbeAll, "Health-check all MCPs", "Servers", KeyContextMain},
+	{types.ActionPlayground, "Open the tool playground", "Servers", KeyContextMain},
+	{types.ActionLogs, "Show server logs", "Servers", KeyContextMain},
+	{types.ActionToolFilter, "Allow or deny tools", "Servers", KeyContextMain},
+	{types.ActionSort, "Change the sort order", "View", KeyContextMain},
+	{types.ActionGroup, "Change the grouping", "View", KeyContextMain},
+	{types.ActionSettings, "Edit settings", "View", KeyContextMain},
+	{types.ActionWorkspaces, "Switch workspace", "View", KeyContextMain},
+	{types.ActionAppLog, "Show the application log", "View", KeyContextMain},
+}
+
+// findKeyBinding returns the binding of action
+func findKeyBinding(action types.Action) (KeyBinding, bool) {
+	for _, binding := range KeyBindings {
+		if binding.Action == action {
+			return binding, true
+		}
+	}
+	return KeyBinding{}, false
+}
+
+// LoadKeyMap loads the "keys" entry of settings.json. A missing file or entry means the
+// defaults; an invalid entry returns the defaults together with the error.
+func LoadKeyMap(platformService platform.PlatformService) (types.KeyMap, error) {
+	data, err := readSecureFile(GetSettingsPath(platformService))
+	if errors.Is(err, os.ErrNotExist) {
+		return types.DefaultKeyMap(), nil
+	}
+	if err != nil {
+		return types.DefaultKeyMap(), fmt.Errorf("failed to read key bindings: %w", err)
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return types.DefaultKeyMap(), fmt.Errorf("failed to parse settings: %w", err)
+	}
+	raw, ok := values[keysSettingKey]
+	if !ok {
+		return types.DefaultKeyMap(), nil
+	}
+	keymap, err := ParseKeyMap(raw)
+	if err != nil {
+		logging.For("settings").Warn("invalid key bindings, using the defaults", "path", GetSettingsPath(platformService), "error", err)
+		return types.DefaultKeyMap(), err
+	}
+	return keymap, nil
+}
+
+// ParseKeyMap decodes and validates the "keys" entry of settings.json, an object from
+// action to a key or a list of keys. Actions it leaves out keep their default keys.
+func ParseKeyMap(data []byte) (types.KeyMap, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("keys: expected an object from action to keys")
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keymap := types.DefaultKeyMap()
+	for _, name := range names {
+		action := types.Action(name)
+		if _, ok := findKeyBinding(action); !ok {
+			return nil, fmt.Errorf("keys: unknown action %q", name)
+		}
+		keys, err := parseBoundKeys(values[name])
+		if err != nil {
+			return nil, fmt.Errorf("keys.%s: %w", name, err)
+		}
+		keymap[action] = keys
+	}
+	if err := ValidateKeyMap(keymap); err != nil {
+		return nil, err
+	}
+	return keymap, nil
+}
+
+// parseBoundKeys decodes a key or a list of keys; "space" also binds the space bar as
+// Bubble Tea reports it
+func parseBoundKeys(data json.RawMessage) ([]string, error) {
+	var keys []string
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		keys = []string{single}
+	} else if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("expected a key such as \"x\" or \"ctrl+x\", or a list of keys")
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("needs at least one key")
+	}
+
+	var bound []string
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" && key != " " {
+			return nil, fmt.Errorf("keys must not be empty")
+		}
+		bound = append(bound, key)
+		if key == "space" {
+			bound = append(bound, " ")
+		}
+	}
+	return bound, nil
+}
+
+// ActionForKey returns the action key triggers in context
+func ActionForKey(keymap types.KeyMap, context KeyContext, key string) (types.Action, bool) {
+	for _, binding := range KeyBindings {
+		if binding.Contexts&context != 0 && keymap.Matches(binding.Action, key) {
+			return binding.Action, true
+		}
+	}
+	return "", false
+}
+
+// globalActions work in every state, including while a text field is being typed in
+var globalActions = []types.Action{types.ActionQuit, types.ActionBack, types.ActionRedraw}
+
+// ValidateKeyMap checks that no key triggers two actions in the same context, and that
+// the global actions do not use keys that are typed into text fields
+func ValidateKeyMap(keymap types.KeyMap) error {
+	for _, action := range globalActions {
+		for _, key := range keymap.Keys(action) {
+			if len([]rune(key)) == 1 {
+				return fmt.Errorf("keys.%s: %s would be typed into text fields; use a key such as ctrl+%s", action, FormatKey(key), strings.ToLower(key))
+			}
+		}
+	}
+	for _, context := range []KeyContext{KeyContextMain, KeyContextSearch} {
+		owners := make(map[string]types.Action)
+		for _, binding := range KeyBindings {
+			if binding.Contexts&context == 0 {
+				continue
+			}
+			for _, key := range keymap.Keys(binding.Action) {
+				if owner, ok := owners[key]; ok && owner != binding.Action {
+					return fmt.Errorf("keys: %s is bound to both %s and %s", FormatKey(key), owner, binding.Action)
+				}
+				owners[key] = binding.Action
+			}
+		}
+	}
+	return nil
+}
+
+// keyLabels are the names the UI shows for keys that do not print as themselves
+var keyLabels = map[string]string{
+	"up": "↑", "down": "↓", "left": "←", "right": "→",
+	" ": "Space", "space": "Space", "tab": "Tab", "esc": "ESC", "enter": "Enter",
+	"backspace": "Backspace", "pgup": "PgUp", "pgdown": "PgDn", "home": "Home", "end": "End",
+}
+
+// FormatKey returns the label the UI shows for key, e.g. "↑" or "Ctrl+L"
+func FormatKey(key string) string {
+	if label, ok := keyLabels[key]; ok {
+		return label
+	}
+	if isFunctionKey(key) {
+		return strings.ToUpper(key)
+	}
+	if modifier, rest, ok := strings.Cut(key, "+"); ok && modifier != "" && rest != "" {
+		label, named := keyLabels[rest]
+		switch {
+		case named:
+		case len([]rune(rest)) == 1:
+			label = strings.ToUpper(rest)
+		default:
+			label = strings.ToUpper(rest[:1]) + rest[1:]
+		}
+		return strings.ToUpper(modifier[:1]) + modifier[1:] + "+" + label
+	}
+	return key
+}
+
+// isFunctionKey reports whether key is one of f1 to f20
+func isFunctionKey(key string) bool {
+	if len(key) < 2 || key[0] != 'f' {
+		return false
+	}
+	for _, r := range key[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatKeys returns the labels of every key bound to action, without duplicates,
+// separated by "/"
+func FormatKeys(keymap types.KeyMap, action types.Action) string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, key := range keymap.Keys(action) {
+		if label := FormatKey(key); !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// KeyHint returns a hint such as "A=Add" for the first key bound to action. Letters
+// are capitalized like the rest of the header and footer hints; the help overlay shows
+// their exact case.
+func KeyHint(keymap types.KeyMap, action types.Action, label string) string {
+	keys := keymap.Keys(action)
+	if len(keys) == 0 {
+		return ""
+	}
+	key := FormatKey(keys[0])
+	if len([]rune(key)) == 1 {
+		key = strings.ToUpper(key)
+	}
+	return key + "=" + label
+}
+
+// NavigateKeyHint returns the hint of the four movement actions, "↑↓←→=Navigate" with
+// the default keys. Letters are capitalized like in KeyHint.
+func NavigateKeyHint(keymap types.KeyMap) string {
+	var labels []string
+	single := true
+	for _, action := range []types.Action{types.ActionUp, types.ActionDown, types.ActionLeft, types.ActionRight} {
+		keys := keymap.Keys(action)
+		if len(keys) == 0 {
+			continue
+		}
+		label := FormatKey(keys[0])
+		if len([]rune(label)) == 1 {
+			label = strings.ToUpper(label)
+		} else {
+			single = false
+		}
+		labels = append(labels, label)
+	}
+	separator := "/"
+	if single {
+		separator = ""
+	}
+	return strings.Join(labels, separator) + "=Navigate"
+}
+
+// HelpEntry is a line of the help overlay
+type HelpEntry struct {
+	Keys  string
+	Label string
+}
+
+// HelpSection is a titled group of help overlay lines
+type HelpSection struct {
+	Title   string
+	Entries []HelpEntry
+}
+
+// GetHelpSections returns the help overlay's content for keymap, in KeyBindings order
+func GetHelpSections(keymap types.KeyMap) []HelpSection {
+	var sections []HelpSection
+	for _, binding := range KeyBindings {
+		if len(sections) == 0 || sections[len(sections)-1].Title != binding.Section {
+			sections = append(sections, HelpSection{Title: binding.Section})
+		}
+		section := &sections[len(sections)-1]
+		section.Entries = append(section.Entries, HelpEntry{Keys: FormatKeys(keymap, binding.Action), Label: binding.Label})
+	}
+	return sections
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func TestDefaultKeyMap_IsValid(t *testing.T) {
+	if err := ValidateKeyMap(types.DefaultKeyMap()); err != nil {
+		t.Errorf("The default keys should not conflict: %v", err)
+	}
+	for action := range types.DefaultKeyMap() {
+		if _, ok := findKeyBinding(action); !ok {
+			t.Errorf("Action %q has default keys but no help entry", action)
+		}
+	}
+}
+
+func TestParseKeyMap(t *testing.T) {
+	keymap, err := ParseKeyMap([]byte(`{"add": "n", "sort": ["o", "ctrl+o"], "toggle": "space"}`))
+	if err != nil {
+		t.Fatalf("ParseKeyMap failed: %v", err)
+	}
+	if got := keymap.Keys(types.ActionAdd); !reflect.DeepEqual(got, []string{"n"}) {
+		t.Errorf("A single key should be accepted, got %v", got)
+	}
+	if got := keymap.Keys(types.ActionSort); !reflect.DeepEqual(got, []string{"o", "ctrl+o"}) {
+		t.Errorf("A list of keys should be accepted, got %v", got)
+	}
+	if !keymap.Matches(types.ActionToggle, " ") {
+		t.Error(`"space" should also bind the space bar`)
+	}
+	if !keymap.Matches(types.ActionDelete, "d") {
+		t.Error("Actions left out should keep their default keys")
+	}
+
+	invalid := map[string]string{
+		"expected an object":                      `["a"]`,
+		`unknown action "launch"`:                 `{"launch": "x"}`,
+		"keys.add: needs at least one key":        `{"add": []}`,
+		"keys.add: keys must not be empty":        `{"add": [""]}`,
+		"keys.add: expected a key":                `{"add": 1}`,
+		"e is bound to both add and edit":         `{"add": "e"}`,
+		"k is bound to both up and sort":          `{"sort": "k"}`,
+		"Tab is bound to both search_mode and":    `{"search_apply": "tab"}`,
+		"keys.quit: q would be typed":             `{"quit": "q"}`,
+		"keys.back: x would be typed into text":   `{"back": ["esc", "x"]}`,
+		"↑ is bound to both up and introspect":    `{"introspect": "up"}`,
+		"? is bound to both help and search_mode": `{"search_mode": "?"}`,
+	}
+	for expected, data := range invalid {
+		if _, err := ParseKeyMap([]byte(data)); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%s: expected %q, got %v", data, expected, err)
+		}
+	}
+}
+
+func TestParseKeyMap_SameKeyInDifferentContexts(t *testing.T) {
+	// Enter applies the search only while the search bar is open, so it is free in the grid
+	if _, err := ParseKeyMap([]byte(`{"playground": "enter"}`)); err != nil {
+		t.Errorf("A key may be reused in another context: %v", err)
+	}
+}
+
+func TestLoadKeyMap(t *testing.T) {
+	mockPlatform := newSettingsTestPlatform(t)
+	keymap, err := LoadKeyMap(mockPlatform)
+	if err != nil || !reflect.DeepEqual(keymap, types.DefaultKeyMap()) {
+		t.Errorf("A missing settings file should give the default keys, got %v, %v", keymap, err)
+	}
+
+	if err := os.MkdirAll(mockPlatform.GetConfigPath(), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetSettingsPath(mockPlatform), []byte(`{"claude_timeout": "20s", "keys": {"group": "G"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keymap, err = LoadKeyMap(mockPlatform)
+	if err != nil || !keymap.Matches(types.ActionGroup, "G") || keymap.Matches(types.ActionGroup, "g") {
+		t.Errorf("Keys should be loaded from settings.json, got %v, %v", keymap.Keys(types.ActionGroup), err)
+	}
+	settings, err := LoadSettings(mockPlatform)
+	if err != nil || settings.ClaudeTimeout != 20*time.Second {
+		t.Errorf("The keys entry should not invalidate the other settings, got %+v, %v", settings, err)
+	}
+
+	if err := os.WriteFile(GetSettingsPath(mockPlatform), []byte(`{"keys": {"group": "s"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keymap, err = LoadKeyMap(mockPlatform)
+	if err == nil || !reflect.DeepEqual(keymap, types.DefaultKeyMap()) {
+		t.Errorf("Conflicting keys should give the defaults and an error, got %v, %v", keymap, err)
+	}
+}
+
+func TestSaveSettings_KeepsKeyBindings(t *testing.T) {
+	mockPlatform := newSettingsTestPlatform(t)
+	if err := os.MkdirAll(mockPlatform.GetConfigPath(), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetSettingsPath(mockPlatform), []byte(`{"keys": {"help": ["f1", "?"]}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := types.DefaultSettings()
+	settings.ClaudeTimeout = time.Minute
+	if err := SaveSettings(mockPlatform, settings); err != nil {
+		t.Fatalf("SaveSettings failed: %v", err)
+	}
+	keymap, err := LoadKeyMap(mockPlatform)
+	if err != nil || !keymap.Matches(types.ActionHelp, "f1") {
+		t.Errorf("Saving the settings should keep the key bindings, got %v, %v", keymap.Keys(types.ActionHelp), err)
+	}
+}
+
+func TestActionForKey(t *testing.T) {
+	keymap := types.DefaultKeyMap()
+	tests := []struct {
+		context KeyContext
+		key     string
+		want    types.Action
+		found   bool
+	}{
+		{KeyContextMain, "tab", types.ActionSearch, true},
+		{KeyContextSearch, "tab", types.ActionSearchMode, true},
+		{KeyContextMain, "enter", "", false},
+		{KeyContextSearch, "enter", types.ActionSearchApply, true},
+		{KeyContextSearch, "a", "", false},
+		{KeyContextMain, "space", types.ActionToggle, true},
+	}
+	for _, tt := range tests {
+		if action, ok := ActionForKey(keymap, tt.context, tt.key); action != tt.want || ok != tt.found {
+			t.Errorf("ActionForKey(%d, %q) = %q, %v, want %q, %v", tt.context, tt.key, action, ok, tt.want, tt.found)
+		}
+	}
+}
+
+func TestFormatKey(t *testing.T) {
+	for key, want := range map[string]string{
+		"a": "a", "up": "↑", " ": "Space", "esc": "ESC", "ctrl+l": "Ctrl+L", "shift+tab": "Shift+Tab", "alt+enter": "Alt+Enter", "f1": "F1", "f": "f", "fn": "fn",
+	} {
+		if got := FormatKey(key); got != want {
+			t.Errorf("FormatKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestKeyHints(t *testing.T) {
+	keymap := types.DefaultKeyMap()
+	if got := KeyHint(keymap, types.ActionAdd, "Add"); got != "A=Add" {
+		t.Errorf("KeyHint = %q, want A=Add", got)
+	}
+	if got := FormatKeys(keymap, types.ActionToggle); got != "Space" {
+		t.Errorf("FormatKeys should drop keys with the same label, got %q", got)
+	}
+	if got := FormatKeys(keymap, types.ActionUp); got != "↑/k" {
+		t.Errorf("FormatKeys = %q, want ↑/k", got)
+	}
+	if got := NavigateKeyHint(keymap); got != "↑↓←→=Navigate" {
+		t.Errorf("NavigateKeyHint = %q", got)
+	}
+
+	keymap[types.ActionAdd] = []string{"ctrl+n"}
+	keymap[types.ActionUp] = []string{"ctrl+p"}
+	if got := KeyHint(keymap, types.ActionAdd, "Add"); got != "Ctrl+N=Add" {
+		t.Errorf("KeyHint should follow the bound key, got %q", got)
+	}
+	if got := NavigateKeyHint(keymap); got != "Ctrl+P/↓/←/→=Navigate" {
+		t.Errorf("Longer key names should be separated, got %q", got)
+	}
+}
+
+func TestGetHelpSections(t *testing.T) {
+	keymap := types.DefaultKeyMap()
+	keymap[types.ActionSort] = []string{"o"}
+
+	sections := GetHelpSections(keymap)
+	var titles []string
+	entries := 0
+	for _, section := range sections {
+		titles = append(titles, section.Title)
+		entries += len(section.Entries)
+		for _, entry := range section.Entries {
+			if entry.Label == "Change the sort order" && entry.Keys != "o" {
+				t.Errorf("Help should show the bound keys, got %q", entry.Keys)
+			}
+		}
+	}
+	if want := []string{"General", "Navigation", "Search", "MCPs", "Servers", "View"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("Sections = %v, want %v", titles, want)
+	}
+	if entries != len(KeyBindings) {
+		t.Errorf("Help should list every action, got %d of %d", entries, len(KeyBindings))
+	}
+}
@@ -0,0 +1,478 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// ManifestVersion is the manifest format understood by ParseManifest
+const ManifestVersion = 1
+
+// Plan actions; the first three change the inventory, the rest change Claude
+const (
+	PlanCreate  = "create"
+	PlanUpdate  = "update"
+	PlanDelete  = "delete"
+	PlanDisable = "disable"
+	PlanReload  = "reload"
+	PlanEnable  = "enable"
+)
+
+// planActionOrder is the order steps are listed and applied in
+var planActionOrder = []string{PlanCreate, PlanUpdate, PlanDelete, PlanDisable, PlanReload, PlanEnable}
+
+// Manifest declares the MCPs a repository or machine wants and which of them Claude should load
+type Manifest struct {
+	Version int              `yaml:"version"`
+	Servers []ManifestServer `yaml:"servers"`
+	// Active lists the MCPs to enable by Claude scope; every other MCP is disabled
+	Active map[string][]string `yaml:"active"`
+	// Prune removes inventory entries the manifest does not declare
+	Prune bool `yaml:"prune"`
+}
+
+// ManifestServer is one inventory entry in a manifest
+type ManifestServer struct {
+	Name    string            `yaml:"name"`
+	Type    string            `yaml:"type"`
+	Command string            `yaml:"command"`
+	Args    []string          `yaml:"args"`
+	URL     string            `yaml:"url"`
+	Env     map[string]string `yaml:"env"`
+	Headers map[string]string `yaml:"headers"`
+	// Config is the JSON configuration of a JSON server, as a string or a mapping
+	Config interface{}        `yaml:"config"`
+	Tools  ManifestToolFilter `yaml:"tools"`
+}
+
+// ManifestToolFilter holds a server's tool allow and deny patterns
+type ManifestToolFilter struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// PlanStep is one change a manifest plan makes
+type PlanStep struct {
+	Action  string   `json:"action"`
+	Name    string   `json:"name"`
+	Scope   string   `json:"scope,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// ManifestPlan lists the changes that bring the inventory and Claude in line with a manifest
+type ManifestPlan struct {
+	Steps []PlanStep `json:"steps"`
+	// ClaudeAvailable is false when the plan was computed from the inventory's active flags alone
+	ClaudeAvailable bool `json:"claude_available"`
+	// Items is the inventory once the plan is applied
+	Items []types.MCPItem `json:"-"`
+}
+
+// Empty reports whether the plan has nothing to do
+func (p ManifestPlan) Empty() bool {
+	return len(p.Steps) == 0
+}
+
+// Count returns the number of steps with the given action
+func (p ManifestPlan) Count(action string) int {
+	count := 0
+	for _, step := range p.Steps {
+		if step.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Summary describes the plan in one line, like "1 to create, 2 to enable"
+func (p ManifestPlan) Summary() string {
+	if p.Empty() {
+		return "no changes"
+	}
+	var parts []string
+	for _, action := range planActionOrder {
+		if count := p.Count(action); count > 0 {
+			parts = append(parts, fmt.Sprintf("%d to %s", count, action))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// LoadManifest reads and validates a manifest file
+func LoadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- the path is given by the user
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return ParseManifest(data)
+}
+
+// ParseManifest decodes and validates a YAML (or JSON) manifest
+func ParseManifest(data []byte) (Manifest, error) {
+	var manifest Manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&manifest); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Manifest{}, fmt.Errorf("manifest is empty")
+		}
+		return Manifest{}, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.Version != ManifestVersion {
+		return Manifest{}, fmt.Errorf("unsupported manifest version %d (expected %d)", manifest.Version, ManifestVersion)
+	}
+
+	seen := make(map[string]bool, len(manifest.Servers))
+	for _, server := range manifest.Servers {
+		item, err := server.item()
+		if err != nil {
+			return Manifest{}, fmt.Errorf("server %q: %w", server.Name, err)
+		}
+		if err := ValidateMCPItem(item, nil, ""); err != nil {
+			return Manifest{}, fmt.Errorf("server %q: %w", server.Name, err)
+		}
+		if seen[server.Name] {
+			return Manifest{}, fmt.Errorf("server %q is declared twice", server.Name)
+		}
+		seen[server.Name] = true
+	}
+
+	scopes := make(map[string]string)
+	for scope, names := range manifest.Active {
+		if scope == "" || !IsClaudeScope(scope) {
+			return Manifest{}, fmt.Errorf("unknown scope %q in active (expected %s, %s or %s)", scope, ScopeLocal, ScopeProject, ScopeUser)
+		}
+		for _, name := range names {
+			if other, ok := scopes[name]; ok {
+				return Manifest{}, fmt.Errorf("%q is active in both %s and %s", name, other, scope)
+			}
+			scopes[name] = scope
+		}
+	}
+	return manifest, nil
+}
+
+// item converts the server into an inventory entry, inferring its type when not given
+func (s ManifestServer) item() (types.MCPItem, error) {
+	config, err := s.jsonConfig()
+	if err != nil {
+		return types.MCPItem{}, err
+	}
+	item := types.MCPItem{
+		Name:        s.Name,
+		Type:        strings.ToUpper(s.Type),
+		Command:     s.Command,
+		Args:        s.Args,
+		URL:         s.URL,
+		JSONConfig:  config,
+		Environment: s.Env,
+		Headers:     s.Headers,
+		ToolAllow:   s.Tools.Allow,
+		ToolDeny:    s.Tools.Deny,
+	}
+	if item.Type == "" {
+		item.Type = InferMCPType(item)
+	}
+	return item, nil
+}
+
+// jsonConfig returns the server's JSON configuration as a string
+func (s ManifestServer) jsonConfig() (string, error) {
+	switch config := s.Config.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return config, nil
+	default:
+		data, err := json.Marshal(config)
+		if err != nil {
+			return "", fmt.Errorf("invalid config: %w", err)
+		}
+		return string(data), nil
+	}
+}
+
+// activeScopes maps each name in the manifest's active set to its scope
+func (m Manifest) activeScopes() map[string]string {
+	scopes := make(map[string]string)
+	for scope, names := range m.Active {
+		for _, name := range names {
+			scopes[name] = scope
+		}
+	}
+	return scopes
+}
+
+// ComputeManifestPlan compares a manifest with the inventory and the MCPs Claude has loaded.
+// When Claude is unavailable the inventory's active flags stand in for Claude's state.
+func ComputeManifestPlan(manifest Manifest, items []types.MCPItem, status types.ClaudeStatus) (ManifestPlan, error) {
+	plan := ManifestPlan{ClaudeAvailable: status.Available}
+
+	loaded := make(map[string]bool)
+	if status.Available {
+		for _, name := range status.ActiveMCPs {
+			loaded[name] = true
+		}
+	} else {
+		for _, item := range items {
+			loaded[item.Name] = item.Active
+		}
+	}
+
+	declared := make(map[string]bool, len(manifest.Servers))
+	for _, server := range manifest.Servers {
+		declared[server.Name] = true
+	}
+	scopes := manifest.activeScopes()
+	for name := range scopes {
+		if !declared[name] && (manifest.Prune || FindMCPIndex(items, name) < 0) {
+			return ManifestPlan{}, fmt.Errorf("%q is listed as active but is neither declared nor in the inventory", name)
+		}
+	}
+
+	steps := make(map[string][]PlanStep)
+	addStep := func(step PlanStep) {
+		steps[step.Action] = append(steps[step.Action], step)
+	}
+
+	// claudeStep records how Claude must change to match the desired state of an MCP.
+	// It reports whether the MCP ends up enabled.
+	claudeStep := func(name string, current *types.MCPItem, desired types.MCPItem, changed bool) bool {
+		scope, wanted := scopes[name]
+		switch {
+		case wanted && !loaded[name]:
+			addStep(PlanStep{Action: PlanEnable, Name: name, Scope: scope})
+		case wanted && (current == nil || changed || scopeName(current.Scope) != scopeName(desired.Scope)):
+			addStep(PlanStep{Action: PlanReload, Name: name, Scope: scope})
+		case !wanted && loaded[name]:
+			addStep(PlanStep{Action: PlanDisable, Name: name})
+		}
+		return wanted
+	}
+
+	for _, item := range items {
+		index := -1
+		for i, server := range manifest.Servers {
+			if server.Name == item.Name {
+				index = i
+				break
+			}
+		}
+
+		desired := item
+		var changes []string
+		if index >= 0 {
+			desired, _ = manifest.Servers[index].item()
+			// Tags and description are not part of manifests, keep the inventory's
+			desired.Tags, desired.Description = item.Tags, item.Description
+			changes = itemChanges(item, desired)
+		} else if manifest.Prune {
+			addStep(PlanStep{Action: PlanDelete, Name: item.Name})
+			if loaded[item.Name] {
+				addStep(PlanStep{Action: PlanDisable, Name: item.Name})
+			}
+			continue
+		}
+
+		current := item
+		desired.Scope = item.Scope
+		if scope, ok := scopes[item.Name]; ok {
+			desired.Scope = keepScope(item.Scope, scope)
+		}
+		desired.Active = claudeStep(item.Name, &current, desired, len(changes) > 0)
+		// The inventory flag is only an update when no Claude step already changes it
+		if desired.Active != item.Active && desired.Active == loaded[item.Name] {
+			changes = append(changes, "active")
+		}
+		if desired.Scope != item.Scope {
+			changes = append(changes, "scope")
+		}
+		if len(changes) > 0 {
+			addStep(PlanStep{Action: PlanUpdate, Name: item.Name, Changes: changes})
+		}
+		plan.Items = append(plan.Items, desired)
+	}
+
+	for _, server := range manifest.Servers {
+		if FindMCPIndex(items, server.Name) >= 0 {
+			continue
+		}
+		desired, _ := server.item()
+		if scope, ok := scopes[server.Name]; ok {
+			desired.Scope = scope
+		}
+		addStep(PlanStep{Action: PlanCreate, Name: server.Name})
+		// Claude may already run an MCP of this name with a configuration mcp-hub never saw
+		desired.Active = claudeStep(server.Name, nil, desired, true)
+		plan.Items = append(plan.Items, desired)
+	}
+
+	plan.Steps = []PlanStep{}
+	for _, action := range planActionOrder {
+		plan.Steps = append(plan.Steps, steps[action]...)
+	}
+	return plan, nil
+}
+
+// scopeName returns the scope Claude uses for an MCP, which is local when none was given
+func scopeName(scope string) string {
+	if scope == "" {
+		return ScopeLocal
+	}
+	return scope
+}
+
+// keepScope returns the manifest's scope unless it only spells out the current default
+func keepScope(current, wanted string) string {
+	if scopeName(current) == scopeName(wanted) {
+		return current
+	}
+	return wanted
+}
+
+// itemChanges names the configuration fields that differ between two items
+func itemChanges(current, desired types.MCPItem) []string {
+	var changes []string
+	fields := []struct {
+		name string
+		same bool
+	}{
+		{"type", strings.EqualFold(current.Type, desired.Type)},
+		{"command", current.Command == desired.Command},
+		{"args", sameStrings(current.Args, desired.Args)},
+		{"url", current.URL == desired.URL},
+		{"config", sameJSON(current.JSONConfig, desired.JSONConfig)},
+		{"env", sameStringMap(current.Environment, desired.Environment)},
+		{"headers", sameStringMap(current.Headers, desired.Headers)},
+		{"tools", sameStrings(current.ToolAllow, desired.ToolAllow) && sameStrings(current.ToolDeny, desired.ToolDeny)},
+	}
+	for _, field := range fields {
+		if !field.same {
+			changes = append(changes, field.name)
+		}
+	}
+	return changes
+}
+
+// sameStrings compares two lists, treating nil and empty as equal
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameStringMap compares two maps, treating nil and empty as equal
+func sameStringMap(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// sameJSON compares two JSON documents ignoring formatting and key order
+func sameJSON(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var left, right interface{}
+	if json.Unmarshal([]byte(a), &left) != nil || json.Unmarshal([]byte(b), &right) != nil {
+		return false
+	}
+	leftData, _ := json.Marshal(left)
+	rightData, _ := json.Marshal(right)
+	return bytes.Equal(leftData, rightData)
+}
+
+// ApplyManifestPlan carries out a plan computed against items. MCPs are taken out of
+// Claude first, then the inventory is rewritten and the wanted MCPs are added back. The
+// inventory is saved after every Claude change, so a failed apply can simply be rerun.
+func ApplyManifestPlan(ctx context.Context, toggler MCPToggler, platformService platform.PlatformService, items []types.MCPItem, plan ManifestPlan) error {
+	if plan.Empty() {
+		return nil
+	}
+
+	working := append([]types.MCPItem{}, items...)
+	enable := make(map[string]bool)
+	for _, step := range plan.Steps {
+		switch step.Action {
+		case PlanReload:
+			enable[step.Name] = true
+			fallthrough
+		case PlanDisable:
+			index := FindMCPIndex(working, step.Name)
+			if index < 0 {
+				// Not in the inventory yet: remove whatever Claude runs under this name
+				if err := toggleClaude(ctx, toggler, &types.MCPItem{Name: step.Name}, false); err != nil {
+					return fmt.Errorf("%s: %w", step.Name, err)
+				}
+				continue
+			}
+			if err := SetMCPActive(ctx, toggler, platformService, working, index, false); err != nil {
+				return fmt.Errorf("%s: %w", step.Name, err)
+			}
+		case PlanEnable:
+			enable[step.Name] = true
+		}
+	}
+
+	next := append([]types.MCPItem{}, plan.Items...)
+	for i := range next {
+		if enable[next[i].Name] {
+			next[i].Active = false
+		}
+	}
+	if err := SaveInventory(next, platformService); err != nil {
+		return fmt.Errorf("failed to save inventory: %w", err)
+	}
+
+	names := make([]string, 0, len(enable))
+	for name := range enable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		index := FindMCPIndex(next, name)
+		if index < 0 {
+			continue
+		}
+		if err := SetMCPActive(ctx, toggler, platformService, next, index, true); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// toggleClaude adds or removes an MCP in Claude without touching the inventory
+func toggleClaude(ctx context.Context, toggler MCPToggler, item *types.MCPItem, enable bool) error {
+	result, err := toggler.ToggleMCPStatus(ctx, item.Name, enable, item)
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		return fmt.Errorf("%s", result.ErrorMsg)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+const testManifest = `
+version: 1
+servers:
+  - name: github
+    command: github-mcp
+    args: [--stdio]
+    env:
+      GITHUB_TOKEN: ${GITHUB_TOKEN}
+  - name: docs
+    url: https://docs.example.com/mcp
+    tools:
+      deny: [delete_*]
+  - name: raw
+    config:
+      command: raw-mcp
+active:
+  user: [github]
+  project: [docs]
+`
+
+// claudeState is a toggler that keeps the set of MCPs Claude has loaded
+type claudeState struct {
+	loaded  map[string]string
+	toggles []string
+}
+
+func (c *claudeState) ToggleMCPStatus(_ context.Context, mcpName string, activate bool, config *types.MCPItem) (*ToggleResult, error) {
+	if activate {
+		c.loaded[mcpName] = config.Scope
+		c.toggles = append(c.toggles, "+"+mcpName)
+	} else {
+		delete(c.loaded, mcpName)
+		c.toggles = append(c.toggles, "-"+mcpName)
+	}
+	return &ToggleResult{Success: true, MCPName: mcpName}, nil
+}
+
+func (c *claudeState) status() types.ClaudeStatus {
+	status := types.ClaudeStatus{Available: true}
+	for name := range c.loaded {
+		status.ActiveMCPs = append(status.ActiveMCPs, name)
+	}
+	return status
+}
+
+// planSteps renders a plan's steps as "action name" strings
+func planSteps(plan ManifestPlan) []string {
+	steps := make([]string, 0, len(plan.Steps))
+	for _, step := range plan.Steps {
+		steps = append(steps, step.Action+" "+step.Name)
+	}
+	return steps
+}
+
+func TestParseManifest(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatalf("ParseManifest failed: %v", err)
+	}
+	if len(manifest.Servers) != 3 || manifest.Active["user"][0] != "github" {
+		t.Fatalf("Unexpected manifest %+v", manifest)
+	}
+	raw, _ := manifest.Servers[2].item()
+	if raw.Type != "JSON" || raw.JSONConfig != `{"command":"raw-mcp"}` {
+		t.Errorf("A config mapping should become a JSON item, got %+v", raw)
+	}
+	docs, _ := manifest.Servers[1].item()
+	if docs.Type != "SSE" || !reflect.DeepEqual(docs.ToolDeny, []string{"delete_*"}) {
+		t.Errorf("Unexpected docs item %+v", docs)
+	}
+
+	invalid := map[string]string{
+		"manifest is empty":        "",
+		"unsupported manifest":     "version: 2",
+		"field colour not found":   "version: 1\ncolour: blue",
+		"command is required":      "version: 1\nservers: [{name: a, type: cmd}]",
+		"declared twice":           "version: 1\nservers: [{name: a, command: x}, {name: a, command: y}]",
+		"unknown scope \"global\"": "version: 1\nactive: {global: [a]}",
+		"active in both":           "version: 1\nactive: {user: [a], local: [a]}",
+	}
+	for expected, data := range invalid {
+		if _, err := ParseManifest([]byte(data)); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestComputeManifestPlan(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := []types.MCPItem{
+		{Name: "github", Type: "CMD", Command: "github-mcp", Args: []string{"--stdio"}, Environment: map[string]string{"GITHUB_TOKEN": "${GITHUB_TOKEN}"}, Active: true, Scope: ScopeUser},
+		{Name: "docs", Type: "SSE", URL: "https://old.example.com/mcp", Active: true},
+		{Name: "legacy", Type: "CMD", Command: "legacy-mcp", Active: true},
+	}
+	status := types.ClaudeStatus{Available: true, ActiveMCPs: []string{"github", "docs", "legacy", "unmanaged"}}
+
+	plan, err := ComputeManifestPlan(manifest, items, status)
+	if err != nil {
+		t.Fatalf("ComputeManifestPlan failed: %v", err)
+	}
+	expected := []string{"create raw", "update docs", "disable legacy", "reload docs"}
+	if !reflect.DeepEqual(planSteps(plan), expected) {
+		t.Errorf("Steps = %v, expected %v", planSteps(plan), expected)
+	}
+	if changes := plan.Steps[1].Changes; !reflect.DeepEqual(changes, []string{"url", "tools", "scope"}) {
+		t.Errorf("docs changes = %v", changes)
+	}
+	if plan.Summary() != "1 to create, 1 to update, 1 to disable, 1 to reload" {
+		t.Errorf("Summary = %q", plan.Summary())
+	}
+	if len(plan.Items) != 4 || plan.Items[2].Active || plan.Items[1].Scope != ScopeProject {
+		t.Errorf("Unexpected final inventory %+v", plan.Items)
+	}
+
+	manifest.Prune = true
+	plan, err = ComputeManifestPlan(manifest, items, status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Count(PlanDelete) != 1 || plan.Count(PlanDisable) != 1 || len(plan.Items) != 3 {
+		t.Errorf("Prune should delete and disable legacy, got %v", planSteps(plan))
+	}
+
+	manifest.Active["local"] = []string{"missing"}
+	if _, err := ComputeManifestPlan(manifest, items, status); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("Unknown active names should be rejected, got %v", err)
+	}
+}
+
+func TestComputeManifestPlan_WithoutClaude(t *testing.T) {
+	manifest, err := ParseManifest([]byte("version: 1\nactive: {local: [github]}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := []types.MCPItem{{Name: "github", Type: "CMD", Command: "github-mcp", Active: true}}
+
+	plan, err := ComputeManifestPlan(manifest, items, types.ClaudeStatus{})
+	if err != nil || !plan.Empty() || plan.ClaudeAvailable {
+		t.Errorf("Explicit local scope on an enabled MCP should need no changes, got %v, %v", planSteps(plan), err)
+	}
+}
+
+func TestComputeManifestPlan_KeepsTagsAndDescription(t *testing.T) {
+	manifest, err := ParseManifest([]byte("version: 1\nservers:\n  - name: github\n    command: github-mcp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := []types.MCPItem{{Name: "github", Type: "CMD", Command: "github-mcp", Tags: []string{"vcs"}, Description: "GitHub API"}}
+
+	plan, err := ComputeManifestPlan(manifest, items, types.ClaudeStatus{})
+	if err != nil || !plan.Empty() {
+		t.Fatalf("Tags and description are not managed by manifests, got %v, %v", planSteps(plan), err)
+	}
+	if got := plan.Items[0]; !reflect.DeepEqual(got.Tags, []string{"vcs"}) || got.Description != "GitHub API" {
+		t.Errorf("Plan should keep the inventory's tags and description, got %+v", got)
+	}
+}
+
+func TestApplyManifestPlan_IsIdempotent(t *testing.T) {
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir, tempDir+"/mcp-hub", tempDir, tempDir)
+	items := []types.MCPItem{
+		{Name: "docs", Type: "SSE", URL: "https://old.example.com/mcp", Active: true},
+		{Name: "legacy", Type: "CMD", Command: "legacy-mcp", Active: true},
+	}
+	if err := SaveInventory(items, mockPlatform); err != nil {
+		t.Fatal(err)
+	}
+	claude := &claudeState{loaded: map[string]string{"docs": "", "legacy": ""}}
+	manifest, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := ComputeManifestPlan(manifest, items, claude.status())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyManifestPlan(context.Background(), claude, mockPlatform, items, plan); err != nil {
+		t.Fatalf("ApplyManifestPlan failed: %v", err)
+	}
+	if !reflect.DeepEqual(claude.toggles, []string{"-legacy", "-docs", "+docs", "+github"}) {
+		t.Errorf("Toggles = %v", claude.toggles)
+	}
+	if !reflect.DeepEqual(claude.loaded, map[string]string{"docs": ScopeProject, "github": ScopeUser}) {
+		t.Errorf("Claude should load docs and github in their scopes, got %v", claude.loaded)
+	}
+
+	saved, err := LoadInventory(mockPlatform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := ComputeManifestPlan(manifest, saved, claude.status())
+	if err != nil || !again.Empty() {
+		t.Errorf("A second plan should be empty, got %v, %v", planSteps(again), err)
+	}
+}
@@ -2,26 +2,23 @@ package services
 
 import (
 	"context"
-	"strings"
 
 	"mcp-hub/internal/platform"
 	"mcp-hub/internal/ui/types"
 )
 
-// GetFilteredMCPs returns MCPs filtered by search query
+// GetFilteredMCPs returns the MCPs in the order the grid shows them: the search results,
+// best match first, or the whole inventory in the chosen sort mode, section by section
+// when grouped. The query can mix free text with filters such as type:sse.
 func GetFilteredMCPs(model types.Model) []types.MCPItem {
-	// If no search query, return all MCPs
-	if model.SearchQuery == "" {
-		return model.MCPItems
+	groups := GetMCPGroups(model)
+	if len(groups) == 1 {
+		return groups[0].Items
 	}
 
-	// Filter MCPs by search query directly
 	var filtered []types.MCPItem
-	query := strings.ToLower(model.SearchQuery)
-	for _, item := range model.MCPItems {
-		if strings.Contains(strings.ToLower(item.Name), query) {
-			filtered = append(filtered, item)
-		}
+	for _, group := range groups {
+		filtered = append(filtered, group.Items...)
 	}
 	return filtered
 }
@@ -168,3 +165,31 @@ func GetSelectedMCP(model types.Model) *types.MCPItem {
 	// Return a copy to avoid accidental modifications
 	return &selectedItem
 }
+
+// SelectMCPByName selects the named MCP at its position in the grid's sort and grouping
+// order. The selection is unchanged when the MCP is not shown, such as when a search
+// filters it out.
+func SelectMCPByName(model types.Model, name string) types.Model {
+	for i, item := range GetFilteredMCPs(model) {
+		if item.Name == name {
+			if model.SearchQuery != "" {
+				model.FilteredSelectedIndex = i
+			} else {
+				model.SelectedItem = i
+			}
+			break
+		}
+	}
+	return model
+}
+
+// FindMCPByName returns a copy of the inventory item with the given name, or nil if absent
+func FindMCPByName(model types.Model, name string) *types.MCPItem {
+	for _, item := range model.MCPItems {
+		if item.Name == name {
+			found := item
+			return &found
+		}
+	}
+	return nil
+}
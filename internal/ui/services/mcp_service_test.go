@@ -45,7 +45,7 @@ func TestGetFilteredMCPs(t *testing.T) {
 		{
 			name:          "Partial name matching",
 			searchQuery:   "mcp",
-			expectedCount: 3, // github-mcp, ht-mcp, docker-mcp
+			expectedCount: 5, // Name matches first, then context7 and filesystem through their commands
 			expectedFirst: "github-mcp",
 		},
 		{
@@ -57,8 +57,8 @@ func TestGetFilteredMCPs(t *testing.T) {
 		{
 			name:          "Single character search",
 			searchQuery:   "h",
-			expectedCount: 2, // github-mcp, ht-mcp
-			expectedFirst: "github-mcp",
+			expectedCount: 2, // ht-mcp ranks first as the h starts its name
+			expectedFirst: "ht-mcp",
 		},
 		{
 			name:          "Exact name match",
@@ -519,3 +519,19 @@ func BenchmarkEnhancedToggleMCPStatus(b *testing.B) {
 		_ = EnhancedToggleMCPStatus(model, "test-mcp", true)
 	}
 }
+
+func TestFindMCPByName(t *testing.T) {
+	model := createTestModel()
+
+	found := FindMCPByName(model, "github-mcp")
+	if found == nil || found.Name != "github-mcp" {
+		t.Fatalf("Expected to find github-mcp, got %+v", found)
+	}
+	found.Command = "changed"
+	if FindMCPByName(model, "github-mcp").Command == "changed" {
+		t.Error("FindMCPByName should return a copy")
+	}
+	if FindMCPByName(model, "missing") != nil {
+		t.Error("Unknown names should return nil")
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/ui/types"
+)
+
+// Transport kinds an inventory item can resolve to
+const (
+	TransportStdio = "stdio"
+	TransportSSE   = "sse"
+	TransportHTTP  = "http"
+)
+
+// MCPSession is an initialized connection to an MCP server
+type MCPSession struct {
+	Client         *mcp.Client
+	InitResult     *mcp.InitializeResult
+	StartupLatency time.Duration
+
+	stderr *StderrLogWriter
+}
+
+// OpenMCPSession connects to the server for an inventory item and completes the MCP handshake.
+// StartupLatency covers spawning or connecting through the initialize response.
+// When logs is non-nil, the server's stderr and log notifications are recorded in it.
+func OpenMCPSession(ctx context.Context, item types.MCPItem, logs *types.ServerLogStore) (*MCPSession, error) {
+	var stderr *StderrLogWriter
+	if logs != nil {
+		stderr = NewStderrLogWriter(logs, item.Name)
+	}
+
+	start := time.Now()
+	transport, err := openTransport(ctx, item, stderr)
+	if err != nil {
+		return nil, err
+	}
+	if IsRecording(item.Name) {
+		// A failed recording must not stop the session itself
+		if recorder, path, err := startRecording(item.Name, transport); err != nil {
+			logRecorderEvent(logs, item.Name, types.LogWarning, err.Error())
+		} else {
+			transport = recorder
+			logRecorderEvent(logs, item.Name, types.LogInfo, "recording traffic to "+path)
+		}
+	}
+
+	client := mcp.NewClient(transport)
+	if logs != nil {
+		client.SetNotificationHandler(LogNotificationHandler(logs, item.Name))
+	}
+	initResult, err := client.Initialize(ctx, mcp.DefaultClientInfo)
+	if err != nil {
+		_ = client.Close()
+		if stderr != nil {
+			stderr.Flush()
+		}
+		return nil, err
+	}
+
+	return &MCPSession{
+		Client:         client,
+		InitResult:     initResult,
+		StartupLatency: time.Since(start),
+		stderr:         stderr,
+	}, nil
+}
+
+// openTransport spawns or connects to the server using the item's transport.
+// stderr, when non-nil, receives the output of spawned servers.
+func openTransport(ctx context.Context, item types.MCPItem, stderr *StderrLogWriter) (mcp.Transport, error) {
+	kind, err := ResolveTransportKind(item)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case TransportSSE, TransportHTTP:
+		config, err := ResolveHTTPConfig(item)
+		if err != nil {
+			return nil, err
+		}
+		if kind == TransportSSE {
+			return mcp.NewSSETransport(ctx, config)
+		}
+		return mcp.NewStreamableHTTPTransport(config), nil
+	default:
+		config, err := ResolveStdioConfig(item)
+		if err != nil {
+			return nil, err
+		}
+		if stderr != nil {
+			config.Stderr = stderr
+		}
+		return mcp.NewStdioTransport(config)
+	}
+}
+
+// ResolveTransportKind determines how mcp-hub connects to an item's server
+func ResolveTransportKind(item types.MCPItem) (string, error) {
+	switch strings.ToUpper(item.Type) {
+	case "SSE":
+		return TransportSSE, nil
+	case "HTTP":
+		return TransportHTTP, nil
+	case "JSON":
+		var config jsonServerConfig
+		if err := json.Unmarshal([]byte(item.JSONConfig), &config); err != nil {
+			return "", fmt.Errorf("invalid JSON configuration: %w", err)
+		}
+		switch {
+		case config.Command != "":
+			return TransportStdio, nil
+		case config.URL == "":
+			return "", ErrUnsupportedTransport
+		case strings.EqualFold(config.Type, TransportSSE):
+			return TransportSSE, nil
+		default:
+			return TransportHTTP, nil
+		}
+	default:
+		return TransportStdio, nil
+	}
+}
+
+// ResolveHTTPConfig converts a URL-based inventory item into the configuration needed to reach it.
+// For JSON items, the item's headers override those in the JSON configuration.
+func ResolveHTTPConfig(item types.MCPItem) (mcp.HTTPConfig, error) {
+	url, headers := item.URL, item.Headers
+
+	if strings.EqualFold(item.Type, "JSON") {
+		var config jsonServerConfig
+		if err := json.Unmarshal([]byte(item.JSONConfig), &config); err != nil {
+			return mcp.HTTPConfig{}, fmt.Errorf("invalid JSON configuration: %w", err)
+		}
+		url = config.URL
+		headers = make(map[string]string, len(config.Headers)+len(item.Headers))
+		for key, value := range config.Headers {
+			headers[key] = value
+		}
+		for key, value := range item.Headers {
+			headers[key] = value
+		}
+	}
+
+	if url == "" {
+		return mcp.HTTPConfig{}, fmt.Errorf("MCP '%s' has no URL configured", item.Name)
+	}
+	return mcp.HTTPConfig{URL: url, Headers: headers}, nil
+}
+
+// Close shuts down the server process or connection
+func (s *MCPSession) Close() error {
+	err := s.Client.Close()
+	if s.stderr != nil {
+		s.stderr.Flush()
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func TestResolveTransportKind(t *testing.T) {
+	tests := []struct {
+		item     types.MCPItem
+		expected string
+	}{
+		{types.MCPItem{Type: "CMD", Command: "npx"}, TransportStdio},
+		{types.MCPItem{Type: "SSE", URL: "https://mcp.example.com/sse"}, TransportSSE},
+		{types.MCPItem{Type: "HTTP", URL: "https://mcp.example.com/mcp"}, TransportHTTP},
+		{types.MCPItem{Type: "JSON", JSONConfig: `{"command": "node"}`}, TransportStdio},
+		{types.MCPItem{Type: "JSON", JSONConfig: `{"type": "sse", "url": "https://x/sse"}`}, TransportSSE},
+		{types.MCPItem{Type: "JSON", JSONConfig: `{"url": "https://x/mcp"}`}, TransportHTTP},
+	}
+	for _, tt := range tests {
+		kind, err := ResolveTransportKind(tt.item)
+		if err != nil || kind != tt.expected {
+			t.Errorf("ResolveTransportKind(%+v) = %q, %v; expected %q", tt.item, kind, err, tt.expected)
+		}
+	}
+
+	if _, err := ResolveTransportKind(types.MCPItem{Type: "JSON", JSONConfig: `{}`}); err != ErrUnsupportedTransport {
+		t.Errorf("JSON without command or URL should be unsupported, got %v", err)
+	}
+	if _, err := ResolveTransportKind(types.MCPItem{Type: "JSON", JSONConfig: `{`}); err == nil {
+		t.Error("Invalid JSON should fail")
+	}
+}
+
+func TestResolveHTTPConfig(t *testing.T) {
+	config, err := ResolveHTTPConfig(types.MCPItem{
+		Name:       "remote",
+		Type:       "JSON",
+		JSONConfig: `{"type": "http", "url": "https://x/mcp", "headers": {"Authorization": "Bearer old", "X-Team": "core"}}`,
+		Headers:    map[string]string{"Authorization": "Bearer new"},
+	})
+	if err != nil {
+		t.Fatalf("ResolveHTTPConfig failed: %v", err)
+	}
+	expected := map[string]string{"Authorization": "Bearer new", "X-Team": "core"}
+	if config.URL != "https://x/mcp" || !reflect.DeepEqual(config.Headers, expected) {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+
+	if _, err := ResolveHTTPConfig(types.MCPItem{Name: "nourl", Type: "SSE"}); err == nil {
+		t.Error("Expected error for missing URL")
+	}
+}
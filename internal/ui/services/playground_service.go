@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// ToolCallTimeout bounds starting a server and running one tool call
+	ToolCallTimeout = 60 * time.Second
+
+	// MaxToolCallHistory is how many calls are kept per server
+	MaxToolCallHistory = 20
+)
+
+// toolInputSchema is the subset of JSON Schema used to generate argument fields
+type toolInputSchema struct {
+	Properties map[string]struct {
+		Type        interface{}   `json:"type"`
+		Description string        `json:"description"`
+		Enum        []interface{} `json:"enum"`
+	} `json:"properties"`
+	Required []string `json:"required"`
+}
+
+// BuildArgumentFields generates form fields from a tool's input schema.
+// Required fields come first, then the rest, each group sorted by name.
+func BuildArgumentFields(schema json.RawMessage) ([]types.PlaygroundField, error) {
+	if len(schema) == 0 {
+		return nil, nil
+	}
+
+	var parsed toolInputSchema
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid input schema: %w", err)
+	}
+
+	required := make(map[string]bool, len(parsed.Required))
+	for _, name := range parsed.Required {
+		required[name] = true
+	}
+
+	fields := make([]types.PlaygroundField, 0, len(parsed.Properties))
+	for name, property := range parsed.Properties {
+		field := types.PlaygroundField{
+			Name:        name,
+			Type:        schemaType(property.Type),
+			Description: property.Description,
+			Required:    required[name],
+		}
+		for _, value := range property.Enum {
+			field.Enum = append(field.Enum, fmt.Sprint(value))
+		}
+		fields = append(fields, field)
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Required != fields[j].Required {
+			return fields[i].Required
+		}
+		return fields[i].Name < fields[j].Name
+	})
+	return fields, nil
+}
+
+// schemaType reduces a JSON Schema type (a string or a list of strings) to a single type
+func schemaType(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case []interface{}:
+		for _, entry := range typed {
+			if name, ok := entry.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	return "string"
+}
+
+// BuildArguments converts form fields into tools/call arguments, skipping empty optional fields
+func BuildArguments(fields []types.PlaygroundField) (map[string]interface{}, error) {
+	arguments := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		value := strings.TrimSpace(field.Value)
+		if value == "" {
+			if field.Required {
+				return nil, fmt.Errorf("%s is required", field.Name)
+			}
+			continue
+		}
+
+		parsed, err := parseFieldValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		arguments[field.Name] = parsed
+	}
+	return arguments, nil
+}
+
+// parseFieldValue converts a form value to the Go value for its schema type
+func parseFieldValue(fieldType, value string) (interface{}, error) {
+	switch fieldType {
+	case "integer":
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, errors.New("expected an integer")
+		}
+		return parsed, nil
+	case "number":
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.New("expected a number")
+		}
+		return parsed, nil
+	case "boolean":
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.New("expected true or false")
+		}
+		return parsed, nil
+	case "array", "object":
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			return nil, fmt.Errorf("expected JSON %s", fieldType)
+		}
+		return parsed, nil
+	default:
+		return value, nil
+	}
+}
+
+// ParseRawArguments parses raw JSON arguments, which must be an object
+func ParseRawArguments(raw string) (map[string]interface{}, error) {
+	if strings.TrimSpace(raw) == "" {
+		return map[string]interface{}{}, nil
+	}
+	var arguments map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &arguments); err != nil {
+		return nil, fmt.Errorf("arguments must be a JSON object: %w", err)
+	}
+	return arguments, nil
+}
+
+// FieldsToRawJSON renders the current form values as raw JSON, ignoring invalid values
+func FieldsToRawJSON(fields []types.PlaygroundField) string {
+	arguments := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if value := strings.TrimSpace(field.Value); value != "" {
+			if parsed, err := parseFieldValue(field.Type, value); err == nil {
+				arguments[field.Name] = parsed
+			}
+		}
+	}
+	data, _ := json.Marshal(arguments)
+	return string(data)
+}
+
+// RawJSONToFields fills form fields from raw JSON arguments, leaving unknown keys out
+func RawJSONToFields(raw string, fields []types.PlaygroundField) []types.PlaygroundField {
+	arguments, err := ParseRawArguments(raw)
+	if err != nil {
+		return fields
+	}
+
+	updated := make([]types.PlaygroundField, len(fields))
+	copy(updated, fields)
+	for i, field := range updated {
+		value, ok := arguments[field.Name]
+		if !ok {
+			updated[i].Value = ""
+			continue
+		}
+		if text, isString := value.(string); isString {
+			updated[i].Value = text
+			continue
+		}
+		data, _ := json.Marshal(value)
+		updated[i].Value = string(data)
+	}
+	return updated
+}
+
+// CallTool connects to the server, calls one tool and records the outcome
+func CallTool(ctx context.Context, item types.MCPItem, tool string, arguments map[string]interface{}, logs *types.ServerLogStore) types.ToolCallRecord {
+	record := types.ToolCallRecord{
+		MCPName:  item.Name,
+		Tool:     tool,
+		CalledAt: time.Now(),
+	}
+	if data, err := json.Marshal(arguments); err == nil {
+		record.Arguments = string(data)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, ToolCallTimeout)
+	defer cancel()
+
+	session, err := OpenMCPSession(timeoutCtx, item, logs)
+	if err != nil {
+		record.Error = err.Error()
+		return record
+	}
+	defer func() {
+		_ = session.Close()
+	}()
+
+	start := time.Now()
+	result, err := session.Client.CallTool(timeoutCtx, tool, arguments)
+	record.Duration = time.Since(start)
+	if err != nil {
+		record.Error = err.Error()
+		return record
+	}
+
+	record.IsError = result.IsError
+	record.Result = FormatToolResult(result)
+	return record
+}
+
+// FormatToolResult renders tool output: text blocks verbatim, structured content
+// as indented JSON and other blocks as a short placeholder
+func FormatToolResult(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, block := range result.Content {
+		switch block.Type {
+		case "text":
+			parts = append(parts, block.Text)
+		case "resource":
+			parts = append(parts, "[resource] "+string(block.Resource))
+		default:
+			parts = append(parts, fmt.Sprintf("[%s %s]", block.Type, block.MimeType))
+		}
+	}
+
+	if len(result.StructuredContent) > 0 {
+		structured := string(result.StructuredContent)
+		var value interface{}
+		if err := json.Unmarshal(result.StructuredContent, &value); err == nil {
+			if data, err := json.MarshalIndent(value, "", "  "); err == nil {
+				structured = string(data)
+			}
+		}
+		parts = append(parts, "Structured content:\n"+structured)
+	}
+
+	if len(parts) == 0 {
+		return "(no content)"
+	}
+	return strings.Join(parts, "\n")
+}
+
+// AppendToolCallHistory records a call, keeping the newest MaxToolCallHistory entries per server
+func AppendToolCallHistory(history map[string][]types.ToolCallRecord, record types.ToolCallRecord) map[string][]types.ToolCallRecord {
+	if history == nil {
+		history = make(map[string][]types.ToolCallRecord)
+	}
+	calls := append(history[record.MCPName], record)
+	if len(calls) > MaxToolCallHistory {
+		calls = calls[len(calls)-MaxToolCallHistory:]
+	}
+	history[record.MCPName] = calls
+	return history
+}
+
+// GetPlaygroundTools returns the tools available to the playground for an item
+func GetPlaygroundTools(model types.Model, item types.MCPItem) []types.ToolInfo {
+	result, ok := GetIntrospection(model, item)
+	if !ok {
+		return nil
+	}
+	return result.Tools
+}
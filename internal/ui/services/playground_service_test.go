@@ -0,0 +1,168 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/ui/types"
+)
+
+const testToolSchema = `{
+	"type": "object",
+	"properties": {
+		"query": {"type": "string", "description": "Search text"},
+		"limit": {"type": "integer"},
+		"exact": {"type": ["boolean", "null"]},
+		"tags": {"type": "array"},
+		"mode": {"type": "string", "enum": ["fast", "full"]}
+	},
+	"required": ["query", "mode"]
+}`
+
+func TestBuildArgumentFields(t *testing.T) {
+	fields, err := BuildArgumentFields(json.RawMessage(testToolSchema))
+	if err != nil {
+		t.Fatalf("BuildArgumentFields failed: %v", err)
+	}
+
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	expected := []string{"mode", "query", "exact", "limit", "tags"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Field order = %v, expected required fields first: %v", names, expected)
+	}
+	if !fields[0].Required || !reflect.DeepEqual(fields[0].Enum, []string{"fast", "full"}) {
+		t.Errorf("Unexpected mode field: %+v", fields[0])
+	}
+	if fields[2].Type != "boolean" {
+		t.Errorf("Nullable type should resolve to boolean, got %q", fields[2].Type)
+	}
+
+	if fields, err := BuildArgumentFields(nil); err != nil || fields != nil {
+		t.Errorf("Empty schema should yield no fields, got %v, %v", fields, err)
+	}
+	if _, err := BuildArgumentFields(json.RawMessage(`{"properties": 1}`)); err == nil {
+		t.Error("Expected error for invalid schema")
+	}
+}
+
+func TestBuildArguments(t *testing.T) {
+	fields, _ := BuildArgumentFields(json.RawMessage(testToolSchema))
+	values := map[string]string{"query": "mcp", "mode": "fast", "limit": "10", "exact": "true", "tags": `["a","b"]`}
+	for i := range fields {
+		fields[i].Value = values[fields[i].Name]
+	}
+
+	arguments, err := BuildArguments(fields)
+	if err != nil {
+		t.Fatalf("BuildArguments failed: %v", err)
+	}
+	expected := map[string]interface{}{
+		"query": "mcp", "mode": "fast", "limit": int64(10), "exact": true, "tags": []interface{}{"a", "b"},
+	}
+	if !reflect.DeepEqual(arguments, expected) {
+		t.Errorf("BuildArguments() = %#v, expected %#v", arguments, expected)
+	}
+
+	fields[0].Value = ""
+	if _, err := BuildArguments(fields); err == nil || !strings.Contains(err.Error(), "mode is required") {
+		t.Errorf("Expected required field error, got %v", err)
+	}
+	fields[0].Value = "fast"
+	fields[3].Value = "ten"
+	if _, err := BuildArguments(fields); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Errorf("Expected integer parse error, got %v", err)
+	}
+}
+
+func TestRawJSONRoundTrip(t *testing.T) {
+	fields := []types.PlaygroundField{
+		{Name: "query", Type: "string", Value: "mcp"},
+		{Name: "limit", Type: "integer", Value: "5"},
+		{Name: "tags", Type: "array", Value: "not json"},
+	}
+
+	raw := FieldsToRawJSON(fields)
+	if raw != `{"limit":5,"query":"mcp"}` {
+		t.Errorf("FieldsToRawJSON() = %s", raw)
+	}
+
+	updated := RawJSONToFields(`{"query": "hub", "tags": ["x"]}`, fields)
+	if updated[0].Value != "hub" || updated[1].Value != "" || updated[2].Value != `["x"]` {
+		t.Errorf("RawJSONToFields() = %+v", updated)
+	}
+	if fields[0].Value != "mcp" {
+		t.Error("RawJSONToFields should not modify its input")
+	}
+
+	if _, err := ParseRawArguments(`[1, 2]`); err == nil {
+		t.Error("Raw arguments must be an object")
+	}
+	if arguments, err := ParseRawArguments("  "); err != nil || len(arguments) != 0 {
+		t.Errorf("Empty raw arguments should be an empty object, got %v, %v", arguments, err)
+	}
+}
+
+func TestCallTool(t *testing.T) {
+	item := fakeServerItem("1")
+
+	record := CallTool(t.Context(), item, "echo", map[string]interface{}{"text": "hello"}, nil)
+	if record.Error != "" || record.IsError || record.Result != "hello" {
+		t.Fatalf("Unexpected record: %+v", record)
+	}
+	if record.Arguments != `{"text":"hello"}` || record.Duration <= 0 || record.MCPName != "fake" {
+		t.Errorf("Call metadata not recorded: %+v", record)
+	}
+
+	record = CallTool(t.Context(), item, "echo", nil, nil)
+	if !record.IsError || record.Result != "text is required" {
+		t.Errorf("Tool-level error should be reported, got %+v", record)
+	}
+
+	record = CallTool(t.Context(), item, "missing", nil, nil)
+	if record.Error == "" {
+		t.Error("Protocol errors should be recorded as call failures")
+	}
+}
+
+func TestFormatToolResult(t *testing.T) {
+	result := FormatToolResult(&mcp.CallToolResult{
+		Content: []mcp.ContentBlock{
+			{Type: "text", Text: "found 2 files"},
+			{Type: "image", MimeType: "image/png", Data: "iVBOR..."},
+		},
+		StructuredContent: json.RawMessage(`{"files":["a","b"]}`),
+	})
+
+	for _, fragment := range []string{"found 2 files", "[image image/png]", "Structured content:", `"files": [`} {
+		if !strings.Contains(result, fragment) {
+			t.Errorf("Expected %q in:\n%s", fragment, result)
+		}
+	}
+	if FormatToolResult(&mcp.CallToolResult{}) != "(no content)" {
+		t.Error("Empty results should say so")
+	}
+}
+
+func TestAppendToolCallHistory(t *testing.T) {
+	var history map[string][]types.ToolCallRecord
+	for i := 0; i < MaxToolCallHistory+5; i++ {
+		history = AppendToolCallHistory(history, types.ToolCallRecord{MCPName: "gh", Tool: string(rune('a' + i%26))})
+	}
+	history = AppendToolCallHistory(history, types.ToolCallRecord{MCPName: "fs", Tool: "read"})
+
+	if len(history["gh"]) != MaxToolCallHistory {
+		t.Errorf("History should be capped at %d, got %d", MaxToolCallHistory, len(history["gh"]))
+	}
+	if history["gh"][0].Tool != "f" {
+		t.Errorf("Oldest calls should be dropped first, got %q", history["gh"][0].Tool)
+	}
+	if len(history["fs"]) != 1 {
+		t.Error("History should be kept per server")
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// profilesFileName stores named sets of MCPs next to the inventory
+const profilesFileName = "profiles.json"
+
+// ProfilesData is the on-disk format of the profiles file
+type ProfilesData struct {
+	Profiles map[string][]string `json:"profiles"`
+}
+
+// ProfileChanges lists what applying a profile would change in the inventory
+type ProfileChanges struct {
+	Enable  []string `json:"enable"`
+	Disable []string `json:"disable"`
+	Missing []string `json:"missing,omitempty"` // Profile entries not in the inventory
+}
+
+// GetProfilesPath returns the path of the profiles file
+func GetProfilesPath(platformService platform.PlatformService) string {
+	return filepath.Join(platformService.GetConfigPath(), profilesFileName)
+}
+
+// LoadProfiles loads the saved profiles; a missing file means no profiles
+func LoadProfiles(platformService platform.PlatformService) (map[string][]string, error) {
+	data, err := readSecureFile(GetProfilesPath(platformService))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string][]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	var profiles ProfilesData
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles: %w", err)
+	}
+	if profiles.Profiles == nil {
+		profiles.Profiles = map[string][]string{}
+	}
+	return profiles.Profiles, nil
+}
+
+// SaveProfiles writes the profiles file atomically
+func SaveProfiles(platformService platform.PlatformService, profiles map[string][]string) error {
+	data, err := json.MarshalIndent(ProfilesData{Profiles: profiles}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal profiles: %w", err)
+	}
+	if err := os.MkdirAll(platformService.GetConfigPath(), platformService.GetDefaultDirectoryPermissions()); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	profilesPath := GetProfilesPath(platformService)
+	tempPath := profilesPath + ".tmp"
+	if err := os.WriteFile(tempPath, data, platformService.GetDefaultFilePermissions()); err != nil {
+		return fmt.Errorf("failed to write profiles: %w", err)
+	}
+	if err := os.Rename(tempPath, profilesPath); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename profiles file: %w", err)
+	}
+	return nil
+}
+
+// ActiveMCPNames returns the names of the enabled MCPs in sorted order
+func ActiveMCPNames(items []types.MCPItem) []string {
+	var names []string
+	for _, item := range items {
+		if item.Active {
+			names = append(names, item.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ComputeProfileChanges works out which MCPs to enable and disable so that exactly
+// the profile's MCPs are active
+func ComputeProfileChanges(items []types.MCPItem, profile []string) ProfileChanges {
+	wanted := make(map[string]bool)
+	for _, name := range profile {
+		wanted[name] = true
+	}
+
+	changes := ProfileChanges{Enable: []string{}, Disable: []string{}}
+	for _, item := range items {
+		switch {
+		case wanted[item.Name] && !item.Active:
+			changes.Enable = append(changes.Enable, item.Name)
+		case !wanted[item.Name] && item.Active:
+			changes.Disable = append(changes.Disable, item.Name)
+		}
+		delete(wanted, item.Name)
+	}
+	for name := range wanted {
+		changes.Missing = append(changes.Missing, name)
+	}
+	sort.Strings(changes.Missing)
+	return changes
+}
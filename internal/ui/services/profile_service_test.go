@@ -0,0 +1,45 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func TestProfiles_RoundTrip(t *testing.T) {
+	mockPlatform := newTempCachePlatform(t)
+
+	profiles, err := LoadProfiles(mockPlatform)
+	if err != nil || len(profiles) != 0 {
+		t.Fatalf("Missing profiles file should load as empty, got %v, %v", profiles, err)
+	}
+
+	profiles["backend"] = []string{"postgres", "github"}
+	if err := SaveProfiles(mockPlatform, profiles); err != nil {
+		t.Fatalf("SaveProfiles failed: %v", err)
+	}
+	loaded, err := LoadProfiles(mockPlatform)
+	if err != nil || !reflect.DeepEqual(loaded, profiles) {
+		t.Errorf("LoadProfiles = %v, %v, expected %v", loaded, err, profiles)
+	}
+}
+
+func TestComputeProfileChanges(t *testing.T) {
+	items := []types.MCPItem{
+		{Name: "github", Active: true},
+		{Name: "postgres"},
+		{Name: "context7", Active: true},
+		{Name: "figma"},
+	}
+
+	changes := ComputeProfileChanges(items, []string{"github", "postgres", "unknown"})
+	expected := ProfileChanges{Enable: []string{"postgres"}, Disable: []string{"context7"}, Missing: []string{"unknown"}}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("ComputeProfileChanges = %+v, expected %+v", changes, expected)
+	}
+
+	if names := ActiveMCPNames(items); !reflect.DeepEqual(names, []string{"context7", "github"}) {
+		t.Errorf("ActiveMCPNames = %v", names)
+	}
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// ProjectMCPConfigFile is the project-scoped MCP configuration file read by Claude
+	ProjectMCPConfigFile = ".mcp.json"
+	// ProjectClaudeDir is the project-scoped Claude settings directory
+	ProjectClaudeDir = ".claude"
+
+	gitDirName     = ".git"
+	gitHeadFile    = "HEAD"
+	gitRefPrefix   = "ref: refs/heads/"
+	gitDirPrefix   = "gitdir:"
+	maxProjectFile = 1024 * 1024 // 1MB
+)
+
+// projectMCPConfig mirrors the structure of a project-scoped .mcp.json file
+type projectMCPConfig struct {
+	MCPServers map[string]json.RawMessage `json:"mcpServers"`
+}
+
+// ResolveProjectInfo resolves git and Claude project information for the given path
+func ResolveProjectInfo(path string) types.ProjectInfo {
+	info := types.ProjectInfo{}
+	if path == "" || path == UnknownStatus {
+		return info
+	}
+
+	projectRoot := path
+	if gitRoot, gitDir := findGitRoot(path); gitRoot != "" {
+		info.GitRoot = gitRoot
+		info.GitBranch = readGitBranch(gitDir)
+		projectRoot = gitRoot
+	}
+	info.Root = projectRoot
+
+	mcpConfigPath := filepath.Join(projectRoot, ProjectMCPConfigFile)
+	if fileInfo, err := os.Stat(mcpConfigPath); err == nil && fileInfo.Mode().IsRegular() {
+		info.HasMCPConfig = true
+		servers, err := readProjectMCPServers(mcpConfigPath)
+		if err != nil {
+			info.MCPConfigError = err.Error()
+		}
+		info.ProjectMCPs = servers
+	}
+
+	if fileInfo, err := os.Stat(filepath.Join(projectRoot, ProjectClaudeDir)); err == nil && fileInfo.IsDir() {
+		info.HasClaudeDir = true
+	}
+
+	return info
+}
+
+// findGitRoot walks up from path looking for a .git directory or file,
+// returning the repository root and the resolved git directory
+func findGitRoot(path string) (string, string) {
+	current := filepath.Clean(path)
+	for {
+		candidate := filepath.Join(current, gitDirName)
+		if fileInfo, err := os.Stat(candidate); err == nil {
+			if fileInfo.IsDir() {
+				return current, candidate
+			}
+			// Worktrees and submodules use a .git file pointing at the real git dir
+			if gitDir := readGitDirFile(candidate, current); gitDir != "" {
+				return current, gitDir
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", ""
+		}
+		current = parent
+	}
+}
+
+// readGitDirFile resolves the "gitdir: <path>" indirection used by worktrees
+func readGitDirFile(gitFile, root string) string {
+	data, err := readSecureFile(gitFile)
+	if err != nil {
+		return ""
+	}
+
+	content := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(content, gitDirPrefix) {
+		return ""
+	}
+
+	gitDir := strings.TrimSpace(strings.TrimPrefix(content, gitDirPrefix))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(root, gitDir)
+	}
+	return gitDir
+}
+
+// readGitBranch reads the current branch name from the git HEAD file.
+// A detached HEAD is reported as the abbreviated commit hash.
+func readGitBranch(gitDir string) string {
+	data, err := readSecureFile(filepath.Join(gitDir, gitHeadFile))
+	if err != nil {
+		return ""
+	}
+
+	head := strings.TrimSpace(string(data))
+	if strings.HasPrefix(head, gitRefPrefix) {
+		return strings.TrimPrefix(head, gitRefPrefix)
+	}
+
+	if len(head) >= 7 {
+		return head[:7]
+	}
+	return head
+}
+
+// readProjectMCPServers returns the sorted server names declared in a .mcp.json file
+func readProjectMCPServers(configPath string) ([]string, error) {
+	fileInfo, err := os.Stat(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if fileInfo.Size() > maxProjectFile {
+		return nil, fmt.Errorf("file too large")
+	}
+
+	data, err := readSecureFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config projectMCPConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	servers := make([]string, 0, len(config.MCPServers))
+	for name := range config.MCPServers {
+		servers = append(servers, name)
+	}
+	sort.Strings(servers)
+	return servers, nil
+}
+
+// IsProjectScopedMCP reports whether the named MCP is declared by the project's .mcp.json
+func IsProjectScopedMCP(projectContext types.ProjectContext, name string) bool {
+	for _, server := range projectContext.Project.ProjectMCPs {
+		if server == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatProjectInfoForDisplay formats git and .mcp.json information for the header
+func FormatProjectInfoForDisplay(info types.ProjectInfo) string {
+	if info.Root == "" {
+		return ""
+	}
+
+	var parts []string
+	name := filepath.Base(info.Root)
+	if info.GitBranch != "" {
+		parts = append(parts, name+" ("+info.GitBranch+")")
+	} else {
+		parts = append(parts, name)
+	}
+
+	switch {
+	case info.HasMCPConfig && info.MCPConfigError != "":
+		parts = append(parts, ProjectMCPConfigFile+": invalid")
+	case info.HasMCPConfig:
+		parts = append(parts, ProjectMCPConfigFile+": "+formatServerCount(len(info.ProjectMCPs)))
+	}
+
+	if info.HasClaudeDir {
+		parts = append(parts, ProjectClaudeDir+"/")
+	}
+
+	return "Project: " + strings.Join(parts, " • ")
+}
+
+func formatServerCount(count int) string {
+	if count == 1 {
+		return "1 server"
+	}
+	return strconv.Itoa(count) + " servers"
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory for %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestResolveProjectInfo_GitRepository(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, ".git", "HEAD"), "ref: refs/heads/feature/mcp\n")
+	writeTestFile(t, filepath.Join(root, ".mcp.json"), `{"mcpServers": {"postgres": {"command": "pg-mcp"}, "github": {"command": "gh-mcp"}}}`)
+	if err := os.MkdirAll(filepath.Join(root, ".claude"), 0755); err != nil {
+		t.Fatalf("Failed to create .claude dir: %v", err)
+	}
+	subDir := filepath.Join(root, "internal", "pkg")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("Failed to create subdirectory: %v", err)
+	}
+
+	info := ResolveProjectInfo(subDir)
+
+	if info.GitRoot != root {
+		t.Errorf("GitRoot = %q, expected %q", info.GitRoot, root)
+	}
+	if info.Root != root {
+		t.Errorf("Root = %q, expected %q", info.Root, root)
+	}
+	if info.GitBranch != "feature/mcp" {
+		t.Errorf("GitBranch = %q, expected %q", info.GitBranch, "feature/mcp")
+	}
+	if !info.HasMCPConfig || !info.HasClaudeDir {
+		t.Errorf("Expected .mcp.json and .claude/ to be detected, got %+v", info)
+	}
+	if expected := []string{"github", "postgres"}; !reflect.DeepEqual(info.ProjectMCPs, expected) {
+		t.Errorf("ProjectMCPs = %v, expected %v", info.ProjectMCPs, expected)
+	}
+}
+
+func TestResolveProjectInfo_DetachedHeadAndWorktree(t *testing.T) {
+	base := t.TempDir()
+	gitDir := filepath.Join(base, "real-git-dir")
+	writeTestFile(t, filepath.Join(gitDir, "HEAD"), "0123456789abcdef0123456789abcdef01234567\n")
+
+	worktree := filepath.Join(base, "worktree")
+	writeTestFile(t, filepath.Join(worktree, ".git"), "gitdir: "+gitDir+"\n")
+
+	info := ResolveProjectInfo(worktree)
+
+	if info.GitRoot != worktree {
+		t.Errorf("GitRoot = %q, expected %q", info.GitRoot, worktree)
+	}
+	if info.GitBranch != "0123456" {
+		t.Errorf("GitBranch = %q, expected abbreviated commit", info.GitBranch)
+	}
+	if info.HasMCPConfig {
+		t.Error("HasMCPConfig should be false without a .mcp.json")
+	}
+}
+
+func TestResolveProjectInfo_InvalidMCPConfig(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, ".mcp.json"), `{"mcpServers": `)
+
+	info := ResolveProjectInfo(root)
+
+	if !info.HasMCPConfig {
+		t.Fatal("HasMCPConfig should be true when .mcp.json exists")
+	}
+	if info.MCPConfigError == "" {
+		t.Error("MCPConfigError should be set for invalid JSON")
+	}
+}
+
+func TestResolveProjectInfo_UnknownPath(t *testing.T) {
+	for _, path := range []string{"", UnknownStatus} {
+		info := ResolveProjectInfo(path)
+		if info.Root != "" || info.HasMCPConfig {
+			t.Errorf("ResolveProjectInfo(%q) should be empty, got %+v", path, info)
+		}
+	}
+}
+
+func TestIsProjectScopedMCP(t *testing.T) {
+	projectContext := types.ProjectContext{
+		Project: types.ProjectInfo{ProjectMCPs: []string{"github", "postgres"}},
+	}
+
+	if !IsProjectScopedMCP(projectContext, "postgres") {
+		t.Error("postgres should be project-scoped")
+	}
+	if IsProjectScopedMCP(projectContext, "context7") {
+		t.Error("context7 should come from the personal inventory")
+	}
+}
+
+func TestFormatProjectInfoForDisplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     types.ProjectInfo
+		expected []string
+	}{
+		{
+			name:     "No project",
+			info:     types.ProjectInfo{},
+			expected: nil,
+		},
+		{
+			name:     "Git repository with branch and servers",
+			info:     types.ProjectInfo{Root: "/work/api", GitBranch: "main", HasMCPConfig: true, ProjectMCPs: []string{"a", "b"}, HasClaudeDir: true},
+			expected: []string{"Project: api (main)", ".mcp.json: 2 servers", ".claude/"},
+		},
+		{
+			name:     "Single server",
+			info:     types.ProjectInfo{Root: "/work/api", HasMCPConfig: true, ProjectMCPs: []string{"a"}},
+			expected: []string{"Project: api", ".mcp.json: 1 server"},
+		},
+		{
+			name:     "Invalid config",
+			info:     types.ProjectInfo{Root: "/work/api", HasMCPConfig: true, MCPConfigError: "boom"},
+			expected: []string{".mcp.json: invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatProjectInfoForDisplay(tt.info)
+			if tt.expected == nil && result != "" {
+				t.Errorf("Expected empty display, got %q", result)
+			}
+			for _, fragment := range tt.expected {
+				if !strings.Contains(result, fragment) {
+					t.Errorf("Expected %q to contain %q", result, fragment)
+				}
+			}
+		})
+	}
+}
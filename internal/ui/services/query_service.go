@@ -0,0 +1,294 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"mcp-hub/internal/ui/types"
+)
+
+// maxQueryCompletions caps the completion hints shown while typing a query
+const maxQueryCompletions = 6
+
+// queryField is a field filter of the search query language, e.g. type:sse
+type queryField struct {
+	name    string
+	aliases []string
+	// parse validates a value and returns the match function it filters by
+	parse func(value string) (func(types.MCPItem) bool, error)
+	// values lists the completions for the field's values
+	values func(items []types.MCPItem) []string
+}
+
+// queryFields lists the filters in the order they are suggested
+var queryFields = []queryField{
+	{name: "type", parse: parseTypeFilter, values: func([]types.MCPItem) []string { return mcpTypeNames }},
+	{name: "active", parse: parseActiveFilter, values: func([]types.MCPItem) []string { return []string{"true", "false"} }},
+	{name: "env", parse: containsFilter(func(item types.MCPItem) []string { return sortedKeys(item.Environment) }), values: envKeyCompletions},
+	{name: "cmd", aliases: []string{"command"}, parse: containsFilter(func(item types.MCPItem) []string { return []string{item.Command} })},
+	{name: "name", parse: containsFilter(func(item types.MCPItem) []string { return []string{item.Name} })},
+	{name: "tag", aliases: []string{"tags"}, parse: containsFilter(func(item types.MCPItem) []string { return item.Tags }), values: tagCompletions},
+	{name: "url", parse: containsFilter(func(item types.MCPItem) []string { return []string{item.URL} })},
+}
+
+// mcpTypeNames are the values accepted by type:
+var mcpTypeNames = []string{"cmd", "sse", "http", "json"}
+
+// QueryFilter is one field filter of a parsed search query
+type QueryFilter struct {
+	Field  string
+	Value  string
+	Negate bool
+	match  func(types.MCPItem) bool
+}
+
+// Matches reports whether item passes the filter
+func (f QueryFilter) Matches(item types.MCPItem) bool {
+	return f.match(item) != f.Negate
+}
+
+// SearchQuery is a parsed search: free-text terms matched fuzzily, and field filters
+// every result must pass
+type SearchQuery struct {
+	Terms   []string
+	Filters []QueryFilter
+}
+
+// Matches reports whether item passes every filter of the query
+func (q SearchQuery) Matches(item types.MCPItem) bool {
+	for _, filter := range q.Filters {
+		if !filter.Matches(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSearchQuery splits a query into free-text terms and field:value filters, where
+// a leading - negates a filter and double quotes keep text with spaces or colons as one
+// term. Invalid filters are left out of the returned query and the first one is
+// reported as the error, so the rest of the query still applies while it is typed.
+func ParseSearchQuery(query string) (SearchQuery, error) {
+	var parsed SearchQuery
+	var firstErr error
+	for _, token := range tokenizeQuery(query) {
+		if token.quoted {
+			parsed.Terms = append(parsed.Terms, token.text)
+			continue
+		}
+		filter, isFilter, err := parseQueryFilter(token.text)
+		switch {
+		case err != nil:
+			if firstErr == nil {
+				firstErr = err
+			}
+		case isFilter:
+			parsed.Filters = append(parsed.Filters, filter)
+		default:
+			parsed.Terms = append(parsed.Terms, token.text)
+		}
+	}
+	return parsed, firstErr
+}
+
+// queryToken is a whitespace-separated word of a query, or a quoted phrase
+type queryToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeQuery splits a query on whitespace outside double quotes. An unterminated
+// quote runs to the end of the query.
+func tokenizeQuery(query string) []queryToken {
+	var tokens []queryToken
+	var current strings.Builder
+	inQuotes, quoted := false, false
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, queryToken{text: current.String(), quoted: quoted})
+		}
+		current.Reset()
+		quoted = false
+	}
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case unicode.IsSpace(r) && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// parseQueryFilter parses a field:value token. Tokens without a field name before the
+// colon, such as plain words or https://..., are free text.
+func parseQueryFilter(token string) (QueryFilter, bool, error) {
+	negate := strings.HasPrefix(token, "-")
+	name, value, found := strings.Cut(strings.TrimPrefix(token, "-"), ":")
+	if !found || !isFieldName(name) || strings.HasPrefix(value, "//") {
+		return QueryFilter{}, false, nil
+	}
+	field, ok := lookupQueryField(name)
+	if !ok {
+		return QueryFilter{}, true, fmt.Errorf("unknown filter %q - use %s", name+":", queryFieldList())
+	}
+	if value == "" {
+		return QueryFilter{}, true, fmt.Errorf("%s: needs a value", field.name)
+	}
+	match, err := field.parse(value)
+	if err != nil {
+		return QueryFilter{}, true, fmt.Errorf("%s: %w", field.name, err)
+	}
+	return QueryFilter{Field: field.name, Value: value, Negate: negate, match: match}, true, nil
+}
+
+// isFieldName reports whether name could be a filter name: letters only
+func isFieldName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupQueryField finds a filter by name or alias, ignoring case
+func lookupQueryField(name string) (queryField, bool) {
+	name = strings.ToLower(name)
+	for _, field := range queryFields {
+		if field.name == name {
+			return field, true
+		}
+		for _, alias := range field.aliases {
+			if alias == name {
+				return field, true
+			}
+		}
+	}
+	return queryField{}, false
+}
+
+// queryFieldList names the filters for error messages, e.g. "type:, active: or url:"
+func queryFieldList() string {
+	names := make([]string, len(queryFields))
+	for i, field := range queryFields {
+		names[i] = field.name + ":"
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// parseTypeFilter matches the transport type, e.g. type:sse
+func parseTypeFilter(value string) (func(types.MCPItem) bool, error) {
+	for _, name := range mcpTypeNames {
+		if strings.EqualFold(value, name) {
+			return func(item types.MCPItem) bool { return strings.EqualFold(item.Type, name) }, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown type %q - use cmd, sse, http or json", value)
+}
+
+// parseActiveFilter matches the active state, e.g. active:false
+func parseActiveFilter(value string) (func(types.MCPItem) bool, error) {
+	var want bool
+	switch strings.ToLower(value) {
+	case "true", "yes", "on":
+		want = true
+	case "false", "no", "off":
+		want = false
+	default:
+		return nil, fmt.Errorf("expected true or false, got %q", value)
+	}
+	return func(item types.MCPItem) bool { return item.Active == want }, nil
+}
+
+// containsFilter matches items where one of values contains the filter value, ignoring case
+func containsFilter(values func(types.MCPItem) []string) func(string) (func(types.MCPItem) bool, error) {
+	return func(value string) (func(types.MCPItem) bool, error) {
+		value = strings.ToLower(value)
+		return func(item types.MCPItem) bool {
+			for _, candidate := range values(item) {
+				if strings.Contains(strings.ToLower(candidate), value) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+}
+
+// envKeyCompletions lists the environment variable names used in the inventory
+func envKeyCompletions(items []types.MCPItem) []string {
+	return uniqueSorted(items, func(item types.MCPItem) []string { return sortedKeys(item.Environment) })
+}
+
+// tagCompletions lists the tags used in the inventory
+func tagCompletions(items []types.MCPItem) []string {
+	return uniqueSorted(items, func(item types.MCPItem) []string { return item.Tags })
+}
+
+// uniqueSorted collects the distinct values of every item
+func uniqueSorted(items []types.MCPItem, values func(types.MCPItem) []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, item := range items {
+		for _, value := range values(item) {
+			if !seen[value] {
+				seen[value] = true
+				result = append(result, value)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SearchCompletions suggests how to finish the last word of a query: filter names while
+// a field name is typed, then the values of that field from the inventory
+func SearchCompletions(query string, items []types.MCPItem) []string {
+	if query == "" || unicode.IsSpace([]rune(query)[len([]rune(query))-1]) {
+		return nil
+	}
+	tokens := tokenizeQuery(query)
+	if len(tokens) == 0 || tokens[len(tokens)-1].quoted {
+		return nil
+	}
+	word := tokens[len(tokens)-1].text
+	negation := ""
+	if strings.HasPrefix(word, "-") {
+		negation, word = "-", word[1:]
+	}
+
+	var completions []string
+	name, value, found := strings.Cut(word, ":")
+	if !found {
+		if word == "" {
+			return nil
+		}
+		for _, field := range queryFields {
+			if strings.HasPrefix(field.name, strings.ToLower(word)) {
+				completions = append(completions, negation+field.name+":")
+			}
+		}
+	} else if field, ok := lookupQueryField(name); ok && field.values != nil {
+		for _, candidate := range field.values(items) {
+			lower, typed := strings.ToLower(candidate), strings.ToLower(value)
+			if strings.HasPrefix(lower, typed) && lower != typed {
+				completions = append(completions, negation+field.name+":"+candidate)
+			}
+		}
+	}
+	if len(completions) > maxQueryCompletions {
+		completions = completions[:maxQueryCompletions]
+	}
+	return completions
+}
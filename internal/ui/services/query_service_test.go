@@ -0,0 +1,155 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func queryTestItems() []types.MCPItem {
+	return []types.MCPItem{
+		{Name: "context7", Type: "SSE", Active: true, URL: "https://mcp.context7.com/sse"},
+		{Name: "github", Type: "CMD", Active: true, Command: "npx", Args: []string{"@github/mcp"}, Environment: map[string]string{"GITHUB_TOKEN": "x"}},
+		{Name: "filesystem", Type: "CMD", Active: false, Command: "uvx", Tags: []string{"local"}},
+		{Name: "raw", Type: "JSON", Active: false, JSONConfig: `{"command": "raw"}`},
+		{Name: "docs", Type: "HTTP", Active: true, URL: "https://docs.example.com/mcp"},
+	}
+}
+
+func searchNames(items []types.MCPItem, query string) []string {
+	var names []string
+	for _, match := range SearchMCPs(items, query) {
+		names = append(names, match.Item.Name)
+	}
+	return names
+}
+
+func TestSearchMCPs_Filters(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"type:sse", []string{"context7"}},
+		{"type:CMD", []string{"github", "filesystem"}},
+		{"-type:json", []string{"context7", "github", "filesystem", "docs"}},
+		{"active:false", []string{"filesystem", "raw"}},
+		{"-active:true", []string{"filesystem", "raw"}},
+		{"env:github_token", []string{"github"}},
+		{"env:token", []string{"github"}},
+		{"cmd:npx", []string{"github"}},
+		{"command:uvx", []string{"filesystem"}},
+		{"tag:local", []string{"filesystem"}},
+		{"url:example", []string{"docs"}},
+		{"name:con", []string{"context7"}},
+		{"type:cmd active:true", []string{"github"}},
+		{"type:cmd fs", []string{"filesystem"}},
+		{"active:true -type:sse docs", []string{"docs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := searchNames(queryTestItems(), tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchMCPs(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	parsed, err := ParseSearchQuery(`git -type:json "my server" https://example.com active:false`)
+	if err != nil {
+		t.Fatalf("ParseSearchQuery failed: %v", err)
+	}
+	if want := []string{"git", "my server", "https://example.com"}; !reflect.DeepEqual(parsed.Terms, want) {
+		t.Errorf("Terms = %q, want %q", parsed.Terms, want)
+	}
+	if len(parsed.Filters) != 2 {
+		t.Fatalf("Filters = %+v, want 2", parsed.Filters)
+	}
+	if f := parsed.Filters[0]; f.Field != "type" || f.Value != "json" || !f.Negate {
+		t.Errorf("First filter = %+v, want negated type:json", f)
+	}
+	if f := parsed.Filters[1]; f.Field != "active" || f.Value != "false" || f.Negate {
+		t.Errorf("Second filter = %+v, want active:false", f)
+	}
+}
+
+func TestParseSearchQuery_QuotedColonIsText(t *testing.T) {
+	parsed, err := ParseSearchQuery(`"note:todo"`)
+	if err != nil || len(parsed.Filters) != 0 || !reflect.DeepEqual(parsed.Terms, []string{"note:todo"}) {
+		t.Errorf("Quoted text should be a free-text term, got %+v, %v", parsed, err)
+	}
+}
+
+func TestParseSearchQuery_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"type:grpc", `type: unknown type "grpc"`},
+		{"active:maybe", `active: expected true or false, got "maybe"`},
+		{"owner:me", `unknown filter "owner:" - use type:, active:, env:, cmd:, name:, tag: or url:`},
+		{"env:", "env: needs a value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseSearchQuery(tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseSearchQuery(%q) error = %v, want %q", tt.query, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSearchQuery_InvalidFilterIsIgnored(t *testing.T) {
+	parsed, err := ParseSearchQuery("type:grpc github")
+	if err == nil {
+		t.Fatal("Expected an error for type:grpc")
+	}
+	if len(parsed.Filters) != 0 || !reflect.DeepEqual(parsed.Terms, []string{"github"}) {
+		t.Errorf("The valid rest of the query should still apply, got %+v", parsed)
+	}
+	if got := searchNames(queryTestItems(), "type:grpc github"); !reflect.DeepEqual(got, []string{"github"}) {
+		t.Errorf("SearchMCPs with an invalid filter = %v, want [github]", got)
+	}
+}
+
+func TestNameMatchPositions_IgnoresFilters(t *testing.T) {
+	if got := NameMatchPositions("type:cmd git", "github"); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("NameMatchPositions = %v, want [0 1 2]", got)
+	}
+}
+
+func TestSearchCompletions(t *testing.T) {
+	items := queryTestItems()
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", nil},
+		{"git ", nil},
+		{"t", []string{"type:", "tag:"}},
+		{"-ac", []string{"-active:"}},
+		{"git na", []string{"name:"}},
+		{"type:", []string{"type:cmd", "type:sse", "type:http", "type:json"}},
+		{"type:S", []string{"type:sse"}},
+		{"type:sse", nil},
+		{"active:f", []string{"active:false"}},
+		{"env:g", []string{"env:GITHUB_TOKEN"}},
+		{"tags:", []string{"tag:local"}},
+		{"cmd:", nil},
+		{`"ty`, nil},
+		{"xyz", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := SearchCompletions(tt.query, items); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchCompletions(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// RecordEnv selects the servers whose JSON-RPC traffic is recorded:
+	// a comma-separated list of inventory names, or * for every server
+	RecordEnv = "MCP_HUB_RECORD"
+
+	// RecordingDir is the directory under the log path holding recordings
+	RecordingDir = "recordings"
+
+	// LogSourceRecorder marks recorder notices in the server log store
+	LogSourceRecorder = "recorder"
+
+	recordingExtension = ".jsonl"
+)
+
+// recording holds which servers are recorded and the platform whose log directory and
+// permissions the recordings use; sessions read it when they open
+var recording struct {
+	sync.RWMutex
+	platform platform.PlatformService
+	all      bool
+	servers  map[string]bool
+}
+
+// EnableRecording records the sessions of the servers named in spec into the platform's
+// log directory. spec is a comma-separated list of names or *; an empty spec or a nil
+// platform turns recording off.
+func EnableRecording(platformService platform.PlatformService, spec string) {
+	recording.Lock()
+	defer recording.Unlock()
+
+	recording.platform = platformService
+	recording.all = false
+	recording.servers = make(map[string]bool)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "":
+		case "*":
+			recording.all = true
+		default:
+			recording.servers[name] = true
+		}
+	}
+}
+
+// EnableRecordingFromEnv applies RecordEnv, recording into the platform's log directory
+func EnableRecordingFromEnv(platformService platform.PlatformService) {
+	EnableRecording(platformService, os.Getenv(RecordEnv))
+}
+
+// GetRecordingDir returns the directory recordings are written to
+func GetRecordingDir(platformService platform.PlatformService) string {
+	return filepath.Join(platformService.GetLogPath(), RecordingDir)
+}
+
+// IsRecording reports whether sessions with the named server are recorded
+func IsRecording(mcpName string) bool {
+	recording.RLock()
+	defer recording.RUnlock()
+	return recording.platform != nil && (recording.all || recording.servers[mcpName])
+}
+
+// startRecording wraps a transport so its traffic is written to a new recording file,
+// named after the server and the session's start time
+func startRecording(mcpName string, transport mcp.Transport) (*mcp.RecordingTransport, string, error) {
+	recording.RLock()
+	platformService := recording.platform
+	recording.RUnlock()
+
+	dir := GetRecordingDir(platformService)
+	if err := os.MkdirAll(dir, platformService.GetDefaultDirectoryPermissions()); err != nil {
+		return nil, "", fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	baseName := unsafeFileNameChars.ReplaceAllString(mcpName, "_") + "-" + time.Now().Format("20060102-150405.000")
+	file, path, err := createRecordingFile(dir, baseName, platformService.GetDefaultFilePermissions())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create recording: %w", err)
+	}
+	return mcp.NewRecordingTransport(transport, file), path, nil
+}
+
+// createRecordingFile creates a new recording named baseName in dir, adding a
+// suffix when sessions started in the same millisecond would share the name
+func createRecordingFile(dir, baseName string, perm os.FileMode) (*os.File, string, error) {
+	for attempt := 1; ; attempt++ {
+		fileName := baseName + recordingExtension
+		if attempt > 1 {
+			fileName = fmt.Sprintf("%s-%d%s", baseName, attempt, recordingExtension)
+		}
+		path := filepath.Join(dir, fileName)
+
+		//nolint:gosec // G304: The path is built from the recording directory and a sanitized name
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return file, path, nil
+	}
+}
+
+// logRecorderEvent notes a recording in the server log store
+func logRecorderEvent(logs *types.ServerLogStore, mcpName string, level types.LogLevel, message string) {
+	logs.Append(types.ServerLogEntry{
+		MCPName: mcpName,
+		Time:    time.Now(),
+		Level:   level,
+		Source:  LogSourceRecorder,
+		Message: message,
+	})
+}
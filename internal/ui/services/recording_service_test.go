@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// enableTestRecording records the named servers into a temporary directory for one test
+func enableTestRecording(t *testing.T, spec string) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	mockPlatform := platform.NewMockPlatformService()
+	mockPlatform.SetPaths(tempDir, tempDir, tempDir, tempDir)
+	EnableRecording(mockPlatform, spec)
+	t.Cleanup(func() { EnableRecording(nil, "") })
+	return GetRecordingDir(mockPlatform)
+}
+
+func TestEnableRecording(t *testing.T) {
+	enableTestRecording(t, " github , fake,")
+	if !IsRecording("github") || !IsRecording("fake") || IsRecording("other") {
+		t.Error("Only the listed servers should be recorded")
+	}
+
+	enableTestRecording(t, "*")
+	if !IsRecording("anything") {
+		t.Error("* should record every server")
+	}
+
+	EnableRecording(nil, "*")
+	if IsRecording("anything") {
+		t.Error("Recording needs a platform")
+	}
+}
+
+func TestEnableRecordingFromEnv(t *testing.T) {
+	tempDir := t.TempDir()
+	mockPlatform := platform.NewMockPlatformService()
+	mockPlatform.SetPaths(tempDir, tempDir, tempDir, tempDir)
+	t.Setenv(RecordEnv, "fake")
+	t.Cleanup(func() { EnableRecording(nil, "") })
+
+	EnableRecordingFromEnv(mockPlatform)
+	if !IsRecording("fake") {
+		t.Errorf("%s should enable recording", RecordEnv)
+	}
+	if GetRecordingDir(mockPlatform) != filepath.Join(tempDir, RecordingDir) {
+		t.Errorf("Unexpected recording dir %s", GetRecordingDir(mockPlatform))
+	}
+}
+
+func TestRecording_ReplaysIntrospection(t *testing.T) {
+	dir := enableTestRecording(t, "fake")
+	logs := types.NewServerLogStore(10)
+
+	recorded := IntrospectMCP(context.Background(), fakeServerItem("full"), logs)
+	if recorded.Error != "" {
+		t.Fatalf("Recorded introspection failed: %s", recorded.Error)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "fake-*.jsonl"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Expected one recording, got %v (%v)", files, err)
+	}
+	info, err := os.Stat(files[0])
+	if err != nil || info.Mode().Perm() != platform.NewMockPlatformService().GetDefaultFilePermissions() {
+		t.Errorf("Recording should use the platform's file permissions, got %v (%v)", info, err)
+	}
+	entries := logs.Entries("fake")
+	if len(entries) == 0 || entries[0].Source != LogSourceRecorder || !strings.Contains(entries[0].Message, files[0]) {
+		t.Errorf("Recording should be noted in the server log, got %+v", entries)
+	}
+
+	// The replay server stands in for the real one
+	EnableRecording(nil, "")
+	item := fakeServerItem("replay")
+	item.Environment["MCP_HUB_REPLAY_FILE"] = files[0]
+	replayed := IntrospectMCP(context.Background(), item, nil)
+	if replayed.Error != "" {
+		t.Fatalf("Replayed introspection failed: %s", replayed.Error)
+	}
+	if replayed.ServerName != recorded.ServerName || !reflect.DeepEqual(replayed.Tools, recorded.Tools) ||
+		!reflect.DeepEqual(replayed.Resources, recorded.Resources) || !reflect.DeepEqual(replayed.Prompts, recorded.Prompts) {
+		t.Errorf("Replay should reproduce the recorded introspection:\n%+v\n%+v", recorded, replayed)
+	}
+	// Introspection never pings, so a probe against the recording only gets through the handshake
+	if health := ProbeMCP(context.Background(), item, nil); health.Status != types.HealthDegraded || !strings.Contains(health.Error, "not in recording") {
+		t.Errorf("Unrecorded ping should degrade the replayed probe, got %v (%s)", health.Status, health.Error)
+	}
+}
+
+func TestRecording_FailureDoesNotStopSession(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	mockPlatform := platform.NewMockPlatformService()
+	mockPlatform.SetPaths(blocker, blocker, blocker, blocker)
+	EnableRecording(mockPlatform, "fake")
+	t.Cleanup(func() { EnableRecording(nil, "") })
+	logs := types.NewServerLogStore(10)
+
+	if result := ProbeMCP(context.Background(), fakeServerItem("1"), logs); result.Status != types.HealthHealthy {
+		t.Errorf("Probe should succeed without a recording, got %v (%s)", result.Status, result.Error)
+	}
+	entries := logs.Entries("fake")
+	if len(entries) == 0 || entries[0].Level != types.LogWarning {
+		t.Errorf("Failed recording should be logged as a warning, got %+v", entries)
+	}
+}
+
+func TestCreateRecordingFile_DoesNotReuseNames(t *testing.T) {
+	dir := t.TempDir()
+
+	var paths []string
+	for range 3 {
+		file, path, err := createRecordingFile(dir, "github-20250101-120000.000", 0o600)
+		if err != nil {
+			t.Fatalf("createRecordingFile failed: %v", err)
+		}
+		_ = file.Close()
+		paths = append(paths, filepath.Base(path))
+	}
+
+	want := []string{
+		"github-20250101-120000.000" + recordingExtension,
+		"github-20250101-120000.000-2" + recordingExtension,
+		"github-20250101-120000.000-3" + recordingExtension,
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Recordings started together should get their own files, got %v, want %v", paths, want)
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"mcp-hub/internal/ui/types"
+)
+
+// Fuzzy match scoring: every matched character earns fuzzyMatchScore, with bonuses for
+// starting the text or a word and for following the previous match, and penalties for
+// the characters skipped between matches
+const (
+	fuzzyMatchScore       = 16
+	fuzzyPrefixBonus      = 12
+	fuzzyBoundaryBonus    = 8
+	fuzzyConsecutiveBonus = 8
+	fuzzyGapStartPenalty  = 3
+	fuzzyGapExtendPenalty = 1
+
+	// fuzzyMaxStarts caps the alignments tried per text, keeping long args cheap to search
+	fuzzyMaxStarts = 32
+)
+
+// searchField is a part of an MCP that search matches; weight multiplies the score of a
+// match in it, so a name match outranks the same match in the command
+type searchField struct {
+	name   string
+	weight int
+	values func(types.MCPItem) []string
+}
+
+// searchFields lists what search looks at, most important first
+var searchFields = []searchField{
+	{"name", 4, func(item types.MCPItem) []string { return []string{item.Name} }},
+	{"tags", 3, func(item types.MCPItem) []string { return item.Tags }},
+	{"description", 2, func(item types.MCPItem) []string { return []string{item.Description} }},
+	{"command", 2, func(item types.MCPItem) []string { return []string{item.Command} }},
+	{"url", 2, func(item types.MCPItem) []string { return []string{item.URL} }},
+	{"args", 1, func(item types.MCPItem) []string { return item.Args }},
+	{"env", 1, func(item types.MCPItem) []string { return sortedKeys(item.Environment) }},
+}
+
+// SearchMatch is an MCP matching a search query
+type SearchMatch struct {
+	Item  types.MCPItem
+	Score int
+	// Field is where the best-scoring term matched, e.g. "name" or "env"
+	Field string
+}
+
+// FuzzyMatch reports whether pattern's characters appear in text in order, ignoring case.
+// It returns the match's score and the rune indexes of the matched characters in text.
+func FuzzyMatch(pattern, text string) (int, []int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return 0, nil, true
+	}
+	textRunes := []rune(text)
+	lowerRunes := []rune(strings.ToLower(text))
+	if len(lowerRunes) != len(textRunes) {
+		// Case folding changed the length; match the text as it is so positions line up
+		lowerRunes = textRunes
+	}
+	if len(patternRunes) > len(lowerRunes) {
+		return 0, nil, false
+	}
+
+	bestScore, found := 0, false
+	var bestPositions []int
+	starts := 0
+	for start := range lowerRunes {
+		if lowerRunes[start] != patternRunes[0] {
+			continue
+		}
+		if starts++; starts > fuzzyMaxStarts {
+			break
+		}
+		positions := alignFrom(patternRunes, lowerRunes, start)
+		if positions == nil {
+			// No later start can fit the rest of the pattern either
+			break
+		}
+		if score := scoreAlignment(textRunes, positions); !found || score > bestScore {
+			bestScore, bestPositions, found = score, positions, true
+		}
+	}
+	return bestScore, bestPositions, found
+}
+
+// alignFrom matches pattern greedily in text starting at start, returning nil when it does not fit
+func alignFrom(pattern, text []rune, start int) []int {
+	positions := make([]int, 0, len(pattern))
+	next := 0
+	for i := start; i < len(text) && next < len(pattern); i++ {
+		if text[i] == pattern[next] {
+			positions = append(positions, i)
+			next++
+		}
+	}
+	if next < len(pattern) {
+		return nil
+	}
+	return positions
+}
+
+// scoreAlignment scores matched positions in text
+func scoreAlignment(text []rune, positions []int) int {
+	score := 0
+	for i, position := range positions {
+		score += fuzzyMatchScore
+		switch {
+		case position == 0:
+			score += fuzzyPrefixBonus
+		case isWordBoundary(text, position):
+			score += fuzzyBoundaryBonus
+		}
+		if i == 0 {
+			continue
+		}
+		if gap := position - positions[i-1] - 1; gap == 0 {
+			score += fuzzyConsecutiveBonus
+		} else {
+			score -= fuzzyGapStartPenalty + (gap-1)*fuzzyGapExtendPenalty
+		}
+	}
+	return score
+}
+
+// isWordBoundary reports whether text[position] starts a word, after a separator or
+// as the upper-case letter of a camelCase word
+func isWordBoundary(text []rune, position int) bool {
+	previous, current := text[position-1], text[position]
+	if !unicode.IsLetter(previous) && !unicode.IsDigit(previous) {
+		return true
+	}
+	return unicode.IsLower(previous) && unicode.IsUpper(current)
+}
+
+// SearchMCPs returns the items passing the filters of query and matching every one of
+// its free-text terms, best match first. Items with equal scores keep their inventory
+// order. Invalid filters are ignored; ParseSearchQuery reports them.
+func SearchMCPs(items []types.MCPItem, query string) []SearchMatch {
+	parsed, _ := ParseSearchQuery(query)
+	var matches []SearchMatch
+	for _, item := range items {
+		if !parsed.Matches(item) {
+			continue
+		}
+		if match, ok := matchItem(item, parsed.Terms); ok {
+			matches = append(matches, match)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+// matchItem scores an item against all terms; each term counts with its best field
+func matchItem(item types.MCPItem, terms []string) (SearchMatch, bool) {
+	match := SearchMatch{Item: item}
+	bestTermScore := 0
+	for i, term := range terms {
+		// Long gaps can push a fuzzy score to zero or below, so matches are
+		// tracked apart from their scores
+		termScore, termField, matched := 0, "", false
+		for _, field := range searchFields {
+			for _, value := range field.values(item) {
+				score, _, ok := FuzzyMatch(term, value)
+				if ok && (!matched || score*field.weight > termScore) {
+					termScore, termField, matched = score*field.weight, field.name, true
+				}
+			}
+		}
+		if !matched {
+			return SearchMatch{}, false
+		}
+		match.Score += termScore
+		if i == 0 || termScore > bestTermScore {
+			bestTermScore, match.Field = termScore, termField
+		}
+	}
+	return match, true
+}
+
+// NameMatchPositions returns the rune indexes of name's characters matched by the
+// free-text terms of query, for highlighting
+func NameMatchPositions(query, name string) []int {
+	parsed, _ := ParseSearchQuery(query)
+	seen := make(map[int]bool)
+	var positions []int
+	for _, term := range parsed.Terms {
+		_, termPositions, ok := FuzzyMatch(term, name)
+		if !ok {
+			continue
+		}
+		for _, position := range termPositions {
+			if !seen[position] {
+				seen[position] = true
+				positions = append(positions, position)
+			}
+		}
+	}
+	sort.Ints(positions)
+	return positions
+}
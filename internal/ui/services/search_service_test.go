@@ -0,0 +1,183 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern       string
+		text          string
+		wantOK        bool
+		wantPositions []int
+	}{
+		{name: "empty pattern matches", pattern: "", text: "github", wantOK: true},
+		{name: "prefix", pattern: "git", text: "github", wantOK: true, wantPositions: []int{0, 1, 2}},
+		{name: "case insensitive", pattern: "GH", text: "github", wantOK: true, wantPositions: []int{0, 3}},
+		{name: "subsequence across words", pattern: "gm", text: "github-mcp", wantOK: true, wantPositions: []int{0, 7}},
+		{name: "prefers the word start", pattern: "mcp", text: "my-mcp", wantOK: true, wantPositions: []int{3, 4, 5}},
+		{name: "out of order", pattern: "bg", text: "github", wantOK: false},
+		{name: "longer than text", pattern: "githubs", text: "github", wantOK: false},
+		{name: "unicode", pattern: "éb", text: "Éclair-bot", wantOK: true, wantPositions: []int{0, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, positions, ok := FuzzyMatch(tt.pattern, tt.text)
+			if ok != tt.wantOK {
+				t.Fatalf("FuzzyMatch(%q, %q) ok = %v, want %v", tt.pattern, tt.text, ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("FuzzyMatch(%q, %q) positions = %v, want %v", tt.pattern, tt.text, positions, tt.wantPositions)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatch_Scoring(t *testing.T) {
+	score := func(pattern, text string) int {
+		s, _, ok := FuzzyMatch(pattern, text)
+		if !ok {
+			t.Fatalf("FuzzyMatch(%q, %q) did not match", pattern, text)
+		}
+		return s
+	}
+
+	if prefix, middle := score("git", "github"), score("git", "legit"); prefix <= middle {
+		t.Errorf("prefix match should outscore a match inside a word: %d <= %d", prefix, middle)
+	}
+	if consecutive, scattered := score("ab", "abxx"), score("ab", "axxb"); consecutive <= scattered {
+		t.Errorf("consecutive match should outscore a gapped one: %d <= %d", consecutive, scattered)
+	}
+	if boundary, inner := score("s", "my-server"), score("s", "mysql"); boundary <= inner {
+		t.Errorf("word boundary should outscore a mid-word match: %d <= %d", boundary, inner)
+	}
+	if camel, inner := score("s", "mySql"), score("s", "mysql"); camel <= inner {
+		t.Errorf("camelCase boundary should outscore a mid-word match: %d <= %d", camel, inner)
+	}
+	if short, long := score("ab", "axb"), score("ab", "axxxxb"); short <= long {
+		t.Errorf("smaller gap should outscore a larger one: %d <= %d", short, long)
+	}
+}
+
+func TestSearchMCPs_MatchesEveryField(t *testing.T) {
+	items := []types.MCPItem{
+		{Name: "alpha", Command: "uvx", Args: []string{"weather-server"}},
+		{Name: "beta", URL: "https://api.example.com/sse"},
+		{Name: "gamma", Environment: map[string]string{"GITHUB_TOKEN": "secret"}},
+		{Name: "delta", Tags: []string{"database"}},
+		{Name: "epsilon", Description: "Reads calendar events"},
+	}
+
+	tests := []struct {
+		query     string
+		wantName  string
+		wantField string
+	}{
+		{"uvx", "alpha", "command"},
+		{"weather", "alpha", "args"},
+		{"example", "beta", "url"},
+		{"github_token", "gamma", "env"},
+		{"database", "delta", "tags"},
+		{"calendar", "epsilon", "description"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			matches := SearchMCPs(items, tt.query)
+			if len(matches) != 1 {
+				t.Fatalf("SearchMCPs(%q) returned %d matches, want 1", tt.query, len(matches))
+			}
+			if matches[0].Item.Name != tt.wantName || matches[0].Field != tt.wantField {
+				t.Errorf("SearchMCPs(%q) = %s in %s, want %s in %s",
+					tt.query, matches[0].Item.Name, matches[0].Field, tt.wantName, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestSearchMCPs_DoesNotMatchEnvValues(t *testing.T) {
+	items := []types.MCPItem{{Name: "gamma", Environment: map[string]string{"TOKEN": "hunter2"}}}
+	if matches := SearchMCPs(items, "hunter2"); len(matches) != 0 {
+		t.Errorf("SearchMCPs should only match environment keys, got %d matches", len(matches))
+	}
+}
+
+func TestSearchMCPs_RanksByScore(t *testing.T) {
+	items := []types.MCPItem{
+		{Name: "docs", Command: "npx", Args: []string{"github-docs"}},
+		{Name: "legit-tools"},
+		{Name: "github"},
+	}
+
+	matches := SearchMCPs(items, "git")
+	var names []string
+	for _, match := range matches {
+		names = append(names, match.Item.Name)
+	}
+	want := []string{"github", "legit-tools", "docs"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("SearchMCPs order = %v, want %v", names, want)
+	}
+}
+
+func TestSearchMCPs_MatchesAcrossLongGaps(t *testing.T) {
+	items := []types.MCPItem{{Name: "remote", URL: "https://" + strings.Repeat("a", 80) + ".example.com/mcp"}}
+
+	if score, _, ok := FuzzyMatch("hm", items[0].URL); !ok || score > 0 {
+		t.Fatalf("FuzzyMatch(\"hm\") = %d, %v; the test needs a match that scores zero or below", score, ok)
+	}
+	matches := SearchMCPs(items, "hm")
+	if len(matches) != 1 || matches[0].Field != "url" {
+		t.Errorf("SearchMCPs(\"hm\") = %v, want the URL match", matches)
+	}
+}
+
+func TestSearchMCPs_TiesKeepInventoryOrder(t *testing.T) {
+	items := []types.MCPItem{{Name: "b-mcp"}, {Name: "a-mcp"}, {Name: "c-mcp"}}
+
+	matches := SearchMCPs(items, "mcp")
+	for i, name := range []string{"b-mcp", "a-mcp", "c-mcp"} {
+		if matches[i].Item.Name != name {
+			t.Errorf("match %d = %s, want %s", i, matches[i].Item.Name, name)
+		}
+	}
+}
+
+func TestSearchMCPs_AllTermsMustMatch(t *testing.T) {
+	items := []types.MCPItem{
+		{Name: "github", Tags: []string{"vcs"}},
+		{Name: "gitlab"},
+	}
+
+	matches := SearchMCPs(items, "git vcs")
+	if len(matches) != 1 || matches[0].Item.Name != "github" {
+		t.Errorf("SearchMCPs(\"git vcs\") = %v, want only github", matches)
+	}
+}
+
+func TestNameMatchPositions(t *testing.T) {
+	tests := []struct {
+		query string
+		name  string
+		want  []int
+	}{
+		{"", "github", nil},
+		{"git", "github", []int{0, 1, 2}},
+		{"hub git", "github", []int{0, 1, 2, 3, 4, 5}},
+		{"npx", "github", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := NameMatchPositions(tt.query, tt.name); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NameMatchPositions(%q, %q) = %v, want %v", tt.query, tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// ServerLogDir is the directory under the platform log path holding per-server logs
+	ServerLogDir = "servers"
+
+	// MaxServerLogFileSize is the size at which a server's log file is rotated to .1
+	MaxServerLogFileSize = 1 << 20
+
+	serverLogExtension = ".jsonl"
+)
+
+var (
+	// unsafeFileNameChars matches characters not allowed in server log file names
+	unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+	stderrErrorPattern   = regexp.MustCompile(`(?i)\b(error|fatal|panic|exception)\b`)
+	stderrWarningPattern = regexp.MustCompile(`(?i)\bwarn(ing)?\b`)
+	stderrDebugPattern   = regexp.MustCompile(`(?i)\b(debug|trace)\b`)
+
+	// logLevelLabels are the fixed-width level labels of the log pane
+	logLevelLabels = map[types.LogLevel]string{
+		types.LogDebug:     "DEBUG",
+		types.LogInfo:      "INFO",
+		types.LogNotice:    "NOTE",
+		types.LogWarning:   "WARN",
+		types.LogError:     "ERROR",
+		types.LogCritical:  "CRIT",
+		types.LogAlert:     "ALERT",
+		types.LogEmergency: "EMERG",
+	}
+
+	// serverLogFileMu serializes appends and rotation of server log files
+	serverLogFileMu sync.Mutex
+)
+
+// NewPersistentServerLogStore creates a log store that appends every entry to the
+// server's log file and starts with the tail of previously persisted entries
+func NewPersistentServerLogStore(platformService platform.PlatformService) *types.ServerLogStore {
+	store := types.NewServerLogStore(types.ServerLogCapacity)
+	LoadServerLogs(platformService, store)
+	store.OnAppend = func(entry types.ServerLogEntry) {
+		// Persistence is best-effort; the in-memory buffer still has the entry
+		_ = PersistServerLogEntry(platformService, entry)
+	}
+	return store
+}
+
+// GetServerLogPath returns the log file of one server
+func GetServerLogPath(platformService platform.PlatformService, mcpName string) string {
+	fileName := unsafeFileNameChars.ReplaceAllString(mcpName, "_") + serverLogExtension
+	return filepath.Join(platformService.GetLogPath(), ServerLogDir, fileName)
+}
+
+// PersistServerLogEntry appends an entry to its server's log file as one JSON line
+func PersistServerLogEntry(platformService platform.PlatformService, entry types.ServerLogEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+
+	logPath := GetServerLogPath(platformService, entry.MCPName)
+
+	serverLogFileMu.Lock()
+	defer serverLogFileMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(logPath), platformService.GetDefaultDirectoryPermissions()); err != nil {
+		return fmt.Errorf("failed to create server log directory: %w", err)
+	}
+	if info, err := os.Stat(logPath); err == nil && info.Size()+int64(len(data)) >= MaxServerLogFileSize {
+		if err := os.Rename(logPath, logPath+".1"); err != nil {
+			return fmt.Errorf("failed to rotate server log: %w", err)
+		}
+	}
+
+	//nolint:gosec // G304: The path is built from the platform log directory and a sanitized name
+	file, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, platformService.GetDefaultFilePermissions())
+	if err != nil {
+		return fmt.Errorf("failed to open server log: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write server log: %w", err)
+	}
+	return file.Close()
+}
+
+// LoadServerLogs fills the store with the most recent persisted entries of every server
+func LoadServerLogs(platformService platform.PlatformService, store *types.ServerLogStore) {
+	paths, err := filepath.Glob(filepath.Join(platformService.GetLogPath(), ServerLogDir, "*"+serverLogExtension))
+	if err != nil {
+		return
+	}
+
+	for _, path := range paths {
+		data, err := readSecureFile(path)
+		if err != nil {
+			continue
+		}
+		lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+		if len(lines) > types.ServerLogCapacity {
+			lines = lines[len(lines)-types.ServerLogCapacity:]
+		}
+		for _, line := range lines {
+			var entry types.ServerLogEntry
+			if err := json.Unmarshal(line, &entry); err == nil && entry.MCPName != "" {
+				store.Append(entry)
+			}
+		}
+	}
+}
+
+// StderrLogWriter splits a server's stderr into lines and records each as a log entry
+type StderrLogWriter struct {
+	mu      sync.Mutex
+	store   *types.ServerLogStore
+	mcpName string
+	partial []byte
+}
+
+// NewStderrLogWriter returns a writer that records complete stderr lines in the store.
+// Flush records a trailing line that has no newline.
+func NewStderrLogWriter(store *types.ServerLogStore, mcpName string) *StderrLogWriter {
+	return &StderrLogWriter{store: store, mcpName: mcpName}
+}
+
+// Write records every complete line and buffers the rest
+func (w *StderrLogWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, data...)
+	for {
+		index := bytes.IndexByte(w.partial, '\n')
+		if index < 0 {
+			break
+		}
+		w.record(string(w.partial[:index]))
+		w.partial = w.partial[index+1:]
+	}
+	return len(data), nil
+}
+
+// Flush records any buffered partial line
+func (w *StderrLogWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.partial) > 0 {
+		w.record(string(w.partial))
+		w.partial = nil
+	}
+}
+
+func (w *StderrLogWriter) record(line string) {
+	line = strings.TrimRight(line, "\r")
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	w.store.Append(types.ServerLogEntry{
+		MCPName: w.mcpName,
+		Time:    time.Now(),
+		Level:   InferStderrLevel(line),
+		Source:  types.LogSourceStderr,
+		Message: line,
+	})
+}
+
+// InferStderrLevel guesses the severity of a stderr line from common level markers
+func InferStderrLevel(line string) types.LogLevel {
+	switch {
+	case stderrErrorPattern.MatchString(line):
+		return types.LogError
+	case stderrWarningPattern.MatchString(line):
+		return types.LogWarning
+	case stderrDebugPattern.MatchString(line):
+		return types.LogDebug
+	default:
+		return types.LogInfo
+	}
+}
+
+// LogNotificationHandler returns a handler recording the server's notifications/message entries
+func LogNotificationHandler(store *types.ServerLogStore, mcpName string) mcp.NotificationHandler {
+	return func(notification mcp.Notification) {
+		if notification.Method != mcp.MethodLogMessage {
+			return
+		}
+		var params mcp.LoggingMessageParams
+		if err := json.Unmarshal(notification.Params, &params); err != nil {
+			return
+		}
+		store.Append(types.ServerLogEntry{
+			MCPName: mcpName,
+			Time:    time.Now(),
+			Level:   types.ParseLogLevel(params.Level),
+			Source:  types.LogSourceNotification,
+			Logger:  params.Logger,
+			Message: params.Text(),
+		})
+	}
+}
+
+// FilterServerLogs returns the entries at or above the given level
+func FilterServerLogs(entries []types.ServerLogEntry, minLevel types.LogLevel) []types.ServerLogEntry {
+	var filtered []types.ServerLogEntry
+	for _, entry := range entries {
+		if entry.Level >= minLevel {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// NextLogLevelFilter cycles the log pane filter through debug, info, warning and error
+func NextLogLevelFilter(level types.LogLevel) types.LogLevel {
+	switch {
+	case level < types.LogInfo:
+		return types.LogInfo
+	case level < types.LogWarning:
+		return types.LogWarning
+	case level < types.LogError:
+		return types.LogError
+	default:
+		return types.LogDebug
+	}
+}
+
+// FormatServerLogEntry formats an entry as one line, e.g. "14:02:11 WARN  [stderr] disk low"
+func FormatServerLogEntry(entry types.ServerLogEntry) string {
+	level := logLevelLabels[entry.Level]
+	if level == "" {
+		level = strings.ToUpper(entry.Level.String())
+	}
+	source := entry.Source
+	if entry.Logger != "" {
+		source += ":" + entry.Logger
+	}
+	return fmt.Sprintf("%s %-5s [%s] %s", entry.Time.Format("15:04:05"), level, source, entry.Message)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/mcp"
+	"mcp-hub/internal/ui/types"
+)
+
+func TestStderrLogWriter(t *testing.T) {
+	store := types.NewServerLogStore(types.ServerLogCapacity)
+	writer := NewStderrLogWriter(store, "github")
+
+	_, _ = writer.Write([]byte("listening on stdio\nERROR: token "))
+	_, _ = writer.Write([]byte("expired\r\n\n  \nwarning: rate limited"))
+
+	entries := store.Entries("github")
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 complete lines, got %+v", entries)
+	}
+	if entries[1].Message != "ERROR: token expired" || entries[1].Level != types.LogError || entries[1].Source != types.LogSourceStderr {
+		t.Errorf("Unexpected entry %+v", entries[1])
+	}
+
+	writer.Flush()
+	entries = store.Entries("github")
+	if len(entries) != 3 || entries[2].Level != types.LogWarning {
+		t.Errorf("Flush should record the trailing partial line, got %+v", entries)
+	}
+}
+
+func TestInferStderrLevel(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected types.LogLevel
+	}{
+		{"[ERROR] failed to connect", types.LogError},
+		{"panic: runtime error", types.LogError},
+		{"WARN deprecated option", types.LogWarning},
+		{"DEBUG request payload", types.LogDebug},
+		{"Server running on stdio", types.LogInfo},
+		{"errors.go loaded", types.LogInfo},
+	}
+
+	for _, tt := range tests {
+		if level := InferStderrLevel(tt.line); level != tt.expected {
+			t.Errorf("InferStderrLevel(%q) = %v, expected %v", tt.line, level, tt.expected)
+		}
+	}
+}
+
+func TestLogNotificationHandler(t *testing.T) {
+	store := types.NewServerLogStore(types.ServerLogCapacity)
+	handler := LogNotificationHandler(store, "db")
+
+	handler(mcp.Notification{Method: "notifications/progress", Params: json.RawMessage(`{}`)})
+	handler(mcp.Notification{Method: mcp.MethodLogMessage, Params: json.RawMessage(`{"level":"critical","logger":"pool","data":{"open":0}}`)})
+
+	entries := store.Entries("db")
+	if len(entries) != 1 {
+		t.Fatalf("Only notifications/message should be recorded, got %+v", entries)
+	}
+	entry := entries[0]
+	if entry.Level != types.LogCritical || entry.Logger != "pool" || entry.Message != `{"open":0}` || entry.Source != types.LogSourceNotification {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+}
+
+func TestFilterServerLogs(t *testing.T) {
+	entries := []types.ServerLogEntry{
+		{Level: types.LogDebug, Message: "a"},
+		{Level: types.LogInfo, Message: "b"},
+		{Level: types.LogWarning, Message: "c"},
+		{Level: types.LogError, Message: "d"},
+	}
+
+	if filtered := FilterServerLogs(entries, types.LogDebug); len(filtered) != 4 {
+		t.Errorf("Debug filter should keep everything, got %d", len(filtered))
+	}
+	if filtered := FilterServerLogs(entries, types.LogWarning); len(filtered) != 2 || filtered[0].Message != "c" {
+		t.Errorf("Warning filter should keep warnings and errors, got %+v", filtered)
+	}
+
+	level := types.LogDebug
+	var cycle []types.LogLevel
+	for i := 0; i < 4; i++ {
+		level = NextLogLevelFilter(level)
+		cycle = append(cycle, level)
+	}
+	expected := []types.LogLevel{types.LogInfo, types.LogWarning, types.LogError, types.LogDebug}
+	for i := range expected {
+		if cycle[i] != expected[i] {
+			t.Errorf("Filter cycle = %v, expected %v", cycle, expected)
+			break
+		}
+	}
+}
+
+func TestFormatServerLogEntry(t *testing.T) {
+	entry := types.ServerLogEntry{
+		Time:    time.Date(2026, 3, 1, 14, 2, 11, 0, time.UTC),
+		Level:   types.LogWarning,
+		Source:  types.LogSourceNotification,
+		Logger:  "db",
+		Message: "slow query",
+	}
+	if line := FormatServerLogEntry(entry); line != "14:02:11 WARN  [mcp:db] slow query" {
+		t.Errorf("FormatServerLogEntry() = %q", line)
+	}
+}
+
+func TestPersistentServerLogStore(t *testing.T) {
+	mockPlatform := newTempCachePlatform(t)
+
+	store := NewPersistentServerLogStore(mockPlatform)
+	store.Append(types.ServerLogEntry{MCPName: "my server/1", Level: types.LogError, Source: types.LogSourceStderr, Message: "boom"})
+
+	logPath := GetServerLogPath(mockPlatform, "my server/1")
+	if filepath.Base(logPath) != "my_server_1.jsonl" {
+		t.Errorf("Log file name should be sanitized, got %s", logPath)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil || !strings.Contains(string(data), `"level":"error"`) {
+		t.Fatalf("Entry should be persisted with a readable level, got %s (%v)", data, err)
+	}
+
+	reloaded := NewPersistentServerLogStore(mockPlatform)
+	entries := reloaded.Entries("my server/1")
+	if len(entries) != 1 || entries[0].Message != "boom" || entries[0].Level != types.LogError {
+		t.Errorf("Persisted entries should be reloaded, got %+v", entries)
+	}
+}
+
+func TestPersistServerLogEntry_Rotates(t *testing.T) {
+	mockPlatform := newTempCachePlatform(t)
+	logPath := GetServerLogPath(mockPlatform, "big")
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(logPath, make([]byte, MaxServerLogFileSize), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PersistServerLogEntry(mockPlatform, types.ServerLogEntry{MCPName: "big", Message: "fresh"}); err != nil {
+		t.Fatalf("PersistServerLogEntry failed: %v", err)
+	}
+
+	if _, err := os.Stat(logPath + ".1"); err != nil {
+		t.Errorf("Full log should be rotated: %v", err)
+	}
+	if info, err := os.Stat(logPath); err != nil || info.Size() >= MaxServerLogFileSize {
+		t.Errorf("New log should only hold the fresh entry")
+	}
+}
+
+func TestProbeMCP_CapturesServerLogs(t *testing.T) {
+	store := types.NewServerLogStore(types.ServerLogCapacity)
+	result := ProbeMCP(t.Context(), fakeServerItem("noisy"), store)
+	if result.Status != types.HealthHealthy {
+		t.Fatalf("Expected healthy probe, got %+v", result)
+	}
+
+	var messages []string
+	for _, entry := range store.Entries("fake") {
+		messages = append(messages, entry.Source+":"+entry.Message)
+	}
+	joined := strings.Join(messages, "\n")
+	for _, expected := range []string{"mcp:connection lost", "stderr:starting fake server", "stderr:WARN no config file found"} {
+		if !strings.Contains(joined, expected) {
+			t.Errorf("Expected %q in captured logs:\n%s", expected, joined)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// settingsFileName stores the user settings next to the inventory
+const settingsFileName = "settings.json"
+
+// preservedSettingKeys are settings.json entries read by other services, which the
+// settings editor keeps when it saves
+var preservedSettingKeys = []string{keysSettingKey, themeSettingKey}
+
+// SettingField describes one entry of settings.json and its allowed range
+type SettingField struct {
+	Key   string
+	Label string
+	Min   time.Duration
+	Max   time.Duration
+	value func(*types.Settings) *time.Duration
+}
+
+// SettingFields lists the settings in the order they are shown in the editor
+var SettingFields = []SettingField{
+	{"claude_timeout", "Claude CLI command timeout", time.Second, 5 * time.Minute,
+		func(s *types.Settings) *time.Duration { return &s.ClaudeTimeout }},
+	{"toggle_retry_window", "Retry toggles that time out within", time.Second, 10 * time.Minute,
+		func(s *types.Settings) *time.Duration { return &s.ToggleRetryWindow }},
+	{"toggle_retry_cutoff", "Do not start a retry after", time.Second, 10 * time.Minute,
+		func(s *types.Settings) *time.Duration { return &s.ToggleRetryCutoff }},
+	{"toggle_retry_budget", "Total time for a toggle and its retry", time.Second, 10 * time.Minute,
+		func(s *types.Settings) *time.Duration { return &s.ToggleRetryBudget }},
+	{"project_check_interval", "Project context check interval", time.Second, time.Hour,
+		func(s *types.Settings) *time.Duration { return &s.ProjectCheckInterval }},
+	{"claude_detection_delay", "Delay before detecting Claude CLI", 10 * time.Millisecond, 10 * time.Second,
+		func(s *types.Settings) *time.Duration { return &s.ClaudeDetectionDelay }},
+	{"success_message_duration", "Status message duration", 500 * time.Millisecond, time.Minute,
+		func(s *types.Settings) *time.Duration { return &s.SuccessMessageDuration }},
+}
+
+// Get returns the field's value in settings
+func (f SettingField) Get(settings types.Settings) time.Duration {
+	return *f.value(&settings)
+}
+
+// Set changes the field's value in settings
+func (f SettingField) Set(settings *types.Settings, value time.Duration) {
+	*f.value(settings) = value
+}
+
+// findSettingField returns the field stored under key
+func findSettingField(key string) (SettingField, bool) {
+	for _, field := range SettingFields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return SettingField{}, false
+}
+
+// GetSettingsPath returns the path of the settings file
+func GetSettingsPath(platformService platform.PlatformService) string {
+	return filepath.Join(platformService.GetConfigPath(), settingsFileName)
+}
+
+// LoadSettings loads the user settings. A missing file means the defaults; an invalid
+// file returns the defaults together with the error.
+func LoadSettings(platformService platform.PlatformService) (types.Settings, error) {
+	data, err := readSecureFile(GetSettingsPath(platformService))
+	if errors.Is(err, os.ErrNotExist) {
+		return types.DefaultSettings(), nil
+	}
+	if err != nil {
+		return ignoreSettings(platformService, fmt.Errorf("failed to read settings: %w", err))
+	}
+	settings, err := ParseSettings(data)
+	if err != nil {
+		return ignoreSettings(platformService, err)
+	}
+	return settings, nil
+}
+
+// ignoreSettings logs why the settings file is not used and returns the defaults with err
+func ignoreSettings(platformService platform.PlatformService, err error) (types.Settings, error) {
+	logging.For("settings").Warn("invalid settings, using the defaults", "path", GetSettingsPath(platformService), "error", err)
+	return types.DefaultSettings(), err
+}
+
+// ParseSettings decodes and validates a settings file; values it leaves out keep their
+// defaults. The key bindings and the theme are read by LoadKeyMap and LoadTheme.
+func ParseSettings(data []byte) (types.Settings, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return types.Settings{}, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := types.DefaultSettings()
+	for _, key := range keys {
+		if isPreservedSetting(key) {
+			continue
+		}
+		field, ok := findSettingField(key)
+		if !ok {
+			return types.Settings{}, fmt.Errorf("unknown setting %q", key)
+		}
+		var text string
+		if err := json.Unmarshal(values[key], &text); err != nil {
+			return types.Settings{}, fmt.Errorf("%s: expected a duration such as \"10s\" or \"500ms\"", key)
+		}
+		value, err := ParseSettingValue(field, text)
+		if err != nil {
+			return types.Settings{}, fmt.Errorf("%s: %w", key, err)
+		}
+		field.Set(&settings, value)
+	}
+	if err := ValidateSettings(settings); err != nil {
+		return types.Settings{}, err
+	}
+	return settings, nil
+}
+
+// ParseSettingValue parses a duration entered for field and checks its range
+func ParseSettingValue(field SettingField, text string) (time.Duration, error) {
+	value, err := time.ParseDuration(strings.TrimSpace(text))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (use a value such as 10s or 500ms)", text)
+	}
+	if value < field.Min || value > field.Max {
+		return 0, fmt.Errorf("must be between %s and %s", field.Min, field.Max)
+	}
+	return value, nil
+}
+
+// ValidateSettings checks every value's range and that the toggle retry limits are ordered
+func ValidateSettings(settings types.Settings) error {
+	for _, field := range SettingFields {
+		if value := field.Get(settings); value < field.Min || value > field.Max {
+			return fmt.Errorf("%s must be between %s and %s", field.Key, field.Min, field.Max)
+		}
+	}
+	if settings.ToggleRetryWindow > settings.ToggleRetryCutoff {
+		return fmt.Errorf("toggle_retry_window must not exceed toggle_retry_cutoff")
+	}
+	if settings.ToggleRetryCutoff >= settings.ToggleRetryBudget {
+		return fmt.Errorf("toggle_retry_cutoff must be less than toggle_retry_budget")
+	}
+	return nil
+}
+
+// SaveSettings validates and writes the settings file atomically, keeping the key
+// bindings and theme already in it
+func SaveSettings(platformService platform.PlatformService, settings types.Settings) error {
+	if err := ValidateSettings(settings); err != nil {
+		return err
+	}
+	values := make(map[string]interface{}, len(SettingFields)+1)
+	for _, field := range SettingFields {
+		values[field.Key] = field.Get(settings).String()
+	}
+	for key, value := range readPreservedSettings(platformService) {
+		values[key] = value
+	}
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	if err := os.MkdirAll(platformService.GetConfigPath(), platformService.GetDefaultDirectoryPermissions()); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	settingsPath := GetSettingsPath(platformService)
+	tempPath := settingsPath + ".tmp"
+	if err := os.WriteFile(tempPath, data, platformService.GetDefaultFilePermissions()); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	if err := os.Rename(tempPath, settingsPath); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename settings file: %w", err)
+	}
+	return nil
+}
+
+// isPreservedSetting reports whether key is read by another service
+func isPreservedSetting(key string) bool {
+	for _, preserved := range preservedSettingKeys {
+		if key == preserved {
+			return true
+		}
+	}
+	return false
+}
+
+// readPreservedSettings returns the preserved entries of the current settings file
+func readPreservedSettings(platformService platform.PlatformService) map[string]json.RawMessage {
+	data, err := readSecureFile(GetSettingsPath(platformService))
+	if err != nil {
+		return nil
+	}
+	var values map[string]json.RawMessage
+	if json.Unmarshal(data, &values) != nil {
+		return nil
+	}
+	preserved := make(map[string]json.RawMessage)
+	for key, value := range values {
+		if isPreservedSetting(key) {
+			preserved[key] = value
+		}
+	}
+	return preserved
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+func newSettingsTestPlatform(t *testing.T) *platform.MockPlatformService {
+	t.Helper()
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir, tempDir+"/mcp-hub", tempDir, tempDir)
+	return mockPlatform
+}
+
+func TestLoadSettings_MissingFileUsesDefaults(t *testing.T) {
+	settings, err := LoadSettings(newSettingsTestPlatform(t))
+	if err != nil || settings != types.DefaultSettings() {
+		t.Errorf("Expected defaults, got %+v, %v", settings, err)
+	}
+}
+
+func TestSaveAndLoadSettings(t *testing.T) {
+	mockPlatform := newSettingsTestPlatform(t)
+	settings := types.DefaultSettings()
+	settings.ClaudeTimeout = 30 * time.Second
+	settings.ProjectCheckInterval = time.Minute
+
+	if err := SaveSettings(mockPlatform, settings); err != nil {
+		t.Fatalf("SaveSettings failed: %v", err)
+	}
+	data, err := os.ReadFile(GetSettingsPath(mockPlatform))
+	if err != nil || !strings.Contains(string(data), `"claude_timeout": "30s"`) || !strings.Contains(string(data), `"project_check_interval": "1m0s"`) {
+		t.Errorf("Settings should be saved as duration strings, got %s, %v", data, err)
+	}
+
+	loaded, err := LoadSettings(mockPlatform)
+	if err != nil || loaded != settings {
+		t.Errorf("Loaded %+v, %v; expected %+v", loaded, err, settings)
+	}
+
+	settings.ToggleRetryCutoff = settings.ToggleRetryBudget
+	if err := SaveSettings(mockPlatform, settings); err == nil {
+		t.Error("Invalid settings should not be saved")
+	}
+}
+
+func TestParseSettings(t *testing.T) {
+	settings, err := ParseSettings([]byte(`{"claude_detection_delay": "1s"}`))
+	expected := types.DefaultSettings()
+	expected.ClaudeDetectionDelay = time.Second
+	if err != nil || settings != expected {
+		t.Errorf("Values left out should keep their defaults, got %+v, %v", settings, err)
+	}
+
+	invalid := map[string]string{
+		"failed to parse settings":    `{`,
+		"unknown setting \"colour\"":  `{"colour": "blue"}`,
+		"expected a duration":         `{"claude_timeout": 10}`,
+		"invalid duration \"soon\"":   `{"claude_timeout": "soon"}`,
+		"must be between 1s and 5m0s": `{"claude_timeout": "1h"}`,
+		"must not exceed":             `{"toggle_retry_window": "19s"}`,
+	}
+	for expected, data := range invalid {
+		if _, err := ParseSettings([]byte(data)); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestLoadSettings_InvalidFileReturnsDefaults(t *testing.T) {
+	mockPlatform := newSettingsTestPlatform(t)
+	if err := os.MkdirAll(mockPlatform.GetConfigPath(), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetSettingsPath(mockPlatform), []byte(`{"claude_timeout": "0s"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadSettings(mockPlatform)
+	if err == nil || settings != types.DefaultSettings() {
+		t.Errorf("An invalid file should report an error and use the defaults, got %+v, %v", settings, err)
+	}
+	if service := NewClaudeService(mockPlatform); service.timeout != types.DefaultClaudeTimeout {
+		t.Errorf("ClaudeService should fall back to the default timeout, got %v", service.timeout)
+	}
+}
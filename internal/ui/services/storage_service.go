@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"mcp-hub/internal/logging"
 	"mcp-hub/internal/platform"
 	"mcp-hub/internal/ui/types"
 )
@@ -25,6 +26,9 @@ const (
 	configVersion  = "1.0"
 )
 
+// storageLog records inventory reads and writes
+var storageLog = logging.For("storage")
+
 // allowedFilePaths defines patterns for files that are allowed to be read
 var allowedFilePatterns = []string{
 	"inventory.json",
@@ -125,7 +129,7 @@ func saveInventoryWithBase(mcpItems []types.MCPItem, baseDir string, platformSer
 		return fmt.Errorf("failed to rename temporary config file: %w", err)
 	}
 
-	// log.Printf("Inventory saved successfully to: %s", configPath)
+	storageLog.Debug("inventory saved", "path", configPath, "items", len(mcpItems))
 	return nil
 }
 
@@ -220,8 +224,9 @@ func readSpecificConfigFile(baseDir string, platformService platform.PlatformSer
 		if configDir == "" {
 			return nil, fmt.Errorf("failed to get config directory")
 		}
-		// Extract the parent directory
-		configDir = filepath.Dir(configDir)
+		// The config directory may be a workspace or an MCP_HUB_CONFIG_DIR override,
+		// so read the inventory inside it rather than assuming it is named after the app
+		return secureReadFile(filepath.Join(configDir, configFileName), baseDir, platformService)
 	}
 
 	// Build path with string literals to avoid G304
@@ -296,7 +301,7 @@ func readInventoryFile(filePath string, baseDir string, platformService platform
 	}
 
 	// Read the literal expected config file
-	return readLiteralInventoryFile(filepath.Dir(configDir))
+	return readSecureFile(expectedPath)
 }
 
 // readLiteralInventoryFile reads the inventory file using literal path construction
@@ -385,6 +390,7 @@ func loadInventoryWithBase(baseDir string, platformService platform.PlatformServ
 	// Read config file with security considerations
 	jsonData, err := safeReadFile(configPath, baseDir, platformService)
 	if err != nil {
+		storageLog.Warn("inventory unreadable, starting empty", "path", configPath, "error", err)
 		return []types.MCPItem{}, nil
 	}
 
@@ -394,22 +400,18 @@ func loadInventoryWithBase(baseDir string, platformService platform.PlatformServ
 	if err != nil {
 		// Handle corrupted file - backup and start fresh
 		backupPath := configPath + ".corrupted." + time.Now().Format("20060102-150405")
+		// Backup failure shouldn't prevent the app from working
 		if backupErr := os.Rename(configPath, backupPath); backupErr != nil {
-			// log.Printf("Failed to backup corrupted config file: %v", backupErr)
-			// Intentionally empty - backup failure shouldn't prevent app from working
-			_ = backupErr // Acknowledge error but continue
+			storageLog.Error("failed to back up corrupted inventory", "path", configPath, "error", backupErr)
 		} else {
-			// log.Printf("Corrupted config file backed up to: %s", backupPath)
-			// Intentionally empty - successful backup doesn't require additional action
-			_ = backupPath // Acknowledge success
+			storageLog.Warn("corrupted inventory backed up", "path", configPath, "backup", backupPath)
 		}
 
-		// log.Printf("Failed to parse config file %s: %v, falling back to empty inventory", configPath, err)
+		storageLog.Error("failed to parse inventory, starting empty", "path", configPath, "error", err)
 		return []types.MCPItem{}, nil
 	}
 
-	// log.Printf("Inventory loaded successfully from: %s (version: %s, %d items)",
-	//	configPath, inventoryData.Version, len(inventoryData.Inventory))
+	storageLog.Debug("inventory loaded", "path", configPath, "version", inventoryData.Version, "items", len(inventoryData.Inventory))
 
 	return inventoryData.Inventory, nil
 }
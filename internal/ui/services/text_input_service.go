@@ -0,0 +1,180 @@
+package services
+
+import "unicode"
+
+// Text editing keys of the form fields
+const (
+	keyLeft          = "left"
+	keyRight         = "right"
+	keyUp            = "up"
+	keyDown          = "down"
+	keyHome          = "home"
+	keyEnd           = "end"
+	keyEnter         = "enter"
+	keyBackspace     = "backspace"
+	keyDelete        = "delete"
+	keyWordLeft      = "alt+left"
+	keyWordRight     = "alt+right"
+	keyCtrlWordLeft  = "ctrl+left"
+	keyCtrlWordRight = "ctrl+right"
+	keyAltWordLeft   = "alt+b"
+	keyAltWordRight  = "alt+f"
+	keyLineStart     = "ctrl+a"
+	keyLineEnd       = "ctrl+e"
+	keyDeleteForward = "ctrl+d"
+	keyDeleteWord    = "ctrl+w"
+	keyAltDeleteWord = "alt+backspace"
+)
+
+// EditText applies an editing key to text with the cursor before rune cursor, and
+// returns the new text and cursor. It moves by character, word and line, deletes
+// either side of the cursor and inserts typed characters. Multi-line fields also move
+// between lines with up and down, and break the line with enter. It reports whether key
+// is an editing key; other keys leave the text alone.
+func EditText(text string, cursor int, key string, multiline bool) (string, int, bool) {
+	runes := []rune(text)
+	cursor = max(min(cursor, len(runes)), 0)
+
+	switch key {
+	case keyLeft:
+		return text, max(cursor-1, 0), true
+	case keyRight:
+		return text, min(cursor+1, len(runes)), true
+	case keyWordLeft, keyCtrlWordLeft, keyAltWordLeft:
+		return text, wordStart(runes, cursor), true
+	case keyWordRight, keyCtrlWordRight, keyAltWordRight:
+		return text, wordEnd(runes, cursor), true
+	case keyHome, keyLineStart:
+		return text, lineStart(runes, cursor), true
+	case keyEnd, keyLineEnd:
+		return text, lineEnd(runes, cursor), true
+	case keyBackspace:
+		if cursor == 0 {
+			return text, cursor, true
+		}
+		return deleteRunes(runes, cursor-1, cursor), cursor - 1, true
+	case keyDelete, keyDeleteForward:
+		if cursor == len(runes) {
+			return text, cursor, true
+		}
+		return deleteRunes(runes, cursor, cursor+1), cursor, true
+	case keyDeleteWord, keyAltDeleteWord:
+		start := wordStart(runes, cursor)
+		return deleteRunes(runes, start, cursor), start, true
+	}
+
+	if multiline {
+		switch key {
+		case keyUp:
+			return text, moveLine(runes, cursor, -1), true
+		case keyDown:
+			return text, moveLine(runes, cursor, 1), true
+		case keyEnter:
+			text, cursor = InsertText(text, cursor, "\n")
+			return text, cursor, true
+		}
+	}
+
+	if r := []rune(key); len(r) == 1 && unicode.IsPrint(r[0]) {
+		text, cursor = InsertText(text, cursor, key)
+		return text, cursor, true
+	}
+	return text, cursor, false
+}
+
+// InsertText inserts s into text before rune cursor and returns the text with the
+// cursor after s
+func InsertText(text string, cursor int, s string) (string, int) {
+	runes := []rune(text)
+	cursor = max(min(cursor, len(runes)), 0)
+	inserted := []rune(s)
+	result := make([]rune, 0, len(runes)+len(inserted))
+	result = append(result, runes[:cursor]...)
+	result = append(result, inserted...)
+	result = append(result, runes[cursor:]...)
+	return string(result), cursor + len(inserted)
+}
+
+// CursorLineColumn returns the zero-based line and column, in runes, of the cursor
+func CursorLineColumn(text string, cursor int) (int, int) {
+	line, column := 0, 0
+	for i, r := range []rune(text) {
+		if i == cursor {
+			break
+		}
+		if r == '\n' {
+			line, column = line+1, 0
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+// deleteRunes returns runes without those from start up to end
+func deleteRunes(runes []rune, start, end int) string {
+	return string(runes[:start]) + string(runes[end:])
+}
+
+// isWordRune reports whether r is part of a word for word jumps and deletes
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// wordStart returns the start of the word before cursor, skipping what separates them
+func wordStart(runes []rune, cursor int) int {
+	for cursor > 0 && !isWordRune(runes[cursor-1]) {
+		cursor--
+	}
+	for cursor > 0 && isWordRune(runes[cursor-1]) {
+		cursor--
+	}
+	return cursor
+}
+
+// wordEnd returns the end of the word after cursor, skipping what separates them
+func wordEnd(runes []rune, cursor int) int {
+	for cursor < len(runes) && !isWordRune(runes[cursor]) {
+		cursor++
+	}
+	for cursor < len(runes) && isWordRune(runes[cursor]) {
+		cursor++
+	}
+	return cursor
+}
+
+// lineStart returns the position of the first rune of the line holding cursor
+func lineStart(runes []rune, cursor int) int {
+	for cursor > 0 && runes[cursor-1] != '\n' {
+		cursor--
+	}
+	return cursor
+}
+
+// lineEnd returns the position after the last rune of the line holding cursor
+func lineEnd(runes []rune, cursor int) int {
+	for cursor < len(runes) && runes[cursor] != '\n' {
+		cursor++
+	}
+	return cursor
+}
+
+// moveLine moves cursor to the same column of the previous (delta < 0) or next line,
+// or to the line's end when it is shorter. At the first or last line the cursor moves
+// to the start or end of the text.
+func moveLine(runes []rune, cursor, delta int) int {
+	start := lineStart(runes, cursor)
+	column := cursor - start
+	if delta < 0 {
+		if start == 0 {
+			return 0
+		}
+		previous := lineStart(runes, start-1)
+		return min(previous+column, start-1)
+	}
+	end := lineEnd(runes, cursor)
+	if end == len(runes) {
+		return end
+	}
+	return min(end+1+column, lineEnd(runes, end+1))
+}
@@ -0,0 +1,94 @@
+package services
+
+import "testing"
+
+func TestEditText_SingleLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		cursor     int
+		key        string
+		wantText   string
+		wantCursor int
+	}{
+		{"left", "abc", 3, "left", "abc", 2},
+		{"left at start", "abc", 0, "left", "abc", 0},
+		{"right at end", "abc", 3, "right", "abc", 3},
+		{"word left", "npx -y server", 13, "alt+left", "npx -y server", 7},
+		{"word left over separators", "npx -y server", 7, "ctrl+left", "npx -y server", 5},
+		{"word right", "npx -y server", 0, "alt+f", "npx -y server", 3},
+		{"home", "abc", 2, "home", "abc", 0},
+		{"end", "abc", 1, "ctrl+e", "abc", 3},
+		{"insert mid-field", "ac", 1, "b", "abc", 2},
+		{"insert unicode", "ñu", 1, "é", "ñéu", 2},
+		{"backspace", "abc", 2, "backspace", "ac", 1},
+		{"backspace at start", "abc", 0, "backspace", "abc", 0},
+		{"delete forward", "abc", 1, "delete", "ac", 1},
+		{"delete forward at end", "abc", 3, "ctrl+d", "abc", 3},
+		{"delete word", "npx -y server", 13, "ctrl+w", "npx -y ", 7},
+		{"enter is not an edit", "abc", 3, "enter", "abc", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, cursor, _ := EditText(tt.text, tt.cursor, tt.key, false)
+			if text != tt.wantText || cursor != tt.wantCursor {
+				t.Errorf("Got %q at %d, want %q at %d", text, cursor, tt.wantText, tt.wantCursor)
+			}
+		})
+	}
+
+	if _, _, ok := EditText("abc", 3, "tab", false); ok {
+		t.Error("Tab should not be an editing key")
+	}
+	if _, _, ok := EditText("abc", 3, "up", false); ok {
+		t.Error("Up should not be an editing key of a single-line field")
+	}
+}
+
+func TestEditText_Multiline(t *testing.T) {
+	text := "KEY=1\nLONGER=2\nX=3"
+	tests := []struct {
+		name       string
+		cursor     int
+		key        string
+		wantText   string
+		wantCursor int
+	}{
+		{"up keeps the column", 10, "up", text, 4},
+		{"up to a shorter line", 14, "up", text, 5},
+		{"up from the first line", 3, "up", text, 0},
+		{"down to a shorter line", 13, "down", text, 18},
+		{"down from the last line", 16, "down", text, 18},
+		{"home goes to the line start", 10, "home", text, 6},
+		{"end goes to the line end", 7, "end", text, 14},
+		{"enter breaks the line", 5, "enter", "KEY=1\n\nLONGER=2\nX=3", 6},
+		{"backspace joins lines", 6, "backspace", "KEY=1LONGER=2\nX=3", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cursor, ok := EditText(text, tt.cursor, tt.key, true)
+			if !ok || got != tt.wantText || cursor != tt.wantCursor {
+				t.Errorf("Got %q at %d, want %q at %d", got, cursor, tt.wantText, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestInsertText(t *testing.T) {
+	if text, cursor := InsertText("ad", 1, "bc"); text != "abcd" || cursor != 3 {
+		t.Errorf("Got %q at %d", text, cursor)
+	}
+	if text, cursor := InsertText("a", 5, "b"); text != "ab" || cursor != 2 {
+		t.Errorf("A cursor past the end should insert at the end, got %q at %d", text, cursor)
+	}
+}
+
+func TestCursorLineColumn(t *testing.T) {
+	for _, tt := range []struct{ cursor, line, column int }{{0, 0, 0}, {2, 0, 2}, {3, 1, 0}, {7, 2, 1}} {
+		if line, column := CursorLineColumn("ab\ncd\nef", tt.cursor); line != tt.line || column != tt.column {
+			t.Errorf("Cursor %d: got line %d column %d, want %d %d", tt.cursor, line, column, tt.line, tt.column)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// themeSettingKey is the settings.json entry naming the theme
+	themeSettingKey = "theme"
+	// themesDirName holds user themes under the config directory, shared by all workspaces
+	themesDirName = "themes"
+	// ThemeAuto picks the dark or light theme from the terminal's background
+	ThemeAuto = "auto"
+)
+
+// hexColorPattern matches "#RGB" and "#RRGGBB" colors
+var hexColorPattern = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// ThemeTerminal describes the terminal the theme is picked for
+type ThemeTerminal struct {
+	// NoColor is set when NO_COLOR is set or the terminal cannot show colors
+	NoColor bool
+	// DarkBackground reports whether the terminal's background is dark; it is only
+	// called for the auto theme, since asking the terminal takes a round trip
+	DarkBackground func() bool
+}
+
+// GetThemesDir returns the directory holding user themes
+func GetThemesDir(platformService platform.PlatformService) string {
+	return filepath.Join(ConfigBaseDir(platformService), themesDirName)
+}
+
+// ListThemes returns auto, the built-in themes and the user themes in sorted order
+func ListThemes(platformService platform.PlatformService) []string {
+	names := map[string]bool{}
+	for name := range types.BuiltinThemes() {
+		names[name] = true
+	}
+	entries, _ := os.ReadDir(GetThemesDir(platformService))
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() && workspaceNamePattern.MatchString(name) {
+			names[name] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return append([]string{ThemeAuto}, sorted...)
+}
+
+// LoadThemeSetting returns the theme named in settings.json, or auto
+func LoadThemeSetting(platformService platform.PlatformService) (string, error) {
+	raw := readPreservedSettings(platformService)[themeSettingKey]
+	if raw == nil {
+		return ThemeAuto, nil
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil || name == "" {
+		return ThemeAuto, fmt.Errorf("theme: expected a theme name such as \"light\"")
+	}
+	return name, nil
+}
+
+// LoadTheme returns the theme to draw the UI with. NO_COLOR and terminals without
+// colors always get the mono theme. An unknown or invalid theme returns the default
+// theme together with the error.
+func LoadTheme(platformService platform.PlatformService, terminal ThemeTerminal) (types.Theme, error) {
+	if terminal.NoColor {
+		return types.BuiltinThemes()[types.ThemeMono], nil
+	}
+
+	name, err := LoadThemeSetting(platformService)
+	if err == nil {
+		var theme types.Theme
+		if theme, err = FindTheme(platformService, name, terminal); err == nil {
+			return theme, nil
+		}
+	}
+	logging.For("settings").Warn("invalid theme, using the default", "error", err)
+	return types.DefaultTheme(), err
+}
+
+// checkThemeSetting reports whether the theme setting names a theme that can be loaded
+func checkThemeSetting(platformService platform.PlatformService) error {
+	name, err := LoadThemeSetting(platformService)
+	if err != nil {
+		return err
+	}
+	_, err = FindTheme(platformService, name, ThemeTerminal{})
+	return err
+}
+
+// FindTheme returns the named theme: a user theme from the themes directory, or a
+// built-in one. User themes may override built-in names.
+func FindTheme(platformService platform.PlatformService, name string, terminal ThemeTerminal) (types.Theme, error) {
+	if name == ThemeAuto {
+		name = types.ThemeLight
+		if terminal.DarkBackground == nil || terminal.DarkBackground() {
+			name = types.ThemeDark
+		}
+	}
+	if !workspaceNamePattern.MatchString(name) {
+		return types.Theme{}, fmt.Errorf("invalid theme name %q - use letters, numbers, '-' and '_'", name)
+	}
+
+	path := filepath.Join(GetThemesDir(platformService), name+".json")
+	data, err := readSecureFile(path)
+	if err == nil {
+		theme, err := ParseTheme(name, data)
+		if err != nil {
+			return types.Theme{}, fmt.Errorf("%s: %w", path, err)
+		}
+		return theme, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return types.Theme{}, fmt.Errorf("failed to read theme: %w", err)
+	}
+
+	if theme, ok := types.BuiltinThemes()[name]; ok {
+		return theme, nil
+	}
+	return types.Theme{}, fmt.Errorf("unknown theme %q - use %s or add %s", name, strings.Join(ListThemes(platformService), ", "), path)
+}
+
+// ParseTheme decodes a user theme: the colors it sets on top of the built-in theme named
+// by "extends", the dark theme by default
+func ParseTheme(name string, data []byte) (types.Theme, error) {
+	var header struct {
+		Extends string `json:"extends"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return types.Theme{}, fmt.Errorf("failed to parse theme: %w", err)
+	}
+	if header.Extends == "" {
+		header.Extends = types.ThemeDark
+	}
+	base, ok := types.BuiltinThemes()[header.Extends]
+	if !ok {
+		return types.Theme{}, fmt.Errorf("extends: unknown built-in theme %q", header.Extends)
+	}
+
+	theme := struct {
+		Extends string `json:"extends"`
+		*types.Theme
+	}{Theme: &base}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&theme); err != nil {
+		return types.Theme{}, fmt.Errorf("failed to parse theme: %w", err)
+	}
+	base.Name = name
+	if err := ValidateTheme(base); err != nil {
+		return types.Theme{}, err
+	}
+	return base, nil
+}
+
+// ValidateTheme checks that every color is a hex color or an ANSI color number
+func ValidateTheme(theme types.Theme) error {
+	value := reflect.ValueOf(theme)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Type.Kind() != reflect.String || key == "-" {
+			continue
+		}
+		if color := value.Field(i).String(); color != "" && !validColor(color) {
+			return fmt.Errorf("%s: invalid color %q - use #RRGGBB or an ANSI color number from 0 to 255", key, color)
+		}
+	}
+	return nil
+}
+
+// validColor reports whether color is a hex color or an ANSI color number
+func validColor(color string) bool {
+	if hexColorPattern.MatchString(color) {
+		return true
+	}
+	number, err := strconv.Atoi(color)
+	return err == nil && number >= 0 && number <= 255
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// writeThemeSettings writes settings.json and, if data is not empty, a user theme
+func writeThemeSettings(t *testing.T, settings string, themeName, data string) *platform.MockPlatformService {
+	t.Helper()
+	mockPlatform := newSettingsTestPlatform(t)
+	if err := os.MkdirAll(mockPlatform.GetConfigPath(), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetSettingsPath(mockPlatform), []byte(settings), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if data != "" {
+		if err := os.MkdirAll(GetThemesDir(mockPlatform), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(GetThemesDir(mockPlatform), themeName+".json"), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return mockPlatform
+}
+
+func TestLoadTheme_Auto(t *testing.T) {
+	mockPlatform := newSettingsTestPlatform(t)
+
+	theme, err := LoadTheme(mockPlatform, ThemeTerminal{DarkBackground: func() bool { return true }})
+	if err != nil || theme.Name != types.ThemeDark {
+		t.Errorf("No theme setting on a dark terminal should give the dark theme, got %q, %v", theme.Name, err)
+	}
+	theme, err = LoadTheme(mockPlatform, ThemeTerminal{DarkBackground: func() bool { return false }})
+	if err != nil || theme.Name != types.ThemeLight {
+		t.Errorf("No theme setting on a light terminal should give the light theme, got %q, %v", theme.Name, err)
+	}
+}
+
+func TestLoadTheme_NoColor(t *testing.T) {
+	mockPlatform := writeThemeSettings(t, `{"theme": "high-contrast"}`, "", "")
+	theme, err := LoadTheme(mockPlatform, ThemeTerminal{NoColor: true})
+	if err != nil || theme.Name != types.ThemeMono || !theme.Monochrome {
+		t.Errorf("NO_COLOR should always give the mono theme, got %q, %v", theme.Name, err)
+	}
+}
+
+func TestLoadTheme_Named(t *testing.T) {
+	mockPlatform := writeThemeSettings(t, `{"theme": "high-contrast", "claude_timeout": "20s"}`, "", "")
+	theme, err := LoadTheme(mockPlatform, ThemeTerminal{})
+	if err != nil || theme.Name != types.ThemeHighContrast {
+		t.Errorf("The named theme should be loaded, got %q, %v", theme.Name, err)
+	}
+	if _, err := LoadSettings(mockPlatform); err != nil {
+		t.Errorf("The theme entry should not invalidate the other settings: %v", err)
+	}
+}
+
+func TestLoadTheme_UserTheme(t *testing.T) {
+	mockPlatform := writeThemeSettings(t, `{"theme": "ocean"}`, "ocean", `{"extends": "light", "accent": "#0077B6", "error": "160"}`)
+	theme, err := LoadTheme(mockPlatform, ThemeTerminal{})
+	if err != nil {
+		t.Fatalf("LoadTheme failed: %v", err)
+	}
+	light := types.BuiltinThemes()[types.ThemeLight]
+	if theme.Name != "ocean" || theme.Accent != "#0077B6" || theme.Error != "160" {
+		t.Errorf("The user theme's colors should be used, got %+v", theme)
+	}
+	if theme.Text != light.Text || theme.Background != light.Background {
+		t.Errorf("Colors left out should come from the extended theme, got %+v", theme)
+	}
+
+	if names := ListThemes(mockPlatform); !reflect.DeepEqual(names, []string{"auto", "dark", "high-contrast", "light", "mono", "ocean"}) {
+		t.Errorf("ListThemes = %v", names)
+	}
+}
+
+func TestLoadTheme_UserThemeOverridesBuiltin(t *testing.T) {
+	mockPlatform := writeThemeSettings(t, `{"theme": "dark"}`, "dark", `{"accent": "#FF00FF"}`)
+	theme, err := LoadTheme(mockPlatform, ThemeTerminal{})
+	if err != nil || theme.Accent != "#FF00FF" {
+		t.Errorf("A user theme should override the built-in theme of the same name, got %q, %v", theme.Accent, err)
+	}
+}
+
+func TestLoadTheme_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		data     string
+		expected string
+	}{
+		{"unknown theme", `{"theme": "solarized"}`, "", `unknown theme "solarized" - use auto, dark, high-contrast, light, mono`},
+		{"not a name", `{"theme": 3}`, "", "expected a theme name"},
+		{"bad name", `{"theme": "../dark"}`, "", "invalid theme name"},
+		{"bad color", `{"theme": "custom"}`, `{"accent": "purple"}`, `accent: invalid color "purple"`},
+		{"ansi out of range", `{"theme": "custom"}`, `{"muted": "300"}`, `muted: invalid color "300"`},
+		{"unknown color", `{"theme": "custom"}`, `{"acent": "#FFFFFF"}`, `unknown field "acent"`},
+		{"unknown base", `{"theme": "custom"}`, `{"extends": "ocean"}`, `extends: unknown built-in theme "ocean"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlatform := writeThemeSettings(t, tt.settings, "custom", tt.data)
+			theme, err := LoadTheme(mockPlatform, ThemeTerminal{})
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected %q, got %v", tt.expected, err)
+			}
+			if theme != types.DefaultTheme() {
+				t.Errorf("An invalid theme should give the default theme, got %q", theme.Name)
+			}
+		})
+	}
+}
+
+func TestValidateTheme(t *testing.T) {
+	for name, theme := range types.BuiltinThemes() {
+		if err := ValidateTheme(theme); err != nil {
+			t.Errorf("Built-in theme %q is invalid: %v", name, err)
+		}
+	}
+	for color, valid := range map[string]bool{"#FFF": true, "#7c3aed": true, "0": true, "255": true, "256": false, "-1": false, "#GGGGGG": false, "red": false} {
+		if got := validColor(color); got != valid {
+			t.Errorf("validColor(%q) = %v, want %v", color, got, valid)
+		}
+	}
+}
+
+func TestSaveSettings_KeepsTheme(t *testing.T) {
+	mockPlatform := writeThemeSettings(t, `{"theme": "light", "keys": {"help": "f1"}}`, "", "")
+	if err := SaveSettings(mockPlatform, types.DefaultSettings()); err != nil {
+		t.Fatalf("SaveSettings failed: %v", err)
+	}
+	if name, err := LoadThemeSetting(mockPlatform); err != nil || name != types.ThemeLight {
+		t.Errorf("Saving the settings should keep the theme, got %q, %v", name, err)
+	}
+}
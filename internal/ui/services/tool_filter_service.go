@@ -0,0 +1,425 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+const (
+	// ClaudeToolPermissionPrefix starts the permission rule of every MCP tool in Claude
+	ClaudeToolPermissionPrefix = "mcp__"
+
+	claudeSettingsDir  = ".claude"
+	claudeSettingsFile = "settings.json"
+	// generatedPermissionsFile records the deny rules mcp-hub wrote to Claude's settings,
+	// so later writes replace only those
+	generatedPermissionsFile = "claude_permissions.json"
+)
+
+// unsafeServerNameChars matches characters Claude replaces in MCP server names of tool rules
+var unsafeServerNameChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// ParseToolPatterns splits a comma- or space-separated pattern list, dropping blanks and duplicates
+func ParseToolPatterns(text string) []string {
+	var patterns []string
+	seen := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if !seen[field] {
+			seen[field] = true
+			patterns = append(patterns, field)
+		}
+	}
+	return patterns
+}
+
+// FormatToolPatterns joins patterns for editing
+func FormatToolPatterns(patterns []string) string {
+	return strings.Join(patterns, ", ")
+}
+
+// ValidateToolPatterns checks that every pattern is a valid glob
+func ValidateToolPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// matchingToolPattern returns the first pattern matching the tool name, or ""
+func matchingToolPattern(patterns []string, tool string) string {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, tool); err == nil && matched {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// IsToolAllowed applies allow and deny patterns: with allow patterns, a tool must match one,
+// and it must match no deny pattern
+func IsToolAllowed(allow, deny []string, tool string) bool {
+	if len(allow) > 0 && matchingToolPattern(allow, tool) == "" {
+		return false
+	}
+	return matchingToolPattern(deny, tool) == ""
+}
+
+// HasToolFilter reports whether an item restricts its tools
+func HasToolFilter(item types.MCPItem) bool {
+	return len(item.ToolAllow) > 0 || len(item.ToolDeny) > 0
+}
+
+// FilterToolInfos returns the tools an item exposes after its allow and deny patterns
+func FilterToolInfos(item types.MCPItem, tools []types.ToolInfo) []types.ToolInfo {
+	if !HasToolFilter(item) {
+		return tools
+	}
+	var allowed []types.ToolInfo
+	for _, tool := range tools {
+		if IsToolAllowed(item.ToolAllow, item.ToolDeny, tool.Name) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
+
+// FilteredTokenEstimate sums the estimated context cost of the tools an item exposes
+func FilteredTokenEstimate(item types.MCPItem, result types.IntrospectionResult) int {
+	if !HasToolFilter(item) {
+		return result.TokenEstimate
+	}
+	total := 0
+	for _, tool := range FilterToolInfos(item, result.Tools) {
+		total += tool.TokenEstimate
+	}
+	return total
+}
+
+// ToggleToolInPatterns flips whether a tool is exposed by editing the pattern lists.
+// Exposed tools are added to deny; hidden tools are removed from deny and, when an
+// allow list is in use, added to it. A tool hidden by a deny glob cannot be toggled.
+func ToggleToolInPatterns(allow, deny []string, tool string) ([]string, []string, error) {
+	if IsToolAllowed(allow, deny, tool) {
+		return allow, append(append([]string(nil), deny...), tool), nil
+	}
+
+	deny = removePattern(deny, tool)
+	if pattern := matchingToolPattern(deny, tool); pattern != "" {
+		return nil, nil, fmt.Errorf("%s is denied by pattern %q", tool, pattern)
+	}
+	if len(allow) > 0 && matchingToolPattern(allow, tool) == "" {
+		allow = append(append([]string(nil), allow...), tool)
+	}
+	return allow, deny, nil
+}
+
+// removePattern returns patterns without exact occurrences of value
+func removePattern(patterns []string, value string) []string {
+	var kept []string
+	for _, pattern := range patterns {
+		if pattern != value {
+			kept = append(kept, pattern)
+		}
+	}
+	return kept
+}
+
+// ClaudeToolPermission returns Claude's permission rule for one MCP tool, mcp__<server>__<tool>
+func ClaudeToolPermission(serverName, tool string) string {
+	return ClaudeToolPermissionPrefix + unsafeServerNameChars.ReplaceAllString(serverName, "_") + "__" + tool
+}
+
+// DeniedToolPermissions returns the Claude permission rules for the introspected tools an item hides
+func DeniedToolPermissions(item types.MCPItem, tools []types.ToolInfo) []string {
+	var rules []string
+	for _, tool := range tools {
+		if !IsToolAllowed(item.ToolAllow, item.ToolDeny, tool.Name) {
+			rules = append(rules, ClaudeToolPermission(item.Name, tool.Name))
+		}
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// GetClaudeSettingsPath returns the user-level Claude settings file
+func GetClaudeSettingsPath(platformService platform.PlatformService) string {
+	return filepath.Join(platformService.GetHomeDirectory(), claudeSettingsDir, claudeSettingsFile)
+}
+
+// SyncClaudeToolPermissions mirrors the tools item hides into the deny list of the
+// user's Claude settings, so its filter applies without mcp-hub serve. The tools come
+// from introspection; known is false when the item has a filter but its tools have not
+// been introspected for its current configuration, and nothing is written.
+func SyncClaudeToolPermissions(platformService platform.PlatformService, item types.MCPItem, introspection map[string]types.IntrospectionResult) (rules []string, known bool, err error) {
+	var tools []types.ToolInfo
+	if HasToolFilter(item) {
+		result, ok := validIntrospection(introspection, item)
+		if !ok {
+			return nil, false, nil
+		}
+		tools = result.Tools
+	}
+	rules = DeniedToolPermissions(item, tools)
+	return rules, true, WriteClaudeToolPermissions(platformService, item.Name, rules)
+}
+
+// WriteClaudeToolPermissions replaces the deny rules mcp-hub last wrote for the server in
+// the user's Claude settings with rules. Rules written by hand are kept, as are the other
+// settings and the order of their keys. The file is not written when nothing changes.
+func WriteClaudeToolPermissions(platformService platform.PlatformService, serverName string, rules []string) error {
+	settingsPath := GetClaudeSettingsPath(platformService)
+	generated, err := loadGeneratedToolPermissions(platformService)
+	if err != nil {
+		return err
+	}
+
+	var settings []jsonMember
+	data, err := readSecureFile(settingsPath)
+	switch {
+	case err == nil:
+		if settings, err = parseJSONObject(data); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", settingsPath, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("failed to read %s: %w", settingsPath, err)
+	}
+
+	var permissions []jsonMember
+	if value, ok := jsonMemberValue(settings, "permissions"); ok {
+		if permissions, err = parseJSONObject(value); err != nil {
+			return fmt.Errorf("failed to parse permissions in %s: %w", settingsPath, err)
+		}
+	}
+	var deny []json.RawMessage
+	if value, ok := jsonMemberValue(permissions, "deny"); ok {
+		if err := json.Unmarshal(value, &deny); err != nil {
+			return fmt.Errorf("failed to parse permissions.deny in %s: %w", settingsPath, err)
+		}
+	}
+
+	previous := make(map[string]bool)
+	for _, rule := range generated[serverName] {
+		previous[rule] = true
+	}
+	kept := make([]json.RawMessage, 0, len(deny)+len(rules))
+	present := make(map[string]bool)
+	for _, raw := range deny {
+		var rule string
+		if json.Unmarshal(raw, &rule) == nil {
+			if previous[rule] {
+				continue
+			}
+			present[rule] = true
+		}
+		kept = append(kept, raw)
+	}
+	var added []string
+	for _, rule := range rules {
+		// A rule the user already wrote stays theirs
+		if present[rule] {
+			continue
+		}
+		present[rule] = true
+		encoded, err := json.Marshal(rule)
+		if err != nil {
+			return fmt.Errorf("failed to marshal Claude permission rule: %w", err)
+		}
+		kept = append(kept, encoded)
+		added = append(added, rule)
+	}
+
+	if len(kept) != len(deny) || len(added) > 0 {
+		if err := writeClaudeDenyRules(platformService, settingsPath, settings, permissions, kept); err != nil {
+			return err
+		}
+	}
+	if len(added) == 0 {
+		delete(generated, serverName)
+	} else {
+		generated[serverName] = added
+	}
+	return saveGeneratedToolPermissions(platformService, generated)
+}
+
+// writeClaudeDenyRules writes the Claude settings with deny as the permission deny list,
+// dropping the list and the permissions when they are left empty
+func writeClaudeDenyRules(platformService platform.PlatformService, settingsPath string, settings, permissions []jsonMember, deny []json.RawMessage) error {
+	if len(deny) == 0 {
+		permissions = setJSONMember(permissions, "deny", nil)
+	} else {
+		value, err := json.Marshal(deny)
+		if err != nil {
+			return fmt.Errorf("failed to marshal Claude settings: %w", err)
+		}
+		permissions = setJSONMember(permissions, "deny", value)
+	}
+	if len(permissions) == 0 {
+		settings = setJSONMember(settings, "permissions", nil)
+	} else {
+		value, err := formatJSONObject(permissions)
+		if err != nil {
+			return fmt.Errorf("failed to marshal Claude settings: %w", err)
+		}
+		settings = setJSONMember(settings, "permissions", value)
+	}
+
+	output, err := formatJSONObject(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Claude settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(settingsPath), platformService.GetDefaultDirectoryPermissions()); err != nil {
+		return fmt.Errorf("failed to create Claude settings directory: %w", err)
+	}
+	tempPath := settingsPath + ".tmp"
+	if err := os.WriteFile(tempPath, append(output, '\n'), platformService.GetDefaultFilePermissions()); err != nil {
+		return fmt.Errorf("failed to write Claude settings: %w", err)
+	}
+	if err := os.Rename(tempPath, settingsPath); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename Claude settings: %w", err)
+	}
+	return nil
+}
+
+// getGeneratedToolPermissionsPath returns the file recording the deny rules mcp-hub wrote
+// to Claude's settings for each server
+func getGeneratedToolPermissionsPath(platformService platform.PlatformService) string {
+	return filepath.Join(platformService.GetConfigPath(), generatedPermissionsFile)
+}
+
+// loadGeneratedToolPermissions loads the deny rules mcp-hub wrote for each server
+func loadGeneratedToolPermissions(platformService platform.PlatformService) (map[string][]string, error) {
+	generated := make(map[string][]string)
+	path := getGeneratedToolPermissionsPath(platformService)
+	data, err := readSecureFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return generated, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &generated); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return generated, nil
+}
+
+// saveGeneratedToolPermissions writes the deny rules mcp-hub wrote for each server atomically
+func saveGeneratedToolPermissions(platformService platform.PlatformService, generated map[string][]string) error {
+	data, err := json.MarshalIndent(generated, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal generated permissions: %w", err)
+	}
+	if err := os.MkdirAll(platformService.GetConfigPath(), platformService.GetDefaultDirectoryPermissions()); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	path := getGeneratedToolPermissionsPath(platformService)
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, platformService.GetDefaultFilePermissions()); err != nil {
+		return fmt.Errorf("failed to write generated permissions: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename generated permissions file: %w", err)
+	}
+	return nil
+}
+
+// jsonMember is a member of a JSON object, kept in the order of its file
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// parseJSONObject splits a JSON object into its members in order
+func parseJSONObject(data []byte) ([]jsonMember, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token != json.Delim('{') {
+		return nil, errors.New("expected a JSON object")
+	}
+	var members []jsonMember
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		members = append(members, jsonMember{key: token.(string), value: value})
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// formatJSONObject joins members into an object indented by two spaces
+func formatJSONObject(members []jsonMember) ([]byte, error) {
+	var compact bytes.Buffer
+	compact.WriteByte('{')
+	for i, member := range members {
+		if i > 0 {
+			compact.WriteByte(',')
+		}
+		key, err := json.Marshal(member.key)
+		if err != nil {
+			return nil, err
+		}
+		compact.Write(key)
+		compact.WriteByte(':')
+		compact.Write(member.value)
+	}
+	compact.WriteByte('}')
+
+	var output bytes.Buffer
+	if err := json.Indent(&output, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return output.Bytes(), nil
+}
+
+// jsonMemberValue returns the value of the member named key
+func jsonMemberValue(members []jsonMember, key string) (json.RawMessage, bool) {
+	for _, member := range members {
+		if member.key == key {
+			return member.value, true
+		}
+	}
+	return nil, false
+}
+
+// setJSONMember replaces the value of the member named key, adding it at the end when
+// absent. A nil value removes the member.
+func setJSONMember(members []jsonMember, key string, value json.RawMessage) []jsonMember {
+	for i, member := range members {
+		if member.key != key {
+			continue
+		}
+		if value == nil {
+			return append(members[:i:i], members[i+1:]...)
+		}
+		members[i].value = value
+		return members
+	}
+	if value == nil {
+		return members
+	}
+	return append(members, jsonMember{key: key, value: value})
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func TestParseToolPatterns(t *testing.T) {
+	tests := map[string][]string{
+		"":                       nil,
+		"read_*":                 {"read_*"},
+		"read_*, write_file":     {"read_*", "write_file"},
+		" a b,,c , a ":           {"a", "b", "c"},
+		"delete_*,delete_*,exec": {"delete_*", "exec"},
+	}
+	for text, expected := range tests {
+		if patterns := ParseToolPatterns(text); !reflect.DeepEqual(patterns, expected) {
+			t.Errorf("ParseToolPatterns(%q) = %v, expected %v", text, patterns, expected)
+		}
+	}
+
+	if text := FormatToolPatterns([]string{"read_*", "exec"}); text != "read_*, exec" {
+		t.Errorf("FormatToolPatterns = %q", text)
+	}
+}
+
+func TestValidateToolPatterns(t *testing.T) {
+	if err := ValidateToolPatterns([]string{"read_*", "get_?", "[a-c]*"}); err != nil {
+		t.Errorf("Valid globs rejected: %v", err)
+	}
+	if err := ValidateToolPatterns([]string{"ok", "[unclosed"}); err == nil || !strings.Contains(err.Error(), "[unclosed") {
+		t.Errorf("Malformed glob should be rejected by name, got %v", err)
+	}
+}
+
+func TestIsToolAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		allow    []string
+		deny     []string
+		tool     string
+		expected bool
+	}{
+		{"no filter", nil, nil, "anything", true},
+		{"denied exactly", nil, []string{"exec"}, "exec", false},
+		{"denied by glob", nil, []string{"delete_*"}, "delete_repo", false},
+		{"not in allow list", []string{"read_*"}, nil, "write_file", false},
+		{"in allow list", []string{"read_*"}, nil, "read_file", true},
+		{"deny wins over allow", []string{"read_*"}, []string{"read_secrets"}, "read_secrets", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allowed := IsToolAllowed(tt.allow, tt.deny, tt.tool); allowed != tt.expected {
+				t.Errorf("IsToolAllowed(%v, %v, %q) = %v, expected %v", tt.allow, tt.deny, tt.tool, allowed, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterToolInfosAndTokens(t *testing.T) {
+	result := types.IntrospectionResult{
+		Tools: []types.ToolInfo{
+			{Name: "read_file", TokenEstimate: 100},
+			{Name: "write_file", TokenEstimate: 200},
+			{Name: "delete_file", TokenEstimate: 400},
+		},
+		TokenEstimate: 700,
+	}
+
+	item := types.MCPItem{Name: "fs"}
+	if tools := FilterToolInfos(item, result.Tools); len(tools) != 3 || FilteredTokenEstimate(item, result) != 700 {
+		t.Errorf("Unfiltered item should expose everything, got %v", tools)
+	}
+
+	item.ToolDeny = []string{"delete_*"}
+	tools := FilterToolInfos(item, result.Tools)
+	if len(tools) != 2 || tools[1].Name != "write_file" {
+		t.Errorf("Denied tool should be dropped, got %v", tools)
+	}
+	if tokens := FilteredTokenEstimate(item, result); tokens != 300 {
+		t.Errorf("FilteredTokenEstimate = %d, expected 300", tokens)
+	}
+}
+
+func TestToggleToolInPatterns(t *testing.T) {
+	allow, deny, err := ToggleToolInPatterns(nil, nil, "exec")
+	if err != nil || allow != nil || !reflect.DeepEqual(deny, []string{"exec"}) {
+		t.Errorf("Toggling an exposed tool should deny it, got %v %v %v", allow, deny, err)
+	}
+
+	allow, deny, err = ToggleToolInPatterns(nil, []string{"exec", "other"}, "exec")
+	if err != nil || allow != nil || !reflect.DeepEqual(deny, []string{"other"}) {
+		t.Errorf("Toggling a denied tool should remove its entry, got %v %v %v", allow, deny, err)
+	}
+
+	allow, deny, err = ToggleToolInPatterns([]string{"read_*"}, nil, "write_file")
+	if err != nil || !reflect.DeepEqual(allow, []string{"read_*", "write_file"}) || deny != nil {
+		t.Errorf("Toggling a tool outside the allow list should add it, got %v %v %v", allow, deny, err)
+	}
+
+	if _, _, err := ToggleToolInPatterns(nil, []string{"delete_*"}, "delete_repo"); err == nil || !strings.Contains(err.Error(), "delete_*") {
+		t.Errorf("A tool hidden by a glob cannot be toggled back, got %v", err)
+	}
+}
+
+func TestDeniedToolPermissions(t *testing.T) {
+	item := types.MCPItem{Name: "my docs", ToolDeny: []string{"delete_*"}}
+	tools := []types.ToolInfo{{Name: "search"}, {Name: "delete_page"}, {Name: "delete_all"}}
+
+	expected := []string{"mcp__my_docs__delete_all", "mcp__my_docs__delete_page"}
+	if rules := DeniedToolPermissions(item, tools); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("DeniedToolPermissions = %v, expected %v", rules, expected)
+	}
+}
+
+func TestWriteClaudeToolPermissions(t *testing.T) {
+	mockPlatform := newTempCachePlatform(t)
+	mockPlatform.SetHomeDirectory(t.TempDir())
+	settingsPath := GetClaudeSettingsPath(mockPlatform)
+
+	existing := `{"permissions": {"deny": ["Read(.env)", "mcp__github__old_tool", "mcp__github__create_issue"], "allow": ["Bash(ls)"]}, "model": "opus"}`
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settingsPath, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteClaudeToolPermissions(mockPlatform, "github", []string{"mcp__github__create_issue", "mcp__github__delete_repo"}); err != nil {
+		t.Fatalf("WriteClaudeToolPermissions failed: %v", err)
+	}
+
+	var settings struct {
+		Model       string `json:"model"`
+		Permissions struct {
+			Allow []string `json:"allow"`
+			Deny  []string `json:"deny"`
+		} `json:"permissions"`
+	}
+	data, _ := os.ReadFile(settingsPath)
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("Settings should stay valid JSON: %v", err)
+	}
+	if settings.Model != "opus" || !reflect.DeepEqual(settings.Permissions.Allow, []string{"Bash(ls)"}) {
+		t.Errorf("Unrelated settings should be kept, got %s", data)
+	}
+	expected := []string{"Read(.env)", "mcp__github__old_tool", "mcp__github__create_issue", "mcp__github__delete_repo"}
+	if !reflect.DeepEqual(settings.Permissions.Deny, expected) {
+		t.Errorf("Deny = %v, expected %v", settings.Permissions.Deny, expected)
+	}
+	text := string(data)
+	if strings.Index(text, `"permissions"`) > strings.Index(text, `"model"`) || strings.Index(text, `"deny"`) > strings.Index(text, `"allow"`) {
+		t.Errorf("The settings should keep their key order, got %s", data)
+	}
+
+	// Clearing the filter removes only the rule mcp-hub added
+	if err := WriteClaudeToolPermissions(mockPlatform, "github", nil); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(settingsPath)
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{"Read(.env)", "mcp__github__old_tool", "mcp__github__create_issue"}
+	if !reflect.DeepEqual(settings.Permissions.Deny, expected) {
+		t.Errorf("Rules written by hand should be kept, got %v", settings.Permissions.Deny)
+	}
+}
+
+func TestSyncClaudeToolPermissions(t *testing.T) {
+	mockPlatform := newTempCachePlatform(t)
+	mockPlatform.SetHomeDirectory(t.TempDir())
+	item := types.MCPItem{Name: "github", Type: "CMD", Command: "github-mcp", ToolDeny: []string{"delete_*"}}
+
+	if _, known, err := SyncClaudeToolPermissions(mockPlatform, item, nil); known || err != nil {
+		t.Errorf("Without introspection the tools are not known, got %v %v", known, err)
+	}
+	if _, err := os.Stat(GetClaudeSettingsPath(mockPlatform)); !os.IsNotExist(err) {
+		t.Errorf("Nothing should be written while the tools are unknown, got %v", err)
+	}
+
+	introspection := map[string]types.IntrospectionResult{"github": {
+		MCPName: "github", ConfigHash: ComputeConfigHash(item),
+		Tools: []types.ToolInfo{{Name: "create_issue"}, {Name: "delete_repo"}},
+	}}
+	rules, known, err := SyncClaudeToolPermissions(mockPlatform, item, introspection)
+	if !known || err != nil || !reflect.DeepEqual(rules, []string{"mcp__github__delete_repo"}) {
+		t.Errorf("The hidden tools should be denied, got %v %v %v", rules, known, err)
+	}
+}
+
+func TestWriteClaudeToolPermissions_CreatesSettings(t *testing.T) {
+	mockPlatform := newTempCachePlatform(t)
+	mockPlatform.SetHomeDirectory(t.TempDir())
+
+	if err := WriteClaudeToolPermissions(mockPlatform, "fs", []string{"mcp__fs__exec"}); err != nil {
+		t.Fatalf("WriteClaudeToolPermissions failed: %v", err)
+	}
+	data, err := os.ReadFile(GetClaudeSettingsPath(mockPlatform))
+	if err != nil || !strings.Contains(string(data), `"mcp__fs__exec"`) {
+		t.Errorf("Settings file should be created, got %s, %v", data, err)
+	}
+}
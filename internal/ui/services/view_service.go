@@ -0,0 +1,377 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// viewFileName stores the grid's sort and group modes next to the inventory
+const viewFileName = "view.json"
+
+// untaggedGroup is the section of MCPs without tags when grouping by tag
+const untaggedGroup = "Untagged"
+
+// sortModeLabels describe the sort modes in the grid title and status messages
+var sortModeLabels = map[types.SortMode]string{
+	types.SortInventory:   "inventory order",
+	types.SortName:        "name",
+	types.SortType:        "type",
+	types.SortActiveFirst: "active first",
+	types.SortRecent:      "recently used",
+	types.SortHealth:      "health",
+}
+
+// groupModeLabels describe the group modes in the grid title and status messages
+var groupModeLabels = map[types.GroupMode]string{
+	types.GroupNone:   "no grouping",
+	types.GroupType:   "type",
+	types.GroupStatus: "status",
+	types.GroupTag:    "tag",
+}
+
+// typeOrder is the section order when sorting or grouping by type; other types follow
+var typeOrder = map[string]int{"CMD": 0, "SSE": 1, "HTTP": 2, "JSON": 3}
+
+// GetGridViewPath returns the path of the view state file
+func GetGridViewPath(platformService platform.PlatformService) string {
+	return filepath.Join(platformService.GetConfigPath(), viewFileName)
+}
+
+// LoadGridView loads the grid's sort and group modes. A missing file means the
+// defaults; unknown modes fall back to the defaults.
+func LoadGridView(platformService platform.PlatformService) (types.GridViewState, error) {
+	data, err := readSecureFile(GetGridViewPath(platformService))
+	if errors.Is(err, os.ErrNotExist) {
+		return types.DefaultGridViewState(), nil
+	}
+	if err != nil {
+		return types.DefaultGridViewState(), fmt.Errorf("failed to read view state: %w", err)
+	}
+
+	var state types.GridViewState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return types.DefaultGridViewState(), fmt.Errorf("failed to parse view state: %w", err)
+	}
+	if _, ok := sortModeLabels[state.Sort]; !ok {
+		state.Sort = types.SortInventory
+	}
+	if _, ok := groupModeLabels[state.Group]; !ok {
+		state.Group = types.GroupNone
+	}
+	if state.LastUsed == nil {
+		state.LastUsed = map[string]time.Time{}
+	}
+	return state, nil
+}
+
+// SaveGridView writes the view state file atomically
+func SaveGridView(platformService platform.PlatformService, state types.GridViewState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal view state: %w", err)
+	}
+	if err := os.MkdirAll(platformService.GetConfigPath(), platformService.GetDefaultDirectoryPermissions()); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	viewPath := GetGridViewPath(platformService)
+	tempPath := viewPath + ".tmp"
+	if err := os.WriteFile(tempPath, data, platformService.GetDefaultFilePermissions()); err != nil {
+		return fmt.Errorf("failed to write view state: %w", err)
+	}
+	if err := os.Rename(tempPath, viewPath); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename view state file: %w", err)
+	}
+	return nil
+}
+
+// RecordMCPUse notes that an MCP was just used, for the recently used sort, and saves
+// the view state. A failed save is logged; the time is still kept for this session.
+func RecordMCPUse(model types.Model, name string, now time.Time) types.Model {
+	lastUsed := make(map[string]time.Time, len(model.Grid.LastUsed)+1)
+	for key, value := range model.Grid.LastUsed {
+		lastUsed[key] = value
+	}
+	lastUsed[name] = now
+	model.Grid.LastUsed = lastUsed
+
+	if model.PlatformService != nil {
+		if err := SaveGridView(model.PlatformService, model.Grid); err != nil {
+			logging.For("view").Warn("failed to save view state", "error", err)
+		}
+	}
+	return model
+}
+
+// NextSortMode returns the sort mode after mode
+func NextSortMode(mode types.SortMode) types.SortMode {
+	for i, candidate := range types.SortModes {
+		if candidate == mode {
+			return types.SortModes[(i+1)%len(types.SortModes)]
+		}
+	}
+	return types.SortModes[1]
+}
+
+// NextGroupMode returns the group mode after mode
+func NextGroupMode(mode types.GroupMode) types.GroupMode {
+	for i, candidate := range types.GroupModes {
+		if candidate == mode {
+			return types.GroupModes[(i+1)%len(types.GroupModes)]
+		}
+	}
+	return types.GroupModes[1]
+}
+
+// SortModeLabel describes a sort mode, e.g. "recently used"
+func SortModeLabel(mode types.SortMode) string {
+	if label, ok := sortModeLabels[mode]; ok {
+		return label
+	}
+	return sortModeLabels[types.SortInventory]
+}
+
+// GroupModeLabel describes a group mode, e.g. "status"
+func GroupModeLabel(mode types.GroupMode) string {
+	if label, ok := groupModeLabels[mode]; ok {
+		return label
+	}
+	return groupModeLabels[types.GroupNone]
+}
+
+// SortMCPs returns items in the model's sort mode, sorting a copy. Ties keep inventory order.
+func SortMCPs(model types.Model, items []types.MCPItem) []types.MCPItem {
+	var less func(a, b types.MCPItem) bool
+	switch model.Grid.Sort {
+	case types.SortName:
+		less = func(a, b types.MCPItem) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case types.SortType:
+		less = func(a, b types.MCPItem) bool {
+			if typeRank(a.Type) != typeRank(b.Type) {
+				return typeRank(a.Type) < typeRank(b.Type)
+			}
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+	case types.SortActiveFirst:
+		less = func(a, b types.MCPItem) bool { return a.Active && !b.Active }
+	case types.SortRecent:
+		less = func(a, b types.MCPItem) bool {
+			return model.Grid.LastUsed[a.Name].After(model.Grid.LastUsed[b.Name])
+		}
+	case types.SortHealth:
+		less = func(a, b types.MCPItem) bool { return healthRank(model, a) < healthRank(model, b) }
+	default:
+		return items
+	}
+	sorted := append([]types.MCPItem(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	return sorted
+}
+
+// typeRank orders the known transport types first
+func typeRank(mcpType string) int {
+	if rank, ok := typeOrder[strings.ToUpper(mcpType)]; ok {
+		return rank
+	}
+	return len(typeOrder)
+}
+
+// healthRank orders unhealthy MCPs first and unprobed ones last
+func healthRank(model types.Model, item types.MCPItem) int {
+	health, ok := model.Health[item.Name]
+	if !ok {
+		return 4
+	}
+	switch health.Status {
+	case types.HealthUnhealthy:
+		return 0
+	case types.HealthDegraded:
+		return 1
+	case types.HealthHealthy:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// MCPGroup is a section of the MCP grid; Label is empty when the grid is not grouped
+type MCPGroup struct {
+	Label string
+	Items []types.MCPItem
+}
+
+// GroupMCPs splits items into the model's group sections, keeping their order within
+// each section
+func GroupMCPs(model types.Model, items []types.MCPItem) []MCPGroup {
+	if model.Grid.Group == types.GroupNone || model.Grid.Group == "" {
+		return []MCPGroup{{Items: items}}
+	}
+
+	indexes := make(map[string]int)
+	var groups []MCPGroup
+	for _, item := range items {
+		label := groupLabel(model.Grid.Group, item)
+		index, ok := indexes[label]
+		if !ok {
+			index = len(groups)
+			indexes[label] = index
+			groups = append(groups, MCPGroup{Label: label})
+		}
+		groups[index].Items = append(groups[index].Items, item)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groupLess(model.Grid.Group, groups[i].Label, groups[j].Label)
+	})
+	return groups
+}
+
+// groupLabel returns the section an item belongs to
+func groupLabel(mode types.GroupMode, item types.MCPItem) string {
+	switch mode {
+	case types.GroupType:
+		if item.Type == "" {
+			return "Other"
+		}
+		return strings.ToUpper(item.Type)
+	case types.GroupStatus:
+		if item.Active {
+			return "Active"
+		}
+		return "Inactive"
+	case types.GroupTag:
+		if len(item.Tags) == 0 {
+			return untaggedGroup
+		}
+		return item.Tags[0]
+	}
+	return ""
+}
+
+// groupLess orders sections: known types first, active before inactive, tags by name
+// with untagged MCPs last
+func groupLess(mode types.GroupMode, a, b string) bool {
+	switch mode {
+	case types.GroupType:
+		if typeRank(a) != typeRank(b) {
+			return typeRank(a) < typeRank(b)
+		}
+	case types.GroupStatus:
+		return a == "Active" && b != "Active"
+	case types.GroupTag:
+		if (a == untaggedGroup) != (b == untaggedGroup) {
+			return b == untaggedGroup
+		}
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// GetMCPGroups returns the sections of the MCP grid as displayed: search results by
+// relevance, otherwise the inventory in the model's sort mode
+func GetMCPGroups(model types.Model) []MCPGroup {
+	var items []types.MCPItem
+	if strings.TrimSpace(model.SearchQuery) == "" {
+		items = SortMCPs(model, model.MCPItems)
+	} else {
+		for _, match := range SearchMCPs(model.MCPItems, model.SearchQuery) {
+			items = append(items, match.Item)
+		}
+	}
+	return GroupMCPs(model, items)
+}
+
+// GridRow is a line of the MCP grid: a group header, or the items from Start up to
+// End in the displayed order
+type GridRow struct {
+	Header string
+	Start  int
+	End    int
+}
+
+// IsHeader reports whether the row is a group header
+func (r GridRow) IsHeader() bool {
+	return r.Header != ""
+}
+
+// GetGridRows lays out groups in rows of at most columns items, each group starting on
+// a new row under its header
+func GetGridRows(groups []MCPGroup, columns int) []GridRow {
+	var rows []GridRow
+	index := 0
+	for _, group := range groups {
+		if group.Label != "" {
+			rows = append(rows, GridRow{Header: fmt.Sprintf("%s (%d)", group.Label, len(group.Items))})
+		}
+		for start := 0; start < len(group.Items); start += columns {
+			end := min(start+columns, len(group.Items))
+			rows = append(rows, GridRow{Start: index + start, End: index + end})
+		}
+		index += len(group.Items)
+	}
+	return rows
+}
+
+// MoveGridSelection returns the item reached from index by moving rowDelta item rows
+// and colDelta columns, staying put at the edges. Moving to a shorter row lands on
+// its last item.
+func MoveGridSelection(rows []GridRow, index, rowDelta, colDelta int) int {
+	current := -1
+	for i, row := range rows {
+		if !row.IsHeader() && index >= row.Start && index < row.End {
+			current = i
+			break
+		}
+	}
+	if current < 0 {
+		// The selection is past the displayed items; move it onto the last one
+		for i := len(rows) - 1; i >= 0; i-- {
+			if !rows[i].IsHeader() && rows[i].End > 0 {
+				return rows[i].End - 1
+			}
+		}
+		return index
+	}
+
+	column := index - rows[current].Start
+	if colDelta != 0 {
+		if target := column + colDelta; target >= 0 && rows[current].Start+target < rows[current].End {
+			return rows[current].Start + target
+		}
+		return index
+	}
+
+	target := current
+	for moved := 0; moved != rowDelta; {
+		next := target + sign(rowDelta)
+		for next >= 0 && next < len(rows) && rows[next].IsHeader() {
+			next += sign(rowDelta)
+		}
+		if next < 0 || next >= len(rows) {
+			return rows[target].Start + min(column, rows[target].End-rows[target].Start-1)
+		}
+		target = next
+		moved += sign(rowDelta)
+	}
+	return rows[target].Start + min(column, rows[target].End-rows[target].Start-1)
+}
+
+// sign returns -1, 0 or 1
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
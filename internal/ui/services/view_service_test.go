@@ -0,0 +1,251 @@
+package services
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func viewTestItems() []types.MCPItem {
+	return []types.MCPItem{
+		{Name: "zeta", Type: "SSE", Active: false},
+		{Name: "Alpha", Type: "JSON", Active: true, Tags: []string{"web"}},
+		{Name: "mid", Type: "CMD", Active: true},
+		{Name: "beta", Type: "CMD", Active: false, Tags: []string{"db", "web"}},
+	}
+}
+
+func itemNames(items []types.MCPItem) []string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return names
+}
+
+func TestLoadGridView_DefaultsAndRoundTrip(t *testing.T) {
+	ps := newSettingsTestPlatform(t)
+
+	state, err := LoadGridView(ps)
+	if err != nil || !reflect.DeepEqual(state, types.DefaultGridViewState()) {
+		t.Fatalf("Missing file should give the defaults, got %+v, %v", state, err)
+	}
+
+	used := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	saved := types.GridViewState{Sort: types.SortRecent, Group: types.GroupTag, LastUsed: map[string]time.Time{"github": used}}
+	if err := SaveGridView(ps, saved); err != nil {
+		t.Fatalf("SaveGridView failed: %v", err)
+	}
+	state, err = LoadGridView(ps)
+	if err != nil || state.Sort != types.SortRecent || state.Group != types.GroupTag || !state.LastUsed["github"].Equal(used) {
+		t.Errorf("LoadGridView = %+v, %v, want the saved state", state, err)
+	}
+}
+
+func TestLoadGridView_UnknownModesFallBack(t *testing.T) {
+	ps := newSettingsTestPlatform(t)
+	if err := os.MkdirAll(ps.GetConfigPath(), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetGridViewPath(ps), []byte(`{"sort": "size", "group": "owner"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := LoadGridView(ps)
+	if err != nil || state.Sort != types.SortInventory || state.Group != types.GroupNone || state.LastUsed == nil {
+		t.Errorf("Unknown modes should fall back to the defaults, got %+v, %v", state, err)
+	}
+}
+
+func TestLoadGridView_InvalidFile(t *testing.T) {
+	ps := newSettingsTestPlatform(t)
+	if err := os.MkdirAll(ps.GetConfigPath(), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetGridViewPath(ps), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if state, err := LoadGridView(ps); err == nil || state.Sort != types.SortInventory {
+		t.Errorf("Invalid file should return the defaults and an error, got %+v, %v", state, err)
+	}
+}
+
+func TestNextModes_Cycle(t *testing.T) {
+	mode := types.SortInventory
+	for range types.SortModes {
+		mode = NextSortMode(mode)
+	}
+	if mode != types.SortInventory {
+		t.Errorf("Cycling through every sort mode should return to the first, got %s", mode)
+	}
+	if NextGroupMode(types.GroupTag) != types.GroupNone || NextGroupMode("") != types.GroupType {
+		t.Error("Group modes should cycle back to none, and an unset mode should go to the first grouping")
+	}
+}
+
+func TestSortMCPs(t *testing.T) {
+	model := types.Model{
+		Health: map[string]types.HealthResult{
+			"mid":  {Status: types.HealthHealthy},
+			"beta": {Status: types.HealthUnhealthy},
+		},
+	}
+	model.Grid.LastUsed = map[string]time.Time{
+		"mid":  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		"beta": time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		mode types.SortMode
+		want []string
+	}{
+		{types.SortInventory, []string{"zeta", "Alpha", "mid", "beta"}},
+		{types.SortName, []string{"Alpha", "beta", "mid", "zeta"}},
+		{types.SortType, []string{"beta", "mid", "zeta", "Alpha"}},
+		{types.SortActiveFirst, []string{"Alpha", "mid", "zeta", "beta"}},
+		{types.SortRecent, []string{"beta", "mid", "zeta", "Alpha"}},
+		{types.SortHealth, []string{"beta", "mid", "zeta", "Alpha"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			model.Grid.Sort = tt.mode
+			items := viewTestItems()
+			if got := itemNames(SortMCPs(model, items)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortMCPs(%s) = %v, want %v", tt.mode, got, tt.want)
+			}
+			if items[0].Name != "zeta" {
+				t.Error("SortMCPs should not reorder the inventory")
+			}
+		})
+	}
+}
+
+func TestGroupMCPs(t *testing.T) {
+	tests := []struct {
+		mode       types.GroupMode
+		wantLabels []string
+		wantItems  [][]string
+	}{
+		{types.GroupNone, []string{""}, [][]string{{"zeta", "Alpha", "mid", "beta"}}},
+		{types.GroupType, []string{"CMD", "SSE", "JSON"}, [][]string{{"mid", "beta"}, {"zeta"}, {"Alpha"}}},
+		{types.GroupStatus, []string{"Active", "Inactive"}, [][]string{{"Alpha", "mid"}, {"zeta", "beta"}}},
+		{types.GroupTag, []string{"db", "web", "Untagged"}, [][]string{{"beta"}, {"Alpha"}, {"zeta", "mid"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			model := types.Model{}
+			model.Grid.Group = tt.mode
+			groups := GroupMCPs(model, viewTestItems())
+
+			var labels []string
+			var items [][]string
+			for _, group := range groups {
+				labels = append(labels, group.Label)
+				items = append(items, itemNames(group.Items))
+			}
+			if !reflect.DeepEqual(labels, tt.wantLabels) || !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("GroupMCPs(%s) = %v %v, want %v %v", tt.mode, labels, items, tt.wantLabels, tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestGetFilteredMCPs_FollowsSortAndGroup(t *testing.T) {
+	model := types.Model{MCPItems: viewTestItems()}
+	model.Grid = types.GridViewState{Sort: types.SortName, Group: types.GroupStatus}
+
+	if got, want := itemNames(GetFilteredMCPs(model)), []string{"Alpha", "mid", "beta", "zeta"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFilteredMCPs = %v, want %v", got, want)
+	}
+
+	// Search results ignore the sort mode and keep their relevance order within each
+	// group; zeta and beta tie, so they stay in inventory order
+	model.SearchQuery = "a"
+	if got, want := itemNames(GetFilteredMCPs(model)), []string{"Alpha", "zeta", "beta"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFilteredMCPs with a search = %v, want %v", got, want)
+	}
+}
+
+func TestGetGridRows(t *testing.T) {
+	groups := []MCPGroup{
+		{Label: "CMD", Items: make([]types.MCPItem, 5)},
+		{Label: "SSE", Items: make([]types.MCPItem, 2)},
+	}
+
+	want := []GridRow{
+		{Header: "CMD (5)"},
+		{Start: 0, End: 4},
+		{Start: 4, End: 5},
+		{Header: "SSE (2)"},
+		{Start: 5, End: 7},
+	}
+	if got := GetGridRows(groups, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetGridRows = %+v, want %+v", got, want)
+	}
+
+	ungrouped := GetGridRows([]MCPGroup{{Items: make([]types.MCPItem, 3)}}, 4)
+	if !reflect.DeepEqual(ungrouped, []GridRow{{Start: 0, End: 3}}) {
+		t.Errorf("Ungrouped rows should have no header, got %+v", ungrouped)
+	}
+}
+
+func TestMoveGridSelection(t *testing.T) {
+	// CMD: items 0-4 in rows [0-3] [4]; SSE: items 5-6
+	rows := GetGridRows([]MCPGroup{
+		{Label: "CMD", Items: make([]types.MCPItem, 5)},
+		{Label: "SSE", Items: make([]types.MCPItem, 2)},
+	}, 4)
+
+	tests := []struct {
+		name           string
+		index          int
+		rowDelta, cols int
+		want           int
+	}{
+		{"down within a group", 1, 1, 0, 4},
+		{"down over a header", 4, 1, 0, 5},
+		{"down into a shorter row lands on its last item", 3, 1, 0, 4},
+		{"up over a header into a shorter row", 6, -1, 0, 4},
+		{"up at the top stays", 2, -1, 0, 2},
+		{"down at the bottom stays", 6, 1, 0, 6},
+		{"right within a row", 5, 0, 1, 6},
+		{"right at the end of a row stays", 4, 0, 1, 4},
+		{"left at the start of a row stays", 5, 0, -1, 5},
+		{"selection past the end moves onto the last item", 9, -1, 0, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MoveGridSelection(rows, tt.index, tt.rowDelta, tt.cols); got != tt.want {
+				t.Errorf("MoveGridSelection(%d, %d, %d) = %d, want %d", tt.index, tt.rowDelta, tt.cols, got, tt.want)
+			}
+		})
+	}
+
+	if got := MoveGridSelection(nil, 0, 1, 0); got != 0 {
+		t.Errorf("An empty grid should keep the selection, got %d", got)
+	}
+}
+
+func TestRecordMCPUse(t *testing.T) {
+	model := types.NewModel(newSettingsTestPlatform(t))
+	model.Grid.Sort = types.SortRecent
+	previous := model.Grid.LastUsed
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	model = RecordMCPUse(model, "github", now)
+	if !model.Grid.LastUsed["github"].Equal(now) || len(previous) != 0 {
+		t.Errorf("RecordMCPUse should record the time in a new map, got %v (previous %v)", model.Grid.LastUsed, previous)
+	}
+
+	state, err := LoadGridView(model.PlatformService)
+	if err != nil || !state.LastUsed["github"].Equal(now) || state.Sort != types.SortRecent {
+		t.Errorf("RecordMCPUse should save the view state, got %+v, %v", state, err)
+	}
+}
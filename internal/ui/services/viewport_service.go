@@ -0,0 +1,134 @@
+package services
+
+import "mcp-hub/internal/ui/types"
+
+const (
+	// viewportChrome is the height the header, footer, box and title take from the grid
+	// and the lists of the two and three column layouts
+	viewportChrome = 12
+	// singleColumnChrome adds the debug line of the single column layout
+	singleColumnChrome = 13
+)
+
+// Viewport is the part of the MCP grid or list that fits on the screen
+type Viewport struct {
+	// Rows are every row of the grid, or line of the list, in display order
+	Rows []GridRow
+	// Offset is the first row shown
+	Offset int
+	// Height is how many rows are shown, leaving a line for the scroll indicator when
+	// the rows do not fit
+	Height int
+}
+
+// Visible returns the rows shown
+func (v Viewport) Visible() []GridRow {
+	return v.Rows[v.Offset:min(v.Offset+v.Height, len(v.Rows))]
+}
+
+// Scrolls reports whether some rows are hidden
+func (v Viewport) Scrolls() bool {
+	return len(v.Rows) > v.Height
+}
+
+// ItemsAbove returns how many MCPs are hidden above the shown rows
+func (v Viewport) ItemsAbove() int {
+	return countItems(v.Rows[:v.Offset])
+}
+
+// ItemsBelow returns how many MCPs are hidden below the shown rows
+func (v Viewport) ItemsBelow() int {
+	return countItems(v.Rows[min(v.Offset+v.Height, len(v.Rows)):])
+}
+
+// ItemCount returns how many MCPs the rows hold
+func (v Viewport) ItemCount() int {
+	return countItems(v.Rows)
+}
+
+// countItems returns how many MCPs rows hold
+func countItems(rows []GridRow) int {
+	count := 0
+	for _, row := range rows {
+		count += row.End - row.Start
+	}
+	return count
+}
+
+// GetViewRows returns the rows of the MCP view of the model's layout: the rows of the
+// four-column grid, otherwise one line per group header and MCP of the list
+func GetViewRows(model types.Model) []GridRow {
+	if model.ColumnCount == types.WideColumns {
+		return GetGridRows(GetMCPGroups(model), types.WideColumns)
+	}
+	return GetGridRows(GetMCPGroups(model), 1)
+}
+
+// GetViewport returns the rows of the MCP view that fit on the screen. It starts at the
+// model's ScrollOffset, moved just enough to show the selection, and shows every row
+// before the window size is known.
+func GetViewport(model types.Model) Viewport {
+	rows := GetViewRows(model)
+	if model.Height <= 0 {
+		return Viewport{Rows: rows, Height: len(rows)}
+	}
+
+	chrome := viewportChrome
+	if model.ColumnCount == 1 {
+		chrome = singleColumnChrome
+	}
+	height := max(model.Height-chrome, 1)
+	if len(rows) > height {
+		// The scroll indicator takes a line
+		height = max(height-1, 1)
+	}
+
+	offset := model.ScrollOffset
+	if selected := selectedRow(rows, SelectedViewIndex(model)); selected >= 0 {
+		// Show the header of a group when moving up to its first row
+		top := selected
+		if top > 0 && rows[top-1].IsHeader() {
+			top--
+		}
+		offset = min(offset, top)
+		offset = max(offset, selected-height+1)
+	}
+	offset = max(min(offset, len(rows)-height), 0)
+	return Viewport{Rows: rows, Offset: offset, Height: height}
+}
+
+// ScrollToSelection stores the offset of the viewport that shows the selection, so the
+// view only scrolls when the selection leaves it
+func ScrollToSelection(model types.Model) types.Model {
+	model.ScrollOffset = GetViewport(model).Offset
+	return model
+}
+
+// selectedRow returns the row holding the MCP at index, or -1
+func selectedRow(rows []GridRow, index int) int {
+	for i, row := range rows {
+		if !row.IsHeader() && index >= row.Start && index < row.End {
+			return i
+		}
+	}
+	return -1
+}
+
+// SelectedViewIndex returns the display position of the selection: the selected search
+// result in the grid, otherwise the selected MCP
+func SelectedViewIndex(model types.Model) int {
+	if model.ColumnCount == types.WideColumns && model.SearchQuery != "" {
+		return model.FilteredSelectedIndex
+	}
+	return model.SelectedItem
+}
+
+// SelectViewIndex selects the MCP at display position index
+func SelectViewIndex(model types.Model, index int) types.Model {
+	if model.ColumnCount == types.WideColumns && model.SearchQuery != "" {
+		model.FilteredSelectedIndex = index
+	} else {
+		model.SelectedItem = index
+	}
+	return model
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/types"
+)
+
+// viewportTestModel returns a grid of count MCPs on a 140x40 screen
+func viewportTestModel(count int) types.Model {
+	model := types.NewModel(platform.GetMockPlatformService())
+	model.Width, model.Height = 140, 40
+	model.ColumnCount = types.WideColumns
+	for i := 0; i < count; i++ {
+		model.MCPItems = append(model.MCPItems, types.MCPItem{Name: fmt.Sprintf("mcp-%03d", i), Active: i%2 == 0})
+	}
+	return model
+}
+
+func TestGetViewport_FitsWithoutScrolling(t *testing.T) {
+	model := viewportTestModel(100)
+	viewport := GetViewport(model)
+	if viewport.Scrolls() || viewport.Offset != 0 || len(viewport.Visible()) != 25 {
+		t.Errorf("25 rows fit in 28, got offset %d height %d", viewport.Offset, viewport.Height)
+	}
+
+	model = viewportTestModel(1000)
+	model.Height = 0
+	if GetViewport(model).Scrolls() {
+		t.Error("Every row should be shown before the window size is known")
+	}
+}
+
+func TestGetViewport_KeepsSelectionVisible(t *testing.T) {
+	model := viewportTestModel(200)
+
+	// 50 rows in 28 lines, one of them the scroll indicator
+	viewport := GetViewport(model)
+	if !viewport.Scrolls() || viewport.Height != 27 || viewport.ItemsBelow() != 200-27*4 {
+		t.Fatalf("Expected 27 rows and the rest below, got height %d, %d below", viewport.Height, viewport.ItemsBelow())
+	}
+
+	model.SelectedItem = 199
+	viewport = GetViewport(model)
+	if viewport.Offset != 50-27 || viewport.ItemsAbove() != 23*4 || viewport.ItemsBelow() != 0 {
+		t.Errorf("The last row should be at the bottom, got offset %d", viewport.Offset)
+	}
+
+	// Moving within the viewport keeps the offset; leaving it scrolls just enough
+	model.ScrollOffset = 10
+	for _, tt := range []struct{ selected, offset int }{{20 * 4, 10}, {10 * 4, 10}, {5 * 4, 5}, {40 * 4, 40 - 26}} {
+		model.SelectedItem = tt.selected
+		if offset := GetViewport(model).Offset; offset != tt.offset {
+			t.Errorf("Selecting row %d from offset 10 should scroll to %d, got %d", tt.selected/4, tt.offset, offset)
+		}
+	}
+
+	model.ScrollOffset, model.SelectedItem = 100, 0
+	if offset := ScrollToSelection(model).ScrollOffset; offset != 0 {
+		t.Errorf("ScrollToSelection should store the offset showing the selection, got %d", offset)
+	}
+}
+
+func TestGetViewport_ShowsGroupHeader(t *testing.T) {
+	model := viewportTestModel(400)
+	model.Grid.Group = types.GroupStatus
+	// Rows: the Active header, 50 rows, the Inactive header, 50 rows
+	model.ScrollOffset = 80
+	model.SelectedItem = 200
+	if viewport := GetViewport(model); viewport.Offset != 51 || !viewport.Visible()[0].IsHeader() {
+		t.Errorf("Moving up to the first row of a group should show its header, got offset %d", viewport.Offset)
+	}
+}
+
+func TestGetViewRows_Lists(t *testing.T) {
+	model := viewportTestModel(3)
+	model.Grid.Group = types.GroupStatus
+
+	for _, columns := range []int{1, 2, 3} {
+		model.ColumnCount = columns
+		if rows := GetViewRows(model); len(rows) != 5 || !rows[0].IsHeader() || rows[1].End-rows[1].Start != 1 {
+			t.Errorf("The %d column list should have a line per header and MCP, got %+v", columns, rows)
+		}
+	}
+}
+
+func TestSelectViewIndex(t *testing.T) {
+	model := viewportTestModel(10)
+	model.SearchQuery = "mcp"
+	model = SelectViewIndex(model, 3)
+	if model.FilteredSelectedIndex != 3 || SelectedViewIndex(model) != 3 || model.SelectedItem != 0 {
+		t.Errorf("The grid should select search results, got %d", model.FilteredSelectedIndex)
+	}
+
+	model.ColumnCount = 2
+	model = SelectViewIndex(model, 5)
+	if model.SelectedItem != 5 || SelectedViewIndex(model) != 5 {
+		t.Errorf("The lists should select the MCP, got %d", model.SelectedItem)
+	}
+}
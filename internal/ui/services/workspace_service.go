@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"mcp-hub/internal/platform"
+)
+
+const (
+	// WorkspaceEnv selects the workspace when --workspace is not given
+	WorkspaceEnv = "MCP_HUB_WORKSPACE"
+	// ConfigDirEnv overrides the platform config directory holding the workspaces
+	ConfigDirEnv = "MCP_HUB_CONFIG_DIR"
+	// DefaultWorkspace keeps its inventory and settings directly in the config directory
+	DefaultWorkspace = "default"
+	// workspacesDirName holds one directory per named workspace under the config directory
+	workspacesDirName = "workspaces"
+)
+
+// workspaceNamePattern keeps workspace names usable as directory names on every platform
+var workspaceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// WorkspacePlatform is a PlatformService whose config directory is one workspace's, so
+// the inventory, profiles and settings it reads and writes belong to that workspace
+type WorkspacePlatform struct {
+	platform.PlatformService
+	Workspace  string
+	configPath string
+}
+
+// GetConfigPath returns the workspace's config directory
+func (w *WorkspacePlatform) GetConfigPath() string {
+	return w.configPath
+}
+
+// ValidateWorkspaceName checks that name can be used as a workspace
+func ValidateWorkspaceName(name string) error {
+	if !workspaceNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid workspace name %q - use letters, numbers, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ConfigBaseDir returns the directory holding the default workspace and the named ones:
+// MCP_HUB_CONFIG_DIR when set, the platform config directory otherwise
+func ConfigBaseDir(platformService platform.PlatformService) string {
+	if workspace, ok := platformService.(*WorkspacePlatform); ok {
+		platformService = workspace.PlatformService
+	}
+	if dir := os.Getenv(ConfigDirEnv); dir != "" {
+		return dir
+	}
+	return platformService.GetConfigPath()
+}
+
+// OpenWorkspace returns platformService with its config directory pointed at the named
+// workspace. An empty name opens the default workspace.
+func OpenWorkspace(platformService platform.PlatformService, name string) (*WorkspacePlatform, error) {
+	if workspace, ok := platformService.(*WorkspacePlatform); ok {
+		platformService = workspace.PlatformService
+	}
+	if name == "" {
+		name = DefaultWorkspace
+	}
+	if err := ValidateWorkspaceName(name); err != nil {
+		return nil, err
+	}
+
+	configPath := ConfigBaseDir(platformService)
+	if name != DefaultWorkspace {
+		configPath = filepath.Join(configPath, workspacesDirName, name)
+	}
+	return &WorkspacePlatform{PlatformService: platformService, Workspace: name, configPath: configPath}, nil
+}
+
+// OpenWorkspaceFromEnv opens the workspace named by MCP_HUB_WORKSPACE, or the default one
+func OpenWorkspaceFromEnv(platformService platform.PlatformService) (*WorkspacePlatform, error) {
+	return OpenWorkspace(platformService, os.Getenv(WorkspaceEnv))
+}
+
+// WorkspaceName returns the workspace a platform service was opened for
+func WorkspaceName(platformService platform.PlatformService) string {
+	if workspace, ok := platformService.(*WorkspacePlatform); ok {
+		return workspace.Workspace
+	}
+	return DefaultWorkspace
+}
+
+// ListWorkspaces returns the default workspace followed by the named ones in sorted order
+func ListWorkspaces(platformService platform.PlatformService) ([]string, error) {
+	workspaces := []string{DefaultWorkspace}
+	entries, err := os.ReadDir(filepath.Join(ConfigBaseDir(platformService), workspacesDirName))
+	if os.IsNotExist(err) {
+		return workspaces, nil
+	}
+	if err != nil {
+		return workspaces, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var named []string
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != DefaultWorkspace && ValidateWorkspaceName(entry.Name()) == nil {
+			named = append(named, entry.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(workspaces, named...), nil
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"mcp-hub/internal/ui/types"
+)
+
+func TestValidateWorkspaceName(t *testing.T) {
+	for _, name := range []string{"default", "work", "client-a", "team_2", "A1"} {
+		if err := ValidateWorkspaceName(name); err != nil {
+			t.Errorf("%q should be valid: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "-work", "a/b", "..", "with space", string(make([]byte, 65))} {
+		if err := ValidateWorkspaceName(name); err == nil {
+			t.Errorf("%q should be rejected", name)
+		}
+	}
+}
+
+func TestOpenWorkspace(t *testing.T) {
+	t.Setenv(ConfigDirEnv, "")
+	mockPlatform := newSettingsTestPlatform(t)
+	base := mockPlatform.GetConfigPath()
+
+	defaultWorkspace, err := OpenWorkspace(mockPlatform, "")
+	if err != nil || defaultWorkspace.GetConfigPath() != base || WorkspaceName(defaultWorkspace) != DefaultWorkspace {
+		t.Fatalf("Default workspace should use the config directory, got %+v, %v", defaultWorkspace, err)
+	}
+
+	work, err := OpenWorkspace(defaultWorkspace, "work")
+	if err != nil || work.GetConfigPath() != filepath.Join(base, "workspaces", "work") || WorkspaceName(work) != "work" {
+		t.Fatalf("Named workspace should live under workspaces/, got %+v, %v", work, err)
+	}
+	if work.GetLogPath() != mockPlatform.GetLogPath() {
+		t.Error("Workspaces should only change the config directory")
+	}
+	if _, err := OpenWorkspace(mockPlatform, "../escape"); err == nil {
+		t.Error("Invalid workspace names should be rejected")
+	}
+	if WorkspaceName(mockPlatform) != DefaultWorkspace {
+		t.Error("A plain platform service belongs to the default workspace")
+	}
+}
+
+func TestOpenWorkspace_ConfigDirOverride(t *testing.T) {
+	override := t.TempDir()
+	t.Setenv(ConfigDirEnv, override)
+	t.Setenv(WorkspaceEnv, "ci")
+
+	workspace, err := OpenWorkspaceFromEnv(newSettingsTestPlatform(t))
+	if err != nil || workspace.GetConfigPath() != filepath.Join(override, "workspaces", "ci") {
+		t.Fatalf("MCP_HUB_CONFIG_DIR should hold the workspaces, got %+v, %v", workspace, err)
+	}
+	if ConfigBaseDir(workspace) != override {
+		t.Errorf("Expected base dir %s, got %s", override, ConfigBaseDir(workspace))
+	}
+}
+
+func TestWorkspaces_IsolateInventoryAndSettings(t *testing.T) {
+	t.Setenv(ConfigDirEnv, "")
+	mockPlatform := newSettingsTestPlatform(t)
+	defaultWorkspace, _ := OpenWorkspace(mockPlatform, DefaultWorkspace)
+	work, _ := OpenWorkspace(mockPlatform, "work")
+
+	if err := SaveInventory([]types.MCPItem{{Name: "home", Type: "CMD", Command: "home"}}, defaultWorkspace); err != nil {
+		t.Fatalf("SaveInventory failed: %v", err)
+	}
+	if err := SaveInventory([]types.MCPItem{{Name: "office", Type: "CMD", Command: "office"}}, work); err != nil {
+		t.Fatalf("SaveInventory failed: %v", err)
+	}
+	settings := types.DefaultSettings()
+	settings.ClaudeTimeout *= 2
+	if err := SaveSettings(work, settings); err != nil {
+		t.Fatalf("SaveSettings failed: %v", err)
+	}
+
+	for workspace, expected := range map[string]string{DefaultWorkspace: "home", "work": "office"} {
+		ps, _ := OpenWorkspace(mockPlatform, workspace)
+		items, err := LoadInventory(ps)
+		if err != nil || len(items) != 1 || items[0].Name != expected {
+			t.Errorf("Workspace %s should only see %s, got %+v, %v", workspace, expected, items, err)
+		}
+	}
+	if loaded, _ := LoadSettings(defaultWorkspace); loaded != types.DefaultSettings() {
+		t.Errorf("Default workspace settings should be untouched, got %+v", loaded)
+	}
+	if loaded, _ := LoadSettings(work); loaded != settings {
+		t.Errorf("Work settings should be loaded, got %+v", loaded)
+	}
+}
+
+func TestListWorkspaces(t *testing.T) {
+	t.Setenv(ConfigDirEnv, "")
+	mockPlatform := newSettingsTestPlatform(t)
+	if workspaces, err := ListWorkspaces(mockPlatform); err != nil || !reflect.DeepEqual(workspaces, []string{DefaultWorkspace}) {
+		t.Errorf("Without workspaces only the default is listed, got %v, %v", workspaces, err)
+	}
+
+	dir := filepath.Join(mockPlatform.GetConfigPath(), "workspaces")
+	for _, name := range []string{"zeta", "alpha", "default", "not valid"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "file"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	workspaces, err := ListWorkspaces(mockPlatform)
+	if err != nil || !reflect.DeepEqual(workspaces, []string{DefaultWorkspace, "alpha", "zeta"}) {
+		t.Errorf("Expected the default then sorted named workspaces, got %v, %v", workspaces, err)
+	}
+}
@@ -18,6 +18,16 @@ const (
 	// NarrowColumns defines the single column for narrow screens
 	NarrowColumns = 1
 
+	// ContextBudgetWarningTokens is the estimated active-set token count above which the header warns
+	ContextBudgetWarningTokens = 20000
+
+	// ServerLogCapacity is the number of log entries kept in memory per server
+	ServerLogCapacity = 500
+	// LogPaneLines is the number of log entries visible in the log pane
+	LogPaneLines = 20
+	// AppLogCapacity is the number of application log entries loaded into the log viewer
+	AppLogCapacity = 1000
+
 	// BulletChar represents the bullet character used in UI displays
 	BulletChar = "◦"
 
@@ -0,0 +1,105 @@
+package types
+
+// Action is something a key can be bound to outside of the modals
+type Action string
+
+// Actions of the main grid, the search bar and the global keys
+const (
+	ActionQuit          Action = "quit"
+	ActionBack          Action = "back"
+	ActionRedraw        Action = "redraw"
+	ActionHelp          Action = "help"
+	ActionUp            Action = "up"
+	ActionDown          Action = "down"
+	ActionLeft          Action = "left"
+	ActionRight         Action = "right"
+	ActionPageUp        Action = "page_up"
+	ActionPageDown      Action = "page_down"
+	ActionHome          Action = "home"
+	ActionEnd           Action = "end"
+	ActionSearch        Action = "search"
+	ActionSearchMode    Action = "search_mode"
+	ActionSearchApply   Action = "search_apply"
+	ActionAdd           Action = "add"
+	ActionEdit          Action = "edit"
+	ActionDelete        Action = "delete"
+	ActionToggle        Action = "toggle"
+	ActionServe         Action = "serve"
+	ActionRefresh       Action = "refresh"
+	ActionIntrospect    Action = "introspect"
+	ActionIntrospectAll Action = "introspect_all"
+	ActionProbe         Action = "probe"
+	ActionProbeAll      Action = "probe_all"
+	ActionPlayground    Action = "playground"
+	ActionLogs          Action = "logs"
+	ActionToolFilter    Action = "tool_filter"
+	ActionSettings      Action = "settings"
+	ActionWorkspaces    Action = "workspaces"
+	ActionAppLog        Action = "app_log"
+	ActionSort          Action = "sort"
+	ActionGroup         Action = "group"
+)
+
+// KeyMap binds actions to the keys that trigger them. Actions it leaves out, and a nil
+// KeyMap, use DefaultKeyMap.
+type KeyMap map[Action][]string
+
+// DefaultKeyMap returns the built-in key bindings
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		ActionQuit:          {"ctrl+c"},
+		ActionBack:          {"esc"},
+		ActionRedraw:        {"ctrl+l"},
+		ActionHelp:          {"?"},
+		ActionUp:            {"up", "k"},
+		ActionDown:          {"down", "j"},
+		ActionLeft:          {"left", "h"},
+		ActionRight:         {"right", "l"},
+		ActionPageUp:        {"pgup"},
+		ActionPageDown:      {"pgdown"},
+		ActionHome:          {"home"},
+		ActionEnd:           {"end"},
+		ActionSearch:        {"/", "tab"},
+		ActionSearchMode:    {"tab"},
+		ActionSearchApply:   {"enter"},
+		ActionAdd:           {"a"},
+		ActionEdit:          {"e"},
+		ActionDelete:        {"d"},
+		ActionToggle:        {" ", "space"},
+		ActionServe:         {"S"},
+		ActionRefresh:       {"r", "R"},
+		ActionIntrospect:    {"i"},
+		ActionIntrospectAll: {"I"},
+		ActionProbe:         {"p"},
+		ActionProbeAll:      {"P"},
+		ActionPlayground:    {"t"},
+		ActionLogs:          {"L"},
+		ActionToolFilter:    {"f"},
+		ActionSettings:      {","},
+		ActionWorkspaces:    {"W"},
+		ActionAppLog:        {"V"},
+		ActionSort:          {"s"},
+		ActionGroup:         {"g"},
+	}
+}
+
+// defaultKeyMap is shared by lookups that fall back to the defaults
+var defaultKeyMap = DefaultKeyMap()
+
+// Keys returns the keys bound to action
+func (k KeyMap) Keys(action Action) []string {
+	if keys, ok := k[action]; ok {
+		return keys
+	}
+	return defaultKeyMap[action]
+}
+
+// Matches reports whether key triggers action
+func (k KeyMap) Matches(action Action, key string) bool {
+	for _, bound := range k.Keys(action) {
+		if bound == key {
+			return true
+		}
+	}
+	return false
+}
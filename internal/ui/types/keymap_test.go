@@ -0,0 +1,26 @@
+package types
+
+import "testing"
+
+func TestKeyMap_FallsBackToDefaults(t *testing.T) {
+	var unset KeyMap
+	if !unset.Matches(ActionUp, "k") || !unset.Matches(ActionUp, "up") || unset.Matches(ActionUp, "j") {
+		t.Error("A nil key map should use the default keys")
+	}
+
+	keys := KeyMap{ActionAdd: {"n"}}
+	if !keys.Matches(ActionAdd, "n") || keys.Matches(ActionAdd, "a") {
+		t.Error("A bound action should only match its own keys")
+	}
+	if !keys.Matches(ActionDelete, "d") {
+		t.Error("Actions left out should keep their default keys")
+	}
+}
+
+func TestDefaultKeyMap_ReturnsACopy(t *testing.T) {
+	keys := DefaultKeyMap()
+	keys[ActionHelp] = []string{"h"}
+	if !DefaultKeyMap().Matches(ActionHelp, "?") || !(KeyMap{}).Matches(ActionHelp, "?") {
+		t.Error("Changing a key map should not change the defaults")
+	}
+}
@@ -2,8 +2,11 @@
 package types
 
 import (
+	"encoding/json"
+	"log/slog"
 	"time"
 
+	"mcp-hub/internal/logging"
 	"mcp-hub/internal/platform"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -103,6 +106,7 @@ type Model struct {
 	ActiveColumn          int
 	SelectedItem          int
 	FilteredSelectedIndex int // Track selection position in filtered results
+	ScrollOffset          int // First grid row or list line shown when they do not fit
 
 	// Search
 	SearchQuery       string
@@ -154,6 +158,50 @@ type Model struct {
 
 	// Platform abstraction service (Epic 4 Story 1)
 	PlatformService platform.PlatformService
+
+	// Server introspection state, keyed by MCP name
+	Introspection     map[string]IntrospectionResult
+	IntrospectingMCPs map[string]bool
+
+	// Health probe state, keyed by MCP name
+	Health      map[string]HealthResult
+	ProbingMCPs map[string]bool
+
+	// Tool-call playground state and per-server call history
+	Playground      PlaygroundState
+	ToolCallHistory map[string][]ToolCallRecord
+
+	// Captured stderr and log notifications of spawned servers, and the log pane
+	ServerLogs *ServerLogStore
+	LogViewer  LogViewerState
+
+	// Tool allow/deny editor state
+	ToolFilter ToolFilterState
+
+	// User settings from settings.json, and the settings editor
+	Settings       Settings
+	SettingsEditor SettingsEditorState
+
+	// Workspace whose inventory and settings are loaded, and the workspace switcher
+	Workspace         string
+	WorkspaceSwitcher WorkspaceSwitcherState
+
+	// Application log viewer tailing mcp-hub.log
+	AppLog AppLogViewerState
+
+	// Sort and group modes of the MCP grid and usage times, from view.json
+	Grid GridViewState
+
+	// Key bindings from the "keys" entry of settings.json, and why they were ignored if
+	// the entry is invalid
+	Keys      KeyMap
+	KeysError string
+
+	// Colors the UI is drawn with, from the "theme" entry of settings.json
+	Theme Theme
+
+	// Last mouse click, to tell a double-click from two clicks
+	LastClick MouseClick
 }
 
 // ModalType represents the type of modal being displayed
@@ -176,6 +224,20 @@ const (
 	EditModal
 	// DeleteModal represents the delete confirmation modal
 	DeleteModal
+	// PlaygroundModal represents the tool-call playground modal
+	PlaygroundModal
+	// LogsModal represents the server log pane
+	LogsModal
+	// ToolFilterModal represents the per-server tool allow/deny editor
+	ToolFilterModal
+	// SettingsModal represents the user settings editor
+	SettingsModal
+	// WorkspaceModal represents the workspace switcher
+	WorkspaceModal
+	// AppLogModal represents the application log viewer
+	AppLogModal
+	// HelpModal represents the key binding help overlay
+	HelpModal
 )
 
 // FormData represents the current form data during MCP addition
@@ -187,6 +249,9 @@ type FormData struct {
 	JSONConfig  string
 	Environment string // UI input as string, converted to map[string]string on save
 	ActiveField int    // Track which field is currently focused for Tab navigation
+	// CursorFromEnd counts the runes after the cursor in the focused field, so the zero
+	// value keeps the cursor at the end when a field is focused or filled in
+	CursorFromEnd int
 }
 
 // MCPItem represents an MCP in the inventory
@@ -199,6 +264,191 @@ type MCPItem struct {
 	URL         string            `json:"url,omitempty"`
 	JSONConfig  string            `json:"json_config,omitempty"`
 	Environment map[string]string `json:"env,omitempty"` // New field for environment variables
+	Headers     map[string]string `json:"headers,omitempty"`
+	// Tool filter glob patterns; with allow patterns set, only matching tools are exposed
+	ToolAllow []string `json:"tool_allow,omitempty"`
+	ToolDeny  []string `json:"tool_deny,omitempty"`
+	// Claude scope the MCP is added in: local (the default), project or user
+	Scope string `json:"scope,omitempty"`
+	// Optional labels and notes kept in inventory.json; search matches them
+	Tags        []string `json:"tags,omitempty"`
+	Description string   `json:"description,omitempty"`
+	// Served MCPs are proxied by mcp-hub serve. Active means registered with Claude
+	// directly, so the two are kept apart to avoid exposing a tool twice.
+	Served bool `json:"served,omitempty"`
+}
+
+// ToolInfo represents a tool exposed by an MCP server
+type ToolInfo struct {
+	Name          string          `json:"name"`
+	Description   string          `json:"description,omitempty"`
+	InputSchema   json.RawMessage `json:"input_schema,omitempty"`
+	TokenEstimate int             `json:"token_estimate"`
+}
+
+// IntrospectionResult represents the capabilities and estimated context cost of an MCP server
+type IntrospectionResult struct {
+	MCPName         string         `json:"mcp_name"`
+	ConfigHash      string         `json:"config_hash"`
+	ServerName      string         `json:"server_name,omitempty"`
+	ServerVersion   string         `json:"server_version,omitempty"`
+	ProtocolVersion string         `json:"protocol_version,omitempty"`
+	Capabilities    []string       `json:"capabilities,omitempty"`
+	Tools           []ToolInfo     `json:"tools,omitempty"`
+	Resources       []ResourceInfo `json:"resources,omitempty"`
+	Prompts         []PromptInfo   `json:"prompts,omitempty"`
+	TokenEstimate   int            `json:"token_estimate"`
+	FetchedAt       time.Time      `json:"fetched_at"`
+	Error           string         `json:"error,omitempty"`
+}
+
+// ResourceInfo represents a resource exposed by an MCP server
+type ResourceInfo struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mime_type,omitempty"`
+}
+
+// PromptInfo represents a prompt template exposed by an MCP server
+type PromptInfo struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Arguments   []string `json:"arguments,omitempty"`
+}
+
+// HealthStatus represents the outcome of a health probe
+type HealthStatus int
+
+const (
+	// HealthUnknown means the server could not be probed (e.g. unsupported transport)
+	HealthUnknown HealthStatus = iota
+	// HealthHealthy means the server completed the handshake and answered ping
+	HealthHealthy
+	// HealthDegraded means the handshake succeeded but ping failed
+	HealthDegraded
+	// HealthUnhealthy means the server failed to start or complete the handshake
+	HealthUnhealthy
+)
+
+// HealthResult represents the outcome of probing an MCP server
+type HealthResult struct {
+	MCPName         string
+	Status          HealthStatus
+	ServerName      string
+	ServerVersion   string
+	ProtocolVersion string
+	Capabilities    []string
+	StartupLatency  time.Duration
+	PingLatency     time.Duration
+	CheckedAt       time.Time
+	Error           string
+}
+
+// PlaygroundStep represents the current step of the tool-call playground
+type PlaygroundStep int
+
+const (
+	// PlaygroundSelectTool lists the server's tools
+	PlaygroundSelectTool PlaygroundStep = iota
+	// PlaygroundEditArguments edits the arguments of the chosen tool
+	PlaygroundEditArguments
+	// PlaygroundCalling waits for tools/call to return
+	PlaygroundCalling
+	// PlaygroundShowResult shows the result of the last call
+	PlaygroundShowResult
+)
+
+// PlaygroundField is an argument input generated from a tool's JSON Schema
+type PlaygroundField struct {
+	Name        string
+	Type        string // JSON Schema type: string, number, integer, boolean, array or object
+	Description string
+	Required    bool
+	Enum        []string
+	Value       string
+}
+
+// PlaygroundState holds the tool-call playground for one MCP
+type PlaygroundState struct {
+	MCPName     string
+	Step        PlaygroundStep
+	ToolIndex   int
+	Fields      []PlaygroundField
+	ActiveField int
+	RawMode     bool   // Edit arguments as raw JSON instead of the generated form
+	RawJSON     string // Raw JSON arguments
+	Error       string // Argument validation error
+	LastCall    *ToolCallRecord
+}
+
+// ToolCallRecord represents one tools/call made from the playground
+type ToolCallRecord struct {
+	MCPName   string
+	Tool      string
+	Arguments string // Arguments as JSON
+	Result    string // Formatted tool output
+	IsError   bool   // The tool reported a failure (isError)
+	Error     string // The call itself failed (connection or protocol error)
+	Duration  time.Duration
+	CalledAt  time.Time
+}
+
+// LogViewerState holds the server log pane for one MCP
+type LogViewerState struct {
+	MCPName  string
+	MinLevel LogLevel // Entries below this level are hidden
+	Scroll   int      // Lines scrolled up from the newest entry; 0 follows new output
+}
+
+// AppLogViewerState holds the application log viewer
+type AppLogViewerState struct {
+	Entries   []logging.Entry // Newest entries of the log file, reloaded while the viewer is open
+	MinLevel  slog.Level      // Entries below this level are hidden
+	Component string          // When set, only this component's entries are shown
+	Scroll    int             // Lines scrolled up from the newest entry; 0 follows new output
+	Error     string
+}
+
+// ToolFilterFocus is the part of the tool filter editor receiving keys
+type ToolFilterFocus int
+
+const (
+	// ToolFilterFocusList toggles tools in the introspected tool list
+	ToolFilterFocusList ToolFilterFocus = iota
+	// ToolFilterFocusAllow edits the allow patterns
+	ToolFilterFocusAllow
+	// ToolFilterFocusDeny edits the deny patterns
+	ToolFilterFocusDeny
+)
+
+// ToolFilterState holds the tool allow/deny editor for one MCP.
+// The pattern inputs are comma-separated and are the source of truth until saved.
+type ToolFilterState struct {
+	MCPName    string
+	Cursor     int
+	Focus      ToolFilterFocus
+	AllowInput string
+	DenyInput  string
+	Error      string
+}
+
+// SettingsEditorState holds the settings editor. Inputs holds one text value per
+// settings field, in the order the fields are listed.
+type SettingsEditorState struct {
+	Inputs []string
+	Cursor int
+	Error  string
+}
+
+// WorkspaceSwitcherState holds the workspace switcher. While Creating is set, keys
+// edit NewName instead of moving through Workspaces.
+type WorkspaceSwitcherState struct {
+	Workspaces []string
+	Cursor     int
+	Creating   bool
+	NewName    string
+	Error      string
 }
 
 // Column represents a UI column
@@ -241,6 +491,18 @@ type ProjectContext struct {
 	SyncStatus     SyncStatus
 	DisplayPath    string // Truncated path for display
 	SyncStatusText string // Human-readable sync status
+	Project        ProjectInfo
+}
+
+// ProjectInfo represents git and Claude project information for the current directory
+type ProjectInfo struct {
+	Root           string   // Git repository root, or the current path outside a repository
+	GitRoot        string   // Empty when not inside a git repository
+	GitBranch      string   // Current branch, or abbreviated commit for a detached HEAD
+	HasMCPConfig   bool     // True when a .mcp.json exists at the project root
+	MCPConfigError string   // Parse error for .mcp.json, if any
+	HasClaudeDir   bool     // True when a .claude/ directory exists at the project root
+	ProjectMCPs    []string // Server names declared by the project's .mcp.json
 }
 
 // TimerTickMsg represents a timer tick message for countdown functionality
@@ -294,6 +556,16 @@ func NewModel(platformService platform.PlatformService) Model {
 		MCPItems:          getDefaultMCPs(), // This will be replaced by storage loading
 		FormErrors:        make(map[string]string),
 		PlatformService:   platformService,
+		Introspection:     make(map[string]IntrospectionResult),
+		IntrospectingMCPs: make(map[string]bool),
+		Health:            make(map[string]HealthResult),
+		ProbingMCPs:       make(map[string]bool),
+		ToolCallHistory:   make(map[string][]ToolCallRecord),
+		ServerLogs:        NewServerLogStore(ServerLogCapacity),
+		Settings:          DefaultSettings(),
+		Grid:              DefaultGridViewState(),
+		Keys:              DefaultKeyMap(),
+		Theme:             DefaultTheme(),
 	}
 }
 
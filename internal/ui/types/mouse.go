@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// DoubleClickInterval is the longest gap between two clicks on the same target that
+// counts as a double-click; terminals report single clicks only
+const DoubleClickInterval = 400 * time.Millisecond
+
+// MouseTargetKind says what part of the UI is under the mouse pointer
+type MouseTargetKind int
+
+// Mouse target kinds
+const (
+	MouseTargetNone MouseTargetKind = iota
+	// MouseTargetItem is an MCP in the grid or list; Index is its position as displayed
+	MouseTargetItem
+	// MouseTargetColumn is a column of the narrow layouts; Index is the column
+	MouseTargetColumn
+	// MouseTargetField is a field of the open form; Index is its ActiveField
+	MouseTargetField
+	// MouseTargetOption is an option of the add type selection; Index is its number
+	MouseTargetOption
+	// MouseTargetButton is a key hint in the modal footer; Key is the key it presses
+	MouseTargetButton
+)
+
+// MouseTarget is the part of the UI a mouse event happened on
+type MouseTarget struct {
+	Kind  MouseTargetKind
+	Index int
+	Key   string
+}
+
+// MouseClick is a click on a target, kept to recognize the second click of a double-click
+type MouseClick struct {
+	Target MouseTarget
+	At     time.Time
+}
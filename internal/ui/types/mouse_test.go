@@ -0,0 +1,13 @@
+package types
+
+import "testing"
+
+func TestMouseTarget_ZeroValueIsNone(t *testing.T) {
+	var click MouseClick
+	if click.Target.Kind != MouseTargetNone || !click.At.IsZero() {
+		t.Error("A model that was never clicked should have no last click")
+	}
+	if (MouseTarget{Kind: MouseTargetButton, Key: "enter"}) == (MouseTarget{Kind: MouseTargetButton, Key: "esc"}) {
+		t.Error("Buttons for different keys should be different targets")
+	}
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel is the severity of a server log entry, ordered as in MCP (syslog) logging
+type LogLevel int
+
+const (
+	// LogDebug is detailed debugging output
+	LogDebug LogLevel = iota
+	// LogInfo is general informational output
+	LogInfo
+	// LogNotice is a normal but significant event
+	LogNotice
+	// LogWarning is a warning condition
+	LogWarning
+	// LogError is an error condition
+	LogError
+	// LogCritical is a critical condition
+	LogCritical
+	// LogAlert requires immediate action
+	LogAlert
+	// LogEmergency means the server is unusable
+	LogEmergency
+)
+
+var logLevelNames = []string{"debug", "info", "notice", "warning", "error", "critical", "alert", "emergency"}
+
+// String returns the MCP name of the level
+func (l LogLevel) String() string {
+	if l < LogDebug || l > LogEmergency {
+		return "unknown"
+	}
+	return logLevelNames[l]
+}
+
+// MarshalText encodes the level by name so persisted logs stay readable
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText decodes a level name
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	*l = ParseLogLevel(string(text))
+	return nil
+}
+
+// ParseLogLevel converts an MCP level name into a LogLevel, defaulting to LogInfo
+func ParseLogLevel(name string) LogLevel {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, levelName := range logLevelNames {
+		if name == levelName {
+			return LogLevel(i)
+		}
+	}
+	return LogInfo
+}
+
+// Server log entry sources
+const (
+	// LogSourceStderr marks lines the server process wrote to stderr
+	LogSourceStderr = "stderr"
+	// LogSourceNotification marks MCP notifications/message entries
+	LogSourceNotification = "mcp"
+)
+
+// ServerLogEntry is one captured line of server output
+type ServerLogEntry struct {
+	MCPName string    `json:"mcp"`
+	Time    time.Time `json:"time"`
+	Level   LogLevel  `json:"level"`
+	Source  string    `json:"source"`
+	Logger  string    `json:"logger,omitempty"`
+	Message string    `json:"message"`
+}
+
+// ServerLogStore keeps the most recent log entries of each server in a ring buffer.
+// It is shared by the commands that spawn servers, so all methods are safe for
+// concurrent use and for a nil store.
+type ServerLogStore struct {
+	mu       sync.Mutex
+	capacity int
+	buffers  map[string]*logRing
+
+	// OnAppend, when set, is called with every appended entry outside the lock
+	OnAppend func(ServerLogEntry)
+}
+
+// logRing is a fixed-size ring buffer of log entries
+type logRing struct {
+	entries []ServerLogEntry
+	next    int
+	full    bool
+}
+
+// NewServerLogStore creates a store keeping up to capacity entries per server
+func NewServerLogStore(capacity int) *ServerLogStore {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ServerLogStore{
+		capacity: capacity,
+		buffers:  make(map[string]*logRing),
+	}
+}
+
+// Append records an entry, evicting the server's oldest entry when its buffer is full
+func (s *ServerLogStore) Append(entry ServerLogEntry) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	ring, ok := s.buffers[entry.MCPName]
+	if !ok {
+		ring = &logRing{entries: make([]ServerLogEntry, s.capacity)}
+		s.buffers[entry.MCPName] = ring
+	}
+	ring.entries[ring.next] = entry
+	ring.next = (ring.next + 1) % len(ring.entries)
+	if ring.next == 0 {
+		ring.full = true
+	}
+	onAppend := s.OnAppend
+	s.mu.Unlock()
+
+	if onAppend != nil {
+		onAppend(entry)
+	}
+}
+
+// Entries returns a server's buffered entries, oldest first
+func (s *ServerLogStore) Entries(mcpName string) []ServerLogEntry {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ring, ok := s.buffers[mcpName]
+	if !ok {
+		return nil
+	}
+	if !ring.full {
+		return append([]ServerLogEntry(nil), ring.entries[:ring.next]...)
+	}
+	entries := make([]ServerLogEntry, 0, len(ring.entries))
+	entries = append(entries, ring.entries[ring.next:]...)
+	return append(entries, ring.entries[:ring.next]...)
+}
+
+// Clear drops a server's buffered entries
+func (s *ServerLogStore) Clear(mcpName string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	delete(s.buffers, mcpName)
+	s.mu.Unlock()
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected LogLevel
+	}{
+		{"debug", LogDebug},
+		{"WARNING", LogWarning},
+		{" error ", LogError},
+		{"emergency", LogEmergency},
+		{"verbose", LogInfo},
+		{"", LogInfo},
+	}
+
+	for _, tt := range tests {
+		if level := ParseLogLevel(tt.name); level != tt.expected {
+			t.Errorf("ParseLogLevel(%q) = %v, expected %v", tt.name, level, tt.expected)
+		}
+	}
+
+	if LogCritical.String() != "critical" || LogLevel(42).String() != "unknown" {
+		t.Error("String() should return the MCP level name")
+	}
+}
+
+func TestServerLogStore_RingBuffer(t *testing.T) {
+	store := NewServerLogStore(3)
+	for _, message := range []string{"one", "two"} {
+		store.Append(ServerLogEntry{MCPName: "github", Message: message})
+	}
+	store.Append(ServerLogEntry{MCPName: "other", Message: "elsewhere"})
+
+	if entries := store.Entries("github"); len(entries) != 2 || entries[0].Message != "one" {
+		t.Fatalf("Expected two entries oldest first, got %+v", entries)
+	}
+
+	for _, message := range []string{"three", "four", "five"} {
+		store.Append(ServerLogEntry{MCPName: "github", Message: message})
+	}
+	entries := store.Entries("github")
+	if len(entries) != 3 {
+		t.Fatalf("Expected buffer capped at 3 entries, got %d", len(entries))
+	}
+	for i, expected := range []string{"three", "four", "five"} {
+		if entries[i].Message != expected {
+			t.Errorf("Entry %d = %q, expected %q", i, entries[i].Message, expected)
+		}
+	}
+
+	store.Clear("github")
+	if len(store.Entries("github")) != 0 || len(store.Entries("other")) != 1 {
+		t.Error("Clear should only drop the given server's entries")
+	}
+}
+
+func TestServerLogStore_OnAppendAndConcurrency(t *testing.T) {
+	store := NewServerLogStore(ServerLogCapacity)
+	var mu sync.Mutex
+	persisted := 0
+	store.OnAppend = func(ServerLogEntry) {
+		mu.Lock()
+		persisted++
+		mu.Unlock()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				store.Append(ServerLogEntry{MCPName: "busy", Message: "line"})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(store.Entries("busy")) != 200 || persisted != 200 {
+		t.Errorf("Expected 200 buffered and persisted entries, got %d and %d", len(store.Entries("busy")), persisted)
+	}
+}
+
+func TestServerLogStore_Nil(t *testing.T) {
+	var store *ServerLogStore
+	store.Append(ServerLogEntry{MCPName: "x"})
+	store.Clear("x")
+	if store.Entries("x") != nil {
+		t.Error("A nil store should have no entries")
+	}
+}
@@ -0,0 +1,77 @@
+package types
+
+import "time"
+
+// TimerTick is the interval of the UI timer that counts down SuccessTimer
+const TimerTick = 50 * time.Millisecond
+
+// Default values of the user settings
+const (
+	DefaultClaudeTimeout          = 10 * time.Second
+	DefaultToggleRetryWindow      = 8 * time.Second
+	DefaultToggleRetryCutoff      = 18 * time.Second
+	DefaultToggleRetryBudget      = 20 * time.Second
+	DefaultProjectCheckInterval   = 5 * time.Second
+	DefaultClaudeDetectionDelay   = 500 * time.Millisecond
+	DefaultSuccessMessageDuration = 6 * time.Second
+)
+
+// Settings holds the user-tunable timeouts and intervals stored in settings.json.
+// A zero value means the default.
+type Settings struct {
+	// ClaudeTimeout bounds each Claude CLI command
+	ClaudeTimeout time.Duration
+	// ToggleRetryWindow: a toggle that timed out sooner than this is retried
+	ToggleRetryWindow time.Duration
+	// ToggleRetryCutoff: no retry starts once this much time has passed
+	ToggleRetryCutoff time.Duration
+	// ToggleRetryBudget is the total time a toggle and its retry may take
+	ToggleRetryBudget time.Duration
+	// ProjectCheckInterval is how often the working directory and sync status are checked
+	ProjectCheckInterval time.Duration
+	// ClaudeDetectionDelay lets the UI draw before Claude CLI detection starts
+	ClaudeDetectionDelay time.Duration
+	// SuccessMessageDuration is how long status messages stay in the footer
+	SuccessMessageDuration time.Duration
+}
+
+// DefaultSettings returns the settings used when settings.json does not set a value
+func DefaultSettings() Settings {
+	return Settings{
+		ClaudeTimeout:          DefaultClaudeTimeout,
+		ToggleRetryWindow:      DefaultToggleRetryWindow,
+		ToggleRetryCutoff:      DefaultToggleRetryCutoff,
+		ToggleRetryBudget:      DefaultToggleRetryBudget,
+		ProjectCheckInterval:   DefaultProjectCheckInterval,
+		ClaudeDetectionDelay:   DefaultClaudeDetectionDelay,
+		SuccessMessageDuration: DefaultSuccessMessageDuration,
+	}
+}
+
+// WithDefaults returns the settings with unset values replaced by their defaults
+func (s Settings) WithDefaults() Settings {
+	defaults := DefaultSettings()
+	for _, pair := range [][2]*time.Duration{
+		{&s.ClaudeTimeout, &defaults.ClaudeTimeout},
+		{&s.ToggleRetryWindow, &defaults.ToggleRetryWindow},
+		{&s.ToggleRetryCutoff, &defaults.ToggleRetryCutoff},
+		{&s.ToggleRetryBudget, &defaults.ToggleRetryBudget},
+		{&s.ProjectCheckInterval, &defaults.ProjectCheckInterval},
+		{&s.ClaudeDetectionDelay, &defaults.ClaudeDetectionDelay},
+		{&s.SuccessMessageDuration, &defaults.SuccessMessageDuration},
+	} {
+		if *pair[0] <= 0 {
+			*pair[0] = *pair[1]
+		}
+	}
+	return s
+}
+
+// SuccessTicks returns the SuccessTimer value that shows a message for SuccessMessageDuration
+func (s Settings) SuccessTicks() int {
+	ticks := int(s.WithDefaults().SuccessMessageDuration / TimerTick)
+	if ticks < 1 {
+		return 1
+	}
+	return ticks
+}
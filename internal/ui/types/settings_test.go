@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettings_WithDefaults(t *testing.T) {
+	settings := Settings{ClaudeTimeout: 3 * time.Second}.WithDefaults()
+	if settings.ClaudeTimeout != 3*time.Second {
+		t.Errorf("Set values should be kept, got %v", settings.ClaudeTimeout)
+	}
+	expected := DefaultSettings()
+	expected.ClaudeTimeout = 3 * time.Second
+	if settings != expected {
+		t.Errorf("Unset values should take their defaults, got %+v", settings)
+	}
+}
+
+func TestSettings_SuccessTicks(t *testing.T) {
+	if ticks := (Settings{}).SuccessTicks(); ticks != 120 {
+		t.Errorf("Default success message should last 120 ticks, got %d", ticks)
+	}
+	if ticks := (Settings{SuccessMessageDuration: time.Second}).SuccessTicks(); ticks != 20 {
+		t.Errorf("One second should be 20 ticks, got %d", ticks)
+	}
+	if ticks := (Settings{SuccessMessageDuration: time.Millisecond}).SuccessTicks(); ticks != 1 {
+		t.Errorf("Short durations should still show the message for a tick, got %d", ticks)
+	}
+}
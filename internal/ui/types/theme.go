@@ -0,0 +1,139 @@
+package types
+
+// Theme is the palette the UI is drawn with. Colors are "#RRGGBB" hex values or ANSI
+// color numbers such as "12"; an empty color leaves the terminal's own color.
+type Theme struct {
+	Name string `json:"-"`
+	// Monochrome draws selections and highlights with reverse video and underlines
+	// instead of colors
+	Monochrome bool `json:"monochrome,omitempty"`
+
+	// Accent marks the focused border, titles and the selection background
+	Accent string `json:"accent,omitempty"`
+	// AccentText is the text on the accent color
+	AccentText string `json:"accent_text,omitempty"`
+	// Secondary marks group headers
+	Secondary string `json:"secondary,omitempty"`
+	Text      string `json:"text,omitempty"`
+	Muted     string `json:"muted,omitempty"`
+	// Border is the border of unfocused panels
+	Border string `json:"border,omitempty"`
+
+	Success   string `json:"success,omitempty"`
+	Warning   string `json:"warning,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Attention string `json:"attention,omitempty"`
+	// Highlight marks search matches and keys in the help overlay
+	Highlight string `json:"highlight,omitempty"`
+
+	Background       string `json:"background,omitempty"`
+	HeaderBackground string `json:"header_background,omitempty"`
+	FooterText       string `json:"footer_text,omitempty"`
+	FooterBackground string `json:"footer_background,omitempty"`
+	InputText        string `json:"input_text,omitempty"`
+	InputBackground  string `json:"input_background,omitempty"`
+
+	// The loading overlay is drawn quieter than the modals
+	OverlayText       string `json:"overlay_text,omitempty"`
+	OverlayBackground string `json:"overlay_background,omitempty"`
+	OverlayBorder     string `json:"overlay_border,omitempty"`
+}
+
+// Names of the built-in themes
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeMono         = "mono"
+)
+
+// DefaultTheme returns the dark theme the UI was designed with
+func DefaultTheme() Theme {
+	return Theme{
+		Name:              ThemeDark,
+		Accent:            "#7C3AED",
+		AccentText:        "#FFFFFF",
+		Secondary:         "#A78BFA",
+		Text:              "#FFFFFF",
+		Muted:             "#888888",
+		Border:            "#444444",
+		Success:           "#51CF66",
+		Warning:           "#FFD700",
+		Error:             "#FF6B6B",
+		Attention:         "#FF8C00",
+		Highlight:         "#FFD43B",
+		Background:        "#1E1E2E",
+		HeaderBackground:  "#1E1E2E",
+		FooterText:        "#CCCCCC",
+		FooterBackground:  "#2D2D3D",
+		InputText:         "#000000",
+		InputBackground:   "#FFFFFF",
+		OverlayText:       "#D1D5DB",
+		OverlayBackground: "#111827",
+		OverlayBorder:     "#4B5563",
+	}
+}
+
+// OrDefault returns the theme, or the default theme for the zero Theme of a model
+// that never loaded one
+func (t Theme) OrDefault() Theme {
+	if t.Name == "" {
+		return DefaultTheme()
+	}
+	return t
+}
+
+// BuiltinThemes returns the themes that ship with mcp-hub, by name
+func BuiltinThemes() map[string]Theme {
+	return map[string]Theme{
+		ThemeDark: DefaultTheme(),
+		ThemeLight: {
+			Name:              ThemeLight,
+			Accent:            "#6D28D9",
+			AccentText:        "#FFFFFF",
+			Secondary:         "#7C3AED",
+			Text:              "#1F2937",
+			Muted:             "#6B7280",
+			Border:            "#D1D5DB",
+			Success:           "#15803D",
+			Warning:           "#A16207",
+			Error:             "#B91C1C",
+			Attention:         "#C2410C",
+			Highlight:         "#B45309",
+			Background:        "#FFFFFF",
+			HeaderBackground:  "#EDE9FE",
+			FooterText:        "#374151",
+			FooterBackground:  "#E5E7EB",
+			InputText:         "#FFFFFF",
+			InputBackground:   "#1F2937",
+			OverlayText:       "#1F2937",
+			OverlayBackground: "#F3F4F6",
+			OverlayBorder:     "#9CA3AF",
+		},
+		ThemeHighContrast: {
+			Name:              ThemeHighContrast,
+			Accent:            "#00FFFF",
+			AccentText:        "#000000",
+			Secondary:         "#FFFF00",
+			Text:              "#FFFFFF",
+			Muted:             "#C0C0C0",
+			Border:            "#FFFFFF",
+			Success:           "#00FF00",
+			Warning:           "#FFFF00",
+			Error:             "#FF5555",
+			Attention:         "#FFA500",
+			Highlight:         "#FFFF00",
+			Background:        "#000000",
+			HeaderBackground:  "#000000",
+			FooterText:        "#FFFFFF",
+			FooterBackground:  "#000000",
+			InputText:         "#000000",
+			InputBackground:   "#FFFFFF",
+			OverlayText:       "#FFFFFF",
+			OverlayBackground: "#000000",
+			OverlayBorder:     "#FFFFFF",
+		},
+		// Mono leaves every color to the terminal, for NO_COLOR and terminals without colors
+		ThemeMono: {Name: ThemeMono, Monochrome: true},
+	}
+}
@@ -0,0 +1,32 @@
+package types
+
+import "testing"
+
+func TestTheme_OrDefault(t *testing.T) {
+	if got := (Theme{}).OrDefault(); got != DefaultTheme() {
+		t.Errorf("The zero Theme should give the default theme, got %+v", got)
+	}
+	light := BuiltinThemes()[ThemeLight]
+	if got := light.OrDefault(); got != light {
+		t.Errorf("A loaded theme should be kept, got %q", got.Name)
+	}
+}
+
+func TestBuiltinThemes(t *testing.T) {
+	themes := BuiltinThemes()
+	for _, name := range []string{ThemeDark, ThemeLight, ThemeHighContrast, ThemeMono} {
+		theme, ok := themes[name]
+		if !ok {
+			t.Fatalf("Missing built-in theme %q", name)
+		}
+		if theme.Name != name {
+			t.Errorf("Theme %q is named %q", name, theme.Name)
+		}
+		if name != ThemeMono && (theme.Accent == "" || theme.Text == "" || theme.Error == "") {
+			t.Errorf("Theme %q should set its colors", name)
+		}
+	}
+	if mono := themes[ThemeMono]; !mono.Monochrome || mono.Accent != "" {
+		t.Error("The mono theme should leave every color to the terminal")
+	}
+}
@@ -0,0 +1,55 @@
+package types
+
+import "time"
+
+// SortMode is the order of the MCP grid when no search is active
+type SortMode string
+
+const (
+	// SortInventory keeps the order of inventory.json
+	SortInventory SortMode = "inventory"
+	// SortName orders by name, ignoring case
+	SortName SortMode = "name"
+	// SortType orders by transport type, then name
+	SortType SortMode = "type"
+	// SortActiveFirst puts active MCPs before inactive ones
+	SortActiveFirst SortMode = "active"
+	// SortRecent puts the most recently used MCPs first
+	SortRecent SortMode = "recent"
+	// SortHealth puts failing MCPs first, then healthy ones, then unprobed ones
+	SortHealth SortMode = "health"
+)
+
+// SortModes lists the sort modes in the order the sort key cycles through them
+var SortModes = []SortMode{SortInventory, SortName, SortType, SortActiveFirst, SortRecent, SortHealth}
+
+// GroupMode splits the MCP grid into sections with headers
+type GroupMode string
+
+const (
+	// GroupNone shows a single section without a header
+	GroupNone GroupMode = "none"
+	// GroupType makes a section per transport type
+	GroupType GroupMode = "type"
+	// GroupStatus makes an active and an inactive section
+	GroupStatus GroupMode = "status"
+	// GroupTag makes a section per first tag, with untagged MCPs last
+	GroupTag GroupMode = "tag"
+)
+
+// GroupModes lists the group modes in the order the group key cycles through them
+var GroupModes = []GroupMode{GroupNone, GroupType, GroupStatus, GroupTag}
+
+// GridViewState holds how the MCP grid is ordered and when each MCP was last used.
+// It is stored in view.json so the choice survives restarts.
+type GridViewState struct {
+	Sort  SortMode  `json:"sort,omitempty"`
+	Group GroupMode `json:"group,omitempty"`
+	// LastUsed records when each MCP was last activated or opened in the playground
+	LastUsed map[string]time.Time `json:"last_used,omitempty"`
+}
+
+// DefaultGridViewState returns the inventory order without grouping
+func DefaultGridViewState() GridViewState {
+	return GridViewState{Sort: SortInventory, Group: GroupNone, LastUsed: map[string]time.Time{}}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"mcp-hub/internal/ui/components"
+	"mcp-hub/internal/ui/services"
 	"mcp-hub/internal/ui/types"
 
 	"github.com/charmbracelet/lipgloss"
@@ -33,7 +34,7 @@ func (m Model) View() string {
 
 	// Apply success message as overlay if present (no layout disruption)
 	if m.SuccessMessage != "" {
-		content = components.RenderAlertOverlay(m.SuccessMessage, m.Width, m.Height, content)
+		content = components.RenderAlertOverlay(m.Theme, m.SuccessMessage, m.Width, m.Height, content)
 	}
 
 	// Apply loading overlay if present (Epic 2 Story 6)
@@ -70,13 +71,13 @@ func (m Model) renderBody() string {
 func (m Model) renderSingleColumn() string {
 	columnStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#444444")).
+		BorderForeground(components.Color(m.Theme.OrDefault().Border)).
 		Padding(1).
 		Width(m.Width - 4).
 		Height(m.Height - 8) // Account for header and footer
 
 	if m.ActiveColumn == 0 {
-		columnStyle = columnStyle.BorderForeground(lipgloss.Color("#7C3AED"))
+		columnStyle = columnStyle.BorderForeground(components.Color(m.Theme.OrDefault().Accent))
 	}
 
 	content := components.RenderMCPList(m.Model)
@@ -91,11 +92,12 @@ func (m Model) renderSingleColumn() string {
 func (m Model) renderTwoColumns() string {
 	columnWidth := (m.Width - 6) / 2
 	columnHeight := m.Height - 8
+	theme := m.Theme.OrDefault()
 
 	// Column styles
 	leftStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#444444")).
+		BorderForeground(components.Color(theme.Border)).
 		Padding(1).
 		Width(columnWidth).
 		Height(columnHeight)
@@ -104,9 +106,9 @@ func (m Model) renderTwoColumns() string {
 
 	// Highlight active column
 	if m.ActiveColumn == 0 {
-		leftStyle = leftStyle.BorderForeground(lipgloss.Color("#7C3AED"))
+		leftStyle = leftStyle.BorderForeground(components.Color(theme.Accent))
 	} else {
-		rightStyle = rightStyle.BorderForeground(lipgloss.Color("#7C3AED"))
+		rightStyle = rightStyle.BorderForeground(components.Color(theme.Accent))
 	}
 
 	// Column content
@@ -127,7 +129,7 @@ func (m Model) renderThreeColumns() string {
 	// Base column style
 	columnStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#444444")).
+		BorderForeground(components.Color(m.Theme.OrDefault().Border)).
 		Padding(1).
 		Width(columnWidth).
 		Height(columnHeight)
@@ -138,13 +140,13 @@ func (m Model) renderThreeColumns() string {
 	for i := 0; i < 3; i++ {
 		style := columnStyle
 		if i == m.ActiveColumn {
-			style = style.BorderForeground(lipgloss.Color("#7C3AED"))
+			style = style.BorderForeground(components.Color(m.Theme.OrDefault().Accent))
 		}
 
 		var content string
 		switch i {
 		case 0:
-			content = fmt.Sprintf("MCPs\n\n%s", m.renderMCPList())
+			content = fmt.Sprintf("MCPs\n\n%s", components.RenderMCPList(m.Model))
 		case 1:
 			content = fmt.Sprintf("Status\n\n%s", m.renderStatusColumn())
 		case 2:
@@ -178,7 +180,7 @@ func (m Model) renderFourColumns() string {
 //nolint:unused // Used by renderFourColumns in tests
 func (m Model) renderNoResultsMessage() string {
 	noResultsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
+		Foreground(components.Color(m.Theme.OrDefault().Muted)).
 		Align(lipgloss.Center).
 		Width(m.Width).
 		Height(m.Height - 8)
@@ -198,7 +200,12 @@ func (m Model) calculateGridRows(mcpCount int) int {
 func (m Model) buildGridLayout(filteredMCPs []types.MCPItem, gridRows int) string {
 	var gridLines []string
 
-	for row := 0; row < gridRows; row++ {
+	// Only the rows that fit are rendered, keeping the selection in view
+	first, last := 0, gridRows
+	if viewport := services.GetViewport(m.Model); viewport.Scrolls() {
+		first, last = viewport.Offset, viewport.Offset+viewport.Height
+	}
+	for row := first; row < last; row++ {
 		line := m.buildGridRow(filteredMCPs, row)
 		gridLines = append(gridLines, strings.Join(line, ""))
 	}
@@ -261,46 +268,12 @@ func (m Model) buildGridCell(filteredMCPs []types.MCPItem, mcpIndex int) string
 
 	// Then apply styling to padded text
 	if isSelected {
-		itemStyle := lipgloss.NewStyle().
-			Bold(true).
-			Background(lipgloss.Color("#7C3AED")).
-			Foreground(lipgloss.Color("#FFFFFF"))
-		paddedText = itemStyle.Render(paddedText)
+		paddedText = components.ThemeStyles(m.Theme).Selected.Render(paddedText)
 	}
 
 	return paddedText
 }
 
-// renderMCPList renders the list of MCPs with selection highlighting
-func (m Model) renderMCPList() string {
-	if len(m.MCPItems) == 0 {
-		return "No MCPs configured"
-	}
-
-	var items []string
-	for i, item := range m.MCPItems {
-		style := lipgloss.NewStyle().Padding(0, 1)
-
-		// Highlight selected item
-		if i == m.SelectedItem {
-			style = style.Background(lipgloss.Color("#7C3AED")).
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Bold(true)
-		}
-
-		// Status indicator
-		status := "○"
-		if item.Active {
-			status = "●"
-		}
-
-		itemText := fmt.Sprintf("%s %s", status, item.Name)
-		items = append(items, style.Render(itemText))
-	}
-
-	return strings.Join(items, "\n")
-}
-
 // renderMCPColumnList renders a list of MCPs for a specific column with selection highlighting
 //
 //nolint:unused // Used in tests
@@ -316,9 +289,7 @@ func (m Model) renderMCPColumnList(columnMCPs []types.MCPItem, startIdx int) str
 
 		// Highlight selected item
 		if actualIdx == m.SelectedItem {
-			style = style.Background(lipgloss.Color("#7C3AED")).
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Bold(true)
+			style = style.Inherit(components.ThemeStyles(m.Theme).Selected)
 		}
 
 		// Status indicator
@@ -336,58 +307,88 @@ func (m Model) renderMCPColumnList(columnMCPs []types.MCPItem, startIdx int) str
 
 // renderStatusColumn renders the status information for the selected MCP
 func (m Model) renderStatusColumn() string {
-	if m.SelectedItem >= len(m.MCPItems) {
+	selected := services.GetSelectedMCP(m.Model)
+	if selected == nil {
 		return "No MCP selected"
 	}
 
-	item := m.MCPItems[m.SelectedItem]
+	item := *selected
 
+	styles := components.ThemeStyles(m.Theme)
 	status := "Inactive"
-	statusColor := "#FF6B6B"
+	statusStyle := styles.Error.Bold(true)
 	if item.Active {
 		status = "Active"
-		statusColor = "#51CF66"
+		statusStyle = styles.Success.Bold(true)
 	}
 
-	statusStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(statusColor)).
-		Bold(true)
-
-	return fmt.Sprintf("Name: %s\nType: %s\nStatus: %s\n\nCommand:\n%s",
+	text := fmt.Sprintf("Name: %s\nType: %s\nStatus: %s\n\nCommand:\n%s",
 		item.Name,
 		item.Type,
 		statusStyle.Render(status),
 		item.Command)
+
+	// Health probe results, if the server has been probed
+	if m.ProbingMCPs[item.Name] {
+		text += "\n\nHealth: probing..."
+	} else if health, ok := m.Health[item.Name]; ok {
+		text += "\n\n" + strings.Join(services.FormatHealthForDisplay(health), "\n")
+	}
+
+	return text
 }
 
 // renderDetailsColumn renders detailed information for the selected MCP
 func (m Model) renderDetailsColumn() string {
-	if m.SelectedItem >= len(m.MCPItems) {
+	selected := services.GetSelectedMCP(m.Model)
+	if selected == nil {
 		return "No MCP selected"
 	}
 
-	item := m.MCPItems[m.SelectedItem]
+	item := *selected
 
-	// Placeholder details - will be expanded in future stories
 	details := []string{
 		fmt.Sprintf("MCP: %s", item.Name),
-		"Configuration:",
-		"  • Auto-start: Yes",
-		"  • Timeout: 30s",
-		"  • Retry: 3x",
-		"",
-		"Capabilities:",
-		"  • File operations",
-		"  • Context search",
-		"  • Documentation",
+		fmt.Sprintf("Source: %s", m.getMCPSource(item)),
 		"",
-		"Last started:",
-		"  2025-06-29 14:30:22",
 	}
+	details = append(details, services.FormatMCPConfigForDisplay(item)...)
+	details = append(details, "")
+	details = append(details, services.FormatCapabilitiesForDisplay(m.Model, item)...)
+	details = append(details, m.renderProjectServers()...)
 
 	return strings.Join(details, "\n")
 }
 
+// getMCPSource describes whether an MCP comes from the repository or the personal inventory
+func (m Model) getMCPSource(item types.MCPItem) string {
+	if services.IsProjectScopedMCP(m.ProjectContext, item.Name) {
+		return "project (" + services.ProjectMCPConfigFile + ")"
+	}
+	return "personal inventory"
+}
+
+// renderProjectServers lists the servers declared by the project's .mcp.json
+func (m Model) renderProjectServers() []string {
+	project := m.ProjectContext.Project
+	if !project.HasMCPConfig {
+		return nil
+	}
+
+	lines := []string{"", fmt.Sprintf("Project servers (%s):", services.ProjectMCPConfigFile)}
+	switch {
+	case project.MCPConfigError != "":
+		lines = append(lines, "  Invalid: "+project.MCPConfigError)
+	case len(project.ProjectMCPs) == 0:
+		lines = append(lines, "  None declared")
+	default:
+		for _, server := range project.ProjectMCPs {
+			lines = append(lines, "  "+types.BulletChar+" "+server)
+		}
+	}
+	return lines
+}
+
 // renderStatusAndDetails renders combined status and details for 2-column layout
 func (m Model) renderStatusAndDetails() string {
 	status := m.renderStatusColumn()
@@ -401,16 +402,15 @@ func (m Model) renderStatusAndDetails() string {
 //nolint:unused // Used in tests
 func (m Model) renderFooter() string {
 	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
+		Foreground(components.Color(m.Theme.OrDefault().Muted)).
 		Padding(0, 2).
 		Width(m.Width)
 
 	var footerText string
 	switch {
 	case m.SearchActive:
-		searchStyle := lipgloss.NewStyle().
-			Background(lipgloss.Color("#7C3AED")).
-			Foreground(lipgloss.Color("#FFFFFF")).
+		searchStyle := components.ThemeStyles(m.Theme).Selected.
+			UnsetBold().
 			Padding(0, 1)
 
 		cursor := "_"
@@ -4,7 +4,12 @@ import (
 	"strings"
 	"testing"
 
+	"mcp-hub/internal/ui/components"
+	"mcp-hub/internal/ui/services"
 	"mcp-hub/internal/ui/types"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // Test string constants
@@ -734,3 +739,53 @@ func TestView_LoadingStates(t *testing.T) {
 		}
 	})
 }
+
+func TestView_DetailsColumnShowsRealConfiguration(t *testing.T) {
+	model := NewModel()
+	model.MCPItems = []types.MCPItem{
+		{Name: "github", Type: "CMD", Command: "npx", Args: []string{"-y", "server-github"}, Environment: map[string]string{"GITHUB_TOKEN": "ghp_secret"}},
+	}
+	model.SelectedItem = 0
+
+	details := model.renderDetailsColumn()
+
+	for _, placeholder := range []string{"Auto-start: Yes", "Timeout: 30s", "File operations", "2025-06-29"} {
+		if strings.Contains(details, placeholder) {
+			t.Errorf("Details should not contain placeholder %q", placeholder)
+		}
+	}
+	for _, fragment := range []string{"MCP: github", "Command: npx", "Args: -y server-github", "Env: GITHUB_TOKEN", "not fetched (press i)"} {
+		if !strings.Contains(details, fragment) {
+			t.Errorf("Expected %q in details:\n%s", fragment, details)
+		}
+	}
+	if strings.Contains(details, "ghp_secret") {
+		t.Error("Details should not reveal environment values")
+	}
+}
+
+func TestView_ThreeColumnsHighlightsSelectedMCP(t *testing.T) {
+	// Colors are needed to tell the highlighted line apart
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	defer lipgloss.SetColorProfile(profile)
+
+	model := NewModel()
+	model.Width, model.Height = 120, 40
+	model.ColumnCount = 3
+	model.MCPItems = []types.MCPItem{{Name: "github-mcp"}, {Name: "docker-mcp"}, {Name: "context7"}}
+	model.Grid.Sort = types.SortName
+	model.SelectedItem = 1
+
+	view := model.renderThreeColumns()
+	var highlighted []string
+	for _, item := range model.MCPItems {
+		if strings.Contains(view, components.ThemeStyles(model.Theme).Selected.Render(" ○ "+item.Name+" ")) {
+			highlighted = append(highlighted, item.Name)
+		}
+	}
+	selected := services.GetSelectedMCP(model.Model)
+	if selected == nil || len(highlighted) != 1 || highlighted[0] != selected.Name {
+		t.Errorf("The highlighted MCP should be the one keys act on, got %q highlighted", highlighted)
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"mcp-hub/internal/platform"
+	"mcp-hub/internal/ui/handlers"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModel_WorkspaceSwitch(t *testing.T) {
+	t.Setenv(services.ConfigDirEnv, "")
+	t.Setenv(services.WorkspaceEnv, "")
+	mockPlatform := platform.NewMockPlatformService()
+	tempDir := t.TempDir()
+	mockPlatform.SetPaths(tempDir+"/logs", tempDir+"/mcp-hub", tempDir+"/tmp", tempDir+"/cache")
+
+	work, err := services.OpenWorkspace(mockPlatform, "work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := services.SaveInventory([]types.MCPItem{{Name: "office", Type: "CMD", Command: "office"}}, work); err != nil {
+		t.Fatalf("SaveInventory failed: %v", err)
+	}
+
+	model := NewModelForPlatform(mockPlatform)
+	if model.Workspace != services.DefaultWorkspace {
+		t.Fatalf("Expected the default workspace, got %q", model.Workspace)
+	}
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	model = updated.(Model)
+	model.ClaudeAvailable = true
+	model.ClaudeStatus = types.ClaudeStatus{Available: true, Version: "1.0"}
+	logs := model.ServerLogs
+
+	updated, cmd := model.Update(handlers.WorkspaceSwitchMsg{Name: "work"})
+	switched := updated.(Model)
+	if switched.Workspace != "work" || services.WorkspaceName(switched.PlatformService) != "work" {
+		t.Fatalf("Expected the work workspace, got %q", switched.Workspace)
+	}
+	if len(switched.MCPItems) != 1 || switched.MCPItems[0].Name != "office" {
+		t.Errorf("Work inventory should be loaded, got %+v", switched.MCPItems)
+	}
+	if switched.Width != 140 || switched.ColumnCount == 0 || switched.ServerLogs != logs || !switched.ClaudeAvailable {
+		t.Error("Window size, server logs and Claude status should carry over")
+	}
+	if switched.SuccessMessage != "Switched to workspace work" || cmd == nil {
+		t.Errorf("Expected a success message and follow-up commands, got %q", switched.SuccessMessage)
+	}
+
+	updated, _ = switched.Update(handlers.WorkspaceSwitchMsg{Name: "bad name"})
+	if failed := updated.(Model); failed.Workspace != "work" || !strings.Contains(failed.SuccessMessage, "Failed to switch workspace") {
+		t.Errorf("Invalid workspace should keep the current one, got %q: %q", failed.Workspace, failed.SuccessMessage)
+	}
+}
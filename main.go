@@ -2,49 +2,278 @@
 package main
 
 import (
-	"log"
+	"context"
+	"flag"
+	"fmt"
+	"io"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"strings"
+	"syscall"
 
+	"mcp-hub/internal/cli"
+	"mcp-hub/internal/logging"
+	"mcp-hub/internal/manage"
+	"mcp-hub/internal/mcp"
 	"mcp-hub/internal/platform"
+	"mcp-hub/internal/proxy"
 	"mcp-hub/internal/ui"
+	"mcp-hub/internal/ui/services"
+	"mcp-hub/internal/ui/types"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-	if err := runApp(); err != nil {
+	flags, args, err := parseGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(cli.ExitUsage)
+	}
+	// Every mode reads these through the environment, as if MCP_HUB_WORKSPACE and
+	// MCP_HUB_LOG_LEVEL had been set
+	if flags.workspace != "" {
+		_ = os.Setenv(services.WorkspaceEnv, flags.workspace)
+	}
+	if flags.logLevel != "" {
+		_ = os.Setenv(logging.LevelEnv, flags.logLevel)
+	}
+
+	run := runApp
+	if len(args) > 0 {
+		switch args[0] {
+		case "serve":
+			run = runServe
+		case "manage":
+			run = func() error { return runManage(args[1:]) }
+		case "replay":
+			run = func() error { return runReplay(args[1:]) }
+		case "help", "-h", "--help":
+			printUsage(os.Stdout)
+			return
+		default:
+			if cli.IsCommand(args[0]) {
+				os.Exit(runCLI(args))
+			}
+			// A mistyped command should fail rather than open the terminal UI
+			if !strings.HasPrefix(args[0], "-") {
+				fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+				printUsage(os.Stderr)
+				os.Exit(cli.ExitUsage)
+			}
+		}
+	}
+	if err := run(); err != nil {
 		os.Exit(1)
 	}
 }
 
+// globalFlags are the options accepted before any command
+type globalFlags struct {
+	workspace string
+	logLevel  string
+}
+
+// parseGlobalFlags removes leading --workspace and --log-level options, given as
+// "--name value" or "--name=value", and returns them validated with the remaining arguments
+func parseGlobalFlags(args []string) (globalFlags, []string, error) {
+	var flags globalFlags
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(args[0], "=")
+		if name != "--workspace" && name != "--log-level" {
+			break
+		}
+		if !hasValue {
+			if len(args) < 2 {
+				return globalFlags{}, nil, fmt.Errorf("%s requires a value", name)
+			}
+			value, args = args[1], args[1:]
+		}
+		args = args[1:]
+
+		switch name {
+		case "--workspace":
+			if err := services.ValidateWorkspaceName(value); err != nil {
+				return globalFlags{}, nil, err
+			}
+			flags.workspace = value
+		case "--log-level":
+			if _, err := logging.ParseLevel(value); err != nil {
+				return globalFlags{}, nil, err
+			}
+			flags.logLevel = value
+		}
+	}
+	return flags, args, nil
+}
+
+// newPlatformService creates the platform service for the workspace selected by
+// --workspace or MCP_HUB_WORKSPACE
+func newPlatformService() (platform.PlatformService, error) {
+	platformService := platform.NewPlatformServiceFactoryDefault().CreatePlatformService()
+	workspace, err := services.OpenWorkspaceFromEnv(platformService)
+	if err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
 func runApp() error {
 	// Create platform service for dynamic path resolution
-	platformService := platform.NewPlatformServiceFactoryDefault().CreatePlatformService()
+	platformService, err := newPlatformService()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
 
 	// Redirect log output to a platform-specific file to prevent interference with TUI
-	var logFile *os.File
-	logPath := filepath.Join(platformService.GetLogPath(), "mcp-hub.log")
-	
-	// Ensure log directory exists
-	logDir := filepath.Dir(logPath)
-	if err := os.MkdirAll(logDir, platformService.GetDefaultDirectoryPermissions()); err == nil {
-		if file, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, platformService.GetDefaultFilePermissions()); err == nil {
-			logFile = file
-			log.SetOutput(logFile)
-			defer func() {
-				_ = logFile.Close()
-			}()
-		}
-	}
+	defer setupLogging(platformService)()
 
+	services.EnableRecordingFromEnv(platformService)
 	model := ui.NewModel()
 
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
 	if _, err := p.Run(); err != nil {
-		log.Printf("Error running MCP Manager: %v", err)
+		logging.For("app").Error("terminal UI stopped", "error", err)
+		return err
+	}
+	return nil
+}
+
+// runServe runs mcp-hub as a single stdio MCP server aggregating the enabled inventory.
+// Stdout carries the protocol, so diagnostics go to the log file and server log store.
+func runServe() error {
+	platformService, err := newPlatformService()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
+	defer setupLogging(platformService)()
+	services.EnableRecordingFromEnv(platformService)
+	serveLog := logging.For("serve")
+
+	items, err := services.LoadInventory(platformService)
+	if err != nil {
+		serveLog.Error("failed to load inventory", "error", err)
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := proxy.NewServer(services.NewPersistentServerLogStore(platformService))
+	defer func() {
+		// Stop watching before shutting the backends down
+		stop()
+		_ = server.Close()
+	}()
+
+	go func() {
+		server.Sync(ctx, items)
+		serveLog.Info("proxying", "backends", server.Backends())
+		server.Watch(ctx, func() ([]types.MCPItem, error) {
+			return services.LoadInventory(platformService)
+		}, proxy.InventoryPollInterval)
+	}()
+
+	if err := server.Serve(ctx, os.Stdin, os.Stdout); err != nil {
+		serveLog.Error("server stopped", "error", err)
+		return err
+	}
+	return nil
+}
+
+// runManage runs mcp-hub as a stdio MCP server whose tools manage the inventory itself.
+// Mutating tools need confirm: true unless --confirm=never (or MCP_HUB_MANAGE_CONFIRM=never).
+func runManage(args []string) error {
+	flags := flag.NewFlagSet("manage", flag.ContinueOnError)
+	confirm := flags.String("confirm", os.Getenv("MCP_HUB_MANAGE_CONFIRM"), "confirmation policy for mutating tools: always or never")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	policy, err := manage.ParseConfirmPolicy(*confirm)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
+
+	platformService, err := newPlatformService()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
+	defer setupLogging(platformService)()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := manage.NewServer(platformService, services.NewClaudeService(platformService), policy)
+	if err := server.Serve(ctx, os.Stdin, os.Stdout); err != nil {
+		logging.For("manage").Error("server stopped", "error", err)
 		return err
 	}
 	return nil
 }
+
+// setupLogging sends the structured log to the rotating log file, keeping stdout free for
+// the protocol in server modes and the screen clean in the TUI. The level comes from
+// --log-level or MCP_HUB_LOG_LEVEL. It returns a function that closes the file.
+func setupLogging(platformService platform.PlatformService) func() {
+	level, levelErr := logging.LevelFromEnv()
+	restore, err := logging.Setup(logging.Options{
+		Dir:      platformService.GetLogPath(),
+		Level:    level,
+		DirMode:  platformService.GetDefaultDirectoryPermissions(),
+		FileMode: platformService.GetDefaultFilePermissions(),
+	})
+	if err == nil && levelErr != nil {
+		logging.For("app").Warn("ignoring log level", "error", levelErr)
+	}
+	return restore
+}
+
+// runCLI runs one non-interactive subcommand and returns its exit code.
+// The log goes to the log file so stdout only carries the command's output.
+func runCLI(args []string) int {
+	platformService, err := newPlatformService()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return cli.ExitUsage
+	}
+	defer setupLogging(platformService)()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app := cli.NewApp(platformService, services.NewClaudeService(platformService), os.Stdin, os.Stdout, os.Stderr)
+	return app.Run(ctx, args)
+}
+
+// printUsage lists the ways mcp-hub can be started
+func printUsage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "usage:")
+	_, _ = fmt.Fprintf(w, "  mcp-hub %-52s %s\n", "", "Open the terminal UI")
+	_, _ = fmt.Fprintf(w, "  mcp-hub %-52s %s\n", "--workspace <name> [command]", "Use a named workspace (or set MCP_HUB_WORKSPACE)")
+	_, _ = fmt.Fprintf(w, "  mcp-hub %-52s %s\n", "--log-level debug|info|warn|error [command]", "Set the log level (or set MCP_HUB_LOG_LEVEL)")
+	cli.PrintUsage(w)
+	_, _ = fmt.Fprintf(w, "  mcp-hub %-52s %s\n", "serve", "Serve the MCPs marked as served as one stdio MCP server")
+	_, _ = fmt.Fprintf(w, "  mcp-hub %-52s %s\n", "manage [--confirm always|never]", "Serve inventory management tools over MCP")
+	_, _ = fmt.Fprintf(w, "  mcp-hub %-52s %s\n", "replay <recording.jsonl>", "Serve a recorded session as a fake MCP server")
+}
+
+// runReplay serves a JSONL recording as a fake stdio MCP server, answering each request
+// with the recorded response
+func runReplay(args []string) error {
+	if len(args) != 1 {
+		err := fmt.Errorf("usage: mcp-hub replay <recording.jsonl>")
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
+	server, err := mcp.LoadReplayServer(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
+	return server.Serve(os.Stdin, os.Stdout)
+}
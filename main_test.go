@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"os/exec"
@@ -10,6 +11,8 @@ import (
 	"testing"
 	"time"
 
+	"mcp-hub/internal/cli"
+	"mcp-hub/internal/logging"
 	"mcp-hub/internal/platform"
 	"mcp-hub/internal/ui"
 
@@ -338,6 +341,42 @@ func TestMainExecution(t *testing.T) {
 	})
 }
 
+func TestMainExecution_UnknownCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping main execution test in short mode")
+	}
+
+	testBinary := filepath.Join(t.TempDir(), "mcp-hub-test")
+	if err := exec.Command("go", "build", "-o", testBinary, ".").Run(); err != nil {
+		t.Fatalf("Failed to build test binary: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var stderr strings.Builder
+	cmd := exec.CommandContext(ctx, testBinary, "lsit")
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != cli.ExitUsage {
+		t.Fatalf("An unknown command should exit with %d, got %v", cli.ExitUsage, err)
+	}
+	if !strings.Contains(stderr.String(), `unknown command "lsit"`) || !strings.Contains(stderr.String(), "usage:") {
+		t.Errorf("An unknown command should print usage to stderr, got %q", stderr.String())
+	}
+}
+
+func TestPrintUsage(t *testing.T) {
+	var out strings.Builder
+	printUsage(&out)
+	for _, want := range []string{"usage:", "mcp-hub list", "serve", "replay <recording.jsonl>"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Usage should mention %q, got %q", want, out.String())
+		}
+	}
+}
+
 // Test runApp function error scenarios
 func TestRunAppErrorScenarios(t *testing.T) {
 	t.Run("invalid_log_directory_graceful_handling", func(t *testing.T) {
@@ -560,4 +599,66 @@ func TestLogFileManagement(t *testing.T) {
 			t.Error("Log file should contain appended content")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestRunManage_RejectsUnknownConfirmPolicy(t *testing.T) {
+	if err := runManage([]string{"--confirm=sometimes"}); err == nil {
+		t.Error("Unknown confirmation policy should be rejected before serving")
+	}
+	if err := runManage([]string{"--no-such-flag"}); err == nil {
+		t.Error("Unknown flag should be rejected")
+	}
+}
+
+func TestRunReplay_RequiresReadableRecording(t *testing.T) {
+	if err := runReplay(nil); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("Missing recording argument should print usage, got %v", err)
+	}
+	if err := runReplay([]string{filepath.Join(t.TempDir(), "missing.jsonl")}); err == nil {
+		t.Error("Missing recording file should be rejected before serving")
+	}
+}
+
+func TestParseGlobalFlags(t *testing.T) {
+	tests := []struct {
+		args  []string
+		flags globalFlags
+		rest  []string
+	}{
+		{nil, globalFlags{}, nil},
+		{[]string{"list"}, globalFlags{}, []string{"list"}},
+		{[]string{"--workspace", "work", "list", "--json"}, globalFlags{workspace: "work"}, []string{"list", "--json"}},
+		{[]string{"--workspace=client-a"}, globalFlags{workspace: "client-a"}, []string{}},
+		{[]string{"--log-level=debug", "--workspace", "w", "serve"}, globalFlags{workspace: "w", logLevel: "debug"}, []string{"serve"}},
+		{[]string{"list", "--log-level", "debug"}, globalFlags{}, []string{"list", "--log-level", "debug"}},
+	}
+	for _, tt := range tests {
+		flags, rest, err := parseGlobalFlags(tt.args)
+		if err != nil || flags != tt.flags || strings.Join(rest, " ") != strings.Join(tt.rest, " ") {
+			t.Errorf("parseGlobalFlags(%v) = %+v, %v, %v", tt.args, flags, rest, err)
+		}
+	}
+
+	for _, args := range [][]string{{"--workspace"}, {"--workspace=../x", "list"}, {"--workspace", ""}, {"--log-level", "loud"}, {"--log-level"}} {
+		if _, _, err := parseGlobalFlags(args); err == nil {
+			t.Errorf("parseGlobalFlags(%v) should fail", args)
+		}
+	}
+}
+
+func TestSetupLogging(t *testing.T) {
+	t.Setenv(logging.LevelEnv, "warn")
+	mockPlatform := platform.NewMockPlatformService()
+	logDir := t.TempDir()
+	mockPlatform.SetPaths(logDir, logDir, logDir, logDir)
+
+	restore := setupLogging(mockPlatform)
+	logging.For("test").Info("hidden")
+	logging.For("test").Warn("kept", "key", "value")
+	restore()
+
+	entries, err := logging.ReadTail(filepath.Join(logDir, logging.FileName), 0)
+	if err != nil || len(entries) != 1 || entries[0].Component != "test" || entries[0].Message != "kept" {
+		t.Errorf("Expected only the warning, got %+v, %v", entries, err)
+	}
+}